@@ -18,12 +18,17 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	gcfg "gopkg.in/gcfg.v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	cloudprovider "k8s.io/cloud-provider"
@@ -34,14 +39,21 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/ratelimit"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
 )
 
 const (
 	// Sleep interval to retry cloud client creation.
 	cloudClientRetryInterval = 10 * time.Second
+
+	// defaultComputeEndpoint is the compute endpoint of the GCE client if
+	// the cloud config sets none.
+	defaultComputeEndpoint = "https://www.googleapis.com/compute/v1/"
+	cloudPlatformScope     = "https://www.googleapis.com/auth/cloud-platform"
 )
 
 // NewKubeConfigForProtobuf returns a Kubernetes client config that uses protobufs
@@ -67,9 +79,10 @@ func NewKubeConfig() (*rest.Config, error) {
 	return clientcmd.BuildConfigFromFlags(flags.F.APIServerHost, flags.F.KubeConfigFile)
 }
 
-// NewGCEClient returns a client to the GCE environment. This will block until
-// a valid configuration file can be read.
-func NewGCEClient() *gce.Cloud {
+// NewGCEClient returns a client to the GCE environment, and the configuration
+// of the clients of the Google Cloud APIs it does not cover. This will block
+// until a valid configuration file can be read.
+func NewGCEClient() (*gce.Cloud, *gcprest.Config) {
 	var configReader func() io.Reader
 	if flags.F.ConfigFilePath != "" {
 		klog.Infof("Reading config from path %q", flags.F.ConfigFilePath)
@@ -105,6 +118,17 @@ func NewGCEClient() *gce.Cloud {
 				klog.Fatalf("Error configuring rate limiting: %v", err)
 			}
 			cloud.SetRateLimiter(rl)
+			restConfig, err := newGCPRESTConfig(configReader, cloud.ProjectID())
+			if err != nil {
+				klog.Fatalf("Error configuring the Google Cloud REST clients: %v", err)
+			}
+			// The calls missing from cloud.Compute() share its rate limiter.
+			if rl != nil {
+				restConfig.RateLimiter = rl
+				composite.SetExtension(cloud, composite.NewGCEExtension(cloud, rl, restConfig))
+			} else {
+				composite.SetExtension(cloud, composite.NewGCEExtension(cloud, nil, restConfig))
+			}
 			// If this controller is scheduled on a node without compute/rw
 			// it won't be allowed to list backends. We can assume that the
 			// user has no need for Ingress in this case. If they grant
@@ -112,7 +136,7 @@ func NewGCEClient() *gce.Cloud {
 			// manually to re-create the client.
 			// TODO: why do we bail with success out if there is a permission error???
 			if _, err = cloud.ListGlobalBackendServices(); err == nil || utils.IsHTTPErrorCode(err, http.StatusForbidden) {
-				return cloud
+				return cloud, restConfig
 			}
 			klog.Warningf("Failed to list backend services, retrying: %v", err)
 		} else {
@@ -122,6 +146,42 @@ func NewGCEClient() *gce.Cloud {
 	}
 }
 
+// newGCPRESTConfig returns the configuration of the REST clients, which
+// authenticate with the token source and call the compute endpoint of the
+// cloud config read by configReader, like the GCE client.
+func newGCPRESTConfig(configReader readerFunc, projectID string) (*gcprest.Config, error) {
+	configFile := &gce.ConfigFile{}
+	if reader := configReader(); reader != nil {
+		if err := gcfg.FatalOnly(gcfg.ReadInto(configFile, reader)); err != nil {
+			return nil, err
+		}
+	}
+	computeEndpoint := defaultComputeEndpoint
+	if configFile.Global.APIEndpoint != "" {
+		computeEndpoint = configFile.Global.APIEndpoint
+	}
+	var tokenSource oauth2.TokenSource
+	switch configFile.Global.TokenURL {
+	case "":
+		tokenSource = google.ComputeTokenSource("")
+	case "nil":
+		// As in the GCE client, this allows to run the controller remotely.
+		var err error
+		if tokenSource, err = google.DefaultTokenSource(context.Background(), cloudPlatformScope); err != nil {
+			return nil, err
+		}
+	default:
+		tokenSource = gce.NewAltTokenSource(configFile.Global.TokenURL, configFile.Global.TokenBody)
+	}
+	return &gcprest.Config{
+		HTTPClient:      oauth2.NewClient(context.Background(), tokenSource),
+		ProjectID:       projectID,
+		ComputeEndpoint: computeEndpoint,
+		// As in the GCE client, the alpha endpoint is derived from the GA one.
+		AlphaComputeEndpoint: strings.Replace(computeEndpoint, "v1", "alpha", -1),
+	}, nil
+}
+
 type readerFunc func() io.Reader
 
 func generateConfigReaderFunc(config []byte) readerFunc {
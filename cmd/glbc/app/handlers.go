@@ -46,6 +46,12 @@ func RunHTTPServer(healthChecker func() context.HealthCheckResults) {
 	klog.Fatal(http.ListenAndServe(fmt.Sprintf(":%v", flags.F.HealthzPort), nil))
 }
 
+// RunACMESolver serves the HTTP-01 challenges answered by solver.
+func RunACMESolver(solver http.Handler) {
+	klog.V(0).Infof("Running ACME solver on :%v", flags.F.ACMESolverPort)
+	klog.Fatal(http.ListenAndServe(fmt.Sprintf(":%v", flags.F.ACMESolverPort), solver))
+}
+
 func RunSIGTERMHandler(lbc *controller.LoadBalancerController, deleteAll bool) {
 	// Multiple SIGTERMs will get dropped
 	signalChan := make(chan os.Signal, 1)
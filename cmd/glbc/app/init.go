@@ -62,6 +62,19 @@ func DefaultBackendServicePort(kubeClient kubernetes.Interface) utils.ServicePor
 	return *svcPort
 }
 
+// ACMESolverServicePortID returns the ServicePortID load balancers route
+// HTTP-01 challenges to.
+func ACMESolverServicePortID() *utils.ServicePortID {
+	name, err := utils.ToNamespacedName(flags.F.ACMESolverSvc)
+	if err != nil {
+		klog.Fatalf("Failed to parse --acme-solver-service: %v", err)
+	}
+	if flags.F.ACMESolverSvcPortName == "" {
+		klog.Fatalf("Please specify --acme-solver-service-port")
+	}
+	return &utils.ServicePortID{Service: name, Port: intstr.FromString(flags.F.ACMESolverSvcPortName)}
+}
+
 // IngressClassEnabled returns whether the IngressClass API exists on the kubernetes cluster
 func IngressClassEnabled(client kubernetes.Interface) bool {
 	klog.V(2).Info("Checking if Ingress Class API exists")
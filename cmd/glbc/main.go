@@ -25,10 +25,13 @@ import (
 
 	flag "github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/frontendconfig"
 	"k8s.io/ingress-gce/pkg/ingparams"
 	"k8s.io/ingress-gce/pkg/serviceattachment"
 	"k8s.io/ingress-gce/pkg/svcneg"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/klog"
 
 	crdclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -39,6 +42,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	backendgrantclient "k8s.io/ingress-gce/pkg/backendgrant/client/clientset/versioned"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 	ingparamsclient "k8s.io/ingress-gce/pkg/ingparams/client/clientset/versioned"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
@@ -46,15 +50,20 @@ import (
 	ingctx "k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller"
 	"k8s.io/ingress-gce/pkg/neg"
+	"k8s.io/ingress-gce/pkg/neg/readiness"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 
 	"k8s.io/ingress-gce/cmd/glbc/app"
+	"k8s.io/ingress-gce/pkg/acme"
 	"k8s.io/ingress-gce/pkg/backendconfig"
+	"k8s.io/ingress-gce/pkg/backendgrant"
 	"k8s.io/ingress-gce/pkg/crd"
 	"k8s.io/ingress-gce/pkg/firewalls"
 	"k8s.io/ingress-gce/pkg/flags"
+	igreadiness "k8s.io/ingress-gce/pkg/instances/readiness"
 	_ "k8s.io/ingress-gce/pkg/klog"
 	"k8s.io/ingress-gce/pkg/l4"
+	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/version"
 )
 
@@ -76,6 +85,12 @@ func main() {
 
 	klog.V(2).Infof("Flags = %+v", flags.F)
 	defer klog.Flush()
+	if err := utils.ValidateStaticGCELabels(); err != nil {
+		klog.Fatalf("Invalid --gce-static-labels: %v", err)
+	}
+	if err := readiness.ValidateBackendServicePolicy(); err != nil {
+		klog.Fatalf("Invalid --neg-readiness-policy: %v", err)
+	}
 	// Create kube-config that uses protobufs to communicate with API server.
 	kubeConfigForProtobuf, err := app.NewKubeConfigForProtobuf()
 	if err != nil {
@@ -161,6 +176,18 @@ func main() {
 		}
 	}
 
+	var backendGrantClient backendgrantclient.Interface
+	if flags.F.EnableCrossNamespaceBackends {
+		backendGrantCRDMeta := backendgrant.CRDMeta()
+		if _, err := crdHandler.EnsureCRD(backendGrantCRDMeta, true); err != nil {
+			klog.Fatalf("Failed to ensure BackendGrant CRD: %v", err)
+		}
+
+		if backendGrantClient, err = backendgrantclient.NewForConfig(kubeConfig); err != nil {
+			klog.Fatalf("Failed to create BackendGrant client: %v", err)
+		}
+	}
+
 	namer, err := app.NewNamer(kubeClient, flags.F.ClusterName, firewalls.DefaultFirewallName)
 	if err != nil {
 		klog.Fatalf("app.NewNamer(ctx.KubeClient, %q, %q) = %v", flags.F.ClusterName, firewalls.DefaultFirewallName, err)
@@ -176,7 +203,28 @@ func main() {
 	}
 	kubeSystemUID := kubeSystemNS.GetUID()
 
-	cloud := app.NewGCEClient()
+	cloud, restConfig := app.NewGCEClient()
+	var auditSinks []audit.Sink
+	if flags.F.AuditLogFile != "" {
+		sink, err := audit.NewFileSink(flags.F.AuditLogFile)
+		if err != nil {
+			klog.Fatalf("Failed to open audit log file %q: %v", flags.F.AuditLogFile, err)
+		}
+		auditSinks = append(auditSinks, sink)
+	}
+	if flags.F.AuditLogURL != "" {
+		auditSinks = append(auditSinks, audit.NewHTTPSink(flags.F.AuditLogURL, wait.NeverStop))
+	}
+	if len(auditSinks) > 0 {
+		klog.V(0).Infof("Audit logging of GCE mutations is enabled")
+		audit.Init(audit.NewMultiSink(auditSinks...), cloud.ProjectID())
+	}
+	if flags.F.TracingOTLPEndpoint != "" {
+		klog.V(0).Infof("Exporting traces to %s", flags.F.TracingOTLPEndpoint)
+		if err := tracing.Init(tracing.NewOTLPExporter(flags.F.TracingOTLPEndpoint, wait.NeverStop), flags.F.TracingSampleFraction); err != nil {
+			klog.Fatalf("Invalid --tracing-sample-fraction: %v", err)
+		}
+	}
 	defaultBackendServicePort := app.DefaultBackendServicePort(kubeClient)
 	ctxConfig := ingctx.ControllerContextConfig{
 		Namespace:             flags.F.WatchNamespace,
@@ -187,8 +235,12 @@ func main() {
 		EnableASMConfigMap:    flags.F.EnableASMConfigMapBasedConfig,
 		ASMConfigMapNamespace: flags.F.ASMConfigMapBasedConfigNamespace,
 		ASMConfigMapName:      flags.F.ASMConfigMapBasedConfigCMName,
+		GCPRESTConfig:         restConfig,
 	}
-	ctx := ingctx.NewControllerContext(kubeConfig, kubeClient, backendConfigClient, frontendConfigClient, svcNegClient, ingParamsClient, cloud, namer, kubeSystemUID, ctxConfig)
+	if flags.F.EnableACME {
+		ctxConfig.ACMESolverSvcPort = app.ACMESolverServicePortID()
+	}
+	ctx := ingctx.NewControllerContext(kubeConfig, kubeClient, backendConfigClient, frontendConfigClient, svcNegClient, ingParamsClient, backendGrantClient, cloud, namer, kubeSystemUID, ctxConfig)
 	go app.RunHTTPServer(ctx.HealthCheck)
 
 	if !flags.F.LeaderElection.LeaderElect {
@@ -288,6 +340,11 @@ func runControllers(ctx *ingctx.ControllerContext) {
 		asmServiceNEGSkipNamespaces = cmconfig.ASMServiceNEGSkipNamespaces
 	}
 
+	var externalNegCloud negtypes.ExternalNetworkEndpointGroupCloud
+	if flags.F.EnableExternalNEGs {
+		externalNegCloud = negtypes.NewExternalAdapter(ctx.Cloud)
+	}
+
 	// TODO: Refactor NEG to use cloud mocks so ctx.Cloud can be referenced within NewController.
 	negController := neg.NewController(
 		ctx.KubeClient,
@@ -301,11 +358,14 @@ func runControllers(ctx *ingctx.ControllerContext) {
 		ctx.EndpointInformer,
 		ctx.DestinationRuleInformer,
 		ctx.SvcNegInformer,
+		ctx.BackendGrantInformer,
+		ctx.WorkloadEndpointSliceInformer,
 		ctx.HasSynced,
 		ctx.ControllerMetrics,
 		ctx.L4Namer,
 		ctx.DefaultBackendSvcPort,
 		negtypes.NewAdapter(ctx.Cloud),
+		externalNegCloud,
 		zoneGetter,
 		ctx.ClusterNamer,
 		flags.F.ResyncPeriod,
@@ -328,6 +388,18 @@ func runControllers(ctx *ingctx.ControllerContext) {
 	go fwc.Run()
 	klog.V(0).Infof("firewall controller started")
 
+	if flags.F.EnableIGReadinessGate {
+		igReadinessController := igreadiness.NewController(ctx, lbc.Translator)
+		go igReadinessController.Run(stopCh)
+		klog.V(0).Infof("IG readiness controller started")
+	}
+
+	if flags.F.EnableACME {
+		issuer := newACMEIssuer(ctx)
+		go issuer.Run()
+		klog.V(0).Infof("ACME issuer started")
+	}
+
 	ctx.Start(stopCh)
 	lbc.Init()
 	lbc.Run()
@@ -340,3 +412,26 @@ func runControllers(ctx *ingctx.ControllerContext) {
 		}
 	}
 }
+
+// newACMEIssuer returns the issuer of ACME certificates, whose challenges are
+// served by the solver started with it.
+func newACMEIssuer(ctx *ingctx.ControllerContext) *acme.Issuer {
+	accountSecret, err := utils.ToNamespacedName(flags.F.ACMEAccountSecret)
+	if err != nil {
+		klog.Fatalf("Failed to parse --acme-account-secret: %v", err)
+	}
+	httpClient, err := acme.NewHTTPClient(flags.F.ACMEDirectoryCAFile)
+	if err != nil {
+		klog.Fatalf("Failed to load --acme-directory-ca-file: %v", err)
+	}
+	solver := acme.NewSolver()
+	go app.RunACMESolver(solver)
+	return acme.NewIssuer(ctx, acme.Config{
+		DirectoryURL:  flags.F.ACMEDirectoryURL,
+		HTTPClient:    httpClient,
+		Email:         flags.F.ACMEEmail,
+		AccountSecret: accountSecret,
+		RenewBefore:   flags.F.ACMERenewBefore,
+		SelfCheck:     flags.F.ACMESelfCheck,
+	}, solver)
+}
@@ -109,3 +109,18 @@ ${GOPATH}/bin/openapi-gen \
   --input-dirs k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1\
   --output-package k8s.io/ingress-gce/pkg/apis/ingparams/v1beta1 \
   --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt
+
+echo "Performing code generation for BackendGrant CRD"
+${CODEGEN_PKG}/generate-groups.sh \
+  "deepcopy,client,informer,lister" \
+  k8s.io/ingress-gce/pkg/backendgrant/client k8s.io/ingress-gce/pkg/apis \
+  "backendgrant:v1alpha1" \
+  --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt
+
+echo "Generating openapi for BackendGrant v1alpha1"
+go install ${OPENAPI_PKG}/cmd/openapi-gen
+${GOPATH}/bin/openapi-gen \
+  --output-file-base zz_generated.openapi \
+  --input-dirs k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1\
+  --output-package k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1 \
+  --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt
@@ -0,0 +1,485 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	gocontext "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+const (
+	// IngressKey is the annotation of the Secrets written by the Issuer. Its
+	// value is the name of the Ingress the certificate was issued for.
+	// Secrets without it, or annotated for another Ingress, are never
+	// overwritten.
+	IngressKey = "networking.gke.io/acme-ingress"
+	// accountKeyKey is the key of the account private key in its Secret.
+	accountKeyKey = "account.key"
+
+	// issueTimeout bounds the issuance of the certificate of a TLS entry.
+	issueTimeout = 5 * time.Minute
+	// selfCheckTimeout bounds the request of the self check of a challenge.
+	selfCheckTimeout = 10 * time.Second
+)
+
+// client is the part of acme.Client used by the Issuer.
+type client interface {
+	Register(ctx gocontext.Context, acct *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error)
+	AuthorizeOrder(ctx gocontext.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error)
+	GetAuthorization(ctx gocontext.Context, url string) (*acme.Authorization, error)
+	HTTP01ChallengeResponse(token string) (string, error)
+	Accept(ctx gocontext.Context, chal *acme.Challenge) (*acme.Challenge, error)
+	WaitAuthorization(ctx gocontext.Context, url string) (*acme.Authorization, error)
+	WaitOrder(ctx gocontext.Context, url string) (*acme.Order, error)
+	CreateOrderCert(ctx gocontext.Context, url string, csr []byte, bundle bool) (der [][]byte, certURL string, err error)
+}
+
+// Config configures the Issuer.
+type Config struct {
+	// DirectoryURL is the directory of the ACME server.
+	DirectoryURL string
+	// HTTPClient talks to the ACME server, http.DefaultClient if nil.
+	HTTPClient *http.Client
+	// Email is the contact of the ACME account, if any.
+	Email string
+	// AccountSecret is the Secret the account key is stored in.
+	AccountSecret types.NamespacedName
+	// RenewBefore is the time before its expiry a certificate is renewed at.
+	RenewBefore time.Duration
+	// SelfCheck verifies that a challenge is served by the load balancer
+	// before it is accepted.
+	SelfCheck bool
+}
+
+// Issuer issues the certificates of the TLS entries of Ingresses with the
+// ACME annotation, and renews them. Certificates are stored in the Secrets
+// of the TLS entries, from which the load balancer controller uploads them.
+// The load balancer routes the HTTP-01 challenges of the TLS hosts to the
+// Solver, see the translator.
+type Issuer struct {
+	ctx    *context.ControllerContext
+	config Config
+	solver *Solver
+	queue  utils.TaskQueue
+
+	// newClient returns the ACME client of the account key.
+	newClient func(key crypto.Signer) client
+	// selfCheck returns an error if the challenge is not served for host.
+	selfCheck func(ctx gocontext.Context, host, token, keyAuth string) error
+	// now returns the current time.
+	now       func() time.Time
+	hasSynced func() bool
+
+	// lock guards client, which is registered on first use.
+	lock   sync.Mutex
+	client client
+}
+
+// NewIssuer returns an Issuer which answers challenges with solver.
+func NewIssuer(ctx *context.ControllerContext, config Config, solver *Solver) *Issuer {
+	i := &Issuer{
+		ctx:    ctx,
+		config: config,
+		solver: solver,
+		newClient: func(key crypto.Signer) client {
+			return &acme.Client{Key: key, DirectoryURL: config.DirectoryURL, HTTPClient: config.HTTPClient, UserAgent: "ingress-gce"}
+		},
+		now:       time.Now,
+		hasSynced: ctx.HasSynced,
+	}
+	i.selfCheck = i.checkChallenge
+	// Failed orders count against the rate limits of ACME servers, retry
+	// them slowly.
+	rl := workqueue.NewItemExponentialFailureRateLimiter(time.Minute, 6*time.Hour)
+	i.queue = utils.NewPeriodicTaskQueueWithLimiter("acme", "ingresses", i.sync, rl)
+
+	// Resyncs of the informer renew the certificates in time.
+	ctx.IngressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ing := obj.(*v1beta1.Ingress); annotations.FromIngress(ing).ACMEEnabled() {
+				i.queue.Enqueue(ing)
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if ing := cur.(*v1beta1.Ingress); annotations.FromIngress(ing).ACMEEnabled() {
+				i.queue.Enqueue(ing)
+			}
+		},
+	})
+	return i
+}
+
+// NewHTTPClient returns a client for an ACME server whose certificate is
+// verified with the CA certificates in caFile. It returns nil, which stands
+// for the default client, if caFile is empty.
+func NewHTTPClient(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
+
+// Run processes the queue until Shutdown is called.
+func (i *Issuer) Run() {
+	i.queue.Run()
+}
+
+// Shutdown stops the Issuer.
+func (i *Issuer) Shutdown() {
+	klog.Infof("Shutting down ACME issuer")
+	i.queue.Shutdown()
+}
+
+func (i *Issuer) sync(key string) error {
+	if !i.hasSynced() {
+		time.Sleep(context.StoreSyncPollPeriod)
+		return fmt.Errorf("waiting for stores to sync")
+	}
+	ing, exists, err := i.ctx.Ingresses().GetByKey(key)
+	if err != nil {
+		return fmt.Errorf("error getting Ingress for key %s: %v", key, err)
+	}
+	if !exists || utils.NeedsCleanup(ing) || !annotations.FromIngress(ing).ACMEEnabled() {
+		return nil
+	}
+
+	var errs []error
+	for _, entry := range ing.Spec.TLS {
+		if err := i.ensureCertificate(ing, entry); err != nil {
+			i.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.CertificateIssuanceFailed, "Failed to issue certificate for Secret %q: %v", entry.SecretName, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+// ensureCertificate issues the certificate of the TLS entry of the Ingress
+// if its Secret does not hold a certificate for the hosts of the entry which
+// is valid for longer than RenewBefore.
+func (i *Issuer) ensureCertificate(ing *v1beta1.Ingress, entry v1beta1.IngressTLS) error {
+	if entry.SecretName == "" {
+		return fmt.Errorf("TLS entry has no secretName")
+	}
+	if len(entry.Hosts) == 0 {
+		return fmt.Errorf("TLS entry has no hosts")
+	}
+	for _, host := range entry.Hosts {
+		if strings.Contains(host, "*") {
+			return fmt.Errorf("wildcard host %q cannot be validated with an HTTP-01 challenge", host)
+		}
+	}
+
+	secret, err := i.ctx.KubeClient.CoreV1().Secrets(ing.Namespace).Get(gocontext.TODO(), entry.SecretName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		secret = nil
+	}
+	if secret != nil {
+		owner, ok := secret.Annotations[IngressKey]
+		if !ok {
+			klog.V(2).Infof("Not issuing certificate for Secret %s/%s of Ingress %s/%s, which is not managed by the ACME issuer", secret.Namespace, secret.Name, ing.Namespace, ing.Name)
+			return nil
+		}
+		// Issuing for both Ingresses would overwrite the certificate of the
+		// other on every sync.
+		if owner != ing.Name {
+			klog.V(2).Infof("Not issuing certificate for Secret %s/%s of Ingress %s/%s, which is managed for Ingress %s", secret.Namespace, secret.Name, ing.Namespace, ing.Name, owner)
+			i.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.CertificateIssuanceFailed, "Secret %q holds the certificate of Ingress %q, not issuing a certificate into it", entry.SecretName, owner)
+			return nil
+		}
+		reason := i.renewalReason(secret, entry.Hosts)
+		if reason == "" {
+			return nil
+		}
+		klog.V(2).Infof("Renewing certificate in Secret %s/%s of Ingress %s/%s: %s", secret.Namespace, secret.Name, ing.Namespace, ing.Name, reason)
+	}
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), issueTimeout)
+	defer cancel()
+	cert, key, err := i.issue(ctx, entry.Hosts)
+	if err != nil {
+		return err
+	}
+
+	desired := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        entry.SecretName,
+			Namespace:   ing.Namespace,
+			Annotations: map[string]string{IngressKey: ing.Name},
+		},
+		Type: apiv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+		},
+	}
+	secrets := i.ctx.KubeClient.CoreV1().Secrets(ing.Namespace)
+	if secret == nil {
+		_, err = secrets.Create(gocontext.TODO(), desired, metav1.CreateOptions{})
+	} else {
+		updated := secret.DeepCopy()
+		updated.Annotations[IngressKey] = ing.Name
+		updated.Data = desired.Data
+		_, err = secrets.Update(gocontext.TODO(), updated, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("error storing certificate: %v", err)
+	}
+	i.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeNormal, events.CertificateIssued, "Certificate for %s issued into Secret %q", strings.Join(entry.Hosts, ", "), entry.SecretName)
+	return nil
+}
+
+// renewalReason returns why the certificate in the Secret must be issued
+// again for hosts, empty if it need not be.
+func (i *Issuer) renewalReason(secret *apiv1.Secret, hosts []string) string {
+	block, _ := pem.Decode(secret.Data[apiv1.TLSCertKey])
+	if block == nil {
+		return "no certificate"
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Sprintf("invalid certificate: %v", err)
+	}
+	if !sets.NewString(cert.DNSNames...).Equal(sets.NewString(hosts...)) {
+		return fmt.Sprintf("certificate is for %v", cert.DNSNames)
+	}
+	if i.now().Add(i.config.RenewBefore).After(cert.NotAfter) {
+		return fmt.Sprintf("certificate expires at %v", cert.NotAfter)
+	}
+	return ""
+}
+
+// issue orders a certificate for hosts. It returns the PEM encoded
+// certificate chain and private key.
+func (i *Issuer) issue(ctx gocontext.Context, hosts []string) ([]byte, []byte, error) {
+	c, err := i.registeredClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	order, err := c.AuthorizeOrder(ctx, acme.DomainIDs(hosts...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating order: %v", err)
+	}
+	for _, url := range order.AuthzURLs {
+		if err := i.authorize(ctx, c, url); err != nil {
+			return nil, nil, err
+		}
+	}
+	if order, err = c.WaitOrder(ctx, order.URI); err != nil {
+		return nil, nil, fmt.Errorf("error waiting for order: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	sorted := append([]string(nil), hosts...)
+	sort.Strings(sorted)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: sorted[0]},
+		DNSNames: sorted,
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, _, err := c.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finalizing order: %v", err)
+	}
+
+	var cert []byte
+	for _, b := range der {
+		cert = append(cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b})...)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), nil
+}
+
+// authorize completes the HTTP-01 challenge of the authorization, unless it
+// is already valid.
+func (i *Issuer) authorize(ctx gocontext.Context, c client, url string) error {
+	authz, err := c.GetAuthorization(ctx, url)
+	if err != nil {
+		return fmt.Errorf("error getting authorization: %v", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+	host := authz.Identifier.Value
+	var chal *acme.Challenge
+	for _, ch := range authz.Challenges {
+		if ch.Type == "http-01" {
+			chal = ch
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("ACME server offers no HTTP-01 challenge for %s", host)
+	}
+	keyAuth, err := c.HTTP01ChallengeResponse(chal.Token)
+	if err != nil {
+		return err
+	}
+
+	i.solver.Present(chal.Token, keyAuth)
+	defer i.solver.CleanUp(chal.Token)
+	if i.config.SelfCheck {
+		if err := i.selfCheck(ctx, host, chal.Token, keyAuth); err != nil {
+			return fmt.Errorf("challenge for %s is not served by the load balancer yet: %v", host, err)
+		}
+	}
+	if _, err := c.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("error accepting challenge for %s: %v", host, err)
+	}
+	if _, err := c.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("error validating %s: %v", host, err)
+	}
+	return nil
+}
+
+// checkChallenge requests the challenge from the load balancer of host.
+func (i *Issuer) checkChallenge(ctx gocontext.Context, host, token, keyAuth string) error {
+	ctx, cancel := gocontext.WithTimeout(ctx, selfCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, "http://"+host+ChallengePath+token, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != keyAuth {
+		return fmt.Errorf("got status %d and an unexpected response", resp.StatusCode)
+	}
+	return nil
+}
+
+// registeredClient returns the client of the ACME account, which is
+// registered on first use. The account key is read from, or created in, the
+// account Secret.
+func (i *Issuer) registeredClient(ctx gocontext.Context) (client, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.client != nil {
+		return i.client, nil
+	}
+
+	key, err := i.accountKey()
+	if err != nil {
+		return nil, fmt.Errorf("error getting ACME account key: %v", err)
+	}
+	c := i.newClient(key)
+	acct := &acme.Account{}
+	if i.config.Email != "" {
+		acct.Contact = []string{"mailto:" + i.config.Email}
+	}
+	if _, err := c.Register(ctx, acct, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return nil, fmt.Errorf("error registering ACME account: %v", err)
+	}
+	klog.V(2).Infof("Registered ACME account with %s", i.config.DirectoryURL)
+	i.client = c
+	return c, nil
+}
+
+// accountKey returns the account key stored in the account Secret, which is
+// created with a new key if it does not exist.
+func (i *Issuer) accountKey() (crypto.Signer, error) {
+	name := i.config.AccountSecret
+	secrets := i.ctx.KubeClient.CoreV1().Secrets(name.Namespace)
+	secret, err := secrets.Get(gocontext.TODO(), name.Name, metav1.GetOptions{})
+	if err == nil {
+		block, _ := pem.Decode(secret.Data[accountKeyKey])
+		if block == nil {
+			return nil, fmt.Errorf("Secret %s has no PEM encoded %s", name, accountKeyKey)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(2).Infof("Creating ACME account key in Secret %s", name)
+	_, err = secrets.Create(gocontext.TODO(), &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Data:       map[string][]byte{accountKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	gocontext "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+var testNow = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeClient is an ACME server which validates challenges through the
+// solver and signs certificates with a test CA.
+type fakeClient struct {
+	solver   *Solver
+	caKey    *ecdsa.PrivateKey
+	caCert   *x509.Certificate
+	validity time.Duration
+
+	registered int
+	orders     int
+	accepted   []string
+}
+
+func newFakeClient(t *testing.T, solver *Solver) *fakeClient {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             testNow.Add(-time.Hour),
+		NotAfter:              testNow.Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fakeClient{solver: solver, caKey: key, caCert: cert, validity: 90 * 24 * time.Hour}
+}
+
+func (f *fakeClient) Register(ctx gocontext.Context, acct *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error) {
+	f.registered++
+	return acct, nil
+}
+
+func (f *fakeClient) AuthorizeOrder(ctx gocontext.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error) {
+	f.orders++
+	order := &acme.Order{URI: "order", Status: acme.StatusPending, FinalizeURL: "finalize"}
+	for _, i := range id {
+		order.AuthzURLs = append(order.AuthzURLs, i.Value)
+	}
+	return order, nil
+}
+
+func (f *fakeClient) GetAuthorization(ctx gocontext.Context, url string) (*acme.Authorization, error) {
+	return &acme.Authorization{
+		URI:        url,
+		Status:     acme.StatusPending,
+		Identifier: acme.AuthzID{Type: "dns", Value: url},
+		Challenges: []*acme.Challenge{
+			{Type: "dns-01", Token: "dns-token-" + url},
+			{Type: "http-01", Token: "token-" + url},
+		},
+	}, nil
+}
+
+func (f *fakeClient) HTTP01ChallengeResponse(token string) (string, error) {
+	return token + ".thumbprint", nil
+}
+
+func (f *fakeClient) Accept(ctx gocontext.Context, chal *acme.Challenge) (*acme.Challenge, error) {
+	rec := httptest.NewRecorder()
+	f.solver.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ChallengePath+chal.Token, nil))
+	if want := chal.Token + ".thumbprint"; rec.Code != http.StatusOK || rec.Body.String() != want {
+		return nil, fmt.Errorf("challenge %s served as %d %q, want %q", chal.Token, rec.Code, rec.Body.String(), want)
+	}
+	f.accepted = append(f.accepted, chal.Token)
+	return chal, nil
+}
+
+func (f *fakeClient) WaitAuthorization(ctx gocontext.Context, url string) (*acme.Authorization, error) {
+	return &acme.Authorization{URI: url, Status: acme.StatusValid}, nil
+}
+
+func (f *fakeClient) WaitOrder(ctx gocontext.Context, url string) (*acme.Order, error) {
+	return &acme.Order{URI: url, Status: acme.StatusReady, FinalizeURL: "finalize"}, nil
+}
+
+func (f *fakeClient) CreateOrderCert(ctx gocontext.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+	req, err := x509.ParseCertificateRequest(csr)
+	if err != nil {
+		return nil, "", err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(f.orders + 1)),
+		Subject:      req.Subject,
+		DNSNames:     req.DNSNames,
+		NotBefore:    testNow,
+		NotAfter:     testNow.Add(f.validity),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, f.caCert, req.PublicKey, f.caKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return [][]byte{der, f.caCert.Raw}, "cert", nil
+}
+
+func newTestIssuer(t *testing.T, config Config) (*Issuer, *fakeClient) {
+	ctxConfig := context.ControllerContextConfig{
+		Namespace:    apiv1.NamespaceAll,
+		ResyncPeriod: time.Minute,
+	}
+	ctx := context.NewControllerContext(nil, fake.NewSimpleClientset(), backendconfigclient.NewSimpleClientset(), nil, nil, nil, nil, nil, namer.NewNamer("uid1", ""), "", ctxConfig)
+	solver := NewSolver()
+	i := NewIssuer(ctx, config, solver)
+	fc := newFakeClient(t, solver)
+	i.newClient = func(crypto.Signer) client { return fc }
+	i.now = func() time.Time { return testNow }
+	i.hasSynced = func() bool { return true }
+	return i, fc
+}
+
+func newTestIngress(hosts ...string) *v1beta1.Ingress {
+	return &v1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ing",
+			Namespace:   "default",
+			Annotations: map[string]string{annotations.ACMEKey: "true"},
+		},
+		Spec: v1beta1.IngressSpec{
+			TLS: []v1beta1.IngressTLS{{Hosts: hosts, SecretName: "tls"}},
+		},
+	}
+}
+
+func TestIssuerSync(t *testing.T) {
+	hosts := []string{"foo.com", "bar.foo.com"}
+	config := Config{
+		AccountSecret: types.NamespacedName{Namespace: "kube-system", Name: "account"},
+		RenewBefore:   30 * 24 * time.Hour,
+	}
+
+	i, fc := newTestIssuer(t, config)
+	ing := newTestIngress(hosts...)
+	i.ctx.IngressInformer.GetIndexer().Add(ing)
+
+	if err := i.sync("default/ing"); err != nil {
+		t.Fatalf("sync() = %v, want nil", err)
+	}
+	wantAccepted := []string{"token-bar.foo.com", "token-foo.com"}
+	sort.Strings(fc.accepted)
+	if fc.registered != 1 || fc.orders != 1 || !reflect.DeepEqual(fc.accepted, wantAccepted) {
+		t.Fatalf("got %d registrations, %d orders, accepted %v, want 1, 1, %v", fc.registered, fc.orders, fc.accepted, wantAccepted)
+	}
+	secret, err := i.ctx.KubeClient.CoreV1().Secrets("default").Get(gocontext.TODO(), "tls", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(tls) = %v", err)
+	}
+	if secret.Annotations[IngressKey] != "ing" || secret.Type != apiv1.SecretTypeTLS {
+		t.Errorf("Secret has annotations %v and type %q, want %s=ing and %q", secret.Annotations, secret.Type, IngressKey, apiv1.SecretTypeTLS)
+	}
+	block, _ := pem.Decode(secret.Data[apiv1.TLSCertKey])
+	if block == nil {
+		t.Fatalf("Secret has no certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cert.DNSNames, []string{"bar.foo.com", "foo.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("certificate is for %v, want %v", got, want)
+	}
+	if block, _ := pem.Decode(secret.Data[apiv1.TLSPrivateKeyKey]); block == nil {
+		t.Errorf("Secret has no private key")
+	} else if key, err := x509.ParseECPrivateKey(block.Bytes); err != nil || !reflect.DeepEqual(key.Public(), cert.PublicKey) {
+		t.Errorf("private key does not match the certificate: %v", err)
+	}
+	if _, err := i.ctx.KubeClient.CoreV1().Secrets("kube-system").Get(gocontext.TODO(), "account", metav1.GetOptions{}); err != nil {
+		t.Errorf("Get(account) = %v, want the account key", err)
+	}
+	if len(i.solver.keyAuths) != 0 {
+		t.Errorf("solver has challenges %v after issuance, want none", i.solver.keyAuths)
+	}
+
+	// The certificate is valid for longer than RenewBefore.
+	if err := i.sync("default/ing"); err != nil || fc.orders != 1 {
+		t.Errorf("sync() = %v with %d orders, want nil with 1 order", err, fc.orders)
+	}
+
+	// The certificate expires within RenewBefore.
+	i.now = func() time.Time { return testNow.Add(70 * 24 * time.Hour) }
+	if err := i.sync("default/ing"); err != nil || fc.orders != 2 {
+		t.Errorf("sync() = %v with %d orders, want nil with 2 orders", err, fc.orders)
+	}
+
+	// A host is added to the TLS entry.
+	i.now = func() time.Time { return testNow }
+	ing = newTestIngress(append(hosts, "baz.com")...)
+	i.ctx.IngressInformer.GetIndexer().Update(ing)
+	if err := i.sync("default/ing"); err != nil || fc.orders != 3 {
+		t.Errorf("sync() = %v with %d orders, want nil with 3 orders", err, fc.orders)
+	}
+	if fc.registered != 1 {
+		t.Errorf("got %d registrations, want 1", fc.registered)
+	}
+}
+
+func TestIssuerSyncSkips(t *testing.T) {
+	config := Config{
+		AccountSecret: types.NamespacedName{Namespace: "kube-system", Name: "account"},
+		RenewBefore:   30 * 24 * time.Hour,
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		ing     *v1beta1.Ingress
+		secret  *apiv1.Secret
+		wantErr bool
+	}{
+		{
+			desc: "not annotated",
+			ing: func() *v1beta1.Ingress {
+				ing := newTestIngress("foo.com")
+				ing.Annotations = nil
+				return ing
+			}(),
+		},
+		{
+			desc:    "wildcard host",
+			ing:     newTestIngress("*.foo.com"),
+			wantErr: true,
+		},
+		{
+			desc: "secret not managed by the issuer",
+			ing:  newTestIngress("foo.com"),
+			secret: &apiv1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default"},
+				Data:       map[string][]byte{apiv1.TLSCertKey: []byte("user cert")},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			i, fc := newTestIssuer(t, config)
+			i.ctx.IngressInformer.GetIndexer().Add(tc.ing)
+			if tc.secret != nil {
+				i.ctx.KubeClient.CoreV1().Secrets("default").Create(gocontext.TODO(), tc.secret, metav1.CreateOptions{})
+			}
+			if err := i.sync("default/ing"); (err != nil) != tc.wantErr {
+				t.Errorf("sync() = %v, want err? %v", err, tc.wantErr)
+			}
+			if fc.orders != 0 {
+				t.Errorf("got %d orders, want none", fc.orders)
+			}
+			if tc.secret != nil {
+				secret, _ := i.ctx.KubeClient.CoreV1().Secrets("default").Get(gocontext.TODO(), "tls", metav1.GetOptions{})
+				if !reflect.DeepEqual(secret, tc.secret) {
+					t.Errorf("Secret = %+v, want it unchanged", secret)
+				}
+			}
+		})
+	}
+}
+
+func TestIssuerSyncSharedSecret(t *testing.T) {
+	config := Config{
+		AccountSecret: types.NamespacedName{Namespace: "kube-system", Name: "account"},
+		RenewBefore:   30 * 24 * time.Hour,
+	}
+	i, fc := newTestIssuer(t, config)
+	ing := newTestIngress("foo.com")
+	other := newTestIngress("bar.com")
+	other.Name = "other"
+	i.ctx.IngressInformer.GetIndexer().Add(ing)
+	i.ctx.IngressInformer.GetIndexer().Add(other)
+
+	if err := i.sync("default/ing"); err != nil || fc.orders != 1 {
+		t.Fatalf("sync(default/ing) = %v with %d orders, want nil with 1 order", err, fc.orders)
+	}
+	want, err := i.ctx.KubeClient.CoreV1().Secrets("default").Get(gocontext.TODO(), "tls", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(tls) = %v", err)
+	}
+
+	// The Secret is managed for the first Ingress, the second one neither
+	// issues into it nor overwrites it.
+	for n := 0; n < 2; n++ {
+		for _, key := range []string{"default/other", "default/ing"} {
+			if err := i.sync(key); err != nil {
+				t.Errorf("sync(%s) = %v, want nil", key, err)
+			}
+		}
+	}
+	if fc.orders != 1 {
+		t.Errorf("got %d orders, want 1", fc.orders)
+	}
+	secret, err := i.ctx.KubeClient.CoreV1().Secrets("default").Get(gocontext.TODO(), "tls", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(tls) = %v", err)
+	}
+	if !reflect.DeepEqual(secret, want) {
+		t.Errorf("Secret = %+v, want it unchanged", secret)
+	}
+}
+
+func TestIssuerSelfCheck(t *testing.T) {
+	config := Config{
+		AccountSecret: types.NamespacedName{Namespace: "kube-system", Name: "account"},
+		SelfCheck:     true,
+	}
+	i, fc := newTestIssuer(t, config)
+	var checked []string
+	i.selfCheck = func(ctx gocontext.Context, host, token, keyAuth string) error {
+		checked = append(checked, host)
+		return fmt.Errorf("not routed")
+	}
+	i.ctx.IngressInformer.GetIndexer().Add(newTestIngress("foo.com"))
+
+	if err := i.sync("default/ing"); err == nil {
+		t.Errorf("sync() = nil, want error")
+	}
+	if !reflect.DeepEqual(checked, []string{"foo.com"}) || len(fc.accepted) != 0 {
+		t.Errorf("checked %v and accepted %v, want [foo.com] and none", checked, fc.accepted)
+	}
+	if len(i.solver.keyAuths) != 0 {
+		t.Errorf("solver has challenges %v, want none", i.solver.keyAuths)
+	}
+}
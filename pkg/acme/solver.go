@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/klog"
+)
+
+// ChallengePath is the path prefix under which HTTP-01 challenges are served.
+const ChallengePath = "/.well-known/acme-challenge/"
+
+// Solver answers the HTTP-01 challenges of pending authorizations. The load
+// balancers of Ingresses with ACME issued certificates route ChallengePath to
+// it.
+type Solver struct {
+	lock sync.RWMutex
+	// keyAuths maps the token of a challenge to its key authorization.
+	keyAuths map[string]string
+}
+
+// NewSolver returns a Solver without challenges.
+func NewSolver() *Solver {
+	return &Solver{keyAuths: map[string]string{}}
+}
+
+// Present starts answering the challenge with the token.
+func (s *Solver) Present(token, keyAuth string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keyAuths[token] = keyAuth
+}
+
+// CleanUp stops answering the challenge with the token.
+func (s *Solver) CleanUp(token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.keyAuths, token)
+}
+
+// ServeHTTP implements http.Handler. Requests outside of ChallengePath are
+// answered with 200, so the solver passes the health checks of its backend.
+func (s *Solver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, ChallengePath) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	token := strings.TrimPrefix(r.URL.Path, ChallengePath)
+	s.lock.RLock()
+	keyAuth, ok := s.keyAuths[token]
+	s.lock.RUnlock()
+	if !ok {
+		klog.V(3).Infof("No ACME challenge for token %q of host %q", token, r.Host)
+		http.NotFound(w, r)
+		return
+	}
+	klog.V(3).Infof("Answering ACME challenge for token %q of host %q", token, r.Host)
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(keyAuth))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSolver(t *testing.T) {
+	s := NewSolver()
+	s.Present("token", "token.thumbprint")
+
+	for _, tc := range []struct {
+		desc     string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			desc:     "health check",
+			path:     "/",
+			wantCode: http.StatusOK,
+		},
+		{
+			desc:     "presented challenge",
+			path:     ChallengePath + "token",
+			wantCode: http.StatusOK,
+			wantBody: "token.thumbprint",
+		},
+		{
+			desc:     "unknown challenge",
+			path:     ChallengePath + "other-token",
+			wantCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://foo.com"+tc.path, nil))
+			if rec.Code != tc.wantCode {
+				t.Errorf("ServeHTTP(%q) = %d, want %d", tc.path, rec.Code, tc.wantCode)
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("ServeHTTP(%q) body = %q, want %q", tc.path, rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+
+	s.CleanUp("token")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://foo.com"+ChallengePath+"token", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("ServeHTTP() after CleanUp() = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package annotations
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 
@@ -64,6 +65,9 @@ const (
 	GceIngressClass      = "gce"
 	GceMultiIngressClass = "gce-multi-cluster"
 	GceL7ILBIngressClass = "gce-internal"
+	// GceL7XLBRegionalIngressClass is the class of Ingresses served by a
+	// regional external L7 load balancer.
+	GceL7XLBRegionalIngressClass = "gce-regional-external"
 
 	// Label key to denote which GCE zone a Kubernetes node is in.
 	ZoneKey     = "failure-domain.beta.kubernetes.io/zone"
@@ -87,6 +91,42 @@ const (
 	//     networking.gke.io/v1beta1.FrontendConfig: 'my-frontendconfig'
 	FrontendConfigKey = "networking.gke.io/v1beta1.FrontendConfig"
 
+	// BackendNamespacesKey is the annotation key used by controller to resolve
+	// Ingress backends to Services in other namespaces. The value is a JSON map
+	// of Service name to the namespace of the Service. A cross-namespace reference
+	// is only honored if a BackendGrant in the target namespace allows it.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/backend-namespaces: '{"shop": "shop-prod"}'
+	BackendNamespacesKey = "networking.gke.io/backend-namespaces"
+
+	// PauseReconciliationKey tells the controllers to stop reconciling the
+	// load balancer of an Ingress or a Service. While set to true, no GCE
+	// resource of the object is created, updated or garbage collected, so the
+	// load balancer can be modified by hand without being reverted.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/pause-reconciliation: "true"
+	PauseReconciliationKey = "networking.gke.io/pause-reconciliation"
+
+	// CertificateMapKey is the annotation key used by controller to attach a
+	// Certificate Manager certificate map to the target https proxy instead
+	// of SSL certificates. The value is the name of a certificate map in the
+	// project of the cluster, or its full resource name.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/certificate-map: 'my-certificate-map'
+	CertificateMapKey = "networking.gke.io/certificate-map"
+
+	// ACMEKey is the annotation key used by controller to issue certificates
+	// for the TLS hosts of the Ingress from an ACME directory. The certificates
+	// are stored in the Secrets of the TLS entries and renewed before they
+	// expire. Requires --enable-acme.
+	// Examples:
+	// - annotations:
+	//     networking.gke.io/acme: "true"
+	ACMEKey = "networking.gke.io/acme"
+
 	// UrlMapKey is the annotation key used by controller to record GCP URL map.
 	UrlMapKey = StatusPrefix + "/url-map"
 	// UrlMapKey is the annotation key used by controller to record GCP URL map used for Https Redirects only.
@@ -107,6 +147,13 @@ const (
 	SSLCertKey = StatusPrefix + "/ssl-cert"
 	// StaticIPKey is the annotation key used by controller to record GCP static ip.
 	StaticIPKey = StatusPrefix + "/static-ip"
+	// PausedKey is the annotation key used by controller to record that
+	// reconciliation of the Ingress is paused.
+	PausedKey = StatusPrefix + "/paused"
+	// ProxyOnlySubnetReadyKey is the annotation key used by controller to
+	// record whether the proxy-only subnet required by a regional Ingress
+	// exists. The value is "True" or "False".
+	ProxyOnlySubnetReadyKey = StatusPrefix + "/proxy-only-subnet-ready"
 )
 
 // Ingress represents ingress annotations.
@@ -146,8 +193,21 @@ func (ing *Ingress) UseNamedTLS() string {
 	return val
 }
 
+// CertificateMap returns the certificate map of the target https proxy.
+// Empty by default.
+func (ing *Ingress) CertificateMap() string {
+	return ing.v[CertificateMapKey]
+}
+
+// ACMEEnabled returns true if certificates of the Ingress are issued from an
+// ACME directory. False by default.
+func (ing *Ingress) ACMEEnabled() bool {
+	v, err := strconv.ParseBool(ing.v[ACMEKey])
+	return err == nil && v
+}
+
 func (ing *Ingress) StaticIPName() (string, error) {
-	if !flags.F.EnableL7Ilb {
+	if !flags.F.EnableL7Ilb && !flags.F.EnableL7XLBRegional {
 		return ing.GlobalStaticIPName(), nil
 	}
 
@@ -210,3 +270,36 @@ func (ing *Ingress) FrontendConfig() string {
 	}
 	return val
 }
+
+// BackendNamespaces returns the map of Service name to namespace used to
+// resolve cross-namespace backends. Returns an empty map if the annotation
+// is not set.
+func (ing *Ingress) BackendNamespaces() (map[string]string, error) {
+	ret := map[string]string{}
+	val, ok := ing.v[BackendNamespacesKey]
+	if !ok {
+		return ret, nil
+	}
+	if err := json.Unmarshal([]byte(val), &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// PauseReconciliation returns true if reconciliation of the Ingress is paused.
+// False by default.
+func (ing *Ingress) PauseReconciliation() bool {
+	return parsePauseReconciliation(ing.v)
+}
+
+func parsePauseReconciliation(v map[string]string) bool {
+	val, ok := v[PauseReconciliationKey]
+	if !ok {
+		return false
+	}
+	paused, err := strconv.ParseBool(val)
+	if err != nil {
+		return false
+	}
+	return paused
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"k8s.io/api/core/v1"
 	"k8s.io/legacy-cloud-providers/gce"
+	"net"
+	"strconv"
 	"strings"
 )
 
@@ -49,6 +51,39 @@ const (
 	// on the Service, and is applied by the NEG Controller.
 	NEGStatusKey = "cloud.google.com/neg-status"
 
+	// ExternalNEGKey is the annotation key of a Service, usually of type
+	// ExternalName, whose Ingress backends are a serverless or an Internet NEG
+	// instead of the endpoints of the Service. The value must be a valid JSON
+	// string in the format specified by type ExternalNEG. The port of
+	// Internet endpoints is the target port of the Service port.
+	// examples:
+	// - `{"networkEndpointType":"SERVERLESS","cloudRun":{"service":"hello"}}`
+	// - `{"networkEndpointType":"INTERNET_FQDN_PORT","fqdn":"example.com"}`
+	// - `{"networkEndpointType":"INTERNET_IP_PORT","ipAddress":"203.0.113.1"}`
+	ExternalNEGKey = "cloud.google.com/external-neg"
+
+	// ServerlessNEGType, InternetFQDNNEGType and InternetIPNEGType are the
+	// network endpoint types of external NEGs.
+	ServerlessNEGType   = "SERVERLESS"
+	InternetFQDNNEGType = "INTERNET_FQDN_PORT"
+	InternetIPNEGType   = "INTERNET_IP_PORT"
+
+	// L4ILBSubsetSizeKey is the annotation key of an L4 ILB service overriding
+	// the maximum number of nodes in the NEGs of the service, across zones.
+	// The value is capped by the --l4-ilb-max-subset-size flag.
+	L4ILBSubsetSizeKey = "networking.gke.io/l4-ilb-subset-size"
+
+	// L4ILBSubsetModeKey is the annotation key of an L4 ILB service selecting
+	// how the nodes in the NEGs of the service are picked, when its external
+	// traffic policy is Cluster. By default, nodes are picked regardless of
+	// where the endpoints of the service run.
+	// The only supported value is L4ILBSubsetModeEndpointAware.
+	L4ILBSubsetModeKey = "networking.gke.io/l4-ilb-subset-mode"
+
+	// L4ILBSubsetModeEndpointAware picks the nodes hosting ready endpoints of
+	// the service first.
+	L4ILBSubsetModeEndpointAware = "EndpointAware"
+
 	// BetaBackendConfigKey is a stringified JSON with two fields:
 	// - "ports": a map of port names or port numbers to backendConfig names
 	// - "default": denotes the default backendConfig name for all ports except
@@ -89,6 +124,9 @@ const (
 	// FirewallRuleForHealthcheckKey is the annotation key used by l4 controller to record
 	// the firewall rule name that allows healthcheck traffic.
 	FirewallRuleForHealthcheckKey = ServiceStatusPrefix + "/firewall-rule-for-hc"
+	// ServicePausedKey is the annotation key used by controllers to record that
+	// reconciliation of the Service is paused, see PauseReconciliationKey.
+	ServicePausedKey = ServiceStatusPrefix + "/paused"
 )
 
 // NegAnnotation is the format of the annotation associated with the
@@ -138,6 +176,104 @@ func (n *NegAnnotation) String() string {
 	return string(bytes)
 }
 
+// ExternalNEG is the format of the annotation associated with the
+// ExternalNEGKey key.
+type ExternalNEG struct {
+	// NetworkEndpointType is one of SERVERLESS, INTERNET_FQDN_PORT and
+	// INTERNET_IP_PORT.
+	NetworkEndpointType string `json:"networkEndpointType"`
+	// Exactly one of CloudRun, AppEngine and CloudFunction is set for
+	// SERVERLESS NEGs.
+	CloudRun      *ExternalNEGCloudRun      `json:"cloudRun,omitempty"`
+	AppEngine     *ExternalNEGAppEngine     `json:"appEngine,omitempty"`
+	CloudFunction *ExternalNEGCloudFunction `json:"cloudFunction,omitempty"`
+	// FQDN is the endpoint of INTERNET_FQDN_PORT NEGs.
+	FQDN string `json:"fqdn,omitempty"`
+	// IPAddress is the endpoint of INTERNET_IP_PORT NEGs.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// ExternalNEGCloudRun selects a Cloud Run service.
+type ExternalNEGCloudRun struct {
+	Service string `json:"service,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	URLMask string `json:"urlMask,omitempty"`
+}
+
+// ExternalNEGAppEngine selects an App Engine service.
+type ExternalNEGAppEngine struct {
+	Service string `json:"service,omitempty"`
+	Version string `json:"version,omitempty"`
+	URLMask string `json:"urlMask,omitempty"`
+}
+
+// ExternalNEGCloudFunction selects a Cloud Function.
+type ExternalNEGCloudFunction struct {
+	Function string `json:"function,omitempty"`
+	URLMask  string `json:"urlMask,omitempty"`
+}
+
+// IsServerless returns true if the NEG is a regional serverless NEG, false
+// for a global Internet NEG.
+func (e *ExternalNEG) IsServerless() bool {
+	return e.NetworkEndpointType == ServerlessNEGType
+}
+
+// Target returns the attributes of the NEG which cannot be updated: its type
+// and its serverless target. The endpoint of an Internet NEG is not part of
+// it, since it is attached and detached in place.
+func (e *ExternalNEG) Target() string {
+	target := *e
+	target.FQDN, target.IPAddress = "", ""
+	bytes, _ := json.Marshal(target)
+	return string(bytes)
+}
+
+func (e *ExternalNEG) validate() error {
+	targets := 0
+	if e.CloudRun != nil {
+		targets++
+		if e.CloudRun.Service == "" && e.CloudRun.URLMask == "" {
+			return errors.New("cloudRun requires a service or a urlMask")
+		}
+	}
+	if e.AppEngine != nil {
+		targets++
+	}
+	if e.CloudFunction != nil {
+		targets++
+		if e.CloudFunction.Function == "" && e.CloudFunction.URLMask == "" {
+			return errors.New("cloudFunction requires a function or a urlMask")
+		}
+	}
+	switch e.NetworkEndpointType {
+	case ServerlessNEGType:
+		if targets != 1 {
+			return errors.New("SERVERLESS NEGs require exactly one of cloudRun, appEngine and cloudFunction")
+		}
+		if e.FQDN != "" || e.IPAddress != "" {
+			return errors.New("SERVERLESS NEGs do not support fqdn and ipAddress")
+		}
+	case InternetFQDNNEGType:
+		if targets != 0 || e.IPAddress != "" {
+			return errors.New("INTERNET_FQDN_PORT NEGs only support fqdn")
+		}
+		if e.FQDN == "" {
+			return errors.New("INTERNET_FQDN_PORT NEGs require fqdn")
+		}
+	case InternetIPNEGType:
+		if targets != 0 || e.FQDN != "" {
+			return errors.New("INTERNET_IP_PORT NEGs only support ipAddress")
+		}
+		if ip := net.ParseIP(e.IPAddress); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("INTERNET_IP_PORT NEGs require an IPv4 ipAddress, got %q", e.IPAddress)
+		}
+	default:
+		return fmt.Errorf("unsupported networkEndpointType %q", e.NetworkEndpointType)
+	}
+	return nil
+}
+
 // PortNegMap is the mapping between service port to NEG name
 type PortNegMap map[string]string
 
@@ -264,6 +400,7 @@ var (
 	ErrBackendConfigInvalidJSON       = errors.New("BackendConfig annotation is invalid json")
 	ErrBackendConfigAnnotationMissing = errors.New("BackendConfig annotation is missing")
 	ErrNEGAnnotationInvalid           = errors.New("NEG annotation is invalid.")
+	ErrExternalNEGAnnotationInvalid   = errors.New("external NEG annotation is invalid json")
 )
 
 // NEGAnnotation returns true if NEG annotation is found.
@@ -283,6 +420,51 @@ func (svc *Service) NEGAnnotation() (*NegAnnotation, bool, error) {
 	return &res, true, nil
 }
 
+// ExternalNEG returns true if the external NEG annotation is found.
+// If found, it also returns the validated external NEG annotation struct.
+func (svc *Service) ExternalNEG() (*ExternalNEG, bool, error) {
+	annotation, ok := svc.v[ExternalNEGKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var res ExternalNEG
+	if err := json.Unmarshal([]byte(annotation), &res); err != nil {
+		return nil, true, ErrExternalNEGAnnotationInvalid
+	}
+	if err := res.validate(); err != nil {
+		return nil, true, fmt.Errorf("invalid %s annotation: %v", ExternalNEGKey, err)
+	}
+	return &res, true, nil
+}
+
+// L4ILBSubsetSize returns true if the L4 ILB subset size annotation is found.
+// If found, it also returns the subset size, which must be positive.
+func (svc *Service) L4ILBSubsetSize() (int, bool, error) {
+	annotation, ok := svc.v[L4ILBSubsetSizeKey]
+	if !ok {
+		return 0, false, nil
+	}
+	size, err := strconv.Atoi(annotation)
+	if err != nil || size <= 0 {
+		return 0, true, fmt.Errorf("invalid %s annotation %q: must be a positive integer", L4ILBSubsetSizeKey, annotation)
+	}
+	return size, true, nil
+}
+
+// L4ILBEndpointAwareSubset returns true if the nodes in the NEGs of the L4 ILB
+// service are picked where its endpoints run. False by default.
+func (svc *Service) L4ILBEndpointAwareSubset() (bool, error) {
+	annotation, ok := svc.v[L4ILBSubsetModeKey]
+	if !ok {
+		return false, nil
+	}
+	if annotation != L4ILBSubsetModeEndpointAware {
+		return false, fmt.Errorf("invalid %s annotation %q: must be %q", L4ILBSubsetModeKey, annotation, L4ILBSubsetModeEndpointAware)
+	}
+	return true, nil
+}
+
 func (svc *Service) NEGStatus() (*NegStatus, bool, error) {
 	var res NegStatus
 	var err error
@@ -333,3 +515,9 @@ func (svc *Service) getBackendConfigAnnotation() (string, bool) {
 	}
 	return "", false
 }
+
+// PauseReconciliation returns true if reconciliation of the Service is paused.
+// False by default.
+func (svc *Service) PauseReconciliation() bool {
+	return parsePauseReconciliation(svc.v)
+}
@@ -167,6 +167,103 @@ func TestNEGAnnotation(t *testing.T) {
 	}
 }
 
+func TestExternalNEG(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		annotation  string
+		expect      *ExternalNEG
+		expectFound bool
+		expectError bool
+	}{
+		{
+			desc: "No external NEG annotation",
+		},
+		{
+			desc:        "Malformed annotation",
+			annotation:  `foo`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Cloud Run service",
+			annotation:  `{"networkEndpointType":"SERVERLESS","cloudRun":{"service":"hello","tag":"blue"}}`,
+			expect:      &ExternalNEG{NetworkEndpointType: ServerlessNEGType, CloudRun: &ExternalNEGCloudRun{Service: "hello", Tag: "blue"}},
+			expectFound: true,
+		},
+		{
+			desc:        "App Engine default service",
+			annotation:  `{"networkEndpointType":"SERVERLESS","appEngine":{}}`,
+			expect:      &ExternalNEG{NetworkEndpointType: ServerlessNEGType, AppEngine: &ExternalNEGAppEngine{}},
+			expectFound: true,
+		},
+		{
+			desc:        "Serverless NEG without target",
+			annotation:  `{"networkEndpointType":"SERVERLESS"}`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Serverless NEG with two targets",
+			annotation:  `{"networkEndpointType":"SERVERLESS","appEngine":{},"cloudFunction":{"function":"f"}}`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Cloud Function without function",
+			annotation:  `{"networkEndpointType":"SERVERLESS","cloudFunction":{}}`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Internet FQDN NEG",
+			annotation:  `{"networkEndpointType":"INTERNET_FQDN_PORT","fqdn":"example.com"}`,
+			expect:      &ExternalNEG{NetworkEndpointType: InternetFQDNNEGType, FQDN: "example.com"},
+			expectFound: true,
+		},
+		{
+			desc:        "Internet FQDN NEG without fqdn",
+			annotation:  `{"networkEndpointType":"INTERNET_FQDN_PORT","ipAddress":"203.0.113.1"}`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Internet IP NEG",
+			annotation:  `{"networkEndpointType":"INTERNET_IP_PORT","ipAddress":"203.0.113.1"}`,
+			expect:      &ExternalNEG{NetworkEndpointType: InternetIPNEGType, IPAddress: "203.0.113.1"},
+			expectFound: true,
+		},
+		{
+			desc:        "Internet IP NEG with IPv6 address",
+			annotation:  `{"networkEndpointType":"INTERNET_IP_PORT","ipAddress":"2001:db8::1"}`,
+			expectFound: true,
+			expectError: true,
+		},
+		{
+			desc:        "Unsupported type",
+			annotation:  `{"networkEndpointType":"GCE_VM_IP_PORT"}`,
+			expectFound: true,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := &v1.Service{}
+			if tc.annotation != "" {
+				svc.Annotations = map[string]string{ExternalNEGKey: tc.annotation}
+			}
+			externalNEG, found, err := FromService(svc).ExternalNEG()
+			if found != tc.expectFound {
+				t.Errorf("ExternalNEG() found = %v, want %v", found, tc.expectFound)
+			}
+			if gotErr := err != nil; gotErr != tc.expectError {
+				t.Fatalf("ExternalNEG() = %v, want error: %v", err, tc.expectError)
+			}
+			if !reflect.DeepEqual(externalNEG, tc.expect) {
+				t.Errorf("ExternalNEG() = %+v, want %+v", externalNEG, tc.expect)
+			}
+		})
+	}
+}
+
 func TestNEGStatus(t *testing.T) {
 	for _, tc := range []struct {
 		desc            string
@@ -230,6 +327,52 @@ func TestNEGStatus(t *testing.T) {
 	}
 }
 
+func TestL4ILBSubset(t *testing.T) {
+	for _, tc := range []struct {
+		desc                string
+		annotations         map[string]string
+		expectSize          int
+		expectFound         bool
+		expectSizeError     bool
+		expectEndpointAware bool
+		expectModeError     bool
+	}{
+		{
+			desc: "No annotations",
+		},
+		{
+			desc:                "Subset size and endpoint aware mode",
+			annotations:         map[string]string{L4ILBSubsetSizeKey: "100", L4ILBSubsetModeKey: L4ILBSubsetModeEndpointAware},
+			expectSize:          100,
+			expectFound:         true,
+			expectEndpointAware: true,
+		},
+		{
+			desc:            "Invalid subset size",
+			annotations:     map[string]string{L4ILBSubsetSizeKey: "0"},
+			expectFound:     true,
+			expectSizeError: true,
+		},
+		{
+			desc:            "Invalid subset mode",
+			annotations:     map[string]string{L4ILBSubsetModeKey: "Random"},
+			expectModeError: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := FromService(&v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}})
+			size, found, err := svc.L4ILBSubsetSize()
+			if (err != nil) != tc.expectSizeError || found != tc.expectFound || size != tc.expectSize {
+				t.Errorf("L4ILBSubsetSize() = (%d, %v, %v), want (%d, %v, error: %v)", size, found, err, tc.expectSize, tc.expectFound, tc.expectSizeError)
+			}
+			endpointAware, err := svc.L4ILBEndpointAwareSubset()
+			if (err != nil) != tc.expectModeError || endpointAware != tc.expectEndpointAware {
+				t.Errorf("L4ILBEndpointAwareSubset() = (%v, %v), want (%v, error: %v)", endpointAware, err, tc.expectEndpointAware, tc.expectModeError)
+			}
+		})
+	}
+}
+
 func TestService(t *testing.T) {
 	for _, tc := range []struct {
 		svc             *v1.Service
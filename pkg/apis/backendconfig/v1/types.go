@@ -46,6 +46,9 @@ type BackendConfigSpec struct {
 	HealthCheck          *HealthCheckConfig          `json:"healthCheck,omitempty"`
 	// Logging specifies the configuration for access logs.
 	Logging *LogConfig `json:"logging,omitempty"`
+	// BackendTLS specifies how the load balancer authenticates HTTPS
+	// backends, and optionally itself to them.
+	BackendTLS *BackendTLSConfig `json:"backendTLS,omitempty"`
 }
 
 // BackendConfigStatus is the status for a BackendConfig resource
@@ -177,3 +180,28 @@ type LogConfig struct {
 	// requests are reported. The default value is 1.0.
 	SampleRate *float64 `json:"sampleRate,omitempty"`
 }
+
+// BackendTLSConfig contains configuration for TLS between the load balancer
+// and HTTPS backends.
+// +k8s:openapi-gen=true
+type BackendTLSConfig struct {
+	// Sni is the server name sent to the backends in the TLS handshake.
+	Sni string `json:"sni,omitempty"`
+	// SubjectAltNames are the DNS names or URIs of which the certificate of a
+	// backend must have one. If empty, the certificate must match Sni.
+	SubjectAltNames []string `json:"subjectAltNames,omitempty"`
+	// CACertificates references the Secret holding the CA certificates that
+	// backend certificates are validated against, under the key ca.crt. If
+	// unset, they are validated against public CAs.
+	CACertificates *BackendTLSSecretReference `json:"caCertificates,omitempty"`
+	// ClientCertificate references the kubernetes.io/tls Secret holding the
+	// certificate which the load balancer presents to the backends.
+	ClientCertificate *BackendTLSSecretReference `json:"clientCertificate,omitempty"`
+}
+
+// BackendTLSSecretReference references a Secret of a BackendTLSConfig.
+// +k8s:openapi-gen=true
+type BackendTLSSecretReference struct {
+	// The name of a k8s secret in the namespace of the BackendConfig.
+	SecretName string `json:"secretName"`
+}
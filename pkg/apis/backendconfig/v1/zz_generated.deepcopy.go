@@ -133,6 +133,11 @@ func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 		*out = new(LogConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackendTLS != nil {
+		in, out := &in.BackendTLS, &out.BackendTLS
+		*out = new(BackendTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -162,6 +167,53 @@ func (in *BackendConfigStatus) DeepCopy() *BackendConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLSConfig) DeepCopyInto(out *BackendTLSConfig) {
+	*out = *in
+	if in.SubjectAltNames != nil {
+		in, out := &in.SubjectAltNames, &out.SubjectAltNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificates != nil {
+		in, out := &in.CACertificates, &out.CACertificates
+		*out = new(BackendTLSSecretReference)
+		**out = **in
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(BackendTLSSecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLSConfig.
+func (in *BackendTLSConfig) DeepCopy() *BackendTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLSSecretReference) DeepCopyInto(out *BackendTLSSecretReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLSSecretReference.
+func (in *BackendTLSSecretReference) DeepCopy() *BackendTLSSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLSSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDNConfig) DeepCopyInto(out *CDNConfig) {
 	*out = *in
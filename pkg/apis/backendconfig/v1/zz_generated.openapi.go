@@ -31,6 +31,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 	return map[string]common.OpenAPIDefinition{
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":              schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":          schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSConfig":           schema_pkg_apis_backendconfig_v1_BackendTLSConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSSecretReference":  schema_pkg_apis_backendconfig_v1_BackendTLSSecretReference(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                  schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":             schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
 		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig":   schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
@@ -141,11 +143,86 @@ func schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref common.ReferenceCall
 							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig"),
 						},
 					},
+					"backendTLS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackendTLS specifies how the load balancer authenticates HTTPS backends, and optionally itself to them.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_BackendTLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackendTLSConfig contains configuration for TLS between the load balancer and HTTPS backends.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sni": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sni is the server name sent to the backends in the TLS handshake.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subjectAltNames": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubjectAltNames are the DNS names or URIs of which the certificate of a backend must have one. If empty, the certificate must match Sni.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"caCertificates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CACertificates references the Secret holding the CA certificates that backend certificates are validated against, under the key ca.crt. If unset, they are validated against public CAs.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSSecretReference"),
+						},
+					},
+					"clientCertificate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientCertificate references the kubernetes.io/tls Secret holding the certificate which the load balancer presents to the backends.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSSecretReference"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendTLSSecretReference"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_BackendTLSSecretReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackendTLSSecretReference references a Secret of a BackendTLSConfig.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of a k8s secret in the namespace of the BackendConfig.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"secretName"},
+			},
+		},
 	}
 }
 
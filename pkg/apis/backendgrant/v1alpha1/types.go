@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackendGrant allows Ingresses in other namespaces to reference Services in
+// the namespace of the BackendGrant as backends.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type BackendGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackendGrantSpec `json:"spec,omitempty"`
+}
+
+// BackendGrantSpec is the spec for a BackendGrant resource
+// +k8s:openapi-gen=true
+type BackendGrantSpec struct {
+	// From is the list of namespaces whose Ingresses are allowed to reference
+	// Services in this namespace.
+	// +required
+	// +listType=atomic
+	From []BackendGrantFrom `json:"from"`
+
+	// To is the list of Services in this namespace that may be referenced.
+	// If empty, all Services in this namespace may be referenced.
+	// +optional
+	// +listType=atomic
+	To []BackendGrantTo `json:"to,omitempty"`
+}
+
+// BackendGrantFrom describes a namespace that is trusted by a BackendGrant.
+// +k8s:openapi-gen=true
+type BackendGrantFrom struct {
+	// Namespace is the namespace of the referencing Ingresses.
+	// +required
+	Namespace string `json:"namespace"`
+}
+
+// BackendGrantTo describes a Service that may be referenced.
+// +k8s:openapi-gen=true
+type BackendGrantTo struct {
+	// Name is the name of the Service.
+	// +required
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// BackendGrantList is a list of BackendGrant resources
+type BackendGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BackendGrant `json:"items"`
+}
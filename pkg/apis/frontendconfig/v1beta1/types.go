@@ -37,6 +37,86 @@ type FrontendConfig struct {
 type FrontendConfigSpec struct {
 	SslPolicy       *string              `json:"sslPolicy,omitempty"`
 	RedirectToHttps *HttpsRedirectConfig `json:"redirectToHttps,omitempty"`
+	// NetworkTier is the network tier of the external IP address and
+	// forwarding rules, PREMIUM (default) or STANDARD. STANDARD tier is only
+	// supported by the gce-regional-external Ingress class.
+	NetworkTier *string `json:"networkTier,omitempty"`
+	// ManagedSslPolicy declares an SSL policy that is created and managed by
+	// the controller. It cannot be used together with SslPolicy.
+	ManagedSslPolicy *ManagedSslPolicy `json:"managedSslPolicy,omitempty"`
+	// RedirectRules are host and path prefix redirects. They apply to both
+	// the HTTP and the HTTPS frontend.
+	RedirectRules []RedirectRule `json:"redirectRules,omitempty"`
+	// ClientTls requires clients of the HTTPS frontend to present a
+	// certificate, which is validated against a trust bundle.
+	ClientTls *ClientTlsConfig `json:"clientTls,omitempty"`
+	// CertificateMap is the name, or the full resource name, of a Certificate
+	// Manager certificate map served by the HTTPS frontend instead of SSL
+	// certificates. Certificates of the Ingress are ignored while it is set.
+	CertificateMap *string `json:"certificateMap,omitempty"`
+}
+
+// ClientTlsConfig is the configuration of mutual TLS on the HTTPS frontend.
+// +k8s:openapi-gen=true
+type ClientTlsConfig struct {
+	// TrustBundle references the PEM encoded CA certificates which client
+	// certificates are validated against.
+	TrustBundle TrustBundleReference `json:"trustBundle"`
+	// ClientValidationMode is one of REJECT_INVALID (default), which rejects
+	// requests without a valid client certificate, or
+	// ALLOW_INVALID_OR_MISSING_CLIENT_CERT, which forwards them to the
+	// backends.
+	ClientValidationMode string `json:"clientValidationMode,omitempty"`
+	// ForwardClientCertHeaders adds headers with the result of the client
+	// certificate validation, e.g. X-Client-Cert-Present, to the requests
+	// forwarded to the backends.
+	ForwardClientCertHeaders bool `json:"forwardClientCertHeaders,omitempty"`
+}
+
+// TrustBundleReference references a key of a Secret or a ConfigMap in the
+// namespace of the FrontendConfig. Exactly one of SecretName and
+// ConfigMapName must be set.
+// +k8s:openapi-gen=true
+type TrustBundleReference struct {
+	SecretName    string `json:"secretName,omitempty"`
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Key is the key of the trust bundle, ca.crt if empty.
+	Key string `json:"key,omitempty"`
+}
+
+// RedirectRule redirects the requests for a host, or for a path prefix of a
+// host, to another host or path prefix.
+// +k8s:openapi-gen=true
+type RedirectRule struct {
+	// Host is the host of the redirected requests. "*" matches the hosts
+	// which are not listed in the Ingress.
+	Host string `json:"host"`
+	// PathPrefix restricts the redirect to the requests whose path is, or
+	// is below, the prefix. All requests for the host are redirected if empty.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// TargetHost replaces the host of the redirected requests.
+	TargetHost string `json:"targetHost,omitempty"`
+	// TargetPathPrefix replaces PathPrefix in the path of the redirected
+	// requests. It requires PathPrefix.
+	TargetPathPrefix string `json:"targetPathPrefix,omitempty"`
+	// String representing the HTTP response code
+	// Options are MOVED_PERMANENTLY_DEFAULT, FOUND, SEE_OTHER, TEMPORARY_REDIRECT, or PERMANENT_REDIRECT
+	ResponseCodeName string `json:"responseCodeName,omitempty"`
+	// Exceptions are path prefixes which are served instead of redirected,
+	// e.g. /.well-known/acme-challenge/.
+	Exceptions []string `json:"exceptions,omitempty"`
+}
+
+// ManagedSslPolicy is the configuration of a controller managed SSL policy.
+// Ingresses whose FrontendConfigs declare the same policy share it.
+// +k8s:openapi-gen=true
+type ManagedSslPolicy struct {
+	// Profile is one of COMPATIBLE (default), MODERN, RESTRICTED or CUSTOM.
+	Profile string `json:"profile,omitempty"`
+	// MinTlsVersion is one of TLS_1_0 (default), TLS_1_1 or TLS_1_2.
+	MinTlsVersion string `json:"minTlsVersion,omitempty"`
+	// CustomFeatures lists the enabled SSL features of a CUSTOM profile.
+	CustomFeatures []string `json:"customFeatures,omitempty"`
 }
 
 // HttpsRedirectConfig representing the configuration of Https redirects
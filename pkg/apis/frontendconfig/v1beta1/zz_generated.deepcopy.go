@@ -24,6 +24,23 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTlsConfig) DeepCopyInto(out *ClientTlsConfig) {
+	*out = *in
+	out.TrustBundle = in.TrustBundle
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTlsConfig.
+func (in *ClientTlsConfig) DeepCopy() *ClientTlsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTlsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendConfig) DeepCopyInto(out *FrontendConfig) {
 	*out = *in
@@ -98,6 +115,33 @@ func (in *FrontendConfigSpec) DeepCopyInto(out *FrontendConfigSpec) {
 		*out = new(HttpsRedirectConfig)
 		**out = **in
 	}
+	if in.NetworkTier != nil {
+		in, out := &in.NetworkTier, &out.NetworkTier
+		*out = new(string)
+		**out = **in
+	}
+	if in.ManagedSslPolicy != nil {
+		in, out := &in.ManagedSslPolicy, &out.ManagedSslPolicy
+		*out = new(ManagedSslPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectRules != nil {
+		in, out := &in.RedirectRules, &out.RedirectRules
+		*out = make([]RedirectRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientTls != nil {
+		in, out := &in.ClientTls, &out.ClientTls
+		*out = new(ClientTlsConfig)
+		**out = **in
+	}
+	if in.CertificateMap != nil {
+		in, out := &in.CertificateMap, &out.CertificateMap
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -142,3 +186,61 @@ func (in *HttpsRedirectConfig) DeepCopy() *HttpsRedirectConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedSslPolicy) DeepCopyInto(out *ManagedSslPolicy) {
+	*out = *in
+	if in.CustomFeatures != nil {
+		in, out := &in.CustomFeatures, &out.CustomFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedSslPolicy.
+func (in *ManagedSslPolicy) DeepCopy() *ManagedSslPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedSslPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectRule) DeepCopyInto(out *RedirectRule) {
+	*out = *in
+	if in.Exceptions != nil {
+		in, out := &in.Exceptions, &out.Exceptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectRule.
+func (in *RedirectRule) DeepCopy() *RedirectRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustBundleReference) DeepCopyInto(out *TrustBundleReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustBundleReference.
+func (in *TrustBundleReference) DeepCopy() *TrustBundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustBundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,9 +29,49 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":      schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":  schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
-		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig": schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ClientTlsConfig":      schema_pkg_apis_frontendconfig_v1beta1_ClientTlsConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig":       schema_pkg_apis_frontendconfig_v1beta1_FrontendConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfigSpec":   schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig":  schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ManagedSslPolicy":     schema_pkg_apis_frontendconfig_v1beta1_ManagedSslPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RedirectRule":         schema_pkg_apis_frontendconfig_v1beta1_RedirectRule(ref),
+		"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.TrustBundleReference": schema_pkg_apis_frontendconfig_v1beta1_TrustBundleReference(ref),
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_ClientTlsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClientTlsConfig is the configuration of mutual TLS on the HTTPS frontend.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"trustBundle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrustBundle references the PEM encoded CA certificates which client certificates are validated against.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.TrustBundleReference"),
+						},
+					},
+					"clientValidationMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientValidationMode is one of REJECT_INVALID (default), which rejects requests without a valid client certificate, or ALLOW_INVALID_OR_MISSING_CLIENT_CERT, which forwards them to the backends.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"forwardClientCertHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ForwardClientCertHeaders adds headers with the result of the client certificate validation, e.g. X-Client-Cert-Present, to the requests forwarded to the backends.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"trustBundle"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.TrustBundleReference"},
 	}
 }
 
@@ -96,11 +136,50 @@ func schema_pkg_apis_frontendconfig_v1beta1_FrontendConfigSpec(ref common.Refere
 							Ref: ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"),
 						},
 					},
+					"networkTier": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkTier is the network tier of the external IP address and forwarding rules, PREMIUM (default) or STANDARD. STANDARD tier is only supported by the gce-regional-external Ingress class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"managedSslPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagedSslPolicy declares an SSL policy that is created and managed by the controller. It cannot be used together with SslPolicy.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ManagedSslPolicy"),
+						},
+					},
+					"redirectRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RedirectRules are host and path prefix redirects. They apply to both the HTTP and the HTTPS frontend.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RedirectRule"),
+									},
+								},
+							},
+						},
+					},
+					"clientTls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientTls requires clients of the HTTPS frontend to present a certificate, which is validated against a trust bundle.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ClientTlsConfig"),
+						},
+					},
+					"certificateMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertificateMap is the name, or the full resource name, of a Certificate Manager certificate map served by the HTTPS frontend instead of SSL certificates. Certificates of the Ingress are ignored while it is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig"},
+			"k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ClientTlsConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.HttpsRedirectConfig", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.ManagedSslPolicy", "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.RedirectRule"},
 	}
 }
 
@@ -130,3 +209,139 @@ func schema_pkg_apis_frontendconfig_v1beta1_HttpsRedirectConfig(ref common.Refer
 		},
 	}
 }
+
+func schema_pkg_apis_frontendconfig_v1beta1_ManagedSslPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ManagedSslPolicy is the configuration of a controller managed SSL policy. Ingresses whose FrontendConfigs declare the same policy share it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"profile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Profile is one of COMPATIBLE (default), MODERN, RESTRICTED or CUSTOM.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"minTlsVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinTlsVersion is one of TLS_1_0 (default), TLS_1_1 or TLS_1_2.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"customFeatures": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CustomFeatures lists the enabled SSL features of a CUSTOM profile.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_RedirectRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedirectRule redirects the requests for a host, or for a path prefix of a host, to another host or path prefix.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host is the host of the redirected requests. \"*\" matches the hosts which are not listed in the Ingress.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathPrefix restricts the redirect to the requests whose path is, or is below, the prefix. All requests for the host are redirected if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetHost": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetHost replaces the host of the redirected requests.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetPathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetPathPrefix replaces PathPrefix in the path of the redirected requests. It requires PathPrefix.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"responseCodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "String representing the HTTP response code Options are MOVED_PERMANENTLY_DEFAULT, FOUND, SEE_OTHER, TEMPORARY_REDIRECT, or PERMANENT_REDIRECT",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"exceptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exceptions are path prefixes which are served instead of redirected, e.g. /.well-known/acme-challenge/.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"host"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_frontendconfig_v1beta1_TrustBundleReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrustBundleReference references a key of a Secret or a ConfigMap in the namespace of the FrontendConfig. Exactly one of SecretName and ConfigMapName must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"configMapName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the key of the trust bundle, ca.crt if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -35,8 +35,45 @@ type ServiceNetworkEndpointGroup struct {
 	Status ServiceNetworkEndpointGroupStatus `json:"status,omitempty"`
 }
 
-// ServiceNetworkEndpointGroupSpec is the spec for a ServiceNetworkEndpointGroup resource
-type ServiceNetworkEndpointGroupSpec struct{}
+// ServiceNetworkEndpointGroupSpec is the spec for a ServiceNetworkEndpointGroup resource.
+// The spec of the objects the controller creates for the NEGs requested by
+// Service annotations is empty. Objects with a ServiceRef are created by users
+// to declare a standalone NEG, which is named after the object.
+// +k8s:openapi-gen=true
+type ServiceNetworkEndpointGroupSpec struct {
+	// ServiceRef is the Service, in the namespace of the object, whose
+	// endpoints are in the NEG.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+
+	// Port is the port of the Service whose endpoints are in the NEG.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// NetworkEndpointType is the type of the NEG, GCE_VM_IP_PORT or
+	// NON_GCP_PRIVATE_IP_PORT. It defaults to the type of the NEGs of the
+	// controller.
+	// +optional
+	NetworkEndpointType NetworkEndpointType `json:"networkEndpointType,omitempty"`
+
+	// Zones restricts the NEG to these zones. Endpoints in other zones are
+	// left out. The NEG is in all zones of the cluster if empty.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// SubsetLabels restricts the endpoints of the NEG to pods with these
+	// labels.
+	// +optional
+	SubsetLabels map[string]string `json:"subsetLabels,omitempty"`
+}
+
+// ServiceReference references a Service in the same namespace.
+// +k8s:openapi-gen=true
+type ServiceReference struct {
+	// Name of the Service.
+	// +required
+	Name string `json:"name"`
+}
 
 // ServiceNetworkEndpointGroupStatus is the status for a ServiceNetworkEndpointGroup resource
 // +k8s:openapi-gen=true
@@ -54,6 +91,41 @@ type ServiceNetworkEndpointGroupStatus struct {
 	// Last time the NEG syncer syncs associated NEGs.
 	// +optional
 	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// EndpointCheckpoint is the last known set of endpoints in the NEGs. A
+	// new NEG syncer starts from it instead of listing the endpoints of the
+	// NEGs.
+	// +optional
+	EndpointCheckpoint *EndpointCheckpoint `json:"endpointCheckpoint,omitempty"`
+}
+
+// EndpointCheckpoint is the set of endpoints in the NEGs, by zone, when the
+// NEGs were last observed in sync.
+// +k8s:openapi-gen=true
+type EndpointCheckpoint struct {
+	// Fingerprint is a hash of the endpoints. A checkpoint whose endpoints do
+	// not match its fingerprint is ignored.
+	// +required
+	Fingerprint string `json:"fingerprint"`
+
+	// Zones holds the endpoints of the NEG of each zone.
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	Zones []ZoneEndpoints `json:"zones,omitempty"`
+}
+
+// ZoneEndpoints is the set of endpoints of the NEG in a zone.
+// +k8s:openapi-gen=true
+type ZoneEndpoints struct {
+	// Zone of the NEG.
+	// +required
+	Zone string `json:"zone"`
+
+	// Endpoints of the NEG, each encoded as "ip||instance||port".
+	// +optional
+	// +listType=set
+	Endpoints []string `json:"endpoints,omitempty"`
 }
 
 // NegObjectReference is the object reference to the NEG resource in GCE
@@ -90,7 +162,8 @@ type Condition struct {
 	// Status of the condition, one of True, False, Unknown.
 	// +required
 	Status corev1.ConditionStatus `json:"status" protobuf:"bytes,2,opt,name=status"`
-	// ObservedGeneration will not be set for ServiceNetworkEndpointGroup as the spec is empty.
+	// ObservedGeneration is the generation of the ServiceNetworkEndpointGroup
+	// the condition was computed for.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,3,opt,name=observedGeneration"`
 	// Last time the condition transitioned from one status to another.
@@ -41,6 +41,29 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointCheckpoint) DeepCopyInto(out *EndpointCheckpoint) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneEndpoints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointCheckpoint.
+func (in *EndpointCheckpoint) DeepCopy() *EndpointCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NegObjectReference) DeepCopyInto(out *NegObjectReference) {
 	*out = *in
@@ -62,7 +85,7 @@ func (in *ServiceNetworkEndpointGroup) DeepCopyInto(out *ServiceNetworkEndpointG
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -121,6 +144,23 @@ func (in *ServiceNetworkEndpointGroupList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceNetworkEndpointGroupSpec) DeepCopyInto(out *ServiceNetworkEndpointGroupSpec) {
 	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceReference)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubsetLabels != nil {
+		in, out := &in.SubsetLabels, &out.SubsetLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -150,6 +190,11 @@ func (in *ServiceNetworkEndpointGroupStatus) DeepCopyInto(out *ServiceNetworkEnd
 		}
 	}
 	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.EndpointCheckpoint != nil {
+		in, out := &in.EndpointCheckpoint, &out.EndpointCheckpoint
+		*out = new(EndpointCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -162,3 +207,40 @@ func (in *ServiceNetworkEndpointGroupStatus) DeepCopy() *ServiceNetworkEndpointG
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneEndpoints) DeepCopyInto(out *ZoneEndpoints) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneEndpoints.
+func (in *ZoneEndpoints) DeepCopy() *ZoneEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -30,9 +30,13 @@ import (
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition":                         schema_pkg_apis_svcneg_v1beta1_Condition(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.EndpointCheckpoint":                schema_pkg_apis_svcneg_v1beta1_EndpointCheckpoint(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference":                schema_pkg_apis_svcneg_v1beta1_NegObjectReference(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceNetworkEndpointGroup":       schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroup(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceNetworkEndpointGroupSpec":   schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupSpec(ref),
 		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceNetworkEndpointGroupStatus": schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupStatus(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceReference":                  schema_pkg_apis_svcneg_v1beta1_ServiceReference(ref),
+		"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ZoneEndpoints":                     schema_pkg_apis_svcneg_v1beta1_ZoneEndpoints(ref),
 	}
 }
 
@@ -59,7 +63,7 @@ func schema_pkg_apis_svcneg_v1beta1_Condition(ref common.ReferenceCallback) comm
 					},
 					"observedGeneration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ObservedGeneration will not be set for ServiceNetworkEndpointGroup as the spec is empty.",
+							Description: "ObservedGeneration is the generation of the ServiceNetworkEndpointGroup the condition was computed for.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -93,6 +97,50 @@ func schema_pkg_apis_svcneg_v1beta1_Condition(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_pkg_apis_svcneg_v1beta1_EndpointCheckpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EndpointCheckpoint is the set of endpoints in the NEGs, by zone, when the NEGs were last observed in sync.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fingerprint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fingerprint is a hash of the endpoints. A checkpoint whose endpoints do not match its fingerprint is ignored.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"zones": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"zone",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Zones holds the endpoints of the NEG of each zone.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ZoneEndpoints"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"fingerprint"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ZoneEndpoints"},
+	}
+}
+
 func schema_pkg_apis_svcneg_v1beta1_NegObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -171,6 +219,70 @@ func schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroup(ref common.Refer
 	}
 }
 
+func schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceNetworkEndpointGroupSpec is the spec for a ServiceNetworkEndpointGroup resource. The spec of the objects the controller creates for the NEGs requested by Service annotations is empty. Objects with a ServiceRef are created by users to declare a standalone NEG, which is named after the object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceRef is the Service, in the namespace of the object, whose endpoints are in the NEG.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceReference"),
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the port of the Service whose endpoints are in the NEG.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"networkEndpointType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkEndpointType is the type of the NEG, GCE_VM_IP_PORT or NON_GCP_PRIVATE_IP_PORT. It defaults to the type of the NEGs of the controller.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"zones": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zones restricts the NEG to these zones. Endpoints in other zones are left out. The NEG is in all zones of the cluster if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"subsetLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubsetLabels restricts the endpoints of the NEG to pods with these labels.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.ServiceReference"},
+	}
+}
+
 func schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -225,10 +337,77 @@ func schema_pkg_apis_svcneg_v1beta1_ServiceNetworkEndpointGroupStatus(ref common
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"endpointCheckpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndpointCheckpoint is the last known set of endpoints in the NEGs. A new NEG syncer starts from it instead of listing the endpoints of the NEGs.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.EndpointCheckpoint"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.Condition", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.EndpointCheckpoint", "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1.NegObjectReference"},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_ServiceReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceReference references a Service in the same namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the Service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_svcneg_v1beta1_ZoneEndpoints(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ZoneEndpoints is the set of endpoints of the NEG in a zone.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone of the NEG.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoints of the NEG, each encoded as \"ip||instance||port\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"zone"},
+			},
+		},
 	}
 }
@@ -0,0 +1,333 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every mutation the controllers make to GCE resources.
+// Records are only produced once a Sink has been configured with Init, so the
+// hooks in the composite layer and at direct gce.Cloud call sites are no-ops
+// by default.
+package audit
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cloudprovider "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog"
+)
+
+const (
+	// Operations shared by all resources. Other operations are named after
+	// the API method, e.g. "setUrlMap".
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+
+	// Controllers that mutate GCE resources.
+	ControllerIngress   = "ingress"
+	ControllerL4        = "l4"
+	ControllerNEG       = "neg"
+	ControllerFirewall  = "firewall"
+	ControllerInstances = "instances"
+
+	// Results of an audited mutation.
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Record is a single audited mutation of a GCE resource.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Controller is the controller that issued the mutation, e.g. "ingress".
+	// It lists all the controllers owning a shared resource, separated by
+	// commas.
+	Controller string `json:"controller,omitempty"`
+	// Objects are the namespace/name keys of the Kubernetes objects the GCE
+	// resource belongs to. A resource shared by several objects, such as a
+	// backend service used by several Ingresses, lists all of them. Empty
+	// for cluster-wide resources.
+	Objects []string `json:"objects,omitempty"`
+	// Resource is the URL of the mutated GCE resource.
+	Resource   string `json:"resource"`
+	Operation  string `json:"operation"`
+	APIVersion string `json:"apiVersion"`
+	// Diff maps the JSON path of every changed field to its old and new value.
+	Diff   map[string]FieldDiff `json:"diff,omitempty"`
+	Result string               `json:"result"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// FieldDiff holds the previous and the requested value of a field.
+type FieldDiff struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// Sink persists audit records.
+type Sink interface {
+	Write(r *Record) error
+}
+
+// owner identifies who manages a GCE resource.
+type owner struct {
+	controller string
+	object     string
+}
+
+var (
+	lock    sync.RWMutex
+	sink    Sink
+	project string
+	// owners maps the scope and name of GCE resources to the controllers and
+	// objects they are synced for.
+	owners = map[string]map[owner]bool{}
+	// lastSeen caches the flattened fields of resources, keyed by resource
+	// URL, as last read from or written to GCE. It is used to compute diffs.
+	lastSeen = map[string]map[string]interface{}{}
+)
+
+// Init enables auditing and sends all records to s. projectID is used to
+// build the resource URLs.
+func Init(s Sink, projectID string) {
+	lock.Lock()
+	defer lock.Unlock()
+	sink = s
+	project = projectID
+	owners = map[string]map[owner]bool{}
+	lastSeen = map[string]map[string]interface{}{}
+}
+
+// Enabled returns true if a Sink has been configured.
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return sink != nil
+}
+
+// SetOwner attributes the GCE resources with the given keys to controller and
+// the Kubernetes object key, in addition to their other owners. Mutations of
+// these resources are recorded with this attribution, until the resources are
+// deleted or the object is removed with RemoveOwner.
+func SetOwner(controller, object string, keys ...*meta.Key) {
+	if !Enabled() {
+		return
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	o := owner{controller: controller, object: object}
+	for _, key := range keys {
+		k := ownerKey(key)
+		if owners[k] == nil {
+			owners[k] = map[owner]bool{}
+		}
+		owners[k][o] = true
+	}
+}
+
+// RemoveOwner removes the attribution of all GCE resources to controller and
+// the Kubernetes object key. It is called when the object is deleted.
+func RemoveOwner(controller, object string) {
+	if !Enabled() {
+		return
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	o := owner{controller: controller, object: object}
+	for k, set := range owners {
+		delete(set, o)
+		if len(set) == 0 {
+			delete(owners, k)
+		}
+	}
+}
+
+// ownerKey returns the key of owners for the resource, made of its scope and
+// name. Resources of different scopes may have the same name.
+func ownerKey(key *meta.Key) string {
+	return key.Region + "/" + key.Zone + "/" + key.Name
+}
+
+// ownedBy returns the controllers and the objects owning the resource. It must
+// be called with the lock held.
+func ownedBy(key *meta.Key) (string, []string) {
+	controllers := map[string]bool{}
+	objectSet := map[string]bool{}
+	for o := range owners[ownerKey(key)] {
+		controllers[o.controller] = true
+		if o.object != "" {
+			objectSet[o.object] = true
+		}
+	}
+	var controllerList, objects []string
+	for controller := range controllers {
+		controllerList = append(controllerList, controller)
+	}
+	for object := range objectSet {
+		objects = append(objects, object)
+	}
+	sort.Strings(controllerList)
+	sort.Strings(objects)
+	return strings.Join(controllerList, ","), objects
+}
+
+// Context captures a single mutation of a GCE resource.
+type Context struct {
+	start      time.Time
+	resource   string
+	operation  string
+	version    meta.Version
+	key        *meta.Key
+	controller string
+	desired    map[string]interface{}
+	// partial is true if desired only holds the fields that are being set.
+	partial bool
+}
+
+// NewContext returns a Context for the given operation on the resource
+// identified by key. resource is the collection name of the API, e.g.
+// "BackendServices". Returns nil if auditing is disabled, all methods of
+// Context are no-ops on a nil receiver.
+func NewContext(resource, operation string, key *meta.Key, version meta.Version) *Context {
+	if !Enabled() {
+		return nil
+	}
+	if version == "" {
+		version = meta.VersionGA
+	}
+	return &Context{
+		start:     time.Now(),
+		resource:  resource,
+		operation: operation,
+		version:   version,
+		key:       key,
+	}
+}
+
+// WithObject sets the full desired state of the resource.
+func (c *Context) WithObject(obj interface{}) *Context {
+	if c == nil {
+		return nil
+	}
+	c.desired = flatten(obj)
+	c.partial = false
+	return c
+}
+
+// WithFields sets the fields that the operation changes.
+func (c *Context) WithFields(fields map[string]interface{}) *Context {
+	if c == nil {
+		return nil
+	}
+	c.desired = flatten(fields)
+	c.partial = true
+	return c
+}
+
+// WithController sets the controller issuing the mutation. It is used for
+// resources which are not attributed through SetOwner.
+func (c *Context) WithController(controller string) *Context {
+	if c == nil {
+		return nil
+	}
+	c.controller = controller
+	return c
+}
+
+// Observe records the result of the mutation and returns err unchanged.
+func (c *Context) Observe(err error) error {
+	if c == nil {
+		return err
+	}
+	url := cloudprovider.SelfLink(c.version, project, resourceCollection(c.resource), c.key)
+
+	lock.Lock()
+	s := sink
+	controller, objects := ownedBy(c.key)
+	old := lastSeen[url]
+	record := &Record{
+		Timestamp:  c.start.UTC(),
+		Controller: controller,
+		Objects:    objects,
+		Resource:   url,
+		Operation:  c.operation,
+		APIVersion: string(c.version),
+		Result:     ResultSuccess,
+	}
+	switch {
+	case c.operation == OperationUpdate:
+		record.Diff = diff(old, c.desired, false)
+	case c.partial:
+		record.Diff = diff(old, c.desired, true)
+	default:
+		record.Diff = diff(nil, c.desired, false)
+	}
+	if err != nil {
+		record.Result = ResultError
+		record.Error = err.Error()
+	} else {
+		switch {
+		case c.operation == OperationCreate || c.operation == OperationUpdate:
+			lastSeen[url] = c.desired
+		case c.operation == OperationDelete:
+			delete(lastSeen, url)
+			delete(owners, ownerKey(c.key))
+		case c.partial && old != nil:
+			for k, v := range c.desired {
+				old[k] = v
+			}
+		}
+	}
+	lock.Unlock()
+
+	if c.controller != "" {
+		record.Controller = c.controller
+	}
+	if s == nil {
+		return err
+	}
+	if writeErr := s.Write(record); writeErr != nil {
+		klog.Errorf("Failed to write audit record for %s %s: %v", c.operation, url, writeErr)
+	}
+	return err
+}
+
+// ObserveGet caches the state of a resource read from GCE so that later
+// updates can be diffed against it.
+func ObserveGet(resource string, key *meta.Key, version meta.Version, obj interface{}) {
+	if !Enabled() {
+		return
+	}
+	if version == "" {
+		version = meta.VersionGA
+	}
+	url := cloudprovider.SelfLink(version, project, resourceCollection(resource), key)
+	fields := flatten(obj)
+
+	lock.Lock()
+	defer lock.Unlock()
+	lastSeen[url] = fields
+}
+
+// resourceCollection converts an API name such as "BackendServices" to the
+// collection name used in resource URLs, e.g. "backendServices".
+func resourceCollection(resource string) string {
+	if resource == "" {
+		return resource
+	}
+	return strings.ToLower(resource[:1]) + resource[1:]
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type fakeSink struct {
+	records []*Record
+}
+
+func (f *fakeSink) Write(r *Record) error {
+	f.records = append(f.records, r)
+	return nil
+}
+
+type fakeBackendService struct {
+	Name        string   `json:"name,omitempty"`
+	Protocol    string   `json:"protocol,omitempty"`
+	Backends    []string `json:"backends,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+}
+
+func TestDisabled(t *testing.T) {
+	Init(nil, "")
+	ac := NewContext("BackendServices", OperationCreate, meta.GlobalKey("foo"), meta.VersionGA).WithObject(&fakeBackendService{Name: "foo"})
+	if ac != nil {
+		t.Fatalf("NewContext() = %+v, want nil when auditing is disabled", ac)
+	}
+	wantErr := fmt.Errorf("error")
+	if err := ac.Observe(wantErr); err != wantErr {
+		t.Errorf("Observe(%v) = %v, want %v", wantErr, err, wantErr)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	sink := &fakeSink{}
+	Init(sink, "test-project")
+	defer Init(nil, "")
+
+	key := meta.GlobalKey("k8s-be-30000")
+	url := "https://www.googleapis.com/compute/v1/projects/test-project/global/backendServices/k8s-be-30000"
+	SetOwner(ControllerIngress, "default/svc", key)
+
+	// Create reports all fields as new.
+	NewContext("BackendServices", OperationCreate, key, "").WithObject(&fakeBackendService{Name: key.Name, Protocol: "HTTP"}).Observe(nil)
+	// Get of the resource returns the output only fingerprint, which must be ignored.
+	ObserveGet("BackendServices", key, meta.VersionGA, &fakeBackendService{Name: key.Name, Protocol: "HTTP", Fingerprint: "abc"})
+	// Update is diffed against the last seen state.
+	NewContext("BackendServices", OperationUpdate, key, meta.VersionGA).WithObject(&fakeBackendService{Name: key.Name, Protocol: "HTTP2", Backends: []string{"ig"}}).Observe(nil)
+	// Set operations only diff the changed fields.
+	NewContext("BackendServices", "setSecurityPolicy", key, meta.VersionGA).WithFields(map[string]interface{}{"protocol": "HTTP2", "securityPolicy": "policy"}).Observe(nil)
+	// Failed deletes are reported with their error.
+	NewContext("BackendServices", OperationDelete, key, meta.VersionGA).WithController(ControllerL4).Observe(fmt.Errorf("in use"))
+
+	want := []*Record{
+		{
+			Controller: ControllerIngress,
+			Objects:    []string{"default/svc"},
+			Resource:   url,
+			Operation:  OperationCreate,
+			APIVersion: "ga",
+			Diff: map[string]FieldDiff{
+				"name":     {New: key.Name},
+				"protocol": {New: "HTTP"},
+			},
+			Result: ResultSuccess,
+		},
+		{
+			Controller: ControllerIngress,
+			Objects:    []string{"default/svc"},
+			Resource:   url,
+			Operation:  OperationUpdate,
+			APIVersion: "ga",
+			Diff: map[string]FieldDiff{
+				"protocol":   {Old: "HTTP", New: "HTTP2"},
+				"backends.0": {New: "ig"},
+			},
+			Result: ResultSuccess,
+		},
+		{
+			Controller: ControllerIngress,
+			Objects:    []string{"default/svc"},
+			Resource:   url,
+			Operation:  "setSecurityPolicy",
+			APIVersion: "ga",
+			Diff: map[string]FieldDiff{
+				"securityPolicy": {New: "policy"},
+			},
+			Result: ResultSuccess,
+		},
+		{
+			Controller: ControllerL4,
+			Objects:    []string{"default/svc"},
+			Resource:   url,
+			Operation:  OperationDelete,
+			APIVersion: "ga",
+			Result:     ResultError,
+			Error:      "in use",
+		},
+	}
+	if len(sink.records) != len(want) {
+		t.Fatalf("Got %d records, want %d: %+v", len(sink.records), len(want), sink.records)
+	}
+	for i, got := range sink.records {
+		if got.Timestamp.IsZero() {
+			t.Errorf("Record %d has no timestamp", i)
+		}
+		got.Timestamp = time.Time{}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Record %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestOwners(t *testing.T) {
+	sink := &fakeSink{}
+	Init(sink, "test-project")
+	defer Init(nil, "")
+
+	globalKey := meta.GlobalKey("k8s-be-30000")
+	regionalKey := meta.RegionalKey("k8s-be-30000", "us-central1")
+	SetOwner(ControllerIngress, "default/svc1", globalKey)
+	SetOwner(ControllerIngress, "default/svc2", globalKey)
+	SetOwner(ControllerL4, "default/svc3", regionalKey)
+
+	observe := func(key *meta.Key, operation string) *Record {
+		t.Helper()
+		NewContext("BackendServices", operation, key, meta.VersionGA).Observe(nil)
+		return sink.records[len(sink.records)-1]
+	}
+
+	// A shared resource is attributed to all its owners.
+	if r := observe(globalKey, OperationUpdate); r.Controller != ControllerIngress || !reflect.DeepEqual(r.Objects, []string{"default/svc1", "default/svc2"}) {
+		t.Errorf("Got record of %q for %v, want %q for [default/svc1 default/svc2]", r.Controller, r.Objects, ControllerIngress)
+	}
+	// Resources with the same name in other scopes have their own owners.
+	if r := observe(regionalKey, OperationUpdate); r.Controller != ControllerL4 || !reflect.DeepEqual(r.Objects, []string{"default/svc3"}) {
+		t.Errorf("Got record of %q for %v, want %q for [default/svc3]", r.Controller, r.Objects, ControllerL4)
+	}
+	// Removed objects no longer own the resource.
+	RemoveOwner(ControllerIngress, "default/svc1")
+	if r := observe(globalKey, OperationUpdate); !reflect.DeepEqual(r.Objects, []string{"default/svc2"}) {
+		t.Errorf("Got record for %v after removing default/svc1, want [default/svc2]", r.Objects)
+	}
+	// Deleted resources are forgotten.
+	observe(globalKey, OperationDelete)
+	if r := observe(globalKey, OperationCreate); r.Controller != "" || len(r.Objects) != 0 {
+		t.Errorf("Got record of %q for %v after the resource was deleted, want no owner", r.Controller, r.Objects)
+	}
+	if len(owners) != 1 {
+		t.Errorf("Got owners %v, want only the owners of the regional resource", owners)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		old     map[string]interface{}
+		new     map[string]interface{}
+		partial bool
+		want    map[string]FieldDiff
+	}{
+		{
+			desc: "no change",
+			old:  map[string]interface{}{"a": "x"},
+			new:  map[string]interface{}{"a": "x"},
+			want: nil,
+		},
+		{
+			desc: "changed, added and removed fields",
+			old:  map[string]interface{}{"a": "x", "b": "y"},
+			new:  map[string]interface{}{"a": "z", "c": "w"},
+			want: map[string]FieldDiff{
+				"a": {Old: "x", New: "z"},
+				"b": {Old: "y"},
+				"c": {New: "w"},
+			},
+		},
+		{
+			desc:    "partial ignores missing fields",
+			old:     map[string]interface{}{"a": "x", "b": "y"},
+			new:     map[string]interface{}{"a": "z"},
+			partial: true,
+			want: map[string]FieldDiff{
+				"a": {Old: "x", New: "z"},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := diff(tc.old, tc.new, tc.partial); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("diff(%v, %v, %v) = %v, want %v", tc.old, tc.new, tc.partial, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink(%q) = %v", path, err)
+	}
+	operations := []string{OperationCreate, OperationDelete}
+	for _, op := range operations {
+		if err := sink.Write(&Record{Operation: op}); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			t.Fatalf("Failed to unmarshal line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, r.Operation)
+	}
+	if !reflect.DeepEqual(got, operations) {
+		t.Errorf("Got operations %v, want %v", got, operations)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	received := make(chan *Record, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := &Record{}
+		if err := json.NewDecoder(req.Body).Decode(r); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		received <- r
+	}))
+	defer server.Close()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sink := NewHTTPSink(server.URL, stopCh)
+	if err := sink.Write(&Record{Operation: OperationUpdate}); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	select {
+	case r := <-received:
+		if r.Operation != OperationUpdate {
+			t.Errorf("Got operation %q, want %q", r.Operation, OperationUpdate)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("Timed out waiting for the audit record")
+	}
+}
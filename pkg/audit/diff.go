@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/klog"
+)
+
+// ignoredFields are output only fields set by GCE. They never appear in the
+// desired state and would otherwise show up as removed in every diff.
+var ignoredFields = map[string]bool{
+	"creationTimestamp": true,
+	"fingerprint":       true,
+	"id":                true,
+	"kind":              true,
+	"selfLink":          true,
+}
+
+// flatten converts obj to a map from JSON path to leaf value. Nested fields
+// are joined with ".", list elements are addressed by their index.
+func flatten(obj interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if obj == nil || (reflect.ValueOf(obj).Kind() == reflect.Ptr && reflect.ValueOf(obj).IsNil()) {
+		return result
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Failed to marshal %T for audit: %v", obj, err)
+		return result
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		klog.Errorf("Failed to unmarshal %T for audit: %v", obj, err)
+		return result
+	}
+	if top, ok := generic.(map[string]interface{}); ok {
+		for k := range ignoredFields {
+			delete(top, k)
+		}
+	}
+	flattenInto(result, "", generic)
+	return result
+}
+
+func flattenInto(result map[string]interface{}, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			flattenInto(result, joinPath(prefix, k), elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			flattenInto(result, joinPath(prefix, fmt.Sprint(i)), elem)
+		}
+	default:
+		result[prefix] = v
+	}
+}
+
+func joinPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
+
+// diff returns the fields that differ between old and new. If partial is
+// true, only fields present in new are compared.
+func diff(old, new map[string]interface{}, partial bool) map[string]FieldDiff {
+	result := map[string]FieldDiff{}
+	for k, newVal := range new {
+		oldVal, ok := old[k]
+		if !ok || !reflect.DeepEqual(oldVal, newVal) {
+			result[k] = FieldDiff{Old: oldVal, New: newVal}
+		}
+	}
+	if !partial {
+		for k, oldVal := range old {
+			if _, ok := new[k]; !ok {
+				result[k] = FieldDiff{Old: oldVal}
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+const (
+	// httpSinkBufferSize is the number of records an HTTP sink holds while
+	// the endpoint is slow. Records are dropped once the buffer is full.
+	httpSinkBufferSize = 1000
+	httpSinkTimeout    = 10 * time.Second
+)
+
+// fileSink writes one JSON encoded record per line to a file.
+type fileSink struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+// NewFileSink returns a Sink that appends records to the file at path.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{w: f}, nil
+}
+
+// Write implements Sink.
+func (s *fileSink) Write(r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// httpSink POSTs every record as JSON to an HTTP endpoint. Records are sent
+// asynchronously so that GCE mutations are not delayed by the endpoint.
+type httpSink struct {
+	url     string
+	client  *http.Client
+	records chan *Record
+}
+
+// NewHTTPSink returns a Sink that sends records to url until stopCh is closed.
+func NewHTTPSink(url string, stopCh <-chan struct{}) Sink {
+	s := &httpSink{
+		url:     url,
+		client:  &http.Client{Timeout: httpSinkTimeout},
+		records: make(chan *Record, httpSinkBufferSize),
+	}
+	go s.run(stopCh)
+	return s
+}
+
+// Write implements Sink.
+func (s *httpSink) Write(r *Record) error {
+	select {
+	case s.records <- r:
+		return nil
+	default:
+		return fmt.Errorf("audit buffer for %s is full, dropping record", s.url)
+	}
+}
+
+func (s *httpSink) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case r := <-s.records:
+			if err := s.send(r); err != nil {
+				klog.Errorf("Failed to send audit record for %s %s: %v", r.Operation, r.Resource, err)
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (s *httpSink) send(r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %q from %s", resp.Status, s.url)
+	}
+	return nil
+}
+
+// multiSink writes records to all of its sinks.
+type multiSink []Sink
+
+// NewMultiSink returns a Sink that writes to all of sinks.
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+// Write implements Sink.
+func (m multiSink) Write(r *Record) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
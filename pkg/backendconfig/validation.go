@@ -18,16 +18,24 @@ package backendconfig
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
+	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/utils"
 )
 
 const (
 	OAuthClientIDKey     = "client_id"
 	OAuthClientSecretKey = "client_secret"
+	// BackendTLSCAKey is the key of the CA certificates in the Secret of
+	// BackendTLSConfig.CACertificates.
+	BackendTLSCAKey = "ca.crt"
 )
 
 var supportedAffinities = map[string]bool{
@@ -122,3 +130,66 @@ func validateLogging(beConfig *backendconfigv1.BackendConfig) error {
 
 	return nil
 }
+
+// GetBackendTLSCertificates reads and validates the certificates of the
+// Secrets referenced by the backend TLS config of beConfig. It returns nil if
+// beConfig has no backend TLS config.
+func GetBackendTLSCertificates(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) (*utils.BackendTLSCertificates, error) {
+	if beConfig == nil || beConfig.Spec.BackendTLS == nil {
+		return nil, nil
+	}
+	certs := &utils.BackendTLSCertificates{}
+	if ref := beConfig.Spec.BackendTLS.CACertificates; ref != nil {
+		secret, err := kubeClient.CoreV1().Secrets(beConfig.Namespace).Get(context.TODO(), ref.SecretName, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving secret %v: %v", ref.SecretName, err)
+		}
+		ca, ok := secret.Data[BackendTLSCAKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %v missing %v data", ref.SecretName, BackendTLSCAKey)
+		}
+		if err := validateCACertificates(ca); err != nil {
+			return nil, fmt.Errorf("secret %v has invalid %v data: %v", ref.SecretName, BackendTLSCAKey, err)
+		}
+		certs.CACertificates = string(ca)
+	}
+	if ref := beConfig.Spec.BackendTLS.ClientCertificate; ref != nil {
+		secret, err := kubeClient.CoreV1().Secrets(beConfig.Namespace).Get(context.TODO(), ref.SecretName, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving secret %v: %v", ref.SecretName, err)
+		}
+		cert, ok := secret.Data[v1.TLSCertKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %v missing %v data", ref.SecretName, v1.TLSCertKey)
+		}
+		key, ok := secret.Data[v1.TLSPrivateKeyKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %v missing %v data", ref.SecretName, v1.TLSPrivateKeyKey)
+		}
+		if _, err := tls.X509KeyPair(cert, key); err != nil {
+			return nil, fmt.Errorf("secret %v has an invalid certificate: %v", ref.SecretName, err)
+		}
+		certs.ClientCertificate = string(cert)
+		certs.ClientPrivateKey = string(key)
+	}
+	return certs, nil
+}
+
+// validateCACertificates returns an error if data is not a non-empty list of
+// PEM encoded certificates.
+func validateCACertificates(data []byte) error {
+	count := 0
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of type %q", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		count++
+	}
+	if count == 0 {
+		return fmt.Errorf("no certificates found")
+	}
+	return nil
+}
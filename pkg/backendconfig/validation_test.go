@@ -328,3 +328,85 @@ func TestValidateLogging(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBackendTLSCertificates(t *testing.T) {
+	ca, err := testutils.NewSelfSignedCertificate("ca", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, key, err := testutils.NewSelfSignedCertificateAndKey("client", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, err := testutils.NewSelfSignedCertificateAndKey("other", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubeClient := fake.NewSimpleClientset(
+		&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "ca"}, Data: map[string][]byte{BackendTLSCAKey: []byte(ca)}},
+		&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "bad-ca"}, Data: map[string][]byte{BackendTLSCAKey: []byte(key)}},
+		&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "client"}, Data: map[string][]byte{v1.TLSCertKey: []byte(cert), v1.TLSPrivateKeyKey: []byte(key)}},
+		&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "mismatch"}, Data: map[string][]byte{v1.TLSCertKey: []byte(cert), v1.TLSPrivateKeyKey: []byte(otherKey)}},
+	)
+
+	testCases := []struct {
+		desc        string
+		config      *backendconfigv1.BackendTLSConfig
+		expectError bool
+	}{
+		{
+			desc:   "SNI only",
+			config: &backendconfigv1.BackendTLSConfig{Sni: "example.com"},
+		},
+		{
+			desc: "CA and client certificate",
+			config: &backendconfigv1.BackendTLSConfig{
+				CACertificates:    &backendconfigv1.BackendTLSSecretReference{SecretName: "ca"},
+				ClientCertificate: &backendconfigv1.BackendTLSSecretReference{SecretName: "client"},
+			},
+		},
+		{
+			desc:        "CA secret does not exist",
+			config:      &backendconfigv1.BackendTLSConfig{CACertificates: &backendconfigv1.BackendTLSSecretReference{SecretName: "foo"}},
+			expectError: true,
+		},
+		{
+			desc:        "CA secret without certificates",
+			config:      &backendconfigv1.BackendTLSConfig{CACertificates: &backendconfigv1.BackendTLSSecretReference{SecretName: "bad-ca"}},
+			expectError: true,
+		},
+		{
+			desc:        "client certificate secret without certificate",
+			config:      &backendconfigv1.BackendTLSConfig{ClientCertificate: &backendconfigv1.BackendTLSSecretReference{SecretName: "ca"}},
+			expectError: true,
+		},
+		{
+			desc:        "client certificate with mismatched key",
+			config:      &backendconfigv1.BackendTLSConfig{ClientCertificate: &backendconfigv1.BackendTLSSecretReference{SecretName: "mismatch"}},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{Namespace: "default"},
+			Spec:       backendconfigv1.BackendConfigSpec{BackendTLS: testCase.config},
+		}
+		certs, err := GetBackendTLSCertificates(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+		if err != nil {
+			continue
+		}
+		if testCase.config.CACertificates != nil && certs.CACertificates != ca {
+			t.Errorf("%v: CA certificates = %q, want %q", testCase.desc, certs.CACertificates, ca)
+		}
+		if testCase.config.ClientCertificate != nil && (certs.ClientCertificate != cert || certs.ClientPrivateKey != key) {
+			t.Errorf("%v: Client certificate was not read from the secret", testCase.desc)
+		}
+	}
+}
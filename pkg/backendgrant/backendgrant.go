@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendgrant
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	apisbackendgrant "k8s.io/ingress-gce/pkg/apis/backendgrant"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
+	"k8s.io/ingress-gce/pkg/crd"
+	"k8s.io/klog"
+)
+
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisbackendgrant.GroupName,
+		"BackendGrant",
+		"BackendGrantList",
+		"backendgrant",
+		"backendgrants",
+		[]*crd.Version{
+			crd.NewVersion("v1alpha1", "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1.BackendGrant", backendgrantv1alpha1.GetOpenAPIDefinitions),
+		},
+		"bgrant",
+	)
+	return meta
+}
+
+// IsReferenceAllowed returns true if an Ingress in fromNamespace is allowed to
+// use the given Service as a backend. References within the same namespace are
+// always allowed. Cross-namespace references are allowed only if a BackendGrant
+// in the namespace of the Service trusts fromNamespace for that Service.
+func IsReferenceAllowed(grantIndexer cache.Indexer, fromNamespace string, svc types.NamespacedName) bool {
+	if fromNamespace == svc.Namespace {
+		return true
+	}
+	if grantIndexer == nil {
+		return false
+	}
+	objs, err := grantIndexer.ByIndex(cache.NamespaceIndex, svc.Namespace)
+	if err != nil {
+		klog.Errorf("Failed to list BackendGrants in namespace %q: %v", svc.Namespace, err)
+		return false
+	}
+	for _, obj := range objs {
+		grant, ok := obj.(*backendgrantv1alpha1.BackendGrant)
+		if !ok {
+			klog.Errorf("Wanted BackendGrant, got %T", obj)
+			continue
+		}
+		if grantAllows(grant, fromNamespace, svc.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// grantAllows returns true if the given grant trusts fromNamespace to
+// reference the Service svcName.
+func grantAllows(grant *backendgrantv1alpha1.BackendGrant, fromNamespace, svcName string) bool {
+	fromAllowed := false
+	for _, from := range grant.Spec.From {
+		if from.Namespace == fromNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	if len(grant.Spec.To) == 0 {
+		return true
+	}
+	for _, to := range grant.Spec.To {
+		if to.Name == svcName {
+			return true
+		}
+	}
+	return false
+}
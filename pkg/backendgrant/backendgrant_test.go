@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendgrant
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestIsReferenceAllowed(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	for _, grant := range []*backendgrantv1alpha1.BackendGrant{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-services", Namespace: "shop"},
+			Spec: backendgrantv1alpha1.BackendGrantSpec{
+				From: []backendgrantv1alpha1.BackendGrantFrom{{Namespace: "edge"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "single-service", Namespace: "billing"},
+			Spec: backendgrantv1alpha1.BackendGrantSpec{
+				From: []backendgrantv1alpha1.BackendGrantFrom{{Namespace: "edge"}, {Namespace: "staging"}},
+				To:   []backendgrantv1alpha1.BackendGrantTo{{Name: "api"}},
+			},
+		},
+	} {
+		indexer.Add(grant)
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		indexer cache.Indexer
+		from    string
+		svc     types.NamespacedName
+		want    bool
+	}{
+		{
+			desc:    "same namespace",
+			indexer: nil,
+			from:    "edge",
+			svc:     types.NamespacedName{Namespace: "edge", Name: "foo"},
+			want:    true,
+		},
+		{
+			desc:    "no grant lister",
+			indexer: nil,
+			from:    "edge",
+			svc:     types.NamespacedName{Namespace: "shop", Name: "foo"},
+			want:    false,
+		},
+		{
+			desc:    "grant for all services",
+			indexer: indexer,
+			from:    "edge",
+			svc:     types.NamespacedName{Namespace: "shop", Name: "foo"},
+			want:    true,
+		},
+		{
+			desc:    "namespace not trusted",
+			indexer: indexer,
+			from:    "staging",
+			svc:     types.NamespacedName{Namespace: "shop", Name: "foo"},
+			want:    false,
+		},
+		{
+			desc:    "grant for named service",
+			indexer: indexer,
+			from:    "staging",
+			svc:     types.NamespacedName{Namespace: "billing", Name: "api"},
+			want:    true,
+		},
+		{
+			desc:    "service not granted",
+			indexer: indexer,
+			from:    "edge",
+			svc:     types.NamespacedName{Namespace: "billing", Name: "internal"},
+			want:    false,
+		},
+		{
+			desc:    "no grant in namespace",
+			indexer: indexer,
+			from:    "edge",
+			svc:     types.NamespacedName{Namespace: "payments", Name: "api"},
+			want:    false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := IsReferenceAllowed(tc.indexer, tc.from, tc.svc); got != tc.want {
+				t.Errorf("IsReferenceAllowed(_, %q, %v) = %v, want %v", tc.from, tc.svc, got, tc.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ limitations under the License.
 package backends
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -65,8 +66,21 @@ func ensureDescription(be *composite.BackendService, sp *utils.ServicePort) (nee
 	return true
 }
 
+// loadBalancingScheme returns the load balancing scheme of the backend service
+// for sp, empty for the default scheme of global backend services.
+func loadBalancingScheme(sp utils.ServicePort) string {
+	switch {
+	case sp.L7ILBEnabled:
+		// This enables l7-ILB and advanced traffic management features
+		return "INTERNAL_MANAGED"
+	case sp.L7XLBRegionalEnabled:
+		return "EXTERNAL_MANAGED"
+	}
+	return ""
+}
+
 // Create implements Pool.
-func (b *Backends) Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error) {
+func (b *Backends) Create(ctx context.Context, sp utils.ServicePort, hcLink string) (*composite.BackendService, error) {
 	name := sp.BackendName()
 	namedPort := &compute.NamedPort{
 		Name: b.namer.NamedPort(sp.NodePort),
@@ -89,9 +103,13 @@ func (b *Backends) Create(sp utils.ServicePort, hcLink string) (*composite.Backe
 		},
 	}
 
-	if sp.L7ILBEnabled {
-		// This enables l7-ILB and advanced traffic management features
-		be.LoadBalancingScheme = "INTERNAL_MANAGED"
+	be.LoadBalancingScheme = loadBalancingScheme(sp)
+	if sp.ExternalNEG != nil {
+		// Backend services of serverless and Internet NEGs have no port and
+		// no health check.
+		be.Port = 0
+		be.PortName = ""
+		be.HealthChecks = nil
 	}
 
 	ensureDescription(be, &sp)
@@ -101,17 +119,17 @@ func (b *Backends) Create(sp utils.ServicePort, hcLink string) (*composite.Backe
 		return nil, err
 	}
 
-	if err := composite.CreateBackendService(b.cloud, key, be); err != nil {
+	if err := composite.CreateBackendServiceWithContext(ctx, b.cloud, key, be); err != nil {
 		return nil, err
 	}
 	// Note: We need to perform a GCE call to re-fetch the object we just created
 	// so that the "Fingerprint" field is filled in. This is needed to update the
 	// object without error.
-	return b.Get(name, version, scope)
+	return b.Get(ctx, name, version, scope)
 }
 
 // Update implements Pool.
-func (b *Backends) Update(be *composite.BackendService) error {
+func (b *Backends) Update(ctx context.Context, be *composite.BackendService) error {
 	// Ensure the backend service has the proper version before updating.
 	be.Version = features.VersionFromDescription(be.Description)
 	scope, err := composite.ScopeFromSelfLink(be.SelfLink)
@@ -123,19 +141,19 @@ func (b *Backends) Update(be *composite.BackendService) error {
 	if err != nil {
 		return err
 	}
-	if err := composite.UpdateBackendService(b.cloud, key, be); err != nil {
+	if err := composite.UpdateBackendServiceWithContext(ctx, b.cloud, key, be); err != nil {
 		return err
 	}
 	return nil
 }
 
 // Get implements Pool.
-func (b *Backends) Get(name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error) {
+func (b *Backends) Get(ctx context.Context, name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error) {
 	key, err := composite.CreateKey(b.cloud, name, scope)
 	if err != nil {
 		return nil, err
 	}
-	be, err := composite.GetBackendService(b.cloud, key, version)
+	be, err := composite.GetBackendServiceWithContext(ctx, b.cloud, key, version)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +163,7 @@ func (b *Backends) Get(name string, version meta.Version, scope meta.KeyType) (*
 	versionRequired := features.VersionFromDescription(be.Description)
 
 	if features.IsLowerVersion(versionRequired, version) {
-		be, err = composite.GetBackendService(b.cloud, key, versionRequired)
+		be, err = composite.GetBackendServiceWithContext(ctx, b.cloud, key, versionRequired)
 		if err != nil {
 			return nil, err
 		}
@@ -154,14 +172,14 @@ func (b *Backends) Get(name string, version meta.Version, scope meta.KeyType) (*
 }
 
 // Delete implements Pool.
-func (b *Backends) Delete(name string, version meta.Version, scope meta.KeyType) error {
+func (b *Backends) Delete(ctx context.Context, name string, version meta.Version, scope meta.KeyType) error {
 	klog.V(2).Infof("Deleting backend service %v", name)
 
 	key, err := composite.CreateKey(b.cloud, name, scope)
 	if err != nil {
 		return err
 	}
-	err = composite.DeleteBackendService(b.cloud, key, version)
+	err = composite.DeleteBackendServiceWithContext(ctx, b.cloud, key, version)
 	if err != nil {
 		if utils.IsHTTPErrorCode(err, http.StatusNotFound) || utils.IsInUsedByError(err) {
 			klog.Infof("DeleteBackendService(_, %v, %v) = %v; ignorable error", key, version, err)
@@ -176,7 +194,7 @@ func (b *Backends) Delete(name string, version meta.Version, scope meta.KeyType)
 
 // Health implements Pool.
 func (b *Backends) Health(name string, version meta.Version, scope meta.KeyType) (string, error) {
-	be, err := b.Get(name, version, scope)
+	be, err := b.Get(context.Background(), name, version, scope)
 	if err != nil {
 		return "Unknown", fmt.Errorf("error getting backend service %s: %v", name, err)
 	}
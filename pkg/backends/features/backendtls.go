@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/networksecurity"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+const (
+	wellKnownRootsPublic = "PUBLIC_ROOTS"
+	wellKnownRootsNone   = "NONE"
+)
+
+// backendTLSEnabled returns true if backend TLS is enabled, the BackendConfig
+// of the ServicePort configures it and it applies to the backend. Only global
+// HTTPS and HTTP2 backends talk TLS to their endpoints, and the TLS settings
+// of backend services are only supported by global external load balancers.
+func backendTLSEnabled(sp *utils.ServicePort) bool {
+	if !flags.F.EnableBackendTLS {
+		return false
+	}
+	if sp.BackendConfig == nil || sp.BackendConfig.Spec.BackendTLS == nil {
+		return false
+	}
+	if sp.L7ILBEnabled || sp.L7XLBRegionalEnabled {
+		return false
+	}
+	return sp.Protocol == annotations.ProtocolHTTPS || sp.Protocol == annotations.ProtocolHTTP2
+}
+
+// EnsureBackendTLSResources ensures the Certificate Manager trust config and
+// certificate, and the Network Security backend authentication config of the
+// backend TLS config of the ServicePort. They are named after the backend
+// service beName. It returns the link of the backend authentication config,
+// empty if backend TLS is not enabled.
+func EnsureBackendTLSResources(cm certificatemanager.Interface, ns networksecurity.Interface, sp utils.ServicePort, beName string) (string, error) {
+	if !backendTLSEnabled(&sp) {
+		return "", nil
+	}
+	if cm == nil || ns == nil {
+		return "", fmt.Errorf("backend TLS of service %s is not supported without the Certificate Manager and Network Security clients", sp.ID.Service.String())
+	}
+	certs := sp.BackendTLSCertificates
+	if certs == nil {
+		return "", fmt.Errorf("certificates of the backend TLS config of service %s were not read", sp.ID.Service.String())
+	}
+	desc := fmt.Sprintf("Backend TLS of BackendConfig %s/%s", sp.BackendConfig.Namespace, sp.BackendConfig.Name)
+	authConfig := &networksecurity.BackendAuthenticationConfig{
+		Description:    desc,
+		WellKnownRoots: wellKnownRootsPublic,
+	}
+
+	if certs.CACertificates != "" {
+		if err := ensureTrustConfig(cm, beName, &certificatemanager.TrustConfig{
+			Description: desc,
+			TrustStores: []certificatemanager.TrustStore{{
+				TrustAnchors: []certificatemanager.TrustedCertificate{{PemCertificate: certs.CACertificates}},
+			}},
+		}); err != nil {
+			return "", fmt.Errorf("error ensuring trust config %s: %v", beName, err)
+		}
+		authConfig.TrustConfig = cm.TrustConfigLink(beName)
+		authConfig.WellKnownRoots = wellKnownRootsNone
+	}
+
+	if certs.ClientCertificate != "" {
+		if err := ensureCertificate(cm, beName, &certificatemanager.Certificate{
+			Description: desc,
+			SelfManaged: &certificatemanager.SelfManagedCertificate{
+				PemCertificate: certs.ClientCertificate,
+				PemPrivateKey:  certs.ClientPrivateKey,
+			},
+		}); err != nil {
+			return "", fmt.Errorf("error ensuring certificate %s: %v", beName, err)
+		}
+		authConfig.ClientCertificate = cm.CertificateLink(beName)
+	}
+
+	existing, err := ns.GetBackendAuthenticationConfig(beName)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return "", err
+	}
+	switch {
+	case existing == nil:
+		klog.V(2).Infof("Creating backend authentication config %s for service %s", beName, sp.ID.Service.String())
+		err = ns.CreateBackendAuthenticationConfig(beName, authConfig)
+	case existing.Description != authConfig.Description || existing.TrustConfig != authConfig.TrustConfig ||
+		existing.ClientCertificate != authConfig.ClientCertificate || existing.WellKnownRoots != authConfig.WellKnownRoots:
+		klog.V(2).Infof("Updating backend authentication config %s for service %s", beName, sp.ID.Service.String())
+		err = ns.UpdateBackendAuthenticationConfig(beName, authConfig)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error ensuring backend authentication config %s: %v", beName, err)
+	}
+
+	// The trust config and the certificate can only be deleted once the
+	// authentication config no longer references them.
+	if existing != nil && existing.TrustConfig != "" && authConfig.TrustConfig == "" {
+		if err := utils.IgnoreHTTPNotFound(cm.DeleteTrustConfig(beName)); err != nil {
+			return "", err
+		}
+	}
+	if existing != nil && existing.ClientCertificate != "" && authConfig.ClientCertificate == "" {
+		if err := utils.IgnoreHTTPNotFound(cm.DeleteCertificate(beName)); err != nil {
+			return "", err
+		}
+	}
+	return ns.BackendAuthenticationConfigLink(beName), nil
+}
+
+func ensureTrustConfig(cm certificatemanager.Interface, name string, trustConfig *certificatemanager.TrustConfig) error {
+	existing, err := cm.GetTrustConfig(name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+	if existing == nil {
+		klog.V(2).Infof("Creating trust config %s", name)
+		return cm.CreateTrustConfig(name, trustConfig)
+	}
+	if existing.Description != trustConfig.Description || !reflect.DeepEqual(existing.TrustStores, trustConfig.TrustStores) {
+		klog.V(2).Infof("Updating trust config %s", name)
+		return cm.UpdateTrustConfig(name, trustConfig)
+	}
+	return nil
+}
+
+func ensureCertificate(cm certificatemanager.Interface, name string, cert *certificatemanager.Certificate) error {
+	existing, err := cm.GetCertificate(name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+	if existing == nil {
+		klog.V(2).Infof("Creating certificate %s", name)
+		return cm.CreateCertificate(name, cert)
+	}
+	// The API does not return the private key, which changes together with
+	// the certificate.
+	if existing.Description != cert.Description || existing.SelfManaged == nil || existing.SelfManaged.PemCertificate != cert.SelfManaged.PemCertificate {
+		klog.V(2).Infof("Updating certificate %s", name)
+		return cm.UpdateCertificate(name, cert)
+	}
+	return nil
+}
+
+// EnsureBackendTLS applies the backend TLS config of the ServicePort to the
+// TLS settings of the backend service of key, referencing the backend
+// authentication config authConfigLink. The settings are removed if backend
+// TLS is not enabled. It returns the backend authentication config the backend
+// service referenced before.
+func EnsureBackendTLS(ctx context.Context, cloud *gce.Cloud, sp utils.ServicePort, key *meta.Key, authConfigLink string) (string, error) {
+	existing, err := composite.GetTlsSettingsForBackendServiceWithContext(ctx, cloud, key)
+	if err != nil {
+		return "", err
+	}
+	var oldAuthConfigLink string
+	if existing != nil {
+		oldAuthConfigLink = existing.AuthenticationConfig
+	}
+	expected := expectedTlsSettings(sp, authConfigLink)
+	if reflect.DeepEqual(existing, expected) {
+		return oldAuthConfigLink, nil
+	}
+	if err := composite.SetTlsSettingsForBackendServiceWithContext(ctx, cloud, key, expected); err != nil {
+		return "", err
+	}
+	if expected == nil {
+		klog.V(2).Infof("Removed backend TLS settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+	} else {
+		klog.V(2).Infof("Updated backend TLS settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+	}
+	return oldAuthConfigLink, nil
+}
+
+// expectedTlsSettings returns the TLS settings of the backend TLS config of
+// the ServicePort, nil if backend TLS is not enabled.
+func expectedTlsSettings(sp utils.ServicePort, authConfigLink string) *composite.BackendServiceTlsSettings {
+	if !backendTLSEnabled(&sp) {
+		return nil
+	}
+	config := sp.BackendConfig.Spec.BackendTLS
+	settings := &composite.BackendServiceTlsSettings{
+		Sni:                  config.Sni,
+		AuthenticationConfig: authConfigLink,
+	}
+	for _, san := range config.SubjectAltNames {
+		if strings.Contains(san, "://") {
+			settings.SubjectAltNames = append(settings.SubjectAltNames, &composite.BackendServiceTlsSettingsSubjectAltName{UniformResourceIdentifier: san})
+		} else {
+			settings.SubjectAltNames = append(settings.SubjectAltNames, &composite.BackendServiceTlsSettingsSubjectAltName{DnsName: san})
+		}
+	}
+	return settings
+}
+
+// DeleteBackendTLSResources deletes the backend TLS resources of the backend
+// service, if its TLS settings referenced them. link is the backend
+// authentication config the backend service referenced.
+func DeleteBackendTLSResources(cm certificatemanager.Interface, ns networksecurity.Interface, beName, link string) error {
+	if link == "" || path.Base(link) != beName {
+		return nil
+	}
+	klog.V(2).Infof("Deleting backend TLS resources of backend service %s", beName)
+	if err := utils.IgnoreHTTPNotFound(ns.DeleteBackendAuthenticationConfig(beName)); err != nil {
+		return err
+	}
+	if err := utils.IgnoreHTTPNotFound(cm.DeleteCertificate(beName)); err != nil {
+		return err
+	}
+	return utils.IgnoreHTTPNotFound(cm.DeleteTrustConfig(beName))
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+const testAuthConfigLink = "projects/test-project/locations/global/backendAuthenticationConfigs/backend"
+
+func newBackendTLSServicePort(protocol annotations.AppProtocol, config *backendconfigv1.BackendTLSConfig) utils.ServicePort {
+	return utils.ServicePort{
+		Protocol: protocol,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{BackendTLS: config},
+		},
+	}
+}
+
+func TestEnsureBackendTLS(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableBackendTLS = enabled }(flags.F.EnableBackendTLS)
+	flags.F.EnableBackendTLS = true
+
+	config := &backendconfigv1.BackendTLSConfig{
+		Sni:             "backend.example.com",
+		SubjectAltNames: []string{"spiffe://example.com/backend", "backend.example.com"},
+	}
+	settings := &composite.BackendServiceTlsSettings{
+		Sni: config.Sni,
+		SubjectAltNames: []*composite.BackendServiceTlsSettingsSubjectAltName{
+			{UniformResourceIdentifier: "spiffe://example.com/backend"},
+			{DnsName: "backend.example.com"},
+		},
+		AuthenticationConfig: testAuthConfigLink,
+	}
+
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		existing       *composite.BackendServiceTlsSettings
+		authConfigLink string
+		want           *composite.BackendServiceTlsSettings
+	}{
+		{
+			desc: "backend TLS missing from both ends",
+			sp:   utils.ServicePort{Protocol: annotations.ProtocolHTTPS},
+		},
+		{
+			desc:           "backend TLS enabled",
+			sp:             newBackendTLSServicePort(annotations.ProtocolHTTPS, config),
+			authConfigLink: testAuthConfigLink,
+			want:           settings,
+		},
+		{
+			desc:           "settings are identical",
+			sp:             newBackendTLSServicePort(annotations.ProtocolHTTP2, config),
+			existing:       settings,
+			authConfigLink: testAuthConfigLink,
+			want:           settings,
+		},
+		{
+			desc:           "SNI changed",
+			sp:             newBackendTLSServicePort(annotations.ProtocolHTTPS, &backendconfigv1.BackendTLSConfig{Sni: "other.example.com"}),
+			existing:       settings,
+			authConfigLink: testAuthConfigLink,
+			want:           &composite.BackendServiceTlsSettings{Sni: "other.example.com", AuthenticationConfig: testAuthConfigLink},
+		},
+		{
+			desc:     "backend TLS removed",
+			sp:       utils.ServicePort{Protocol: annotations.ProtocolHTTPS, BackendConfig: &backendconfigv1.BackendConfig{}},
+			existing: settings,
+		},
+		{
+			desc:           "HTTP backend ignores backend TLS",
+			sp:             newBackendTLSServicePort(annotations.ProtocolHTTP, config),
+			authConfigLink: testAuthConfigLink,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+			composite.RegisterFakeExtension(fakeGCE, fakeGCE.Compute().(*cloud.MockGCE))
+			key := meta.GlobalKey("backend")
+			if err := composite.CreateBackendService(fakeGCE, key, &composite.BackendService{Name: "backend", Version: meta.VersionGA}); err != nil {
+				t.Fatalf("CreateBackendService() = %v", err)
+			}
+			if tc.existing != nil {
+				if err := composite.SetTlsSettingsForBackendService(fakeGCE, key, tc.existing); err != nil {
+					t.Fatalf("SetTlsSettingsForBackendService() = %v", err)
+				}
+			}
+
+			old, err := EnsureBackendTLS(context.Background(), fakeGCE, tc.sp, key, tc.authConfigLink)
+			if err != nil {
+				t.Fatalf("EnsureBackendTLS() = %v", err)
+			}
+			var wantOld string
+			if tc.existing != nil {
+				wantOld = tc.existing.AuthenticationConfig
+			}
+			if old != wantOld {
+				t.Errorf("EnsureBackendTLS() returned %q, want %q", old, wantOld)
+			}
+			got, err := composite.GetTlsSettingsForBackendService(fakeGCE, key)
+			if err != nil {
+				t.Fatalf("GetTlsSettingsForBackendService() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Got TLS settings %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackendTLSDisabled(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableBackendTLS = enabled }(flags.F.EnableBackendTLS)
+	flags.F.EnableBackendTLS = false
+
+	sp := newBackendTLSServicePort(annotations.ProtocolHTTPS, &backendconfigv1.BackendTLSConfig{Sni: "backend.example.com"})
+	if backendTLSEnabled(&sp) {
+		t.Errorf("backendTLSEnabled() = true, want false without the flag")
+	}
+	if link, err := EnsureBackendTLSResources(nil, nil, sp, "backend"); link != "" || err != nil {
+		t.Errorf("EnsureBackendTLSResources() = %q, %v, want no resources", link, err)
+	}
+}
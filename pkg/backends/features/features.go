@@ -36,8 +36,14 @@ const (
 	// FeatureL7ILB defines the feature name of L7 Internal Load Balancer
 	// L7-ILB Resources are currently alpha and regional
 	FeatureL7ILB = "L7ILB"
+	// FeatureL7XLBRegional defines the feature name of regional external L7
+	// load balancers, whose resources are regional.
+	FeatureL7XLBRegional = "L7XLBRegional"
 	//FeatureVMIPNEG defines the feature name of GCE_VM_IP NEGs which are used for L4 ILB.
 	FeatureVMIPNEG = "VMIPNEG"
+	// FeatureBackendTLS defines the feature name of backend TLS. Its TLS
+	// settings are patched with the GA API.
+	FeatureBackendTLS = "BackendTLS"
 )
 
 var (
@@ -48,7 +54,7 @@ var (
 	}
 	// TODO: (shance) refactor all scope to be above the serviceport level
 	scopeToFeatures = map[meta.KeyType][]string{
-		meta.Regional: []string{FeatureL7ILB, FeatureVMIPNEG},
+		meta.Regional: []string{FeatureL7ILB, FeatureL7XLBRegional, FeatureVMIPNEG},
 	}
 )
 
@@ -76,6 +82,12 @@ func featuresFromServicePort(sp *utils.ServicePort) []string {
 	if sp.L7ILBEnabled {
 		features = append(features, FeatureL7ILB)
 	}
+	if sp.L7XLBRegionalEnabled {
+		features = append(features, FeatureL7XLBRegional)
+	}
+	if backendTLSEnabled(sp) {
+		features = append(features, FeatureBackendTLS)
+	}
 	// Keep feature names sorted to be consistent.
 	sort.Strings(features)
 	return features
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -74,9 +75,24 @@ var (
 		ID:         fakeSvcPortID,
 		NEGEnabled: true,
 	}
+
+	svcPortWithHTTPSBackendTLS = utils.ServicePort{
+		ID:       fakeSvcPortID,
+		Protocol: annotations.ProtocolHTTPS,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				BackendTLS: &backendconfigv1.BackendTLSConfig{
+					Sni: "backend.example.com",
+				},
+			},
+		},
+	}
 )
 
 func TestFeaturesFromServicePort(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableBackendTLS = enabled }(flags.F.EnableBackendTLS)
+	flags.F.EnableBackendTLS = true
+
 	testCases := []struct {
 		desc             string
 		svcPort          utils.ServicePort
@@ -107,6 +123,11 @@ func TestFeaturesFromServicePort(t *testing.T) {
 			svcPort:          svcPortWithHTTP2SecurityPolicy,
 			expectedFeatures: []string{"HTTP2", "SecurityPolicy"},
 		},
+		{
+			desc:             "HTTPS + BackendTLS",
+			svcPort:          svcPortWithHTTPSBackendTLS,
+			expectedFeatures: []string{"BackendTLS"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -14,6 +14,7 @@ limitations under the License.
 package backends
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -80,7 +81,7 @@ func NewInstanceGroupLinker(
 }
 
 // Link implements Link.
-func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
+func (l *instanceGroupLinker) Link(ctx context.Context, sp utils.ServicePort, groups []GroupKey) error {
 	var igLinks []string
 	for _, group := range groups {
 		ig, err := l.instancePool.Get(sp.IGName(), group.Zone)
@@ -93,7 +94,7 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 	// ig_linker only supports L7 HTTP(s) External Load Balancer
 	// Hardcoded here since IGs are not supported for non GA-Global right now
 	// TODO(shance): find a way to remove hardcoded values
-	be, err := l.backendPool.Get(sp.BackendName(), meta.VersionGA, meta.Global)
+	be, err := l.backendPool.Get(ctx, sp.BackendName(), meta.VersionGA, meta.Global)
 	if err != nil {
 		return err
 	}
@@ -128,7 +129,7 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 		newBackends := getBackendsForIGs(addIGs, bm)
 		be.Backends = append(originalIGBackends, newBackends...)
 
-		if err := l.backendPool.Update(be); err != nil {
+		if err := l.backendPool.Update(ctx, be); err != nil {
 			if utils.IsHTTPErrorCode(err, http.StatusBadRequest) {
 				klog.V(2).Infof("Updating backend service backends with balancing mode %v failed, will try another mode. err:%v", bm, err)
 				errs = append(errs, err.Error())
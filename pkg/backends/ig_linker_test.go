@@ -60,9 +60,9 @@ func TestLink(t *testing.T) {
 	}
 
 	// Mimic the syncer creating the backend.
-	linker.backendPool.Create(sp, "fake-health-check-link")
+	linker.backendPool.Create(context.Background(), sp, "fake-health-check-link")
 
-	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+	if err := linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 		t.Fatalf("%v", err)
 	}
 
@@ -104,9 +104,9 @@ func TestLinkWithCreationModeError(t *testing.T) {
 		}
 
 		// Mimic the syncer creating the backend.
-		linker.backendPool.Create(sp, "fake-health-check-link")
+		linker.backendPool.Create(context.Background(), sp, "fake-health-check-link")
 
-		if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		if err := linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 			t.Fatalf("%v", err)
 		}
 
@@ -124,6 +124,6 @@ func TestLinkWithCreationModeError(t *testing.T) {
 				t.Fatalf("Wrong balancing mode, expected %v got %v", modes[(i+1)%len(modes)], b.BalancingMode)
 			}
 		}
-		linker.backendPool.Delete(sp.BackendName(), features.VersionFromServicePort(&sp), features.ScopeFromServicePort(&sp))
+		linker.backendPool.Delete(context.Background(), sp.BackendName(), features.VersionFromServicePort(&sp), features.ScopeFromServicePort(&sp))
 	}
 }
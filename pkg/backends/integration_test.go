@@ -60,7 +60,7 @@ func newTestJig(fakeGCE *gce.Cloud) *Jig {
 	return &Jig{
 		fakeInstancePool: fakeInstancePool,
 		linker:           NewInstanceGroupLinker(fakeInstancePool, fakeBackendPool),
-		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, fakeGCE),
+		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, fakeGCE, nil),
 		pool:             fakeBackendPool,
 	}
 }
@@ -75,10 +75,10 @@ func TestBackendInstanceGroupClobbering(t *testing.T) {
 		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v: %v", sp, err)
 	}
 
-	if err := jig.syncer.Sync([]utils.ServicePort{sp}); err != nil {
+	if err := jig.syncer.Sync(context.Background(), []utils.ServicePort{sp}); err != nil {
 		t.Fatalf("Did not expect error when syncing backend with port %v", sp.NodePort)
 	}
-	if err := jig.linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+	if err := jig.linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 		t.Fatalf("Did not expect error when linking backend with port %v to groups", sp.NodePort)
 	}
 
@@ -103,10 +103,10 @@ func TestBackendInstanceGroupClobbering(t *testing.T) {
 		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v: %v", sp, err)
 	}
 
-	if err := jig.syncer.Sync([]utils.ServicePort{sp}); err != nil {
+	if err := jig.syncer.Sync(context.Background(), []utils.ServicePort{sp}); err != nil {
 		t.Fatalf("Did not expect error when syncing backend with port %v", sp.NodePort)
 	}
-	if err := jig.linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+	if err := jig.linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 		t.Fatalf("Did not expect error when linking backend with port %v to groups", sp.NodePort)
 	}
 
@@ -148,10 +148,10 @@ func TestSyncChaosMonkey(t *testing.T) {
 		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v, err %v", sp, err)
 	}
 
-	if err := jig.syncer.Sync([]utils.ServicePort{sp}); err != nil {
+	if err := jig.syncer.Sync(context.Background(), []utils.ServicePort{sp}); err != nil {
 		t.Fatalf("Did not expect error when syncing backend with port %v, err: %v", sp.NodePort, err)
 	}
-	if err := jig.linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+	if err := jig.linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 		t.Fatalf("Did not expect error when linking backend with port %v to groups, err: %v", sp.NodePort, err)
 	}
 
@@ -181,10 +181,10 @@ func TestSyncChaosMonkey(t *testing.T) {
 		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v: %v", sp, err)
 	}
 
-	if err := jig.syncer.Sync([]utils.ServicePort{sp}); err != nil {
+	if err := jig.syncer.Sync(context.Background(), []utils.ServicePort{sp}); err != nil {
 		t.Fatalf("Did not expect error when syncing backend with port %v", sp.NodePort)
 	}
-	if err := jig.linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+	if err := jig.linker.Link(context.Background(), sp, []GroupKey{{Zone: defaultZone}}); err != nil {
 		t.Fatalf("Did not expect error when linking backend with port %v to groups", sp.NodePort)
 	}
 	if createCalls > 0 {
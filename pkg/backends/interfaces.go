@@ -17,6 +17,8 @@ limitations under the License.
 package backends
 
 import (
+	"context"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -33,14 +35,15 @@ type GroupKey struct {
 // Pool is an interface to perform CRUD operations on a pool of GCE
 // Backend Services.
 type Pool interface {
-	// Get a composite BackendService given a required version.
-	Get(name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error)
+	// Get a composite BackendService given a required version. The calls
+	// to GCE of this and the other CRUD operations are traced under ctx.
+	Get(ctx context.Context, name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error)
 	// Create a composite BackendService and returns it.
-	Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error)
+	Create(ctx context.Context, sp utils.ServicePort, hcLink string) (*composite.BackendService, error)
 	// Update a BackendService given the composite type.
-	Update(be *composite.BackendService) error
+	Update(ctx context.Context, be *composite.BackendService) error
 	// Delete a BackendService given its name.
-	Delete(name string, version meta.Version, scope meta.KeyType) error
+	Delete(ctx context.Context, name string, version meta.Version, scope meta.KeyType) error
 	// Get the health of a BackendService given its name.
 	Health(name string, version meta.Version, scope meta.KeyType) (string, error)
 	// Get a list of BackendService names that are managed by this pool.
@@ -52,8 +55,8 @@ type Syncer interface {
 	// Init an implementation of ProbeProvider.
 	Init(p ProbeProvider)
 	// Sync a BackendService. Implementations should only create the BackendService
-	// but not its groups.
-	Sync(svcPorts []utils.ServicePort) error
+	// but not its groups. The calls to GCE are traced under ctx.
+	Sync(ctx context.Context, svcPorts []utils.ServicePort) error
 	// GC garbage collects unused BackendService's
 	GC(svcPorts []utils.ServicePort) error
 	// Status returns the status of a BackendService given its name.
@@ -64,8 +67,9 @@ type Syncer interface {
 
 // Linker is an interface to link backends with their associated groups.
 type Linker interface {
-	// Link a BackendService to its groups.
-	Link(sp utils.ServicePort, groups []GroupKey) error
+	// Link a BackendService to its groups, tracing the calls to GCE under
+	// ctx.
+	Link(ctx context.Context, sp utils.ServicePort, groups []GroupKey) error
 }
 
 // NEGGetter is an interface to retrieve NEG object
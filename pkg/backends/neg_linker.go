@@ -14,7 +14,10 @@ limitations under the License.
 package backends
 
 import (
+	"context"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/annotations"
 	befeatures "k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/neg/types"
@@ -45,16 +48,26 @@ func NewNEGLinker(
 }
 
 // Link implements Link.
-func (l *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
+func (l *negLinker) Link(ctx context.Context, sp utils.ServicePort, groups []GroupKey) error {
 	version := befeatures.VersionFromServicePort(&sp)
 	var negs []*composite.NetworkEndpointGroup
 	var err error
+	if sp.ExternalNEG != nil {
+		// The serverless or Internet NEG of the service is its only backend.
+		// It is managed by the NEG controller.
+		key := types.ExternalNEGKey(sp.NEGName(), l.cloud.Region(), sp.ExternalNEG)
+		negs = append(negs, &composite.NetworkEndpointGroup{
+			NetworkEndpointType: sp.ExternalNEG.NetworkEndpointType,
+			SelfLink:            cloud.SelfLink(version, l.cloud.ProjectID(), "networkEndpointGroups", key),
+		})
+		groups = nil
+	}
 	for _, group := range groups {
 		// If the group key contains a name, then use that.
 		// Otherwise, get the name from svc port.
 		negName := group.Name
 		if negName == "" {
-			negName = sp.BackendName()
+			negName = sp.NEGName()
 		}
 		neg, err := l.negGetter.GetNetworkEndpointGroup(negName, group.Zone, version)
 		if err != nil {
@@ -70,7 +83,7 @@ func (l *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 	if err != nil {
 		return err
 	}
-	backendService, err := composite.GetBackendService(l.cloud, key, version)
+	backendService, err := composite.GetBackendServiceWithContext(ctx, l.cloud, key, version)
 	if err != nil {
 		return err
 	}
@@ -91,7 +104,7 @@ func (l *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 	if !oldBackends.Equal(newBackends) {
 		klog.V(2).Infof("Backends changed for service port %s, removing: %s and adding: %s", sp.ID, oldBackends.Difference(newBackends), newBackends.Difference(oldBackends))
 		backendService.Backends = targetBackends
-		return composite.UpdateBackendService(l.cloud, key, backendService)
+		return composite.UpdateBackendServiceWithContext(ctx, l.cloud, key, backendService)
 	}
 	return nil
 }
@@ -102,11 +115,14 @@ func getBackendsForNEGs(negs []*composite.NetworkEndpointGroup) []*composite.Bac
 		b := &composite.Backend{
 			Group: neg.SelfLink,
 		}
-		if neg.NetworkEndpointType == string(types.VmIpEndpointType) {
+		switch neg.NetworkEndpointType {
+		case annotations.ServerlessNEGType, annotations.InternetFQDNNEGType, annotations.InternetIPNEGType:
+			// Serverless and Internet NEGs do not support balancing modes.
+		case string(types.VmIpEndpointType):
 			// Setting MaxConnectionsPerEndpoint is not supported for L4 ILB - https://cloud.google.com/load-balancing/docs/backend-service#target_capacity
 			// hence only mode is being set.
 			b.BalancingMode = string(Connections)
-		} else {
+		default:
 			b.BalancingMode = string(Rate)
 			b.MaxRatePerEndpoint = maxRPS
 		}
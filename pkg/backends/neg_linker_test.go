@@ -14,6 +14,7 @@ limitations under the License.
 package backends
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -66,7 +67,7 @@ func TestLinkBackendServiceToNEG(t *testing.T) {
 			BackendNamer: defaultNamer},
 	} {
 		// Mimic how the syncer would create the backend.
-		if _, err := linker.backendPool.Create(svcPort, "fake-healthcheck-link"); err != nil {
+		if _, err := linker.backendPool.Create(context.Background(), svcPort, "fake-healthcheck-link"); err != nil {
 			t.Fatalf("Failed to create backend service to NEG for svcPort %v: %v", svcPort, err)
 		}
 
@@ -86,7 +87,7 @@ func TestLinkBackendServiceToNEG(t *testing.T) {
 			}
 		}
 
-		if err := linker.Link(svcPort, zones); err != nil {
+		if err := linker.Link(context.Background(), svcPort, zones); err != nil {
 			t.Fatalf("Failed to link backend service to NEG for svcPort %v: %v", svcPort, err)
 		}
 
@@ -119,3 +120,91 @@ func TestLinkBackendServiceToNEG(t *testing.T) {
 		}
 	}
 }
+
+func TestLinkBackendServiceToExternalNEG(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+	linker := newTestNEGLinker(fakeNEG, fakeGCE)
+
+	zones := []GroupKey{{Zone: "zone1"}, {Zone: "zone2"}}
+
+	for _, tc := range []struct {
+		desc        string
+		svcName     string
+		externalNEG *annotations.ExternalNEG
+		// newExternalNEG has another target than externalNEG.
+		newExternalNEG *annotations.ExternalNEG
+		wantGroup      string
+	}{
+		{
+			desc:           "serverless NEG",
+			svcName:        "serverless",
+			externalNEG:    &annotations.ExternalNEG{NetworkEndpointType: annotations.ServerlessNEGType, CloudRun: &annotations.ExternalNEGCloudRun{Service: "hello"}},
+			newExternalNEG: &annotations.ExternalNEG{NetworkEndpointType: annotations.ServerlessNEGType, CloudRun: &annotations.ExternalNEGCloudRun{Service: "world"}},
+			wantGroup:      "/regions/" + gce.DefaultTestClusterValues().Region + "/networkEndpointGroups/",
+		},
+		{
+			desc:           "Internet NEG",
+			svcName:        "internet",
+			externalNEG:    &annotations.ExternalNEG{NetworkEndpointType: annotations.InternetFQDNNEGType, FQDN: "example.com"},
+			newExternalNEG: &annotations.ExternalNEG{NetworkEndpointType: annotations.InternetIPNEGType, IPAddress: "1.2.3.4"},
+			wantGroup:      "/global/networkEndpointGroups/",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcPort := utils.ServicePort{
+				ID:           utils.ServicePortID{Service: types.NamespacedName{Namespace: "ns", Name: tc.svcName}},
+				Port:         443,
+				Protocol:     annotations.ProtocolHTTPS,
+				ExternalNEG:  tc.externalNEG,
+				BackendNamer: defaultNamer,
+			}
+			// Mimic how the syncer would create the backend.
+			if _, err := linker.backendPool.Create(context.Background(), svcPort, ""); err != nil {
+				t.Fatalf("Failed to create backend service for svcPort %v: %v", svcPort, err)
+			}
+			if err := linker.Link(context.Background(), svcPort, zones); err != nil {
+				t.Fatalf("Failed to link backend service to NEG for svcPort %v: %v", svcPort, err)
+			}
+
+			key, err := composite.CreateKey(fakeGCE, svcPort.BackendName(), befeatures.ScopeFromServicePort(&svcPort))
+			if err != nil {
+				t.Fatalf("Failed to create composite key - %v", err)
+			}
+			bs, err := composite.GetBackendService(fakeGCE, key, befeatures.VersionFromServicePort(&svcPort))
+			if err != nil {
+				t.Fatalf("Failed to retrieve backend service using key %+v: %v", key, err)
+			}
+			if len(bs.HealthChecks) != 0 {
+				t.Errorf("Got health checks %v, want none", bs.HealthChecks)
+			}
+			if len(bs.Backends) != 1 {
+				t.Fatalf("Got %d backends, want 1: %+v", len(bs.Backends), bs.Backends)
+			}
+			be := bs.Backends[0]
+			if !strings.HasSuffix(be.Group, tc.wantGroup+svcPort.NEGName()) {
+				t.Errorf("Got backend link %q, want suffix %q", be.Group, tc.wantGroup+svcPort.NEGName())
+			}
+			if be.BalancingMode != "" || be.MaxRatePerEndpoint != 0 {
+				t.Errorf("Got balancing mode %q with max rate %v, want none", be.BalancingMode, be.MaxRatePerEndpoint)
+			}
+
+			// Another target moves the backend service to the NEG of the
+			// target.
+			oldNEGName := svcPort.NEGName()
+			svcPort.ExternalNEG = tc.newExternalNEG
+			if svcPort.NEGName() == oldNEGName {
+				t.Fatalf("Got NEG name %q for another target, want another name", oldNEGName)
+			}
+			if err := linker.Link(context.Background(), svcPort, zones); err != nil {
+				t.Fatalf("Failed to link backend service to NEG for svcPort %v: %v", svcPort, err)
+			}
+			if bs, err = composite.GetBackendService(fakeGCE, key, befeatures.VersionFromServicePort(&svcPort)); err != nil {
+				t.Fatalf("Failed to retrieve backend service using key %+v: %v", key, err)
+			}
+			if len(bs.Backends) != 1 || !strings.HasSuffix(bs.Backends[0].Group, tc.wantGroup+svcPort.NEGName()) {
+				t.Errorf("Got backends %+v, want one with suffix %q", bs.Backends, tc.wantGroup+svcPort.NEGName())
+			}
+		})
+	}
+}
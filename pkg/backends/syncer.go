@@ -14,18 +14,24 @@ limitations under the License.
 package backends
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	lbfeatures "k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/networksecurity"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
 )
@@ -36,6 +42,15 @@ type backendSyncer struct {
 	healthChecker healthchecks.HealthChecker
 	prober        ProbeProvider
 	cloud         *gce.Cloud
+	// certificateManager and networkSecurity manage the resources of
+	// backend TLS, nil if backend TLS is not enabled.
+	certificateManager certificatemanager.Interface
+	networkSecurity    networksecurity.Interface
+
+	pendingLock sync.Mutex
+	// pendingTLSDeletions are the backend services whose backend TLS
+	// resources could not be deleted yet, e.g. while their operations run.
+	pendingTLSDeletions sets.String
 }
 
 // backendSyncer is a Syncer
@@ -44,12 +59,19 @@ var _ Syncer = (*backendSyncer)(nil)
 func NewBackendSyncer(
 	backendPool Pool,
 	healthChecker healthchecks.HealthChecker,
-	cloud *gce.Cloud) Syncer {
-	return &backendSyncer{
-		backendPool:   backendPool,
-		healthChecker: healthChecker,
-		cloud:         cloud,
+	cloud *gce.Cloud,
+	restConfig *gcprest.Config) Syncer {
+	s := &backendSyncer{
+		backendPool:         backendPool,
+		healthChecker:       healthChecker,
+		cloud:               cloud,
+		pendingTLSDeletions: sets.NewString(),
 	}
+	if flags.F.EnableBackendTLS && restConfig != nil {
+		s.certificateManager = certificatemanager.NewClient(restConfig, cloud.ProjectID())
+		s.networkSecurity = networksecurity.NewClient(restConfig, cloud.ProjectID())
+	}
+	return s
 }
 
 // Init implements Syncer.
@@ -58,10 +80,10 @@ func (s *backendSyncer) Init(pp ProbeProvider) {
 }
 
 // Sync implements Syncer.
-func (s *backendSyncer) Sync(svcPorts []utils.ServicePort) error {
+func (s *backendSyncer) Sync(ctx context.Context, svcPorts []utils.ServicePort) error {
 	for _, sp := range svcPorts {
 		klog.V(3).Infof("Sync: backend %+v", sp)
-		if err := s.ensureBackendService(sp); err != nil {
+		if err := s.ensureBackendService(ctx, sp); err != nil {
 			return err
 		}
 	}
@@ -69,15 +91,19 @@ func (s *backendSyncer) Sync(svcPorts []utils.ServicePort) error {
 }
 
 // ensureBackendService will update or create a BackendService for the given port.
-func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
+func (s *backendSyncer) ensureBackendService(ctx context.Context, sp utils.ServicePort) error {
 	// We must track the ports even if creating the backends failed, because
 	// we might've created health-check for them.
 	be := &composite.BackendService{}
 	beName := sp.BackendName()
 	version := features.VersionFromServicePort(&sp)
 	scope := features.ScopeFromServicePort(&sp)
+	// The health check has the name and scope of the backend service.
+	if key, err := composite.CreateKey(s.cloud, beName, scope); err == nil {
+		audit.SetOwner(audit.ControllerIngress, sp.ID.Service.String(), key)
+	}
 
-	be, getErr := s.backendPool.Get(beName, version, scope)
+	be, getErr := s.backendPool.Get(ctx, beName, version, scope)
 
 	// Ensure health check for backend service exists.
 	hcLink, err := s.ensureHealthCheck(sp)
@@ -93,12 +119,17 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 		}
 		// Only create the backend service if the error was 404.
 		klog.V(2).Infof("Creating backend service for port %v named %v", sp.NodePort, beName)
-		be, err = s.backendPool.Create(sp, hcLink)
+		be, err = s.backendPool.Create(ctx, sp, hcLink)
 		if err != nil {
 			return err
 		}
 	}
 
+	authConfigLink, err := features.EnsureBackendTLSResources(s.certificateManager, s.networkSecurity, sp, beName)
+	if err != nil {
+		return err
+	}
+
 	needUpdate := ensureProtocol(be, sp)
 	needUpdate = ensureHealthCheckLink(be, hcLink) || needUpdate
 	needUpdate = ensureDescription(be, &sp) || needUpdate
@@ -113,11 +144,16 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 	}
 
 	if needUpdate {
-		if err := s.backendPool.Update(be); err != nil {
+		if err := s.backendPool.Update(ctx, be); err != nil {
 			return err
 		}
 	}
 
+	// Backend TLS is also removed with the BackendConfig.
+	if err := s.ensureBackendTLS(ctx, sp, beName, scope, authConfigLink); err != nil {
+		return err
+	}
+
 	if sp.BackendConfig != nil {
 		if err := features.EnsureSecurityPolicy(s.cloud, sp, be, beName); err != nil {
 			return err
@@ -127,6 +163,52 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 	return nil
 }
 
+// ensureBackendTLS applies the backend TLS settings of the ServicePort to the
+// global backend service beName, and deletes the backend TLS resources it no
+// longer references. Backend TLS is only managed if it is enabled.
+func (s *backendSyncer) ensureBackendTLS(ctx context.Context, sp utils.ServicePort, beName string, scope meta.KeyType, authConfigLink string) error {
+	if s.certificateManager == nil || s.networkSecurity == nil || scope != meta.Global {
+		return nil
+	}
+	key, err := composite.CreateKey(s.cloud, beName, scope)
+	if err != nil {
+		return err
+	}
+	oldAuthConfigLink, err := features.EnsureBackendTLS(ctx, s.cloud, sp, key, authConfigLink)
+	if err != nil {
+		return err
+	}
+	if authConfigLink != "" {
+		return nil
+	}
+	if s.isTLSDeletionPending(beName) {
+		oldAuthConfigLink = s.networkSecurity.BackendAuthenticationConfigLink(beName)
+	}
+	return s.deleteBackendTLSResources(beName, oldAuthConfigLink)
+}
+
+// deleteBackendTLSResources deletes the backend TLS resources of the backend
+// service beName referenced by link. They are deleted again on the next sync
+// or GC if it fails, as the backend service no longer references them.
+func (s *backendSyncer) deleteBackendTLSResources(beName, link string) error {
+	s.pendingLock.Lock()
+	s.pendingTLSDeletions.Insert(beName)
+	s.pendingLock.Unlock()
+	if err := features.DeleteBackendTLSResources(s.certificateManager, s.networkSecurity, beName, link); err != nil {
+		return err
+	}
+	s.pendingLock.Lock()
+	s.pendingTLSDeletions.Delete(beName)
+	s.pendingLock.Unlock()
+	return nil
+}
+
+func (s *backendSyncer) isTLSDeletionPending(beName string) bool {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	return s.pendingTLSDeletions.Has(beName)
+}
+
 // GC implements Syncer.
 func (s *backendSyncer) GC(svcPorts []utils.ServicePort) error {
 	knownPorts, err := knownPortsFromServicePorts(s.cloud, svcPorts)
@@ -134,8 +216,8 @@ func (s *backendSyncer) GC(svcPorts []utils.ServicePort) error {
 		return err
 	}
 
-	// Only GC L7 ILB backends if it's enabled
-	if flags.F.EnableL7Ilb {
+	// Only GC regional backends if L7-ILB or regional XLB is enabled
+	if flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional {
 		// TODO(shance): Refactor out empty key field
 		key, err := composite.CreateKey(s.cloud, "", meta.Regional)
 		if err != nil {
@@ -165,6 +247,31 @@ func (s *backendSyncer) GC(svcPorts []utils.ServicePort) error {
 		return fmt.Errorf("error GCing Backends: %v", err)
 	}
 
+	return s.gcBackendTLSResources(knownPorts)
+}
+
+// gcBackendTLSResources retries the deletion of the backend TLS resources of
+// the deleted backend services.
+func (s *backendSyncer) gcBackendTLSResources(knownPorts sets.String) error {
+	if s.certificateManager == nil || s.networkSecurity == nil {
+		return nil
+	}
+	s.pendingLock.Lock()
+	pending := s.pendingTLSDeletions.List()
+	s.pendingLock.Unlock()
+	for _, name := range pending {
+		key, err := composite.CreateKey(s.cloud, name, meta.Global)
+		if err != nil {
+			return err
+		}
+		if knownPorts.Has(key.String()) {
+			// Retried by the sync of the backend service.
+			continue
+		}
+		if err := s.deleteBackendTLSResources(name, s.networkSecurity.BackendAuthenticationConfigLink(name)); err != nil {
+			return fmt.Errorf("error deleting backend TLS resources of %s: %v", name, err)
+		}
+	}
 	return nil
 }
 
@@ -189,8 +296,21 @@ func (s *backendSyncer) gc(backends []*composite.BackendService, knownPorts sets
 		if knownPorts.Has(key.String()) {
 			continue
 		}
+		// The backend TLS resources can only be deleted once the backend
+		// service no longer references them.
+		var authConfigLink string
+		if s.certificateManager != nil && s.networkSecurity != nil && scope == meta.Global {
+			settings, err := composite.GetTlsSettingsForBackendService(s.cloud, key)
+			if utils.IgnoreHTTPNotFound(err) != nil {
+				return err
+			}
+			if settings != nil {
+				authConfigLink = settings.AuthenticationConfig
+			}
+		}
+
 		klog.V(2).Infof("GCing backendService for port %s", name)
-		err = s.backendPool.Delete(name, be.Version, scope)
+		err = s.backendPool.Delete(context.Background(), name, be.Version, scope)
 		if err != nil {
 			klog.Errorf("backendPool.Delete(%v, %v, %v) = %v", name, be.Version, scope, err)
 			return err
@@ -199,6 +319,12 @@ func (s *backendSyncer) gc(backends []*composite.BackendService, knownPorts sets
 		if err := s.healthChecker.Delete(name, scope); err != nil {
 			return err
 		}
+
+		if authConfigLink != "" {
+			if err := s.deleteBackendTLSResources(name, authConfigLink); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -234,6 +360,11 @@ func (s *backendSyncer) Shutdown() error {
 }
 
 func (s *backendSyncer) ensureHealthCheck(sp utils.ServicePort) (string, error) {
+	// Backend services of serverless and Internet NEGs have no health check.
+	if sp.ExternalNEG != nil {
+		return "", nil
+	}
+
 	var probe *v1.Probe
 	var err error
 
@@ -265,6 +396,13 @@ func ensureProtocol(be *composite.BackendService, p utils.ServicePort) (needsUpd
 
 // ensureHealthCheckLink updates the BackendService HealthCheck with the expected value
 func ensureHealthCheckLink(be *composite.BackendService, hcLink string) (needsUpdate bool) {
+	if hcLink == "" {
+		if len(be.HealthChecks) == 0 {
+			return false
+		}
+		be.HealthChecks = nil
+		return true
+	}
 	existingHCLink := getHealthCheckLink(be)
 
 	if utils.EqualResourceIDs(existingHCLink, hcLink) {
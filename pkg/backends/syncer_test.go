@@ -32,10 +32,15 @@ import (
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
+	"k8s.io/ingress-gce/pkg/networksecurity"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -141,10 +146,14 @@ func newTestSyncer(fakeGCE *gce.Cloud) *backendSyncer {
 	fakeBackendPool := NewPool(fakeGCE, defaultNamer)
 
 	syncer := &backendSyncer{
-		backendPool:   fakeBackendPool,
-		healthChecker: fakeHealthChecks,
-		cloud:         fakeGCE,
+		backendPool:         fakeBackendPool,
+		healthChecker:       fakeHealthChecks,
+		cloud:               fakeGCE,
+		certificateManager:  certificatemanager.NewFakeClient(),
+		networkSecurity:     networksecurity.NewFakeClient(),
+		pendingTLSDeletions: sets.NewString(),
 	}
+	composite.RegisterFakeExtension(fakeGCE, fakeGCE.Compute().(*cloud.MockGCE))
 
 	probes := map[utils.ServicePort]*api_v1.Probe{{NodePort: 443, Protocol: annotations.ProtocolHTTPS, BackendNamer: defaultNamer}: existingProbe}
 	syncer.Init(NewFakeProbeProvider(probes))
@@ -174,13 +183,13 @@ func TestSync(t *testing.T) {
 
 	for _, sp := range testCases {
 		t.Run(fmt.Sprintf("Port: %v Protocol: %v", sp.NodePort, sp.Protocol), func(t *testing.T) {
-			if err := syncer.Sync([]utils.ServicePort{sp}); err != nil {
+			if err := syncer.Sync(context.Background(), []utils.ServicePort{sp}); err != nil {
 				t.Fatalf("Unexpected error when syncing backend with port %v: %v", sp.NodePort, err)
 			}
 			beName := sp.BackendName()
 
 			// Check that the new backend has the right port
-			be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&sp), features.ScopeFromServicePort(&sp))
+			be, err := syncer.backendPool.Get(context.Background(), beName, features.VersionFromServicePort(&sp), features.ScopeFromServicePort(&sp))
 			if err != nil {
 				t.Fatalf("Did not find expected backend with port %v", sp.NodePort)
 			}
@@ -209,10 +218,10 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 	syncer := newTestSyncer(fakeGCE)
 
 	p := utils.ServicePort{NodePort: 3000, Protocol: annotations.ProtocolHTTP, BackendNamer: defaultNamer}
-	syncer.Sync([]utils.ServicePort{p})
+	syncer.Sync(context.Background(), []utils.ServicePort{p})
 	beName := p.BackendName()
 
-	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
+	be, err := syncer.backendPool.Get(context.Background(), beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
 	if err != nil {
 		t.Fatalf("Unexpected err: %v", err)
 	}
@@ -229,9 +238,9 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 
 	// Update service port to encrypted
 	p.Protocol = annotations.ProtocolHTTPS
-	syncer.Sync([]utils.ServicePort{p})
+	syncer.Sync(context.Background(), []utils.ServicePort{p})
 
-	be, err = syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
+	be, err = syncer.backendPool.Get(context.Background(), beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
 	if err != nil {
 		t.Fatalf("Unexpected err retrieving backend service after update: %v", err)
 	}
@@ -253,10 +262,10 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 	syncer := newTestSyncer(fakeGCE)
 
 	p := utils.ServicePort{NodePort: 3000, Protocol: annotations.ProtocolHTTP, BackendNamer: defaultNamer}
-	syncer.Sync([]utils.ServicePort{p})
+	syncer.Sync(context.Background(), []utils.ServicePort{p})
 	beName := p.BackendName()
 
-	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
+	be, err := syncer.backendPool.Get(context.Background(), beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
 	if err != nil {
 		t.Fatalf("Unexpected err: %v", err)
 	}
@@ -273,9 +282,9 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 
 	// Update service port to HTTP2
 	p.Protocol = annotations.ProtocolHTTP2
-	syncer.Sync([]utils.ServicePort{p})
+	syncer.Sync(context.Background(), []utils.ServicePort{p})
 
-	beBeta, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
+	beBeta, err := syncer.backendPool.Get(context.Background(), beName, features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
 	if err != nil {
 		t.Fatalf("Unexpected err retrieving backend service after update: %v", err)
 	}
@@ -307,8 +316,8 @@ func TestGC(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := syncer.Sync(ps.existingPorts()); err != nil {
-		t.Fatalf("syncer.Sync(%+v) = %v, want nil ", ps.existingPorts(), err)
+	if err := syncer.Sync(context.Background(), ps.existingPorts()); err != nil {
+		t.Fatalf("syncer.Sync(context.Background(), %+v) = %v, want nil ", ps.existingPorts(), err)
 	}
 
 	if err := ps.check(fakeGCE); err != nil {
@@ -357,8 +366,8 @@ func TestGCMixed(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := syncer.Sync(ps.existingPorts()); err != nil {
-		t.Fatalf("syncer.Sync(%+v) = %v, want nil ", ps.existingPorts(), err)
+	if err := syncer.Sync(context.Background(), ps.existingPorts()); err != nil {
+		t.Fatalf("syncer.Sync(context.Background(), %+v) = %v, want nil ", ps.existingPorts(), err)
 	}
 
 	if err := ps.check(fakeGCE); err != nil {
@@ -485,19 +494,19 @@ func TestSyncQuota(t *testing.T) {
 				return false, nil
 			}
 
-			if err := syncer.Sync(tc.oldPorts); err != nil {
+			if err := syncer.Sync(context.Background(), tc.oldPorts); err != nil {
 				t.Errorf("Expected backend pool to add node ports, err: %v", err)
 			}
 
 			// Ensuring these ports again without first Garbage Collecting goes over
 			// the set quota. Expect an error here, until GC is called.
-			err := syncer.Sync(tc.newPorts)
+			err := syncer.Sync(context.Background(), tc.newPorts)
 			if tc.expectSyncErr && err == nil {
 				t.Errorf("Expect initial sync to go over quota, but received no error")
 			}
 
 			syncer.GC(tc.newPorts)
-			if err := syncer.Sync(tc.newPorts); err != nil {
+			if err := syncer.Sync(context.Background(), tc.newPorts); err != nil {
 				t.Errorf("Expected backend pool to add node ports, err: %v", err)
 			}
 
@@ -515,7 +524,7 @@ func TestSyncNEG(t *testing.T) {
 	syncer := newTestSyncer(fakeGCE)
 
 	svcPort := utils.ServicePort{NodePort: 81, Protocol: annotations.ProtocolHTTP, BackendNamer: defaultNamer}
-	if err := syncer.Sync([]utils.ServicePort{svcPort}); err != nil {
+	if err := syncer.Sync(context.Background(), []utils.ServicePort{svcPort}); err != nil {
 		t.Errorf("Expected backend pool to add node ports, err: %v", err)
 	}
 
@@ -527,7 +536,7 @@ func TestSyncNEG(t *testing.T) {
 
 	// Convert to NEG
 	svcPort.NEGEnabled = true
-	if err := syncer.Sync([]utils.ServicePort{svcPort}); err != nil {
+	if err := syncer.Sync(context.Background(), []utils.ServicePort{svcPort}); err != nil {
 		t.Errorf("Expected backend pool to add node ports, err: %v", err)
 	}
 
@@ -539,14 +548,14 @@ func TestSyncNEG(t *testing.T) {
 	// GC should garbage collect the Backend on the old naming schema
 	syncer.GC([]utils.ServicePort{svcPort})
 
-	bs, err := syncer.backendPool.Get(nodePortName, features.VersionFromServicePort(&svcPort), features.ScopeFromServicePort(&svcPort))
+	bs, err := syncer.backendPool.Get(context.Background(), nodePortName, features.VersionFromServicePort(&svcPort), features.ScopeFromServicePort(&svcPort))
 	if err == nil {
 		t.Fatalf("Expected not to get BackendService with name %v, got: %+v", nodePortName, bs)
 	}
 
 	// Convert back to non-NEG
 	svcPort.NEGEnabled = false
-	if err := syncer.Sync([]utils.ServicePort{svcPort}); err != nil {
+	if err := syncer.Sync(context.Background(), []utils.ServicePort{svcPort}); err != nil {
 		t.Errorf("Expected backend pool to add node ports, err: %v", err)
 	}
 
@@ -558,12 +567,186 @@ func TestSyncNEG(t *testing.T) {
 	}
 }
 
+func TestSyncLoadBalancingSchemeChange(t *testing.T) {
+	// Move a backend between L7-ILB and regional XLB.
+	// Expect a backend service with the new scheme, and the old one to be
+	// GCed once it is no longer used.
+	flags.F.EnableL7Ilb = true
+	flags.F.EnableL7XLBRegional = true
+	defer func() {
+		flags.F.EnableL7Ilb = false
+		flags.F.EnableL7XLBRegional = false
+	}()
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+
+	svcPort := utils.ServicePort{NodePort: 81, Protocol: annotations.ProtocolHTTP, NEGEnabled: true, L7ILBEnabled: true, BackendNamer: defaultNamer}
+	var oldName string
+	for _, tc := range []struct {
+		ilb, xlbRegional bool
+		wantScheme       string
+	}{
+		{ilb: true, wantScheme: "INTERNAL_MANAGED"},
+		{xlbRegional: true, wantScheme: "EXTERNAL_MANAGED"},
+		{ilb: true, wantScheme: "INTERNAL_MANAGED"},
+	} {
+		svcPort.L7ILBEnabled, svcPort.L7XLBRegionalEnabled = tc.ilb, tc.xlbRegional
+		version := features.VersionFromServicePort(&svcPort)
+		if err := syncer.Sync(context.Background(), []utils.ServicePort{svcPort}); err != nil {
+			t.Fatalf("syncer.Sync(context.Background(), %+v) = %v", svcPort, err)
+		}
+		name := svcPort.BackendName()
+		if name == oldName {
+			t.Fatalf("Backend service name %q did not change with load balancing scheme %q", name, tc.wantScheme)
+		}
+		bs, err := syncer.backendPool.Get(context.Background(), name, version, meta.Regional)
+		if err != nil {
+			t.Fatalf("Failed to get backend service: %v", err)
+		}
+		if bs.LoadBalancingScheme != tc.wantScheme {
+			t.Errorf("Backend service has load balancing scheme %q, want %q", bs.LoadBalancingScheme, tc.wantScheme)
+		}
+		if oldName != "" {
+			// The old backend service is kept until the URL map moves to the
+			// new one.
+			if _, err := syncer.backendPool.Get(context.Background(), oldName, version, meta.Regional); err != nil {
+				t.Errorf("Failed to get old backend service %q before GC: %v", oldName, err)
+			}
+		}
+		if err := syncer.GC([]utils.ServicePort{svcPort}); err != nil {
+			t.Fatalf("syncer.GC(%+v) = %v", svcPort, err)
+		}
+		if oldName != "" {
+			if _, err := syncer.backendPool.Get(context.Background(), oldName, version, meta.Regional); !utils.IsNotFoundError(err) {
+				t.Errorf("Old backend service %q exists after GC, err = %v", oldName, err)
+			}
+		}
+		oldName = name
+	}
+}
+
+func TestSyncBackendTLS(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableBackendTLS = enabled }(flags.F.EnableBackendTLS)
+	flags.F.EnableBackendTLS = true
+
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+	certificateManager := syncer.certificateManager.(*certificatemanager.FakeClient)
+	networkSecurity := syncer.networkSecurity.(*networksecurity.FakeClient)
+
+	tlsConfig := &backendconfigv1.BackendTLSConfig{
+		Sni:               "backend.example.com",
+		SubjectAltNames:   []string{"backend.example.com"},
+		CACertificates:    &backendconfigv1.BackendTLSSecretReference{SecretName: "ca"},
+		ClientCertificate: &backendconfigv1.BackendTLSSecretReference{SecretName: "client"},
+	}
+	certs := &utils.BackendTLSCertificates{CACertificates: "ca-cert", ClientCertificate: "client-cert", ClientPrivateKey: "client-key"}
+	svcPort := utils.ServicePort{
+		NodePort:               3000,
+		Protocol:               annotations.ProtocolHTTPS,
+		BackendNamer:           defaultNamer,
+		BackendConfig:          &backendconfigv1.BackendConfig{Spec: backendconfigv1.BackendConfigSpec{BackendTLS: tlsConfig}},
+		BackendTLSCertificates: certs,
+	}
+	beName := svcPort.BackendName()
+
+	sync := func() *composite.BackendServiceTlsSettings {
+		t.Helper()
+		if err := syncer.Sync(context.Background(), []utils.ServicePort{svcPort}); err != nil {
+			t.Fatalf("syncer.Sync(context.Background(), %+v) = %v", svcPort, err)
+		}
+		settings, err := composite.GetTlsSettingsForBackendService(fakeGCE, meta.GlobalKey(beName))
+		if err != nil {
+			t.Fatalf("Failed to get TLS settings of backend service: %v", err)
+		}
+		return settings
+	}
+	verifyResources := func(wantAuthConfig, wantTrustConfig, wantCertificate bool) {
+		t.Helper()
+		if _, err := networkSecurity.GetBackendAuthenticationConfig(beName); (err == nil) != wantAuthConfig {
+			t.Errorf("Backend authentication config exists = %v, want %v", err == nil, wantAuthConfig)
+		}
+		if _, err := certificateManager.GetTrustConfig(beName); (err == nil) != wantTrustConfig {
+			t.Errorf("Trust config exists = %v, want %v", err == nil, wantTrustConfig)
+		}
+		if _, err := certificateManager.GetCertificate(beName); (err == nil) != wantCertificate {
+			t.Errorf("Certificate exists = %v, want %v", err == nil, wantCertificate)
+		}
+	}
+
+	// TLS settings are patched with the GA API, which external load
+	// balancers support.
+	if v := features.VersionFromServicePort(&svcPort); v != meta.VersionGA {
+		t.Errorf("VersionFromServicePort() = %v, want %v", v, meta.VersionGA)
+	}
+	settings := sync()
+	wantSettings := &composite.BackendServiceTlsSettings{
+		Sni:                  tlsConfig.Sni,
+		SubjectAltNames:      []*composite.BackendServiceTlsSettingsSubjectAltName{{DnsName: "backend.example.com"}},
+		AuthenticationConfig: networkSecurity.BackendAuthenticationConfigLink(beName),
+	}
+	if !reflect.DeepEqual(settings, wantSettings) {
+		t.Errorf("Backend service has TLS settings %+v, want %+v", settings, wantSettings)
+	}
+	verifyResources(true, true, true)
+
+	// Dropping the client certificate keeps TLS without mTLS.
+	tlsConfig.ClientCertificate = nil
+	certs.ClientCertificate, certs.ClientPrivateKey = "", ""
+	if settings = sync(); !reflect.DeepEqual(settings, wantSettings) {
+		t.Errorf("Backend service has TLS settings %+v, want %+v", settings, wantSettings)
+	}
+	verifyResources(true, true, false)
+
+	// Removing the BackendConfig removes backend TLS.
+	svcPort.BackendConfig = nil
+	svcPort.BackendTLSCertificates = nil
+	if settings = sync(); settings != nil {
+		t.Errorf("Backend service has TLS settings %+v, want none", settings)
+	}
+	verifyResources(false, false, false)
+
+	// Garbage collecting the backend service deletes its resources, also if
+	// the first deletion fails.
+	svcPort.BackendConfig = &backendconfigv1.BackendConfig{Spec: backendconfigv1.BackendConfigSpec{BackendTLS: tlsConfig}}
+	svcPort.BackendTLSCertificates = certs
+	sync()
+	verifyResources(true, true, false)
+	syncer.networkSecurity = &failingNetworkSecurity{FakeClient: networkSecurity, deleteFailures: 1}
+	if err := syncer.GC(nil); err == nil {
+		t.Fatalf("syncer.GC() = nil, want the deletion error")
+	}
+	if _, err := fakeGCE.GetGlobalBackendService(beName); err == nil {
+		t.Errorf("Backend service %s exists after GC", beName)
+	}
+	verifyResources(true, true, false)
+	if err := syncer.GC(nil); err != nil {
+		t.Fatalf("syncer.GC() = %v", err)
+	}
+	verifyResources(false, false, false)
+}
+
+// failingNetworkSecurity fails the first deleteFailures deletions of backend
+// authentication configs.
+type failingNetworkSecurity struct {
+	*networksecurity.FakeClient
+	deleteFailures int
+}
+
+func (f *failingNetworkSecurity) DeleteBackendAuthenticationConfig(name string) error {
+	if f.deleteFailures > 0 {
+		f.deleteFailures--
+		return &googleapi.Error{Code: http.StatusInternalServerError, Message: "deletion failed"}
+	}
+	return f.FakeClient.DeleteBackendAuthenticationConfig(name)
+}
+
 func TestShutdown(t *testing.T) {
 	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
 	syncer := newTestSyncer(fakeGCE)
 
 	// Sync a backend and verify that it doesn't exist after Shutdown()
-	syncer.Sync([]utils.ServicePort{{NodePort: 80, BackendNamer: defaultNamer}})
+	syncer.Sync(context.Background(), []utils.ServicePort{{NodePort: 80, BackendNamer: defaultNamer}})
 	syncer.Shutdown()
 	if _, err := fakeGCE.GetGlobalBackendService(defaultNamer.IGBackend(80)); err == nil {
 		t.Fatalf("%v", err)
@@ -585,8 +768,8 @@ func TestEnsureBackendServiceProtocol(t *testing.T) {
 			t.Run(
 				fmt.Sprintf("Updating Port:%v Protocol:%v to Port:%v Protocol:%v", oldPort.NodePort, oldPort.Protocol, newPort.NodePort, newPort.Protocol),
 				func(t *testing.T) {
-					syncer.Sync([]utils.ServicePort{oldPort})
-					be, err := syncer.backendPool.Get(oldPort.BackendName(), features.VersionFromServicePort(&oldPort), features.ScopeFromServicePort(&oldPort))
+					syncer.Sync(context.Background(), []utils.ServicePort{oldPort})
+					be, err := syncer.backendPool.Get(context.Background(), oldPort.BackendName(), features.VersionFromServicePort(&oldPort), features.ScopeFromServicePort(&oldPort))
 					if err != nil {
 						t.Fatalf("%v", err)
 					}
@@ -629,8 +812,8 @@ func TestEnsureBackendServiceDescription(t *testing.T) {
 			t.Run(
 				fmt.Sprintf("Updating Port:%v Protocol:%v to Port:%v Protocol:%v", oldPort.NodePort, oldPort.Protocol, newPort.NodePort, newPort.Protocol),
 				func(t *testing.T) {
-					syncer.Sync([]utils.ServicePort{oldPort})
-					be, err := syncer.backendPool.Get(oldPort.BackendName(), features.VersionFromServicePort(&oldPort), features.ScopeFromServicePort(&oldPort))
+					syncer.Sync(context.Background(), []utils.ServicePort{oldPort})
+					be, err := syncer.backendPool.Get(context.Background(), oldPort.BackendName(), features.VersionFromServicePort(&oldPort), features.ScopeFromServicePort(&oldPort))
 					if err != nil {
 						t.Fatalf("%v", err)
 					}
@@ -657,8 +840,8 @@ func TestEnsureBackendServiceHealthCheckLink(t *testing.T) {
 	syncer := newTestSyncer(fakeGCE)
 
 	p := utils.ServicePort{NodePort: 80, Protocol: annotations.ProtocolHTTP, ID: utils.ServicePortID{Port: intstr.FromInt(1)}, BackendNamer: defaultNamer}
-	syncer.Sync([]utils.ServicePort{p})
-	be, err := syncer.backendPool.Get(p.BackendName(), features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
+	syncer.Sync(context.Background(), []utils.ServicePort{p})
+	be, err := syncer.backendPool.Get(context.Background(), p.BackendName(), features.VersionFromServicePort(&p), features.ScopeFromServicePort(&p))
 	if err != nil {
 		t.Fatalf("%v", err)
 	}
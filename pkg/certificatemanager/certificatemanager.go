@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certificatemanager manages the global resources of the Certificate
+// Manager API which the load balancers of the controller use.
+package certificatemanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
+	"k8s.io/klog"
+)
+
+const basePath = "https://certificatemanager.googleapis.com/v1/"
+
+// TrustConfig is a set of CA certificates which client certificates are
+// validated against.
+type TrustConfig struct {
+	// Name is the relative resource name of the trust config, e.g.
+	// projects/p/locations/global/trustConfigs/name. It is ignored on
+	// create and update.
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	TrustStores []TrustStore `json:"trustStores,omitempty"`
+}
+
+// TrustStore holds the trust anchors and intermediate CAs of a trust config.
+type TrustStore struct {
+	TrustAnchors    []TrustedCertificate `json:"trustAnchors,omitempty"`
+	IntermediateCas []TrustedCertificate `json:"intermediateCas,omitempty"`
+}
+
+// TrustedCertificate is a PEM encoded certificate of a trust store.
+type TrustedCertificate struct {
+	PemCertificate string `json:"pemCertificate,omitempty"`
+}
+
+// Certificate is a certificate which load balancers present to their peers.
+type Certificate struct {
+	// Name is the relative resource name of the certificate, e.g.
+	// projects/p/locations/global/certificates/name. It is ignored on
+	// create and update.
+	Name        string                  `json:"name,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	SelfManaged *SelfManagedCertificate `json:"selfManaged,omitempty"`
+}
+
+// SelfManagedCertificate is a certificate and its private key provided by the
+// user. The private key is never returned by the API.
+type SelfManagedCertificate struct {
+	PemCertificate string `json:"pemCertificate,omitempty"`
+	PemPrivateKey  string `json:"pemPrivateKey,omitempty"`
+}
+
+// Interface manages Certificate Manager resources. Resources are identified
+// by their name, the project and location of the client are implied.
+type Interface interface {
+	// TrustConfigLink returns the relative resource name of the trust config.
+	TrustConfigLink(name string) string
+	// GetTrustConfig returns the trust config. A not found error is returned
+	// if it does not exist.
+	GetTrustConfig(name string) (*TrustConfig, error)
+	// CreateTrustConfig creates the trust config.
+	CreateTrustConfig(name string, tc *TrustConfig) error
+	// UpdateTrustConfig replaces the description and trust stores of the
+	// trust config.
+	UpdateTrustConfig(name string, tc *TrustConfig) error
+	// DeleteTrustConfig deletes the trust config.
+	DeleteTrustConfig(name string) error
+
+	// CertificateLink returns the relative resource name of the certificate.
+	CertificateLink(name string) string
+	// GetCertificate returns the certificate, without its private key. A not
+	// found error is returned if it does not exist.
+	GetCertificate(name string) (*Certificate, error)
+	// CreateCertificate creates the certificate.
+	CreateCertificate(name string, cert *Certificate) error
+	// UpdateCertificate replaces the description and the self managed
+	// certificate of the certificate.
+	UpdateCertificate(name string, cert *Certificate) error
+	// DeleteCertificate deletes the certificate.
+	DeleteCertificate(name string) error
+}
+
+// Client implements Interface with the Certificate Manager API.
+type Client struct {
+	rest    *gcprest.Client
+	project string
+}
+
+// NewClient returns a Client for the global resources of project. Changes
+// return a *gcprest.OperationInProgressError until their operation is done.
+func NewClient(config *gcprest.Config, project string) *Client {
+	return &Client{rest: gcprest.NewClient(config, "CertificateManager", basePath), project: project}
+}
+
+// TrustConfigLink implements Interface.
+func (c *Client) TrustConfigLink(name string) string {
+	return fmt.Sprintf("projects/%s/locations/global/trustConfigs/%s", c.project, name)
+}
+
+// GetTrustConfig implements Interface.
+func (c *Client) GetTrustConfig(name string) (*TrustConfig, error) {
+	ret := &TrustConfig{}
+	if err := c.rest.Get(context.Background(), c.TrustConfigLink(name), ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CreateTrustConfig implements Interface.
+func (c *Client) CreateTrustConfig(name string, tc *TrustConfig) error {
+	klog.V(3).Infof("Creating trust config %s", name)
+	path := fmt.Sprintf("projects/%s/locations/global/trustConfigs?trustConfigId=%s", c.project, name)
+	return c.rest.Start(context.Background(), http.MethodPost, path, c.TrustConfigLink(name), withoutName(tc))
+}
+
+// UpdateTrustConfig implements Interface.
+func (c *Client) UpdateTrustConfig(name string, tc *TrustConfig) error {
+	klog.V(3).Infof("Updating trust config %s", name)
+	path := c.TrustConfigLink(name) + "?updateMask=description,trustStores"
+	return c.rest.Start(context.Background(), http.MethodPatch, path, c.TrustConfigLink(name), withoutName(tc))
+}
+
+// DeleteTrustConfig implements Interface.
+func (c *Client) DeleteTrustConfig(name string) error {
+	klog.V(3).Infof("Deleting trust config %s", name)
+	return c.rest.Start(context.Background(), http.MethodDelete, c.TrustConfigLink(name), c.TrustConfigLink(name), nil)
+}
+
+// CertificateLink implements Interface.
+func (c *Client) CertificateLink(name string) string {
+	return fmt.Sprintf("projects/%s/locations/global/certificates/%s", c.project, name)
+}
+
+// GetCertificate implements Interface.
+func (c *Client) GetCertificate(name string) (*Certificate, error) {
+	ret := &Certificate{}
+	if err := c.rest.Get(context.Background(), c.CertificateLink(name), ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CreateCertificate implements Interface.
+func (c *Client) CreateCertificate(name string, cert *Certificate) error {
+	klog.V(3).Infof("Creating certificate %s", name)
+	path := fmt.Sprintf("projects/%s/locations/global/certificates?certificateId=%s", c.project, name)
+	body := *cert
+	body.Name = ""
+	return c.rest.Start(context.Background(), http.MethodPost, path, c.CertificateLink(name), &body)
+}
+
+// UpdateCertificate implements Interface.
+func (c *Client) UpdateCertificate(name string, cert *Certificate) error {
+	klog.V(3).Infof("Updating certificate %s", name)
+	path := c.CertificateLink(name) + "?updateMask=description,selfManaged"
+	body := *cert
+	body.Name = ""
+	return c.rest.Start(context.Background(), http.MethodPatch, path, c.CertificateLink(name), &body)
+}
+
+// DeleteCertificate implements Interface.
+func (c *Client) DeleteCertificate(name string) error {
+	klog.V(3).Infof("Deleting certificate %s", name)
+	return c.rest.Start(context.Background(), http.MethodDelete, c.CertificateLink(name), c.CertificateLink(name), nil)
+}
+
+func withoutName(tc *TrustConfig) *TrustConfig {
+	ret := *tc
+	ret.Name = ""
+	return &ret
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatemanager
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+)
+
+// FakeClient is an in memory implementation of Interface for tests.
+type FakeClient struct {
+	mu           sync.Mutex
+	trustConfigs map[string]*TrustConfig
+	certificates map[string]*Certificate
+}
+
+// NewFakeClient returns an empty FakeClient.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		trustConfigs: map[string]*TrustConfig{},
+		certificates: map[string]*Certificate{},
+	}
+}
+
+// TrustConfigLink implements Interface.
+func (f *FakeClient) TrustConfigLink(name string) string {
+	return fmt.Sprintf("projects/test-project/locations/global/trustConfigs/%s", name)
+}
+
+// GetTrustConfig implements Interface.
+func (f *FakeClient) GetTrustConfig(name string) (*TrustConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tc, ok := f.trustConfigs[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	ret := *tc
+	return &ret, nil
+}
+
+// CreateTrustConfig implements Interface.
+func (f *FakeClient) CreateTrustConfig(name string, tc *TrustConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.trustConfigs[name]; ok {
+		return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("trust config %s already exists", name)}
+	}
+	f.set(name, tc)
+	return nil
+}
+
+// UpdateTrustConfig implements Interface.
+func (f *FakeClient) UpdateTrustConfig(name string, tc *TrustConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.trustConfigs[name]; !ok {
+		return notFound(name)
+	}
+	f.set(name, tc)
+	return nil
+}
+
+// DeleteTrustConfig implements Interface.
+func (f *FakeClient) DeleteTrustConfig(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.trustConfigs[name]; !ok {
+		return notFound(name)
+	}
+	delete(f.trustConfigs, name)
+	return nil
+}
+
+func (f *FakeClient) set(name string, tc *TrustConfig) {
+	stored := *tc
+	stored.Name = f.TrustConfigLink(name)
+	f.trustConfigs[name] = &stored
+}
+
+// CertificateLink implements Interface.
+func (f *FakeClient) CertificateLink(name string) string {
+	return fmt.Sprintf("projects/test-project/locations/global/certificates/%s", name)
+}
+
+// GetCertificate implements Interface.
+func (f *FakeClient) GetCertificate(name string) (*Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cert, ok := f.certificates[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	ret := *cert
+	if cert.SelfManaged != nil {
+		// Like the API, do not return the private key.
+		ret.SelfManaged = &SelfManagedCertificate{PemCertificate: cert.SelfManaged.PemCertificate}
+	}
+	return &ret, nil
+}
+
+// CreateCertificate implements Interface.
+func (f *FakeClient) CreateCertificate(name string, cert *Certificate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.certificates[name]; ok {
+		return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("certificate %s already exists", name)}
+	}
+	f.setCertificate(name, cert)
+	return nil
+}
+
+// UpdateCertificate implements Interface.
+func (f *FakeClient) UpdateCertificate(name string, cert *Certificate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.certificates[name]; !ok {
+		return notFound(name)
+	}
+	f.setCertificate(name, cert)
+	return nil
+}
+
+// DeleteCertificate implements Interface.
+func (f *FakeClient) DeleteCertificate(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.certificates[name]; !ok {
+		return notFound(name)
+	}
+	delete(f.certificates, name)
+	return nil
+}
+
+func (f *FakeClient) setCertificate(name string, cert *Certificate) {
+	stored := *cert
+	if cert.SelfManaged != nil {
+		selfManaged := *cert.SelfManaged
+		stored.SelfManaged = &selfManaged
+	}
+	stored.Name = f.CertificateLink(name)
+	f.certificates[name] = &stored
+}
+
+func notFound(name string) error {
+	return &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("%s was not found", name)}
+}
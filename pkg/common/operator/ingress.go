@@ -8,6 +8,7 @@ import (
 
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
+	"k8s.io/ingress-gce/pkg/utils"
 )
 
 // Ingresses returns the wrapper
@@ -68,6 +69,22 @@ func (op *IngressesOperator) ReferencesService(svc *api_v1.Service) *IngressesOp
 	return Ingresses(i)
 }
 
+// ReferencesServiceInNamespace returns the Ingresses that reference a Service in
+// the given namespace from another namespace.
+func (op *IngressesOperator) ReferencesServiceInNamespace(namespace string) *IngressesOperator {
+	dupes := map[string]bool{}
+
+	var i []*v1beta1.Ingress
+	for _, ing := range op.i {
+		key := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
+		if ing.Namespace != namespace && doesIngressReferenceNamespace(ing, namespace) && !dupes[key] {
+			i = append(i, ing)
+			dupes[key] = true
+		}
+	}
+	return Ingresses(i)
+}
+
 // ReferencesBackendConfig returns the Ingresses that references the given BackendConfig.
 func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.BackendConfig, svcsOp *ServicesOperator) *IngressesOperator {
 	dupes := map[string]bool{}
@@ -100,3 +117,17 @@ func (op *IngressesOperator) ReferencesFrontendConfig(feConfig *frontendconfigv1
 	}
 	return Ingresses(i)
 }
+
+// doesIngressReferenceNamespace returns true if the passed in Ingress references
+// a Service in the given namespace.
+func doesIngressReferenceNamespace(ing *v1beta1.Ingress, namespace string) bool {
+	doesReference := false
+	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+		if id.Service.Namespace == namespace {
+			doesReference = true
+			return true
+		}
+		return false
+	})
+	return doesReference
+}
@@ -61,13 +61,9 @@ func (op *ServicesOperator) ReferencedByIngress(ing *v1beta1.Ingress) *ServicesO
 // doesIngressReferenceService returns true if the passed in Ingress directly references
 // the passed in Service.
 func doesIngressReferenceService(ing *v1beta1.Ingress, svc *api_v1.Service) bool {
-	if ing.Namespace != svc.Namespace {
-		return false
-	}
-
 	doesReference := false
 	utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
-		if id.Service.Name == svc.Name {
+		if id.Service.Name == svc.Name && id.Service.Namespace == svc.Namespace {
 			doesReference = true
 			return true
 		}
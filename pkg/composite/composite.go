@@ -18,25 +18,36 @@ limitations under the License.
 package composite
 
 import (
+	"context"
 	"fmt"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	computealpha "google.golang.org/api/compute/v0.alpha"
 	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/composite/metrics"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
 )
 
 // SetUrlMapForTargetHttpsProxy() sets the UrlMap for a target https proxy
 func SetUrlMapForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, urlMapLink string) error {
-	ctx, cancel := cloud.ContextWithCallTimeout()
+	return SetUrlMapForTargetHttpsProxyWithContext(context.Background(), gceCloud, key, targetHttpsProxy, urlMapLink)
+}
+
+// SetUrlMapForTargetHttpsProxyWithContext is like SetUrlMapForTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func SetUrlMapForTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, urlMapLink string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
 	mc := metrics.NewMetricContext("TargetHttpsProxy", "set_url_map", key.Region, key.Zone, string(targetHttpsProxy.Version))
 
 	// Set name in case it is not present in the key
 	key.Name = targetHttpsProxy.Name
+	ac := audit.NewContext("TargetHttpsProxies", "setUrlMap", key, targetHttpsProxy.Version).WithFields(map[string]interface{}{"urlMap": urlMapLink})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "setUrlMap", key, targetHttpsProxy.Version)
+	defer span.End()
 	klog.V(3).Infof("setting URLMap for TargetHttpsProxy %v", key)
 
 	switch targetHttpsProxy.Version {
@@ -44,37 +55,45 @@ func SetUrlMapForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttp
 		ref := &computealpha.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	case meta.VersionBeta:
 		ref := &computebeta.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	default:
 		ref := &compute.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	}
 }
 
 // SetSslCertificateForTargetHttpsProxy() sets the SSL Certificate for a target https proxy
 func SetSslCertificateForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, sslCertURLs []string) error {
-	ctx, cancel := cloud.ContextWithCallTimeout()
+	return SetSslCertificateForTargetHttpsProxyWithContext(context.Background(), gceCloud, key, targetHttpsProxy, sslCertURLs)
+}
+
+// SetSslCertificateForTargetHttpsProxyWithContext is like SetSslCertificateForTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func SetSslCertificateForTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, sslCertURLs []string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
 	mc := metrics.NewMetricContext("TargetHttpsProxy", "set_ssl_certificate", key.Region, key.Zone, string(targetHttpsProxy.Version))
 
 	// Set name in case it is not present in the key
 	key.Name = targetHttpsProxy.Name
+	ac := audit.NewContext("TargetHttpsProxies", "setSslCertificates", key, targetHttpsProxy.Version).WithFields(map[string]interface{}{"sslCertificates": sslCertURLs})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "setSslCertificates", key, targetHttpsProxy.Version)
+	defer span.End()
 	klog.V(3).Infof("setting SslCertificate for TargetHttpsProxy %v", key)
 
 	switch targetHttpsProxy.Version {
@@ -82,40 +101,48 @@ func SetSslCertificateForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, ta
 		switch key.Type() {
 		case meta.Regional:
 			req := &computealpha.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		default:
 			req := &computealpha.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			req := &computebeta.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		default:
 			req := &computebeta.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			req := &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		default:
 			req := &compute.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertURLs}
-			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetSslCertificates(ctx, key, req))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetSslCertificates(callCtx, key, req))))
 		}
 	}
 }
 
 // SetSslPolicyForTargetHttpsProxy() sets the url map for a target proxy
 func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, SslPolicyLink string) error {
-	ctx, cancel := cloud.ContextWithCallTimeout()
+	return SetSslPolicyForTargetHttpsProxyWithContext(context.Background(), gceCloud, key, targetHttpsProxy, SslPolicyLink)
+}
+
+// SetSslPolicyForTargetHttpsProxyWithContext is like SetSslPolicyForTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func SetSslPolicyForTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy, SslPolicyLink string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
 	mc := metrics.NewMetricContext("TargetHttpProxy", "set_url_map", key.Region, key.Zone, string(targetHttpsProxy.Version))
 
 	// Set name in case it is not present in the key
 	key.Name = targetHttpsProxy.Name
+	ac := audit.NewContext("TargetHttpsProxies", "setSslPolicy", key, targetHttpsProxy.Version).WithFields(map[string]interface{}{"sslPolicy": SslPolicyLink})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "setSslPolicy", key, targetHttpsProxy.Version)
+	defer span.End()
 	klog.V(3).Infof("Setting SslPolicy for TargetHttpProxy %v", key)
 
 	switch targetHttpsProxy.Version {
@@ -125,7 +152,7 @@ func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetH
 		case meta.Regional:
 			return fmt.Errorf("SetSslPolicy() is not supported for regional Target Https Proxies")
 		default:
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetSslPolicy(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().SetSslPolicy(callCtx, key, ref))))
 		}
 	case meta.VersionBeta:
 		ref := &computebeta.SslPolicyReference{SslPolicy: SslPolicyLink}
@@ -133,7 +160,7 @@ func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetH
 		case meta.Regional:
 			return fmt.Errorf("SetSslPolicy() is not supported for regional Target Https Proxies")
 		default:
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetSslPolicy(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().SetSslPolicy(callCtx, key, ref))))
 		}
 	default:
 		ref := &compute.SslPolicyReference{SslPolicy: SslPolicyLink}
@@ -141,19 +168,125 @@ func SetSslPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetH
 		case meta.Regional:
 			return fmt.Errorf("SetSslPolicy() is not supported for regional Target Https Proxies")
 		default:
-			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetSslPolicy(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpsProxies().SetSslPolicy(callCtx, key, ref))))
 		}
 	}
 }
 
+// SetServerTlsPolicyForTargetHttpsProxy() sets the server TLS policy of a
+// target https proxy in place. An empty link removes the policy.
+func SetServerTlsPolicyForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, serverTlsPolicyLink string) error {
+	return SetServerTlsPolicyForTargetHttpsProxyWithContext(context.Background(), gceCloud, key, serverTlsPolicyLink)
+}
+
+// SetServerTlsPolicyForTargetHttpsProxyWithContext is like SetServerTlsPolicyForTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func SetServerTlsPolicyForTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, serverTlsPolicyLink string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("TargetHttpsProxy", "patch", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("TargetHttpsProxies", "patch", key, meta.VersionGA).WithFields(map[string]interface{}{"serverTlsPolicy": serverTlsPolicyLink})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "patch", key, meta.VersionGA)
+	defer span.End()
+	klog.V(3).Infof("Setting server TLS policy %q for TargetHttpsProxy %v", serverTlsPolicyLink, key)
+
+	var value interface{}
+	if serverTlsPolicyLink != "" {
+		value = serverTlsPolicyLink
+	}
+	err := extensionFor(gceCloud).PatchTargetHttpsProxy(callCtx, key, meta.VersionGA, map[string]interface{}{"serverTlsPolicy": value})
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// SetCertificateMapForTargetHttpsProxy() sets the certificate map and the SSL
+// certificates of a target https proxy in place. An empty link removes the
+// map, which the SSL certificates then replace. The certificate map is only in
+// the alpha API, so the proxy is patched with it.
+func SetCertificateMapForTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, certificateMapLink string, sslCertURLs []string) error {
+	return SetCertificateMapForTargetHttpsProxyWithContext(context.Background(), gceCloud, key, certificateMapLink, sslCertURLs)
+}
+
+// SetCertificateMapForTargetHttpsProxyWithContext is like SetCertificateMapForTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func SetCertificateMapForTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, certificateMapLink string, sslCertURLs []string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("TargetHttpsProxy", "patch", key.Region, key.Zone, string(meta.VersionAlpha))
+	ac := audit.NewContext("TargetHttpsProxies", "patch", key, meta.VersionAlpha).WithFields(map[string]interface{}{"certificateMap": certificateMapLink, "sslCertificates": sslCertURLs})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "patch", key, meta.VersionAlpha)
+	defer span.End()
+	klog.V(3).Infof("Setting certificate map %q and SSL certificates %v for TargetHttpsProxy %v", certificateMapLink, sslCertURLs, key)
+
+	// Both fields are patched at once since the proxy must always have
+	// either of them.
+	fields := map[string]interface{}{"certificateMap": nil, "sslCertificates": nil}
+	if certificateMapLink != "" {
+		fields["certificateMap"] = certificateMapLink
+	}
+	if len(sslCertURLs) > 0 {
+		fields["sslCertificates"] = sslCertURLs
+	}
+	err := extensionFor(gceCloud).PatchTargetHttpsProxy(callCtx, key, meta.VersionAlpha, fields)
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// GetTlsSettingsForBackendService() returns the TLS settings of a backend
+// service, nil if it has none.
+func GetTlsSettingsForBackendService(gceCloud *gce.Cloud, key *meta.Key) (*BackendServiceTlsSettings, error) {
+	return GetTlsSettingsForBackendServiceWithContext(context.Background(), gceCloud, key)
+}
+
+// GetTlsSettingsForBackendServiceWithContext is like GetTlsSettingsForBackendService, with the span of the call a child of the span in ctx.
+func GetTlsSettingsForBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) (*BackendServiceTlsSettings, error) {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendService", "get", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "get", key, meta.VersionGA)
+	defer span.End()
+	klog.V(3).Infof("Getting TLS settings of BackendService %v", key)
+
+	settings, err := extensionFor(gceCloud).GetBackendServiceTlsSettings(callCtx, key)
+	return settings, tracing.ObserveSpan(span, mc.Observe(err))
+}
+
+// SetTlsSettingsForBackendService() sets the TLS settings of a backend
+// service in place. nil settings remove them.
+func SetTlsSettingsForBackendService(gceCloud *gce.Cloud, key *meta.Key, settings *BackendServiceTlsSettings) error {
+	return SetTlsSettingsForBackendServiceWithContext(context.Background(), gceCloud, key, settings)
+}
+
+// SetTlsSettingsForBackendServiceWithContext is like SetTlsSettingsForBackendService, with the span of the call a child of the span in ctx.
+func SetTlsSettingsForBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, settings *BackendServiceTlsSettings) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("BackendService", "patch", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("BackendServices", "patch", key, meta.VersionGA).WithFields(map[string]interface{}{"tlsSettings": settings})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "patch", key, meta.VersionGA)
+	defer span.End()
+	klog.V(3).Infof("Setting TLS settings %+v for BackendService %v", settings, key)
+
+	var value interface{}
+	if settings != nil {
+		value = settings
+	}
+	err := extensionFor(gceCloud).PatchBackendService(callCtx, key, map[string]interface{}{"tlsSettings": value})
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
 // SetUrlMapForTargetHttpProxy() sets the url map for a target proxy
 func SetUrlMapForTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *TargetHttpProxy, urlMapLink string) error {
-	ctx, cancel := cloud.ContextWithCallTimeout()
+	return SetUrlMapForTargetHttpProxyWithContext(context.Background(), gceCloud, key, targetHttpProxy, urlMapLink)
+}
+
+// SetUrlMapForTargetHttpProxyWithContext is like SetUrlMapForTargetHttpProxy, with the span of the call a child of the span in ctx.
+func SetUrlMapForTargetHttpProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *TargetHttpProxy, urlMapLink string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
 	mc := metrics.NewMetricContext("TargetHttpProxy", "set_url_map", key.Region, key.Zone, string(targetHttpProxy.Version))
 
 	// Set name in case it is not present in the key
 	key.Name = targetHttpProxy.Name
+	ac := audit.NewContext("TargetHttpProxies", "setUrlMap", key, targetHttpProxy.Version).WithFields(map[string]interface{}{"urlMap": urlMapLink})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpProxies", "setUrlMap", key, targetHttpProxy.Version)
+	defer span.End()
 	klog.V(3).Infof("setting URLMap for TargetHttpProxy %v", key)
 
 	switch targetHttpProxy.Version {
@@ -161,37 +294,45 @@ func SetUrlMapForTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpP
 		ref := &computealpha.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	case meta.VersionBeta:
 		ref := &computebeta.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	default:
 		ref := &compute.UrlMapReference{UrlMap: urlMapLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		default:
-			return mc.Observe(gceCloud.Compute().TargetHttpProxies().SetUrlMap(ctx, key, ref))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpProxies().SetUrlMap(callCtx, key, ref))))
 		}
 	}
 }
 
 // SetProxyForForwardingRule() sets the target proxy for a forwarding rule
 func SetProxyForForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRule *ForwardingRule, targetProxyLink string) error {
-	ctx, cancel := cloud.ContextWithCallTimeout()
+	return SetProxyForForwardingRuleWithContext(context.Background(), gceCloud, key, forwardingRule, targetProxyLink)
+}
+
+// SetProxyForForwardingRuleWithContext is like SetProxyForForwardingRule, with the span of the call a child of the span in ctx.
+func SetProxyForForwardingRuleWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, forwardingRule *ForwardingRule, targetProxyLink string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
 	defer cancel()
 	mc := metrics.NewMetricContext("ForwardingRule", "set_proxy", key.Region, key.Zone, string(forwardingRule.Version))
 
 	// Set name in case it is not present in the key
 	key.Name = forwardingRule.Name
+	ac := audit.NewContext("ForwardingRules", "setTarget", key, forwardingRule.Version).WithFields(map[string]interface{}{"target": targetProxyLink})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "setTarget", key, forwardingRule.Version)
+	defer span.End()
 	klog.V(3).Infof("setting proxy for forwarding rule ForwardingRule %v", key)
 
 	switch forwardingRule.Version {
@@ -199,25 +340,397 @@ func SetProxyForForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRul
 		target := &computealpha.TargetReference{Target: targetProxyLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().AlphaForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaForwardingRules().SetTarget(callCtx, key, target))))
 		default:
-			return mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().SetTarget(callCtx, key, target))))
 		}
 	case meta.VersionBeta:
 		target := &computebeta.TargetReference{Target: targetProxyLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().BetaForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaForwardingRules().SetTarget(callCtx, key, target))))
 		default:
-			return mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().SetTarget(callCtx, key, target))))
 		}
 	default:
 		target := &compute.TargetReference{Target: targetProxyLink}
 		switch key.Type() {
 		case meta.Regional:
-			return mc.Observe(gceCloud.Compute().ForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().ForwardingRules().SetTarget(callCtx, key, target))))
 		default:
-			return mc.Observe(gceCloud.Compute().GlobalForwardingRules().SetTarget(ctx, key, target))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().GlobalForwardingRules().SetTarget(callCtx, key, target))))
 		}
 	}
 }
+
+// SetLabelsForForwardingRule() sets the labels of a forwarding rule. Labels
+// can only be set through the beta API.
+func SetLabelsForForwardingRule(gceCloud *gce.Cloud, key *meta.Key, labels map[string]string, labelFingerprint string) error {
+	return SetLabelsForForwardingRuleWithContext(context.Background(), gceCloud, key, labels, labelFingerprint)
+}
+
+// SetLabelsForForwardingRuleWithContext is like SetLabelsForForwardingRule, with the span of the call a child of the span in ctx.
+func SetLabelsForForwardingRuleWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, labels map[string]string, labelFingerprint string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("ForwardingRule", "set_labels", key.Region, key.Zone, string(meta.VersionBeta))
+	ac := audit.NewContext("ForwardingRules", "setLabels", key, meta.VersionBeta).WithFields(map[string]interface{}{"labels": labels})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "setLabels", key, meta.VersionBeta)
+	defer span.End()
+	klog.V(3).Infof("setting labels %v for forwarding rule %v", labels, key)
+
+	var err error
+	switch key.Type() {
+	case meta.Regional:
+		req := &computebeta.RegionSetLabelsRequest{Labels: labels, LabelFingerprint: labelFingerprint}
+		err = extensionFor(gceCloud).SetForwardingRuleLabels(callCtx, key, req)
+	default:
+		req := &computebeta.GlobalSetLabelsRequest{Labels: labels, LabelFingerprint: labelFingerprint}
+		err = extensionFor(gceCloud).SetGlobalForwardingRuleLabels(callCtx, key, req)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// SetLabelsForAddress() sets the labels of an address. Labels can only be set
+// through the beta API.
+func SetLabelsForAddress(gceCloud *gce.Cloud, key *meta.Key, labels map[string]string, labelFingerprint string) error {
+	return SetLabelsForAddressWithContext(context.Background(), gceCloud, key, labels, labelFingerprint)
+}
+
+// SetLabelsForAddressWithContext is like SetLabelsForAddress, with the span of the call a child of the span in ctx.
+func SetLabelsForAddressWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, labels map[string]string, labelFingerprint string) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("Address", "set_labels", key.Region, key.Zone, string(meta.VersionBeta))
+	ac := audit.NewContext("Addresses", "setLabels", key, meta.VersionBeta).WithFields(map[string]interface{}{"labels": labels})
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "Addresses", "setLabels", key, meta.VersionBeta)
+	defer span.End()
+	klog.V(3).Infof("setting labels %v for address %v", labels, key)
+
+	var err error
+	switch key.Type() {
+	case meta.Regional:
+		req := &computebeta.RegionSetLabelsRequest{Labels: labels, LabelFingerprint: labelFingerprint}
+		err = extensionFor(gceCloud).SetAddressLabels(callCtx, key, req)
+	default:
+		req := &computebeta.GlobalSetLabelsRequest{Labels: labels, LabelFingerprint: labelFingerprint}
+		err = extensionFor(gceCloud).SetGlobalAddressLabels(callCtx, key, req)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// GetSslPolicy gets the global SSL policy with the given key. SSL policies
+// are only supported at the GA API version.
+func GetSslPolicy(gceCloud *gce.Cloud, key *meta.Key) (*compute.SslPolicy, error) {
+	return GetSslPolicyWithContext(context.Background(), gceCloud, key)
+}
+
+// GetSslPolicyWithContext is like GetSslPolicy, with the span of the call a child of the span in ctx.
+func GetSslPolicyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) (*compute.SslPolicy, error) {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("SslPolicy", "get", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslPolicies", "get", key, meta.VersionGA)
+	defer span.End()
+
+	klog.V(3).Infof("Getting ga SslPolicy %v", key.Name)
+	policy, err := gceCloud.Compute().SslPolicies().Get(callCtx, key)
+	if err != nil {
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
+	}
+	audit.ObserveGet("SslPolicies", key, meta.VersionGA, policy)
+	return policy, nil
+}
+
+// CreateSslPolicy creates the global SSL policy with the given key.
+func CreateSslPolicy(gceCloud *gce.Cloud, key *meta.Key, policy *compute.SslPolicy) error {
+	return CreateSslPolicyWithContext(context.Background(), gceCloud, key, policy)
+}
+
+// CreateSslPolicyWithContext is like CreateSslPolicy, with the span of the call a child of the span in ctx.
+func CreateSslPolicyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, policy *compute.SslPolicy) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("SslPolicy", "create", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslPolicies", "create", key, meta.VersionGA)
+	defer span.End()
+	ac := audit.NewContext("SslPolicies", audit.OperationCreate, key, meta.VersionGA).WithObject(policy)
+
+	klog.V(3).Infof("Creating ga SslPolicy %v", policy.Name)
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().SslPolicies().Insert(callCtx, key, policy))))
+}
+
+// DeleteSslPolicy deletes the global SSL policy with the given key.
+func DeleteSslPolicy(gceCloud *gce.Cloud, key *meta.Key) error {
+	return DeleteSslPolicyWithContext(context.Background(), gceCloud, key)
+}
+
+// DeleteSslPolicyWithContext is like DeleteSslPolicy, with the span of the call a child of the span in ctx.
+func DeleteSslPolicyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("SslPolicy", "delete", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslPolicies", "delete", key, meta.VersionGA)
+	defer span.End()
+	ac := audit.NewContext("SslPolicies", audit.OperationDelete, key, meta.VersionGA)
+
+	klog.V(3).Infof("Deleting ga SslPolicy %v", key.Name)
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().SslPolicies().Delete(callCtx, key))))
+}
+
+// CreateNonZonalNetworkEndpointGroup() creates a regional or global network
+// endpoint group, for serverless and Internet backends. These are not
+// provided by gceCloud.Compute(), so the GA compute API is called directly.
+func CreateNonZonalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup) error {
+	return CreateNonZonalNetworkEndpointGroupWithContext(context.Background(), gceCloud, key, networkEndpointGroup)
+}
+
+// CreateNonZonalNetworkEndpointGroupWithContext is like CreateNonZonalNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func CreateNonZonalNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "create", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("NetworkEndpointGroups", audit.OperationCreate, key, meta.VersionGA).WithObject(networkEndpointGroup)
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "create", key, meta.VersionGA)
+	defer span.End()
+
+	ga, err := networkEndpointGroup.ToGA()
+	if err != nil {
+		return err
+	}
+	ga.Name = key.Name
+	var op *compute.Operation
+	switch key.Type() {
+	case meta.Regional:
+		klog.V(3).Infof("Creating ga regional NetworkEndpointGroup %v", key.Name)
+		op, err = gceCloud.ComputeServices().GA.RegionNetworkEndpointGroups.Insert(gceCloud.ProjectID(), key.Region, ga).Context(callCtx).Do()
+	case meta.Global:
+		klog.V(3).Infof("Creating ga global NetworkEndpointGroup %v", key.Name)
+		op, err = gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.Insert(gceCloud.ProjectID(), ga).Context(callCtx).Do()
+	default:
+		return fmt.Errorf("Key %v not valid for regional or global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	if err == nil {
+		err = waitForGAOp(callCtx, gceCloud, op)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// GetNonZonalNetworkEndpointGroup() gets a regional or global network
+// endpoint group.
+func GetNonZonalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key) (*NetworkEndpointGroup, error) {
+	return GetNonZonalNetworkEndpointGroupWithContext(context.Background(), gceCloud, key)
+}
+
+// GetNonZonalNetworkEndpointGroupWithContext is like GetNonZonalNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func GetNonZonalNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) (*NetworkEndpointGroup, error) {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "get", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "get", key, meta.VersionGA)
+	defer span.End()
+
+	var ga *compute.NetworkEndpointGroup
+	var err error
+	switch key.Type() {
+	case meta.Regional:
+		klog.V(3).Infof("Getting ga regional NetworkEndpointGroup %v", key.Name)
+		ga, err = gceCloud.ComputeServices().GA.RegionNetworkEndpointGroups.Get(gceCloud.ProjectID(), key.Region, key.Name).Context(callCtx).Do()
+	case meta.Global:
+		klog.V(3).Infof("Getting ga global NetworkEndpointGroup %v", key.Name)
+		ga, err = gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.Get(gceCloud.ProjectID(), key.Name).Context(callCtx).Do()
+	default:
+		return nil, fmt.Errorf("Key %v not valid for regional or global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	if err != nil {
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
+	}
+	neg, err := GAToNetworkEndpointGroup(ga)
+	if err != nil {
+		return nil, err
+	}
+	neg.Scope = key.Type()
+	neg.Version = meta.VersionGA
+	audit.ObserveGet("NetworkEndpointGroups", key, meta.VersionGA, neg)
+	return neg, nil
+}
+
+// ListNonZonalNetworkEndpointGroups() lists the network endpoint groups of
+// the region of a regional key, or the global ones for a global key.
+func ListNonZonalNetworkEndpointGroups(gceCloud *gce.Cloud, key *meta.Key) ([]*NetworkEndpointGroup, error) {
+	return ListNonZonalNetworkEndpointGroupsWithContext(context.Background(), gceCloud, key)
+}
+
+// ListNonZonalNetworkEndpointGroupsWithContext is like ListNonZonalNetworkEndpointGroups, with the span of the call a child of the span in ctx.
+func ListNonZonalNetworkEndpointGroupsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) ([]*NetworkEndpointGroup, error) {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "list", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "list", key, meta.VersionGA)
+	defer span.End()
+
+	var gas []*compute.NetworkEndpointGroup
+	appendPage := func(list *compute.NetworkEndpointGroupList) error {
+		gas = append(gas, list.Items...)
+		return nil
+	}
+	var err error
+	switch key.Type() {
+	case meta.Regional:
+		klog.V(3).Infof("Listing ga regional NetworkEndpointGroups")
+		err = gceCloud.ComputeServices().GA.RegionNetworkEndpointGroups.List(gceCloud.ProjectID(), key.Region).Pages(callCtx, appendPage)
+	case meta.Global:
+		klog.V(3).Infof("Listing ga global NetworkEndpointGroups")
+		err = gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.List(gceCloud.ProjectID()).Pages(callCtx, appendPage)
+	default:
+		return nil, fmt.Errorf("Key %v not valid for regional or global resource NetworkEndpointGroup", key)
+	}
+	if err != nil {
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
+	}
+	negs, err := toNetworkEndpointGroupList(gas)
+	if err != nil {
+		return nil, err
+	}
+	for _, neg := range negs {
+		neg.Scope = key.Type()
+		neg.Version = meta.VersionGA
+	}
+	return negs, nil
+}
+
+// DeleteNonZonalNetworkEndpointGroup() deletes a regional or global network
+// endpoint group.
+func DeleteNonZonalNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key) error {
+	return DeleteNonZonalNetworkEndpointGroupWithContext(context.Background(), gceCloud, key)
+}
+
+// DeleteNonZonalNetworkEndpointGroupWithContext is like DeleteNonZonalNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func DeleteNonZonalNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "delete", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("NetworkEndpointGroups", audit.OperationDelete, key, meta.VersionGA)
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "delete", key, meta.VersionGA)
+	defer span.End()
+
+	var op *compute.Operation
+	var err error
+	switch key.Type() {
+	case meta.Regional:
+		klog.V(3).Infof("Deleting ga regional NetworkEndpointGroup %v", key.Name)
+		op, err = gceCloud.ComputeServices().GA.RegionNetworkEndpointGroups.Delete(gceCloud.ProjectID(), key.Region, key.Name).Context(callCtx).Do()
+	case meta.Global:
+		klog.V(3).Infof("Deleting ga global NetworkEndpointGroup %v", key.Name)
+		op, err = gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.Delete(gceCloud.ProjectID(), key.Name).Context(callCtx).Do()
+	default:
+		return fmt.Errorf("Key %v not valid for regional or global resource NetworkEndpointGroup %v", key, key.Name)
+	}
+	if err == nil {
+		err = waitForGAOp(callCtx, gceCloud, op)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// AttachGlobalNetworkEndpoints() attaches endpoints to a global network
+// endpoint group.
+func AttachGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, endpoints []*NetworkEndpoint) error {
+	return AttachGlobalNetworkEndpointsWithContext(context.Background(), gceCloud, key, endpoints)
+}
+
+// AttachGlobalNetworkEndpointsWithContext is like AttachGlobalNetworkEndpoints, with the span of the call a child of the span in ctx.
+func AttachGlobalNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, endpoints []*NetworkEndpoint) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "attach", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("NetworkEndpointGroups", "attachNetworkEndpoints", key, meta.VersionGA).WithObject(endpoints)
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "attachNetworkEndpoints", key, meta.VersionGA)
+	defer span.End()
+
+	req := &compute.GlobalNetworkEndpointGroupsAttachEndpointsRequest{}
+	for _, endpoint := range endpoints {
+		ga, err := endpoint.ToGA()
+		if err != nil {
+			return err
+		}
+		req.NetworkEndpoints = append(req.NetworkEndpoints, ga)
+	}
+	klog.V(3).Infof("Attaching %d endpoints to ga global NetworkEndpointGroup %v", len(endpoints), key.Name)
+	op, err := gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.AttachNetworkEndpoints(gceCloud.ProjectID(), key.Name, req).Context(callCtx).Do()
+	if err == nil {
+		err = waitForGAOp(callCtx, gceCloud, op)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// DetachGlobalNetworkEndpoints() detaches endpoints from a global network
+// endpoint group.
+func DetachGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, endpoints []*NetworkEndpoint) error {
+	return DetachGlobalNetworkEndpointsWithContext(context.Background(), gceCloud, key, endpoints)
+}
+
+// DetachGlobalNetworkEndpointsWithContext is like DetachGlobalNetworkEndpoints, with the span of the call a child of the span in ctx.
+func DetachGlobalNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, endpoints []*NetworkEndpoint) error {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "detach", key.Region, key.Zone, string(meta.VersionGA))
+	ac := audit.NewContext("NetworkEndpointGroups", "detachNetworkEndpoints", key, meta.VersionGA).WithObject(endpoints)
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "detachNetworkEndpoints", key, meta.VersionGA)
+	defer span.End()
+
+	req := &compute.GlobalNetworkEndpointGroupsDetachEndpointsRequest{}
+	for _, endpoint := range endpoints {
+		ga, err := endpoint.ToGA()
+		if err != nil {
+			return err
+		}
+		req.NetworkEndpoints = append(req.NetworkEndpoints, ga)
+	}
+	klog.V(3).Infof("Detaching %d endpoints from ga global NetworkEndpointGroup %v", len(endpoints), key.Name)
+	op, err := gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.DetachNetworkEndpoints(gceCloud.ProjectID(), key.Name, req).Context(callCtx).Do()
+	if err == nil {
+		err = waitForGAOp(callCtx, gceCloud, op)
+	}
+	return ac.Observe(tracing.ObserveSpan(span, mc.Observe(err)))
+}
+
+// ListGlobalNetworkEndpoints() lists the endpoints of a global network
+// endpoint group.
+func ListGlobalNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key) ([]*NetworkEndpoint, error) {
+	return ListGlobalNetworkEndpointsWithContext(context.Background(), gceCloud, key)
+}
+
+// ListGlobalNetworkEndpointsWithContext is like ListGlobalNetworkEndpoints, with the span of the call a child of the span in ctx.
+func ListGlobalNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key) ([]*NetworkEndpoint, error) {
+	callCtx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+	mc := metrics.NewMetricContext("NetworkEndpointGroup", "list_network_endpoints", key.Region, key.Zone, string(meta.VersionGA))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "listNetworkEndpoints", key, meta.VersionGA)
+	defer span.End()
+
+	var gas []*compute.NetworkEndpoint
+	klog.V(3).Infof("Listing endpoints of ga global NetworkEndpointGroup %v", key.Name)
+	err := gceCloud.ComputeServices().GA.GlobalNetworkEndpointGroups.ListNetworkEndpoints(gceCloud.ProjectID(), key.Name).Pages(callCtx, func(list *compute.NetworkEndpointGroupsListNetworkEndpoints) error {
+		for _, item := range list.Items {
+			if item.NetworkEndpoint != nil {
+				gas = append(gas, item.NetworkEndpoint)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
+	}
+	return toNetworkEndpointList(gas)
+}
+
+// waitForGAOp waits for an operation of a call made directly on the GA
+// compute API, for methods which are not provided by gceCloud.Compute().
+func waitForGAOp(ctx context.Context, gceCloud *gce.Cloud, op *compute.Operation) error {
+	s := &cloud.Service{
+		GA:            gceCloud.ComputeServices().GA,
+		ProjectRouter: &cloud.SingleProjectRouter{ID: gceCloud.ProjectID()},
+		RateLimiter:   &cloud.NopRateLimiter{},
+	}
+	return s.WaitForCompletion(ctx, op)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+// defaultOperationPollInterval is the minimum interval between two polls of
+// an operation when no rate limiter is configured, as in the gce cloud.
+const defaultOperationPollInterval = 3 * time.Second
+
+// Extension provides the compute API methods that are not part of
+// gceCloud.Compute(). Like the methods of gceCloud.Compute(), they are rate
+// limited and wait for the completion of their operation.
+type Extension interface {
+	// SetForwardingRuleLabels sets the labels of a regional forwarding rule.
+	SetForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error
+	// SetGlobalForwardingRuleLabels sets the labels of a global forwarding rule.
+	SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error
+	// SetAddressLabels sets the labels of a regional address.
+	SetAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error
+	// SetGlobalAddressLabels sets the labels of a global address.
+	SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error
+	// PatchTargetHttpsProxy sets fields of a global or regional target https
+	// proxy with the API version, by their JSON name. A nil value clears the
+	// field.
+	PatchTargetHttpsProxy(ctx context.Context, key *meta.Key, version meta.Version, fields map[string]interface{}) error
+	// GetBackendServiceTlsSettings returns the TLS settings of a global or
+	// regional backend service, nil if it has none.
+	GetBackendServiceTlsSettings(ctx context.Context, key *meta.Key) (*BackendServiceTlsSettings, error)
+	// PatchBackendService sets fields of a global or regional backend
+	// service, by their JSON name. A nil value clears the field.
+	PatchBackendService(ctx context.Context, key *meta.Key, fields map[string]interface{}) error
+}
+
+// BackendServiceTlsSettings are the settings of the TLS connections of a
+// backend service of an external load balancer to its backends. They are not
+// in the compute client library yet.
+type BackendServiceTlsSettings struct {
+	// Sni is the server name sent to the backends.
+	Sni string `json:"sni,omitempty"`
+	// SubjectAltNames are accepted in the certificates of the backends,
+	// instead of Sni.
+	SubjectAltNames []*BackendServiceTlsSettingsSubjectAltName `json:"subjectAltNames,omitempty"`
+	// AuthenticationConfig is the link of the Network Security backend
+	// authentication config.
+	AuthenticationConfig string `json:"authenticationConfig,omitempty"`
+}
+
+// BackendServiceTlsSettingsSubjectAltName is a DNS name or an URI.
+type BackendServiceTlsSettingsSubjectAltName struct {
+	DnsName                   string `json:"dnsName,omitempty"`
+	UniformResourceIdentifier string `json:"uniformResourceIdentifier,omitempty"`
+}
+
+var (
+	extensionsLock sync.Mutex
+	// extensions are the Extensions registered for each cloud.
+	extensions = map[*gce.Cloud]Extension{}
+)
+
+// SetExtension registers the Extension used for the calls of gceCloud that
+// are not part of gceCloud.Compute(). Tests register a fake Extension for
+// a cloud backed by a mock.
+func SetExtension(gceCloud *gce.Cloud, ext Extension) {
+	extensionsLock.Lock()
+	defer extensionsLock.Unlock()
+	extensions[gceCloud] = ext
+}
+
+// extensionFor returns the Extension registered for gceCloud, or an
+// Extension with the default rate limiting if none is registered.
+func extensionFor(gceCloud *gce.Cloud) Extension {
+	extensionsLock.Lock()
+	defer extensionsLock.Unlock()
+	ext, ok := extensions[gceCloud]
+	if !ok {
+		ext = NewGCEExtension(gceCloud, nil, nil)
+		extensions[gceCloud] = ext
+	}
+	return ext
+}
+
+// NewGCEExtension returns an Extension calling the compute API of gceCloud,
+// rate limited by rl. rl should be the rate limiter of gceCloud. If rl is nil,
+// only the polling of operations is rate limited. The methods missing from
+// the compute client library are issued with restConfig, they fail if it is
+// nil.
+func NewGCEExtension(gceCloud *gce.Cloud, rl cloud.RateLimiter, restConfig *gcprest.Config) Extension {
+	if rl == nil {
+		rl = &operationPollRateLimiter{minimum: defaultOperationPollInterval}
+	}
+	services := gceCloud.ComputeServices()
+	ext := &gceExtension{
+		projectID: gceCloud.ProjectID(),
+		s: &cloud.Service{
+			GA:            services.GA,
+			Alpha:         services.Alpha,
+			Beta:          services.Beta,
+			ProjectRouter: &cloud.SingleProjectRouter{ID: gceCloud.ProjectID()},
+			RateLimiter:   rl,
+		},
+	}
+	if restConfig != nil {
+		ext.targetHttpsProxies = gcprest.NewClient(restConfig, "TargetHttpsProxies", restConfig.ComputeEndpoint)
+		ext.regionTargetHttpsProxies = gcprest.NewClient(restConfig, "RegionTargetHttpsProxies", restConfig.ComputeEndpoint)
+		if restConfig.AlphaComputeEndpoint != "" {
+			ext.alphaTargetHttpsProxies = gcprest.NewClient(restConfig, "TargetHttpsProxies", restConfig.AlphaComputeEndpoint)
+			ext.alphaRegionTargetHttpsProxies = gcprest.NewClient(restConfig, "RegionTargetHttpsProxies", restConfig.AlphaComputeEndpoint)
+		}
+		ext.backendServices = gcprest.NewClient(restConfig, "BackendServices", restConfig.ComputeEndpoint)
+		ext.regionBackendServices = gcprest.NewClient(restConfig, "RegionBackendServices", restConfig.ComputeEndpoint)
+	}
+	return ext
+}
+
+// operationPollRateLimiter waits for a minimum interval before each poll of
+// an operation, and accepts any other call immediately.
+type operationPollRateLimiter struct {
+	minimum time.Duration
+}
+
+// Accept implements cloud.RateLimiter.
+func (rl *operationPollRateLimiter) Accept(ctx context.Context, key *cloud.RateLimitKey) error {
+	if key.Operation == "Get" && key.Service == "Operations" {
+		return (&cloud.MinimumRateLimiter{RateLimiter: &cloud.NopRateLimiter{}, Minimum: rl.minimum}).Accept(ctx, key)
+	}
+	return (&cloud.NopRateLimiter{}).Accept(ctx, key)
+}
+
+// gceExtension implements Extension with the compute API.
+type gceExtension struct {
+	projectID string
+	s         *cloud.Service
+	// targetHttpsProxies and regionTargetHttpsProxies issue the patch
+	// requests of proxies, nil without a REST config.
+	targetHttpsProxies       *gcprest.Client
+	regionTargetHttpsProxies *gcprest.Client
+	// alphaTargetHttpsProxies and alphaRegionTargetHttpsProxies issue the
+	// patch requests of proxies with the alpha API, nil without a REST config.
+	alphaTargetHttpsProxies       *gcprest.Client
+	alphaRegionTargetHttpsProxies *gcprest.Client
+	// backendServices and regionBackendServices read and patch the TLS
+	// settings of backend services, nil without a REST config.
+	backendServices       *gcprest.Client
+	regionBackendServices *gcprest.Client
+}
+
+// accept waits for the rate limiter of a call.
+func (e *gceExtension) accept(ctx context.Context, service, operation string, version meta.Version) error {
+	return e.s.RateLimiter.Accept(ctx, &cloud.RateLimitKey{
+		ProjectID: e.projectID,
+		Operation: operation,
+		Version:   version,
+		Service:   service,
+	})
+}
+
+// SetForwardingRuleLabels implements Extension.
+func (e *gceExtension) SetForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error {
+	if err := e.accept(ctx, "ForwardingRules", "SetLabels", meta.VersionBeta); err != nil {
+		return err
+	}
+	op, err := e.s.Beta.ForwardingRules.SetLabels(e.projectID, key.Region, key.Name, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
+
+// SetGlobalForwardingRuleLabels implements Extension.
+func (e *gceExtension) SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error {
+	if err := e.accept(ctx, "GlobalForwardingRules", "SetLabels", meta.VersionBeta); err != nil {
+		return err
+	}
+	op, err := e.s.Beta.GlobalForwardingRules.SetLabels(e.projectID, key.Name, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
+
+// SetAddressLabels implements Extension.
+func (e *gceExtension) SetAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error {
+	if err := e.accept(ctx, "Addresses", "SetLabels", meta.VersionBeta); err != nil {
+		return err
+	}
+	op, err := e.s.Beta.Addresses.SetLabels(e.projectID, key.Region, key.Name, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
+
+// SetGlobalAddressLabels implements Extension.
+func (e *gceExtension) SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error {
+	if err := e.accept(ctx, "GlobalAddresses", "SetLabels", meta.VersionBeta); err != nil {
+		return err
+	}
+	op, err := e.s.Beta.GlobalAddresses.SetLabels(e.projectID, key.Name, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
+
+// PatchTargetHttpsProxy implements Extension.
+func (e *gceExtension) PatchTargetHttpsProxy(ctx context.Context, key *meta.Key, version meta.Version, fields map[string]interface{}) error {
+	var client *gcprest.Client
+	path := fmt.Sprintf("projects/%s/global/targetHttpsProxies/%s", e.projectID, key.Name)
+	switch {
+	case key.Type() == meta.Regional && version == meta.VersionAlpha:
+		client = e.alphaRegionTargetHttpsProxies
+	case key.Type() == meta.Regional && version == meta.VersionGA:
+		client = e.regionTargetHttpsProxies
+	case version == meta.VersionAlpha:
+		client = e.alphaTargetHttpsProxies
+	case version == meta.VersionGA:
+		client = e.targetHttpsProxies
+	default:
+		return fmt.Errorf("patching target https proxy %v is not supported with API version %s", key, version)
+	}
+	if key.Type() == meta.Regional {
+		path = fmt.Sprintf("projects/%s/regions/%s/targetHttpsProxies/%s", e.projectID, key.Region, key.Name)
+	}
+	if client == nil {
+		return fmt.Errorf("patching target https proxy %v with API version %s is not supported without a REST config", key, version)
+	}
+	op := &compute.Operation{}
+	if err := client.Do(ctx, http.MethodPatch, path, fields, op); err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
+
+// backendServiceClient returns the REST client and the path of the backend
+// service of key.
+func (e *gceExtension) backendServiceClient(key *meta.Key) (*gcprest.Client, string, error) {
+	client := e.backendServices
+	path := fmt.Sprintf("projects/%s/global/backendServices/%s", e.projectID, key.Name)
+	if key.Type() == meta.Regional {
+		client = e.regionBackendServices
+		path = fmt.Sprintf("projects/%s/regions/%s/backendServices/%s", e.projectID, key.Region, key.Name)
+	}
+	if client == nil {
+		return nil, "", fmt.Errorf("TLS settings of backend service %v are not supported without a REST config", key)
+	}
+	return client, path, nil
+}
+
+// GetBackendServiceTlsSettings implements Extension.
+func (e *gceExtension) GetBackendServiceTlsSettings(ctx context.Context, key *meta.Key) (*BackendServiceTlsSettings, error) {
+	client, path, err := e.backendServiceClient(key)
+	if err != nil {
+		return nil, err
+	}
+	be := &struct {
+		TlsSettings *BackendServiceTlsSettings `json:"tlsSettings"`
+	}{}
+	if err := client.Do(ctx, http.MethodGet, path, nil, be); err != nil {
+		return nil, err
+	}
+	return be.TlsSettings, nil
+}
+
+// PatchBackendService implements Extension.
+func (e *gceExtension) PatchBackendService(ctx context.Context, key *meta.Key, fields map[string]interface{}) error {
+	client, path, err := e.backendServiceClient(key)
+	if err != nil {
+		return err
+	}
+	op := &compute.Operation{}
+	if err := client.Do(ctx, http.MethodPatch, path, fields, op); err != nil {
+		return err
+	}
+	return e.s.WaitForCompletion(ctx, op)
+}
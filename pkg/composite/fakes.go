@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computealpha "google.golang.org/api/compute/v0.alpha"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+// RegisterFakeExtension registers a fake Extension for a cloud backed by
+// mock, which stores the changes in the objects of mock.
+func RegisterFakeExtension(gceCloud *gce.Cloud, mock *cloud.MockGCE) {
+	SetExtension(gceCloud, &fakeExtension{mock: mock, tlsSettings: map[meta.Key]*BackendServiceTlsSettings{}})
+}
+
+// fakeExtension implements Extension on top of a cloud.MockGCE.
+type fakeExtension struct {
+	mock *cloud.MockGCE
+
+	mu sync.Mutex
+	// tlsSettings are the TLS settings of the backend services, which the
+	// mock objects have no field for.
+	tlsSettings map[meta.Key]*BackendServiceTlsSettings
+}
+
+// SetForwardingRuleLabels implements Extension.
+func (f *fakeExtension) SetForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error {
+	fr, err := f.mock.BetaForwardingRules().Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	fr.Labels = req.Labels
+	f.mock.MockBetaForwardingRules.Lock.Lock()
+	defer f.mock.MockBetaForwardingRules.Lock.Unlock()
+	f.mock.MockBetaForwardingRules.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: fr}
+	return nil
+}
+
+// SetGlobalForwardingRuleLabels implements Extension.
+func (f *fakeExtension) SetGlobalForwardingRuleLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error {
+	fr, err := f.mock.BetaGlobalForwardingRules().Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	fr.Labels = req.Labels
+	f.mock.MockBetaGlobalForwardingRules.Lock.Lock()
+	defer f.mock.MockBetaGlobalForwardingRules.Lock.Unlock()
+	f.mock.MockBetaGlobalForwardingRules.Objects[*key] = &cloud.MockGlobalForwardingRulesObj{Obj: fr}
+	return nil
+}
+
+// SetAddressLabels implements Extension.
+func (f *fakeExtension) SetAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.RegionSetLabelsRequest) error {
+	addr, err := f.mock.BetaAddresses().Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	addr.Labels = req.Labels
+	f.mock.MockBetaAddresses.Lock.Lock()
+	defer f.mock.MockBetaAddresses.Lock.Unlock()
+	f.mock.MockBetaAddresses.Objects[*key] = &cloud.MockAddressesObj{Obj: addr}
+	return nil
+}
+
+// SetGlobalAddressLabels implements Extension.
+func (f *fakeExtension) SetGlobalAddressLabels(ctx context.Context, key *meta.Key, req *computebeta.GlobalSetLabelsRequest) error {
+	addr, err := f.mock.BetaGlobalAddresses().Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	addr.Labels = req.Labels
+	f.mock.MockBetaGlobalAddresses.Lock.Lock()
+	defer f.mock.MockBetaGlobalAddresses.Lock.Unlock()
+	f.mock.MockBetaGlobalAddresses.Objects[*key] = &cloud.MockGlobalAddressesObj{Obj: addr}
+	return nil
+}
+
+// PatchTargetHttpsProxy implements Extension.
+func (f *fakeExtension) PatchTargetHttpsProxy(ctx context.Context, key *meta.Key, version meta.Version, fields map[string]interface{}) error {
+	var proxy *computealpha.TargetHttpsProxy
+	var err error
+	if key.Type() == meta.Regional {
+		proxy, err = f.mock.AlphaRegionTargetHttpsProxies().Get(ctx, key)
+	} else {
+		proxy, err = f.mock.AlphaTargetHttpsProxies().Get(ctx, key)
+	}
+	if err != nil {
+		return err
+	}
+	// Apply the patch like the API: set fields are replaced, nil fields are
+	// cleared.
+	obj := map[string]interface{}{}
+	if err := copyViaJSON(&obj, proxy); err != nil {
+		return err
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(obj, name)
+		} else {
+			obj[name] = value
+		}
+	}
+	patched := &computealpha.TargetHttpsProxy{}
+	if err := copyViaJSON(patched, obj); err != nil {
+		return err
+	}
+	if key.Type() == meta.Regional {
+		f.mock.MockAlphaRegionTargetHttpsProxies.Lock.Lock()
+		defer f.mock.MockAlphaRegionTargetHttpsProxies.Lock.Unlock()
+		f.mock.MockAlphaRegionTargetHttpsProxies.Objects[*key] = &cloud.MockRegionTargetHttpsProxiesObj{Obj: patched}
+		return nil
+	}
+	f.mock.MockAlphaTargetHttpsProxies.Lock.Lock()
+	defer f.mock.MockAlphaTargetHttpsProxies.Lock.Unlock()
+	f.mock.MockAlphaTargetHttpsProxies.Objects[*key] = &cloud.MockTargetHttpsProxiesObj{Obj: patched}
+	return nil
+}
+
+// getBackendService returns an error if the backend service of key does not
+// exist.
+func (f *fakeExtension) getBackendService(ctx context.Context, key *meta.Key) error {
+	var err error
+	if key.Type() == meta.Regional {
+		_, err = f.mock.AlphaRegionBackendServices().Get(ctx, key)
+	} else {
+		_, err = f.mock.AlphaBackendServices().Get(ctx, key)
+	}
+	return err
+}
+
+// GetBackendServiceTlsSettings implements Extension.
+func (f *fakeExtension) GetBackendServiceTlsSettings(ctx context.Context, key *meta.Key) (*BackendServiceTlsSettings, error) {
+	if err := f.getBackendService(ctx, key); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tlsSettings[*key], nil
+}
+
+// PatchBackendService implements Extension. Only the tlsSettings field is
+// supported.
+func (f *fakeExtension) PatchBackendService(ctx context.Context, key *meta.Key, fields map[string]interface{}) error {
+	if err := f.getBackendService(ctx, key); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for name, value := range fields {
+		if name != "tlsSettings" {
+			return fmt.Errorf("patching field %q of backend services is not supported", name)
+		}
+		if value == nil {
+			delete(f.tlsSettings, *key)
+			continue
+		}
+		settings := &BackendServiceTlsSettings{}
+		if err := copyViaJSON(settings, value); err != nil {
+			return err
+		}
+		f.tlsSettings[*key] = settings
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ limitations under the License.
 package composite
 
 import (
+	"context"
 	"fmt"
 
 	cloudprovider "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -28,7 +29,9 @@ import (
 	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
+	"k8s.io/ingress-gce/pkg/audit"
 	compositemetrics "k8s.io/ingress-gce/pkg/composite/metrics"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
 )
@@ -3450,9 +3453,17 @@ type WeightedBackendService struct {
 }
 
 func CreateAddress(gceCloud *gce.Cloud, key *meta.Key, address *Address) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateAddressWithContext(context.Background(), gceCloud, key, address)
+}
+
+// CreateAddressWithContext is like CreateAddress, with the span of the call a child of the span in ctx.
+func CreateAddressWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, address *Address) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("Address", "create", key.Region, key.Zone, string(address.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "Addresses", "create", key, address.Version)
+	defer span.End()
+	ac := audit.NewContext("Addresses", audit.OperationCreate, key, address.Version).WithObject(address)
 
 	switch address.Version {
 	case meta.VersionAlpha:
@@ -3464,10 +3475,10 @@ func CreateAddress(gceCloud *gce.Cloud, key *meta.Key, address *Address) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region Address %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaAddresses().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaAddresses().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha Address %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaGlobalAddresses().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaGlobalAddresses().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := address.ToBeta()
@@ -3478,10 +3489,10 @@ func CreateAddress(gceCloud *gce.Cloud, key *meta.Key, address *Address) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region Address %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaAddresses().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaAddresses().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta Address %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaGlobalAddresses().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaGlobalAddresses().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := address.ToGA()
@@ -3492,54 +3503,69 @@ func CreateAddress(gceCloud *gce.Cloud, key *meta.Key, address *Address) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region Address %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().Addresses().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Addresses().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga Address %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().GlobalAddresses().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().GlobalAddresses().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteAddress(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteAddressWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteAddressWithContext is like DeleteAddress, with the span of the call a child of the span in ctx.
+func DeleteAddressWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("Address", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "Addresses", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("Addresses", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaAddresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaAddresses().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaGlobalAddresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaGlobalAddresses().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaAddresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaAddresses().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaGlobalAddresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaGlobalAddresses().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().Addresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Addresses().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga Address %v", key.Name)
-			return mc.Observe(gceCloud.Compute().GlobalAddresses().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().GlobalAddresses().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetAddress(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*Address, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetAddressWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetAddressWithContext is like GetAddress, with the span of the call a child of the span in ctx.
+func GetAddressWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*Address, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("Address", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "Addresses", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -3548,32 +3574,32 @@ func GetAddress(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*Addr
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaAddresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaAddresses().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaGlobalAddresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaGlobalAddresses().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaAddresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaAddresses().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaGlobalAddresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaGlobalAddresses().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().Addresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().Addresses().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga Address %v", key.Name)
-			gceObj, err = gceCloud.Compute().GlobalAddresses().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().GlobalAddresses().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toAddress(gceObj)
 	if err != nil {
@@ -3583,13 +3609,21 @@ func GetAddress(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*Addr
 		compositeType.Scope = meta.Regional
 	}
 	compositeType.Version = version
+	audit.ObserveGet("Addresses", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListAddresses(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*Address, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListAddressesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListAddressesWithContext is like ListAddresses, with the span of the call a child of the span in ctx.
+func ListAddressesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*Address, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("Address", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "Addresses", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -3598,32 +3632,32 @@ func ListAddresses(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region Address")
-			gceObjs, err = gceCloud.Compute().AlphaAddresses().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaAddresses().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha Address")
-			gceObjs, err = gceCloud.Compute().AlphaGlobalAddresses().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaGlobalAddresses().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region Address")
-			gceObjs, err = gceCloud.Compute().BetaAddresses().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaAddresses().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta Address")
-			gceObjs, err = gceCloud.Compute().BetaGlobalAddresses().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaGlobalAddresses().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region Address")
-			gceObjs, err = gceCloud.Compute().Addresses().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().Addresses().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga Address")
-			gceObjs, err = gceCloud.Compute().GlobalAddresses().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().GlobalAddresses().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toAddressList(gceObjs)
@@ -3728,9 +3762,17 @@ func (address *Address) ToGA() (*compute.Address, error) {
 }
 
 func CreateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *BackendService) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateBackendServiceWithContext(context.Background(), gceCloud, key, backendService)
+}
+
+// CreateBackendServiceWithContext is like CreateBackendService, with the span of the call a child of the span in ctx.
+func CreateBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, backendService *BackendService) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("BackendService", "create", key.Region, key.Zone, string(backendService.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "create", key, backendService.Version)
+	defer span.End()
+	ac := audit.NewContext("BackendServices", audit.OperationCreate, key, backendService.Version).WithObject(backendService)
 
 	switch backendService.Version {
 	case meta.VersionAlpha:
@@ -3742,10 +3784,10 @@ func CreateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region BackendService %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha BackendService %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaBackendServices().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaBackendServices().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := backendService.ToBeta()
@@ -3756,10 +3798,10 @@ func CreateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region BackendService %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta BackendService %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaBackendServices().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaBackendServices().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := backendService.ToGA()
@@ -3770,18 +3812,26 @@ func CreateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region BackendService %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionBackendServices().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionBackendServices().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga BackendService %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().BackendServices().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BackendServices().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func UpdateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *BackendService) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return UpdateBackendServiceWithContext(context.Background(), gceCloud, key, backendService)
+}
+
+// UpdateBackendServiceWithContext is like UpdateBackendService, with the span of the call a child of the span in ctx.
+func UpdateBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, backendService *BackendService) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("BackendService", "update", key.Region, key.Zone, string(backendService.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "update", key, backendService.Version)
+	defer span.End()
+	ac := audit.NewContext("BackendServices", audit.OperationUpdate, key, backendService.Version).WithObject(backendService)
 	switch backendService.Version {
 	case meta.VersionAlpha:
 		alpha, err := backendService.ToAlpha()
@@ -3791,10 +3841,10 @@ func UpdateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating alpha region BackendService %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Update(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Updating alpha BackendService %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaBackendServices().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaBackendServices().Update(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := backendService.ToBeta()
@@ -3804,10 +3854,10 @@ func UpdateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating beta region BackendService %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Update(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Updating beta BackendService %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaBackendServices().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaBackendServices().Update(callCtx, key, beta))))
 		}
 	default:
 		ga, err := backendService.ToGA()
@@ -3817,54 +3867,69 @@ func UpdateBackendService(gceCloud *gce.Cloud, key *meta.Key, backendService *Ba
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating ga region BackendService %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().RegionBackendServices().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionBackendServices().Update(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Updating ga BackendService %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().BackendServices().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BackendServices().Update(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteBackendService(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteBackendServiceWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteBackendServiceWithContext is like DeleteBackendService, with the span of the call a child of the span in ctx.
+func DeleteBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("BackendService", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("BackendServices", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionBackendServices().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaBackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaBackendServices().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionBackendServices().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaBackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaBackendServices().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionBackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionBackendServices().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga BackendService %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BackendServices().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BackendServices().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetBackendService(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*BackendService, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetBackendServiceWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetBackendServiceWithContext is like GetBackendService, with the span of the call a child of the span in ctx.
+func GetBackendServiceWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*BackendService, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("BackendService", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -3873,45 +3938,53 @@ func GetBackendService(gceCloud *gce.Cloud, key *meta.Key, version meta.Version)
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionBackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionBackendServices().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaBackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaBackendServices().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionBackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionBackendServices().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaBackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaBackendServices().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionBackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionBackendServices().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga BackendService %v", key.Name)
-			gceObj, err = gceCloud.Compute().BackendServices().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BackendServices().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toBackendService(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("BackendServices", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListBackendServices(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*BackendService, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListBackendServicesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListBackendServicesWithContext is like ListBackendServices, with the span of the call a child of the span in ctx.
+func ListBackendServicesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*BackendService, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("BackendService", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "BackendServices", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -3920,32 +3993,32 @@ func ListBackendServices(gceCloud *gce.Cloud, key *meta.Key, version meta.Versio
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region BackendService")
-			gceObjs, err = gceCloud.Compute().AlphaRegionBackendServices().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionBackendServices().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha BackendService")
-			gceObjs, err = gceCloud.Compute().AlphaBackendServices().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaBackendServices().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region BackendService")
-			gceObjs, err = gceCloud.Compute().BetaRegionBackendServices().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionBackendServices().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta BackendService")
-			gceObjs, err = gceCloud.Compute().BetaBackendServices().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaBackendServices().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region BackendService")
-			gceObjs, err = gceCloud.Compute().RegionBackendServices().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionBackendServices().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga BackendService")
-			gceObjs, err = gceCloud.Compute().BackendServices().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BackendServices().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toBackendServiceList(gceObjs)
@@ -4080,9 +4153,17 @@ func (backendService *BackendService) ToGA() (*compute.BackendService, error) {
 }
 
 func CreateForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRule *ForwardingRule) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateForwardingRuleWithContext(context.Background(), gceCloud, key, forwardingRule)
+}
+
+// CreateForwardingRuleWithContext is like CreateForwardingRule, with the span of the call a child of the span in ctx.
+func CreateForwardingRuleWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, forwardingRule *ForwardingRule) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("ForwardingRule", "create", key.Region, key.Zone, string(forwardingRule.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "create", key, forwardingRule.Version)
+	defer span.End()
+	ac := audit.NewContext("ForwardingRules", audit.OperationCreate, key, forwardingRule.Version).WithObject(forwardingRule)
 
 	switch forwardingRule.Version {
 	case meta.VersionAlpha:
@@ -4094,10 +4175,10 @@ func CreateForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRule *Fo
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region ForwardingRule %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaForwardingRules().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaForwardingRules().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha ForwardingRule %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := forwardingRule.ToBeta()
@@ -4108,10 +4189,10 @@ func CreateForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRule *Fo
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region ForwardingRule %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaForwardingRules().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaForwardingRules().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta ForwardingRule %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := forwardingRule.ToGA()
@@ -4122,54 +4203,69 @@ func CreateForwardingRule(gceCloud *gce.Cloud, key *meta.Key, forwardingRule *Fo
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region ForwardingRule %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().ForwardingRules().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().ForwardingRules().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga ForwardingRule %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().GlobalForwardingRules().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().GlobalForwardingRules().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteForwardingRule(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteForwardingRuleWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteForwardingRuleWithContext is like DeleteForwardingRule, with the span of the call a child of the span in ctx.
+func DeleteForwardingRuleWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("ForwardingRule", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("ForwardingRules", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaForwardingRules().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaGlobalForwardingRules().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaForwardingRules().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaGlobalForwardingRules().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().ForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().ForwardingRules().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga ForwardingRule %v", key.Name)
-			return mc.Observe(gceCloud.Compute().GlobalForwardingRules().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().GlobalForwardingRules().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetForwardingRule(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*ForwardingRule, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetForwardingRuleWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetForwardingRuleWithContext is like GetForwardingRule, with the span of the call a child of the span in ctx.
+func GetForwardingRuleWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*ForwardingRule, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("ForwardingRule", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -4178,32 +4274,32 @@ func GetForwardingRule(gceCloud *gce.Cloud, key *meta.Key, version meta.Version)
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaForwardingRules().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaGlobalForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaGlobalForwardingRules().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaForwardingRules().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaGlobalForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaGlobalForwardingRules().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().ForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().ForwardingRules().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga ForwardingRule %v", key.Name)
-			gceObj, err = gceCloud.Compute().GlobalForwardingRules().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().GlobalForwardingRules().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toForwardingRule(gceObj)
 	if err != nil {
@@ -4213,13 +4309,21 @@ func GetForwardingRule(gceCloud *gce.Cloud, key *meta.Key, version meta.Version)
 		compositeType.Scope = meta.Regional
 	}
 	compositeType.Version = version
+	audit.ObserveGet("ForwardingRules", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListForwardingRules(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*ForwardingRule, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListForwardingRulesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListForwardingRulesWithContext is like ListForwardingRules, with the span of the call a child of the span in ctx.
+func ListForwardingRulesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*ForwardingRule, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("ForwardingRule", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "ForwardingRules", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -4228,32 +4332,32 @@ func ListForwardingRules(gceCloud *gce.Cloud, key *meta.Key, version meta.Versio
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region ForwardingRule")
-			gceObjs, err = gceCloud.Compute().AlphaForwardingRules().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaForwardingRules().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha ForwardingRule")
-			gceObjs, err = gceCloud.Compute().AlphaGlobalForwardingRules().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaGlobalForwardingRules().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region ForwardingRule")
-			gceObjs, err = gceCloud.Compute().BetaForwardingRules().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaForwardingRules().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta ForwardingRule")
-			gceObjs, err = gceCloud.Compute().BetaGlobalForwardingRules().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaGlobalForwardingRules().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region ForwardingRule")
-			gceObjs, err = gceCloud.Compute().ForwardingRules().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().ForwardingRules().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga ForwardingRule")
-			gceObjs, err = gceCloud.Compute().GlobalForwardingRules().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().GlobalForwardingRules().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toForwardingRuleList(gceObjs)
@@ -4358,9 +4462,17 @@ func (forwardingRule *ForwardingRule) ToGA() (*compute.ForwardingRule, error) {
 }
 
 func CreateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCheck) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateHealthCheckWithContext(context.Background(), gceCloud, key, healthCheck)
+}
+
+// CreateHealthCheckWithContext is like CreateHealthCheck, with the span of the call a child of the span in ctx.
+func CreateHealthCheckWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCheck) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("HealthCheck", "create", key.Region, key.Zone, string(healthCheck.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "HealthChecks", "create", key, healthCheck.Version)
+	defer span.End()
+	ac := audit.NewContext("HealthChecks", audit.OperationCreate, key, healthCheck.Version).WithObject(healthCheck)
 
 	switch healthCheck.Version {
 	case meta.VersionAlpha:
@@ -4372,10 +4484,10 @@ func CreateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region HealthCheck %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha HealthCheck %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaHealthChecks().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaHealthChecks().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := healthCheck.ToBeta()
@@ -4386,10 +4498,10 @@ func CreateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region HealthCheck %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta HealthCheck %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaHealthChecks().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaHealthChecks().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := healthCheck.ToGA()
@@ -4400,18 +4512,26 @@ func CreateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region HealthCheck %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionHealthChecks().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionHealthChecks().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga HealthCheck %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().HealthChecks().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().HealthChecks().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func UpdateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCheck) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return UpdateHealthCheckWithContext(context.Background(), gceCloud, key, healthCheck)
+}
+
+// UpdateHealthCheckWithContext is like UpdateHealthCheck, with the span of the call a child of the span in ctx.
+func UpdateHealthCheckWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCheck) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("HealthCheck", "update", key.Region, key.Zone, string(healthCheck.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "HealthChecks", "update", key, healthCheck.Version)
+	defer span.End()
+	ac := audit.NewContext("HealthChecks", audit.OperationUpdate, key, healthCheck.Version).WithObject(healthCheck)
 	switch healthCheck.Version {
 	case meta.VersionAlpha:
 		alpha, err := healthCheck.ToAlpha()
@@ -4421,10 +4541,10 @@ func UpdateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating alpha region HealthCheck %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Update(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Updating alpha HealthCheck %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaHealthChecks().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaHealthChecks().Update(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := healthCheck.ToBeta()
@@ -4434,10 +4554,10 @@ func UpdateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating beta region HealthCheck %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Update(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Updating beta HealthCheck %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaHealthChecks().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaHealthChecks().Update(callCtx, key, beta))))
 		}
 	default:
 		ga, err := healthCheck.ToGA()
@@ -4447,54 +4567,69 @@ func UpdateHealthCheck(gceCloud *gce.Cloud, key *meta.Key, healthCheck *HealthCh
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating ga region HealthCheck %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().RegionHealthChecks().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionHealthChecks().Update(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Updating ga HealthCheck %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().HealthChecks().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().HealthChecks().Update(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteHealthCheck(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteHealthCheckWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteHealthCheckWithContext is like DeleteHealthCheck, with the span of the call a child of the span in ctx.
+func DeleteHealthCheckWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("HealthCheck", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "HealthChecks", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("HealthChecks", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionHealthChecks().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaHealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaHealthChecks().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionHealthChecks().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaHealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaHealthChecks().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionHealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionHealthChecks().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga HealthCheck %v", key.Name)
-			return mc.Observe(gceCloud.Compute().HealthChecks().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().HealthChecks().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetHealthCheck(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*HealthCheck, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetHealthCheckWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetHealthCheckWithContext is like GetHealthCheck, with the span of the call a child of the span in ctx.
+func GetHealthCheckWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*HealthCheck, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("HealthCheck", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "HealthChecks", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -4503,45 +4638,53 @@ func GetHealthCheck(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionHealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionHealthChecks().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaHealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaHealthChecks().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionHealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionHealthChecks().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaHealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaHealthChecks().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionHealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionHealthChecks().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga HealthCheck %v", key.Name)
-			gceObj, err = gceCloud.Compute().HealthChecks().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().HealthChecks().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toHealthCheck(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("HealthChecks", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListHealthChecks(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*HealthCheck, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListHealthChecksWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListHealthChecksWithContext is like ListHealthChecks, with the span of the call a child of the span in ctx.
+func ListHealthChecksWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*HealthCheck, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("HealthCheck", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "HealthChecks", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -4550,32 +4693,32 @@ func ListHealthChecks(gceCloud *gce.Cloud, key *meta.Key, version meta.Version)
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region HealthCheck")
-			gceObjs, err = gceCloud.Compute().AlphaRegionHealthChecks().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionHealthChecks().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha HealthCheck")
-			gceObjs, err = gceCloud.Compute().AlphaHealthChecks().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaHealthChecks().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region HealthCheck")
-			gceObjs, err = gceCloud.Compute().BetaRegionHealthChecks().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionHealthChecks().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta HealthCheck")
-			gceObjs, err = gceCloud.Compute().BetaHealthChecks().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaHealthChecks().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region HealthCheck")
-			gceObjs, err = gceCloud.Compute().RegionHealthChecks().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionHealthChecks().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga HealthCheck")
-			gceObjs, err = gceCloud.Compute().HealthChecks().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().HealthChecks().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toHealthCheckList(gceObjs)
@@ -4862,9 +5005,17 @@ func (networkEndpoint *NetworkEndpoint) ToGA() (*compute.NetworkEndpoint, error)
 }
 
 func CreateNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateNetworkEndpointGroupWithContext(context.Background(), gceCloud, key, networkEndpointGroup)
+}
+
+// CreateNetworkEndpointGroupWithContext is like CreateNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func CreateNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, networkEndpointGroup *NetworkEndpointGroup) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "create", key.Region, key.Zone, string(networkEndpointGroup.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "create", key, networkEndpointGroup.Version)
+	defer span.End()
+	ac := audit.NewContext("NetworkEndpointGroups", audit.OperationCreate, key, networkEndpointGroup.Version).WithObject(networkEndpointGroup)
 	switch key.Type() {
 	case meta.Zonal:
 	default:
@@ -4878,28 +5029,36 @@ func CreateNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, networkEndpo
 			return err
 		}
 		klog.V(3).Infof("Creating alpha zonal NetworkEndpointGroup %v", alpha.Name)
-		return mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().Insert(ctx, key, alpha))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().Insert(callCtx, key, alpha))))
 	case meta.VersionBeta:
 		beta, err := networkEndpointGroup.ToBeta()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Creating beta zonal NetworkEndpointGroup %v", beta.Name)
-		return mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().Insert(ctx, key, beta))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().Insert(callCtx, key, beta))))
 	default:
 		ga, err := networkEndpointGroup.ToGA()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Creating ga zonal NetworkEndpointGroup %v", ga.Name)
-		return mc.Observe(gceCloud.Compute().NetworkEndpointGroups().Insert(ctx, key, ga))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().NetworkEndpointGroups().Insert(callCtx, key, ga))))
 	}
 }
 
 func DeleteNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteNetworkEndpointGroupWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteNetworkEndpointGroupWithContext is like DeleteNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func DeleteNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("NetworkEndpointGroups", audit.OperationDelete, key, version)
 	switch key.Type() {
 	case meta.Zonal:
 	default:
@@ -4909,20 +5068,27 @@ func DeleteNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, version meta
 	switch version {
 	case meta.VersionAlpha:
 		klog.V(3).Infof("Deleting alpha zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().Delete(callCtx, key))))
 	case meta.VersionBeta:
 		klog.V(3).Infof("Deleting beta zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().Delete(callCtx, key))))
 	default:
 		klog.V(3).Infof("Deleting ga zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().NetworkEndpointGroups().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().NetworkEndpointGroups().Delete(callCtx, key))))
 	}
 }
 
 func GetNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*NetworkEndpointGroup, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetNetworkEndpointGroupWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetNetworkEndpointGroupWithContext is like GetNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func GetNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*NetworkEndpointGroup, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -4934,17 +5100,17 @@ func GetNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, version meta.Ve
 	switch version {
 	case meta.VersionAlpha:
 		klog.V(3).Infof("Getting alpha zonal NetworkEndpointGroup %v", key.Name)
-		gceObj, err = gceCloud.Compute().AlphaNetworkEndpointGroups().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().AlphaNetworkEndpointGroups().Get(callCtx, key)
 	case meta.VersionBeta:
 		klog.V(3).Infof("Getting beta zonal NetworkEndpointGroup %v", key.Name)
-		gceObj, err = gceCloud.Compute().BetaNetworkEndpointGroups().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().BetaNetworkEndpointGroups().Get(callCtx, key)
 
 	default:
 		klog.V(3).Infof("Getting ga zonal NetworkEndpointGroup %v", key.Name)
-		gceObj, err = gceCloud.Compute().NetworkEndpointGroups().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().NetworkEndpointGroups().Get(callCtx, key)
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toNetworkEndpointGroup(gceObj)
 	if err != nil {
@@ -4952,13 +5118,21 @@ func GetNetworkEndpointGroup(gceCloud *gce.Cloud, key *meta.Key, version meta.Ve
 	}
 	compositeType.Scope = meta.Zonal
 	compositeType.Version = version
+	audit.ObserveGet("NetworkEndpointGroups", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListNetworkEndpointGroups(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*NetworkEndpointGroup, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListNetworkEndpointGroupsWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListNetworkEndpointGroupsWithContext is like ListNetworkEndpointGroups, with the span of the call a child of the span in ctx.
+func ListNetworkEndpointGroupsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*NetworkEndpointGroup, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -4970,16 +5144,16 @@ func ListNetworkEndpointGroups(gceCloud *gce.Cloud, key *meta.Key, version meta.
 	switch version {
 	case meta.VersionAlpha:
 		klog.V(3).Infof("Listing alpha zoneNetworkEndpointGroup")
-		gceObjs, err = gceCloud.Compute().AlphaNetworkEndpointGroups().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().AlphaNetworkEndpointGroups().List(callCtx, key.Zone, filter.None)
 	case meta.VersionBeta:
 		klog.V(3).Infof("Listing beta zoneNetworkEndpointGroup")
-		gceObjs, err = gceCloud.Compute().BetaNetworkEndpointGroups().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().BetaNetworkEndpointGroups().List(callCtx, key.Zone, filter.None)
 	default:
 		klog.V(3).Infof("Listing ga zoneNetworkEndpointGroup")
-		gceObjs, err = gceCloud.Compute().NetworkEndpointGroups().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().NetworkEndpointGroups().List(callCtx, key.Zone, filter.None)
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toNetworkEndpointGroupList(gceObjs)
@@ -4993,9 +5167,17 @@ func ListNetworkEndpointGroups(gceCloud *gce.Cloud, key *meta.Key, version meta.
 }
 
 func AttachNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsAttachEndpointsRequest) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return AttachNetworkEndpointsWithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// AttachNetworkEndpointsWithContext is like AttachNetworkEndpoints, with the span of the call a child of the span in ctx.
+func AttachNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsAttachEndpointsRequest) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "attach", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "attach", key, version)
+	defer span.End()
+	ac := audit.NewContext("NetworkEndpointGroups", "attach", key, version).WithObject(req)
 
 	switch key.Type() {
 	case meta.Zonal:
@@ -5010,28 +5192,36 @@ func AttachNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Ver
 			return err
 		}
 		klog.V(3).Infof("Attaching to alpha zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, alphareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().AttachNetworkEndpoints(callCtx, key, alphareq))))
 	case meta.VersionBeta:
 		betareq, err := req.ToBeta()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Attaching to beta zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, betareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().AttachNetworkEndpoints(callCtx, key, betareq))))
 	default:
 		gareq, err := req.ToGA()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Attaching to ga zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().NetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, gareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().NetworkEndpointGroups().AttachNetworkEndpoints(callCtx, key, gareq))))
 	}
 }
 
 func DetachNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsDetachEndpointsRequest) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DetachNetworkEndpointsWithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// DetachNetworkEndpointsWithContext is like DetachNetworkEndpoints, with the span of the call a child of the span in ctx.
+func DetachNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsDetachEndpointsRequest) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "detach", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "detach", key, version)
+	defer span.End()
+	ac := audit.NewContext("NetworkEndpointGroups", "detach", key, version).WithObject(req)
 
 	switch key.Type() {
 	case meta.Zonal:
@@ -5046,28 +5236,35 @@ func DetachNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Ver
 			return err
 		}
 		klog.V(3).Infof("Detaching from alpha zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, alphareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaNetworkEndpointGroups().DetachNetworkEndpoints(callCtx, key, alphareq))))
 	case meta.VersionBeta:
 		betareq, err := req.ToBeta()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Detaching from beta zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, betareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaNetworkEndpointGroups().DetachNetworkEndpoints(callCtx, key, betareq))))
 	default:
 		gareq, err := req.ToGA()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Detaching from ga zonal NetworkEndpointGroup %v", key.Name)
-		return mc.Observe(gceCloud.Compute().NetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, gareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().NetworkEndpointGroups().DetachNetworkEndpoints(callCtx, key, gareq))))
 	}
 }
 
 func ListNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsListEndpointsRequest) ([]*NetworkEndpointWithHealthStatus, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListNetworkEndpointsWithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// ListNetworkEndpointsWithContext is like ListNetworkEndpoints, with the span of the call a child of the span in ctx.
+func ListNetworkEndpointsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *NetworkEndpointGroupsListEndpointsRequest) ([]*NetworkEndpointWithHealthStatus, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -5085,24 +5282,24 @@ func ListNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Versi
 			return nil, reqerr
 		}
 		klog.V(3).Infof("Listing alpha zonal NetworkEndpointGroup %v", key.Name)
-		gceObjs, err = gceCloud.Compute().AlphaNetworkEndpointGroups().ListNetworkEndpoints(ctx, key, alphareq, filter.None)
+		gceObjs, err = gceCloud.Compute().AlphaNetworkEndpointGroups().ListNetworkEndpoints(callCtx, key, alphareq, filter.None)
 	case meta.VersionBeta:
 		betareq, reqerr := req.ToBeta()
 		if reqerr != nil {
 			return nil, reqerr
 		}
 		klog.V(3).Infof("Listing beta zonal NetworkEndpointGroup %v", key.Name)
-		gceObjs, err = gceCloud.Compute().BetaNetworkEndpointGroups().ListNetworkEndpoints(ctx, key, betareq, filter.None)
+		gceObjs, err = gceCloud.Compute().BetaNetworkEndpointGroups().ListNetworkEndpoints(callCtx, key, betareq, filter.None)
 	default:
 		gareq, reqerr := req.ToGA()
 		if reqerr != nil {
 			return nil, reqerr
 		}
 		klog.V(3).Infof("Listing ga zonal NetworkEndpointGroup %v", key.Name)
-		gceObjs, err = gceCloud.Compute().NetworkEndpointGroups().ListNetworkEndpoints(ctx, key, gareq, filter.None)
+		gceObjs, err = gceCloud.Compute().NetworkEndpointGroups().ListNetworkEndpoints(callCtx, key, gareq, filter.None)
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toNetworkEndpointWithHealthStatusList(gceObjs)
@@ -5116,9 +5313,16 @@ func ListNetworkEndpoints(gceCloud *gce.Cloud, key *meta.Key, version meta.Versi
 }
 
 func AggregatedListNetworkEndpointGroup(gceCloud *gce.Cloud, version meta.Version) (map[*meta.Key]*NetworkEndpointGroup, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return AggregatedListNetworkEndpointGroupWithContext(context.Background(), gceCloud, version)
+}
+
+// AggregatedListNetworkEndpointGroupWithContext is like AggregatedListNetworkEndpointGroup, with the span of the call a child of the span in ctx.
+func AggregatedListNetworkEndpointGroupWithContext(ctx context.Context, gceCloud *gce.Cloud, version meta.Version) (map[*meta.Key]*NetworkEndpointGroup, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("NetworkEndpointGroup", "aggregateList", "", "", string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "NetworkEndpointGroups", "aggregateList", meta.GlobalKey(""), version)
+	defer span.End()
 
 	compositeMap := make(map[*meta.Key]*NetworkEndpointGroup)
 	var gceObjs interface{}
@@ -5126,9 +5330,9 @@ func AggregatedListNetworkEndpointGroup(gceCloud *gce.Cloud, version meta.Versio
 	switch version {
 	case meta.VersionAlpha:
 		klog.V(3).Infof("Aggregate List of alpha zonal NetworkEndpointGroup")
-		alphaMap, err := gceCloud.Compute().AlphaNetworkEndpointGroups().AggregatedList(ctx, filter.None)
+		alphaMap, err := gceCloud.Compute().AlphaNetworkEndpointGroups().AggregatedList(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		alphaList := []*computealpha.NetworkEndpointGroup{}
@@ -5138,9 +5342,9 @@ func AggregatedListNetworkEndpointGroup(gceCloud *gce.Cloud, version meta.Versio
 		gceObjs = alphaList
 	case meta.VersionBeta:
 		klog.V(3).Infof("Aggregate List of beta zonal NetworkEndpointGroup")
-		betaMap, err := gceCloud.Compute().BetaNetworkEndpointGroups().AggregatedList(ctx, filter.None)
+		betaMap, err := gceCloud.Compute().BetaNetworkEndpointGroups().AggregatedList(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		betaList := []*computebeta.NetworkEndpointGroup{}
@@ -5150,9 +5354,9 @@ func AggregatedListNetworkEndpointGroup(gceCloud *gce.Cloud, version meta.Versio
 		gceObjs = betaList
 	default:
 		klog.V(3).Infof("Aggregate List of ga zonal NetworkEndpointGroup")
-		gaMap, err := gceCloud.Compute().NetworkEndpointGroups().AggregatedList(ctx, filter.None)
+		gaMap, err := gceCloud.Compute().NetworkEndpointGroups().AggregatedList(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		gaList := []*compute.NetworkEndpointGroup{}
@@ -5634,9 +5838,17 @@ func (networkEndpointWithHealthStatus *NetworkEndpointWithHealthStatus) ToGA() (
 }
 
 func CreateSslCertificate(gceCloud *gce.Cloud, key *meta.Key, sslCertificate *SslCertificate) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateSslCertificateWithContext(context.Background(), gceCloud, key, sslCertificate)
+}
+
+// CreateSslCertificateWithContext is like CreateSslCertificate, with the span of the call a child of the span in ctx.
+func CreateSslCertificateWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, sslCertificate *SslCertificate) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("SslCertificate", "create", key.Region, key.Zone, string(sslCertificate.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslCertificates", "create", key, sslCertificate.Version)
+	defer span.End()
+	ac := audit.NewContext("SslCertificates", audit.OperationCreate, key, sslCertificate.Version).WithObject(sslCertificate)
 
 	switch sslCertificate.Version {
 	case meta.VersionAlpha:
@@ -5648,10 +5860,10 @@ func CreateSslCertificate(gceCloud *gce.Cloud, key *meta.Key, sslCertificate *Ss
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region SslCertificate %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionSslCertificates().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionSslCertificates().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha SslCertificate %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaSslCertificates().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaSslCertificates().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := sslCertificate.ToBeta()
@@ -5662,10 +5874,10 @@ func CreateSslCertificate(gceCloud *gce.Cloud, key *meta.Key, sslCertificate *Ss
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region SslCertificate %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionSslCertificates().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionSslCertificates().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta SslCertificate %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaSslCertificates().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaSslCertificates().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := sslCertificate.ToGA()
@@ -5676,54 +5888,69 @@ func CreateSslCertificate(gceCloud *gce.Cloud, key *meta.Key, sslCertificate *Ss
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region SslCertificate %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionSslCertificates().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionSslCertificates().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga SslCertificate %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().SslCertificates().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().SslCertificates().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteSslCertificate(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteSslCertificateWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteSslCertificateWithContext is like DeleteSslCertificate, with the span of the call a child of the span in ctx.
+func DeleteSslCertificateWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("SslCertificate", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslCertificates", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("SslCertificates", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionSslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionSslCertificates().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaSslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaSslCertificates().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionSslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionSslCertificates().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaSslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaSslCertificates().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionSslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionSslCertificates().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga SslCertificate %v", key.Name)
-			return mc.Observe(gceCloud.Compute().SslCertificates().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().SslCertificates().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetSslCertificate(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*SslCertificate, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetSslCertificateWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetSslCertificateWithContext is like GetSslCertificate, with the span of the call a child of the span in ctx.
+func GetSslCertificateWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*SslCertificate, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("SslCertificate", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslCertificates", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -5732,45 +5959,53 @@ func GetSslCertificate(gceCloud *gce.Cloud, key *meta.Key, version meta.Version)
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionSslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionSslCertificates().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaSslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaSslCertificates().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionSslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionSslCertificates().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaSslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaSslCertificates().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionSslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionSslCertificates().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga SslCertificate %v", key.Name)
-			gceObj, err = gceCloud.Compute().SslCertificates().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().SslCertificates().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toSslCertificate(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("SslCertificates", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListSslCertificates(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*SslCertificate, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListSslCertificatesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListSslCertificatesWithContext is like ListSslCertificates, with the span of the call a child of the span in ctx.
+func ListSslCertificatesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*SslCertificate, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("SslCertificate", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "SslCertificates", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -5779,32 +6014,32 @@ func ListSslCertificates(gceCloud *gce.Cloud, key *meta.Key, version meta.Versio
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region SslCertificate")
-			gceObjs, err = gceCloud.Compute().AlphaRegionSslCertificates().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionSslCertificates().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha SslCertificate")
-			gceObjs, err = gceCloud.Compute().AlphaSslCertificates().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaSslCertificates().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region SslCertificate")
-			gceObjs, err = gceCloud.Compute().BetaRegionSslCertificates().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionSslCertificates().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta SslCertificate")
-			gceObjs, err = gceCloud.Compute().BetaSslCertificates().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaSslCertificates().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region SslCertificate")
-			gceObjs, err = gceCloud.Compute().RegionSslCertificates().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionSslCertificates().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga SslCertificate")
-			gceObjs, err = gceCloud.Compute().SslCertificates().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().SslCertificates().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toSslCertificateList(gceObjs)
@@ -5909,9 +6144,17 @@ func (sslCertificate *SslCertificate) ToGA() (*compute.SslCertificate, error) {
 }
 
 func CreateTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *TargetHttpProxy) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateTargetHttpProxyWithContext(context.Background(), gceCloud, key, targetHttpProxy)
+}
+
+// CreateTargetHttpProxyWithContext is like CreateTargetHttpProxy, with the span of the call a child of the span in ctx.
+func CreateTargetHttpProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *TargetHttpProxy) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpProxy", "create", key.Region, key.Zone, string(targetHttpProxy.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpProxies", "create", key, targetHttpProxy.Version)
+	defer span.End()
+	ac := audit.NewContext("TargetHttpProxies", audit.OperationCreate, key, targetHttpProxy.Version).WithObject(targetHttpProxy)
 
 	switch targetHttpProxy.Version {
 	case meta.VersionAlpha:
@@ -5923,10 +6166,10 @@ func CreateTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region TargetHttpProxy %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha TargetHttpProxy %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := targetHttpProxy.ToBeta()
@@ -5937,10 +6180,10 @@ func CreateTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region TargetHttpProxy %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta TargetHttpProxy %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := targetHttpProxy.ToGA()
@@ -5951,54 +6194,69 @@ func CreateTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpProxy *
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region TargetHttpProxy %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga TargetHttpProxy %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().TargetHttpProxies().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpProxies().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteTargetHttpProxyWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteTargetHttpProxyWithContext is like DeleteTargetHttpProxy, with the span of the call a child of the span in ctx.
+func DeleteTargetHttpProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpProxy", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpProxies", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("TargetHttpProxies", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpProxies().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpProxies().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga TargetHttpProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().TargetHttpProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpProxies().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*TargetHttpProxy, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetTargetHttpProxyWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetTargetHttpProxyWithContext is like GetTargetHttpProxy, with the span of the call a child of the span in ctx.
+func GetTargetHttpProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*TargetHttpProxy, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpProxy", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpProxies", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -6007,45 +6265,53 @@ func GetTargetHttpProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Version
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionTargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionTargetHttpProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaTargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaTargetHttpProxies().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionTargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionTargetHttpProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaTargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaTargetHttpProxies().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionTargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionTargetHttpProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga TargetHttpProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().TargetHttpProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().TargetHttpProxies().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toTargetHttpProxy(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("TargetHttpProxies", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListTargetHttpProxies(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*TargetHttpProxy, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListTargetHttpProxiesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListTargetHttpProxiesWithContext is like ListTargetHttpProxies, with the span of the call a child of the span in ctx.
+func ListTargetHttpProxiesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*TargetHttpProxy, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpProxy", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpProxies", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -6054,32 +6320,32 @@ func ListTargetHttpProxies(gceCloud *gce.Cloud, key *meta.Key, version meta.Vers
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().AlphaRegionTargetHttpProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionTargetHttpProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().AlphaTargetHttpProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaTargetHttpProxies().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().BetaRegionTargetHttpProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionTargetHttpProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().BetaTargetHttpProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaTargetHttpProxies().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().RegionTargetHttpProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionTargetHttpProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga TargetHttpProxy")
-			gceObjs, err = gceCloud.Compute().TargetHttpProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().TargetHttpProxies().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toTargetHttpProxyList(gceObjs)
@@ -6184,9 +6450,17 @@ func (targetHttpProxy *TargetHttpProxy) ToGA() (*compute.TargetHttpProxy, error)
 }
 
 func CreateTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateTargetHttpsProxyWithContext(context.Background(), gceCloud, key, targetHttpsProxy)
+}
+
+// CreateTargetHttpsProxyWithContext is like CreateTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func CreateTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy *TargetHttpsProxy) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpsProxy", "create", key.Region, key.Zone, string(targetHttpsProxy.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "create", key, targetHttpsProxy.Version)
+	defer span.End()
+	ac := audit.NewContext("TargetHttpsProxies", audit.OperationCreate, key, targetHttpsProxy.Version).WithObject(targetHttpsProxy)
 
 	switch targetHttpsProxy.Version {
 	case meta.VersionAlpha:
@@ -6198,10 +6472,10 @@ func CreateTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region TargetHttpsProxy %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha TargetHttpsProxy %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := targetHttpsProxy.ToBeta()
@@ -6212,10 +6486,10 @@ func CreateTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region TargetHttpsProxy %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta TargetHttpsProxy %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := targetHttpsProxy.ToGA()
@@ -6226,54 +6500,69 @@ func CreateTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, targetHttpsProxy
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region TargetHttpsProxy %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga TargetHttpsProxy %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpsProxies().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteTargetHttpsProxyWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteTargetHttpsProxyWithContext is like DeleteTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func DeleteTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpsProxy", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("TargetHttpsProxies", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionTargetHttpsProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaTargetHttpsProxies().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionTargetHttpsProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaTargetHttpsProxies().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionTargetHttpsProxies().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga TargetHttpsProxy %v", key.Name)
-			return mc.Observe(gceCloud.Compute().TargetHttpsProxies().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().TargetHttpsProxies().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*TargetHttpsProxy, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetTargetHttpsProxyWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetTargetHttpsProxyWithContext is like GetTargetHttpsProxy, with the span of the call a child of the span in ctx.
+func GetTargetHttpsProxyWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*TargetHttpsProxy, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpsProxy", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -6282,45 +6571,53 @@ func GetTargetHttpsProxy(gceCloud *gce.Cloud, key *meta.Key, version meta.Versio
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionTargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionTargetHttpsProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaTargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaTargetHttpsProxies().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionTargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionTargetHttpsProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaTargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaTargetHttpsProxies().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionTargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionTargetHttpsProxies().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga TargetHttpsProxy %v", key.Name)
-			gceObj, err = gceCloud.Compute().TargetHttpsProxies().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().TargetHttpsProxies().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toTargetHttpsProxy(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("TargetHttpsProxies", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListTargetHttpsProxies(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*TargetHttpsProxy, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListTargetHttpsProxiesWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListTargetHttpsProxiesWithContext is like ListTargetHttpsProxies, with the span of the call a child of the span in ctx.
+func ListTargetHttpsProxiesWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*TargetHttpsProxy, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("TargetHttpsProxy", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "TargetHttpsProxies", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -6329,32 +6626,32 @@ func ListTargetHttpsProxies(gceCloud *gce.Cloud, key *meta.Key, version meta.Ver
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().AlphaRegionTargetHttpsProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionTargetHttpsProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().AlphaTargetHttpsProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaTargetHttpsProxies().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().BetaRegionTargetHttpsProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionTargetHttpsProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().BetaTargetHttpsProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaTargetHttpsProxies().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().RegionTargetHttpsProxies().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionTargetHttpsProxies().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga TargetHttpsProxy")
-			gceObjs, err = gceCloud.Compute().TargetHttpsProxies().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().TargetHttpsProxies().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toTargetHttpsProxyList(gceObjs)
@@ -6459,9 +6756,17 @@ func (targetHttpsProxy *TargetHttpsProxy) ToGA() (*compute.TargetHttpsProxy, err
 }
 
 func CreateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return CreateUrlMapWithContext(context.Background(), gceCloud, key, urlMap)
+}
+
+// CreateUrlMapWithContext is like CreateUrlMap, with the span of the call a child of the span in ctx.
+func CreateUrlMapWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("UrlMap", "create", key.Region, key.Zone, string(urlMap.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "UrlMaps", "create", key, urlMap.Version)
+	defer span.End()
+	ac := audit.NewContext("UrlMaps", audit.OperationCreate, key, urlMap.Version).WithObject(urlMap)
 
 	switch urlMap.Version {
 	case meta.VersionAlpha:
@@ -6473,10 +6778,10 @@ func CreateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region UrlMap %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha UrlMap %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaUrlMaps().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaUrlMaps().Insert(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := urlMap.ToBeta()
@@ -6487,10 +6792,10 @@ func CreateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region UrlMap %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta UrlMap %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaUrlMaps().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaUrlMaps().Insert(callCtx, key, beta))))
 		}
 	default:
 		ga, err := urlMap.ToGA()
@@ -6501,18 +6806,26 @@ func CreateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region UrlMap %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().RegionUrlMaps().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionUrlMaps().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga UrlMap %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().UrlMaps().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().UrlMaps().Insert(callCtx, key, ga))))
 		}
 	}
 }
 
 func UpdateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return UpdateUrlMapWithContext(context.Background(), gceCloud, key, urlMap)
+}
+
+// UpdateUrlMapWithContext is like UpdateUrlMap, with the span of the call a child of the span in ctx.
+func UpdateUrlMapWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("UrlMap", "update", key.Region, key.Zone, string(urlMap.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "UrlMaps", "update", key, urlMap.Version)
+	defer span.End()
+	ac := audit.NewContext("UrlMaps", audit.OperationUpdate, key, urlMap.Version).WithObject(urlMap)
 	switch urlMap.Version {
 	case meta.VersionAlpha:
 		alpha, err := urlMap.ToAlpha()
@@ -6522,10 +6835,10 @@ func UpdateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating alpha region UrlMap %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Update(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Updating alpha UrlMap %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().AlphaUrlMaps().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaUrlMaps().Update(callCtx, key, alpha))))
 		}
 	case meta.VersionBeta:
 		beta, err := urlMap.ToBeta()
@@ -6535,10 +6848,10 @@ func UpdateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating beta region UrlMap %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Update(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Updating beta UrlMap %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().BetaUrlMaps().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaUrlMaps().Update(callCtx, key, beta))))
 		}
 	default:
 		ga, err := urlMap.ToGA()
@@ -6548,54 +6861,69 @@ func UpdateUrlMap(gceCloud *gce.Cloud, key *meta.Key, urlMap *UrlMap) error {
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating ga region UrlMap %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().RegionUrlMaps().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionUrlMaps().Update(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Updating ga UrlMap %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().UrlMaps().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().UrlMaps().Update(callCtx, key, ga))))
 		}
 	}
 }
 
 func DeleteUrlMap(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return DeleteUrlMapWithContext(context.Background(), gceCloud, key, version)
+}
+
+// DeleteUrlMapWithContext is like DeleteUrlMap, with the span of the call a child of the span in ctx.
+func DeleteUrlMapWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("UrlMap", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "UrlMaps", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("UrlMaps", audit.OperationDelete, key, version)
 
 	switch version {
 	case meta.VersionAlpha:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaRegionUrlMaps().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().AlphaUrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().AlphaUrlMaps().Delete(callCtx, key))))
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting beta region UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaRegionUrlMaps().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting beta UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().BetaUrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().BetaUrlMaps().Delete(callCtx, key))))
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().RegionUrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().RegionUrlMaps().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga UrlMap %v", key.Name)
-			return mc.Observe(gceCloud.Compute().UrlMaps().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().UrlMaps().Delete(callCtx, key))))
 		}
 	}
 }
 
 func GetUrlMap(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*UrlMap, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return GetUrlMapWithContext(context.Background(), gceCloud, key, version)
+}
+
+// GetUrlMapWithContext is like GetUrlMap, with the span of the call a child of the span in ctx.
+func GetUrlMapWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*UrlMap, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("UrlMap", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "UrlMaps", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -6604,45 +6932,53 @@ func GetUrlMap(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*UrlMa
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaRegionUrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaRegionUrlMaps().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting alpha UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().AlphaUrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().AlphaUrlMaps().Get(callCtx, key)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting beta region UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaRegionUrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaRegionUrlMaps().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting beta UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().BetaUrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().BetaUrlMaps().Get(callCtx, key)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting ga region UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().RegionUrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().RegionUrlMaps().Get(callCtx, key)
 		default:
 			klog.V(3).Infof("Getting ga UrlMap %v", key.Name)
-			gceObj, err = gceCloud.Compute().UrlMaps().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().UrlMaps().Get(callCtx, key)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := toUrlMap(gceObj)
 	if err != nil {
 		return nil, err
 	}
 	compositeType.Version = version
+	audit.ObserveGet("UrlMaps", key, version, compositeType)
 	return compositeType, nil
 }
 
 func ListUrlMaps(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*UrlMap, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return ListUrlMapsWithContext(context.Background(), gceCloud, key, version)
+}
+
+// ListUrlMapsWithContext is like ListUrlMaps, with the span of the call a child of the span in ctx.
+func ListUrlMapsWithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*UrlMap, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("UrlMap", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "UrlMaps", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -6651,32 +6987,32 @@ func ListUrlMaps(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*U
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region UrlMap")
-			gceObjs, err = gceCloud.Compute().AlphaRegionUrlMaps().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaRegionUrlMaps().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing alpha UrlMap")
-			gceObjs, err = gceCloud.Compute().AlphaUrlMaps().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().AlphaUrlMaps().List(callCtx, filter.None)
 		}
 	case meta.VersionBeta:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing beta region UrlMap")
-			gceObjs, err = gceCloud.Compute().BetaRegionUrlMaps().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaRegionUrlMaps().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing beta UrlMap")
-			gceObjs, err = gceCloud.Compute().BetaUrlMaps().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().BetaUrlMaps().List(callCtx, filter.None)
 		}
 	default:
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing ga region UrlMap")
-			gceObjs, err = gceCloud.Compute().RegionUrlMaps().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().RegionUrlMaps().List(callCtx, key.Region, filter.None)
 		default:
 			klog.V(3).Infof("Listing ga UrlMap")
-			gceObjs, err = gceCloud.Compute().UrlMaps().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().UrlMaps().List(callCtx, filter.None)
 		}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := toUrlMapList(gceObjs)
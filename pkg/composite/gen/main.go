@@ -76,6 +76,7 @@ limitations under the License.
 
 package composite
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/klog"
@@ -86,7 +87,9 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	cloudprovider "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"k8s.io/ingress-gce/pkg/audit"
 	compositemetrics "k8s.io/ingress-gce/pkg/composite/metrics"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/legacy-cloud-providers/gce"
 )
 `
@@ -219,9 +222,17 @@ func genFuncs(wr io.Writer) {
 	{{if .IsMainService}}
 		{{if .HasCRUD}}
 func Create{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return Create{{.Name}}WithContext(context.Background(), gceCloud, key, {{.VarName}})
+}
+
+// Create{{.Name}}WithContext is like Create{{.Name}}, with the span of the call a child of the span in ctx.
+func Create{{.Name}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "create", key.Region, key.Zone, string({{.VarName}}.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "create", key, {{.VarName}}.Version)
+	defer span.End()
+	ac := audit.NewContext("{{.GetCloudProviderName}}", audit.OperationCreate, key, {{.VarName}}.Version).WithObject({{.VarName}})
 
 	{{- if $onlyZonalKeySupported}}
 	switch key.Type() {
@@ -239,16 +250,16 @@ func Create{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Creating alpha zonal {{.Name}} %v", alpha.Name)
-		return mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Insert(ctx, key, alpha))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Insert(callCtx, key, alpha))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Creating alpha region {{.Name}} %v", alpha.Name)
 			alpha.Region = key.Region
-			return mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Creating alpha {{.Name}} %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, alpha))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	case meta.VersionBeta:
@@ -258,16 +269,16 @@ func Create{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Creating beta zonal {{.Name}} %v", beta.Name)
-		return mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Insert(ctx, key, beta))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Insert(callCtx, key, beta))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Creating beta region {{.Name}} %v", beta.Name)
 			beta.Region = key.Region
-			return mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Creating beta {{.Name}} %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, beta))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	default:
@@ -277,16 +288,16 @@ func Create{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Creating ga zonal {{.Name}} %v", ga.Name)
-		return mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Insert(ctx, key, ga))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Insert(callCtx, key, ga))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Creating ga region {{.Name}} %v", ga.Name)
 			ga.Region = key.Region
-			return mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Creating ga {{.Name}} %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Insert(callCtx, key, ga))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	}
@@ -294,9 +305,17 @@ func Create{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 
 {{if .HasUpdate}}
 func Update{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return Update{{.Name}}WithContext(context.Background(), gceCloud, key, {{.VarName}})
+}
+
+// Update{{.Name}}WithContext is like Update{{.Name}}, with the span of the call a child of the span in ctx.
+func Update{{.Name}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "update", key.Region, key.Zone, string({{.VarName}}.Version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "update", key, {{.VarName}}.Version)
+	defer span.End()
+	ac := audit.NewContext("{{.GetCloudProviderName}}", audit.OperationUpdate, key, {{.VarName}}.Version).WithObject({{.VarName}})
 
 	{{- if $onlyZonalKeySupported}}
 	switch key.Type() {
@@ -314,15 +333,15 @@ func Update{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Updating alpha zonal {{.Name}} %v", alpha.Name)
-		return mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Update(ctx, key, alpha))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Update(callCtx, key, alpha))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating alpha region {{.Name}} %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, alpha))))
 		default:
 			klog.V(3).Infof("Updating alpha {{.Name}} %v", alpha.Name)
-			return mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, alpha))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, alpha))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	case meta.VersionBeta:
@@ -332,15 +351,15 @@ func Update{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Updating beta zonal {{.Name}} %v", beta.Name)
-		return mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Update(ctx, key, beta))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Update(callCtx, key, beta))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 		  klog.V(3).Infof("Updating beta region {{.Name}} %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, beta))))
 		default:
 			klog.V(3).Infof("Updating beta {{.Name}} %v", beta.Name)
-			return mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, beta))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, beta))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	default:
@@ -350,15 +369,15 @@ func Update{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 		}
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Updating ga zonal {{.Name}} %v", ga.Name)
-		return mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Update(ctx, key, ga))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Update(callCtx, key, ga))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Updating ga region {{.Name}} %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, ga))))
 		default:
 			klog.V(3).Infof("Updating ga {{.Name}} %v", ga.Name)
-			return mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(ctx, key, ga))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Update(callCtx, key, ga))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	}
@@ -366,9 +385,17 @@ func Update{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, {{.VarName}} *{{.Name}}
 {{- end}} {{/*HasUpdate*/}}
 
 func Delete{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return Delete{{.Name}}WithContext(context.Background(), gceCloud, key, version)
+}
+
+// Delete{{.Name}}WithContext is like Delete{{.Name}}, with the span of the call a child of the span in ctx.
+func Delete{{.Name}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "delete", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "delete", key, version)
+	defer span.End()
+	ac := audit.NewContext("{{.GetCloudProviderName}}", audit.OperationDelete, key, version)
 
 	{{- if $onlyZonalKeySupported}}
 	switch key.Type() {
@@ -382,52 +409,59 @@ func Delete{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) e
 	case meta.VersionAlpha:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Deleting alpha zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Delete(callCtx, key))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting alpha region {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting alpha {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	case meta.VersionBeta:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Deleting beta zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().Delete(callCtx, key))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 		  klog.V(3).Infof("Deleting beta region {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		default:
 		  klog.V(3).Infof("Deleting beta {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	default:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Deleting ga zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Delete(ctx, key))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().Delete(callCtx, key))))
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Deleting ga region {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		default:
 			klog.V(3).Infof("Deleting ga {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(ctx, key))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Delete(callCtx, key))))
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	}
 }
 
 func Get{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*{{.Name}}, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return Get{{.Name}}WithContext(context.Background(), gceCloud, key, version)
+}
+
+// Get{{.Name}}WithContext is like Get{{.Name}}, with the span of the call a child of the span in ctx.
+func Get{{.Name}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*{{.Name}}, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "get", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "get", key, version)
+	defer span.End()
 
 	var gceObj interface{}
 	var err error
@@ -444,48 +478,48 @@ func Get{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*{{
 	case meta.VersionAlpha:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Getting alpha zonal {{.Name}} %v", key.Name)
-		gceObj, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().Get(callCtx, key)
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Getting alpha region {{.Name}} %v", key.Name)
-			gceObj, err = gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		default:
 		  	klog.V(3).Infof("Getting alpha {{.Name}} %v", key.Name)
-			gceObj, err = gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	case meta.VersionBeta:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Getting beta zonal {{.Name}} %v", key.Name)
-		gceObj, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().Get(callCtx, key)
 	{{else}}
 		switch key.Type() {
 		case meta.Regional:
 		  	klog.V(3).Infof("Getting beta region {{.Name}} %v", key.Name)
-			gceObj, err = gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		default:
 		  	klog.V(3).Infof("Getting beta {{.Name}} %v", key.Name)
-			gceObj, err = gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+			gceObj, err = gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	default:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Getting ga zonal {{.Name}} %v", key.Name)
-		gceObj, err = gceCloud.Compute().{{.GetCloudProviderName}}().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().{{.GetCloudProviderName}}().Get(callCtx, key)
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
       		klog.V(3).Infof("Getting ga region {{.Name}} %v", key.Name)
-		gceObj, err = gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		default:
       		klog.V(3).Infof("Getting ga {{.Name}} %v", key.Name)
-		gceObj, err = gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(ctx, key)
+		gceObj, err = gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().Get(callCtx, key)
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 	compositeType, err := to{{.Name}}(gceObj)
   	if err != nil {
@@ -500,13 +534,21 @@ func Get{{.Name}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) (*{{
 	}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
   	compositeType.Version = version
+	audit.ObserveGet("{{.GetCloudProviderName}}", key, version, compositeType)
   	return compositeType, nil
 }
 
 func List{{.GetCloudProviderName}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*{{.Name}}, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return List{{.GetCloudProviderName}}WithContext(context.Background(), gceCloud, key, version)
+}
+
+// List{{.GetCloudProviderName}}WithContext is like List{{.GetCloudProviderName}}, with the span of the call a child of the span in ctx.
+func List{{.GetCloudProviderName}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version) ([]*{{.Name}}, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -523,48 +565,48 @@ func List{{.GetCloudProviderName}}(gceCloud *gce.Cloud, key *meta.Key, version m
 	case meta.VersionAlpha:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Listing alpha zone{{.Name}}")
-		gceObjs, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().List(callCtx, key.Zone, filter.None)
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 			klog.V(3).Infof("Listing alpha region {{.Name}}")
-			gceObjs, err = gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().Alpha{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, key.Region, filter.None)
 		default:
 		  	klog.V(3).Infof("Listing alpha {{.Name}}")
-			gceObjs, err = gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().Alpha{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, filter.None)
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	case meta.VersionBeta:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Listing beta zone{{.Name}}")
-		gceObjs, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().List(callCtx, key.Zone, filter.None)
 	{{- else}}
 		switch key.Type() {
 		case meta.Regional:
 		  	klog.V(3).Infof("Listing beta region {{.Name}}")
-			gceObjs, err = gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().Beta{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, key.Region, filter.None)
 		default:
 		  	klog.V(3).Infof("Listing beta {{.Name}}")
-			gceObjs, err = gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().Beta{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, filter.None)
 		}
 	{{- end}} {{/* $onlyZonalKeySupported*/}}
 	default:
 	{{- if $onlyZonalKeySupported}}
 		klog.V(3).Infof("Listing ga zone{{.Name}}")
-		gceObjs, err = gceCloud.Compute().{{.GetCloudProviderName}}().List(ctx, key.Zone, filter.None)
+		gceObjs, err = gceCloud.Compute().{{.GetCloudProviderName}}().List(callCtx, key.Zone, filter.None)
     {{- else}}
 		switch key.Type() {
 		case meta.Regional:
  			klog.V(3).Infof("Listing ga region {{.Name}}")
-			gceObjs, err = gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, key.Region, filter.None)
+			gceObjs, err = gceCloud.Compute().{{$regionalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, key.Region, filter.None)
 		default:
  			klog.V(3).Infof("Listing ga {{.Name}}")
-			gceObjs, err = gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(ctx, filter.None)
+			gceObjs, err = gceCloud.Compute().{{$globalKeyFiller}}{{.GetCloudProviderName}}().List(callCtx, filter.None)
 		}
     {{- end}} {{/* $onlyZonalKeySupported*/}}
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := to{{.Name}}List(gceObjs)
@@ -579,9 +621,17 @@ func List{{.GetCloudProviderName}}(gceCloud *gce.Cloud, key *meta.Key, version m
 
 {{if .IsGroupResourceService}}
 func {{.GetGroupResourceInfo.AttachFuncName}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.AttachReqName}}) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return {{.GetGroupResourceInfo.AttachFuncName}}WithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// {{.GetGroupResourceInfo.AttachFuncName}}WithContext is like {{.GetGroupResourceInfo.AttachFuncName}}, with the span of the call a child of the span in ctx.
+func {{.GetGroupResourceInfo.AttachFuncName}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.AttachReqName}}) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "attach", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "attach", key, version)
+	defer span.End()
+	ac := audit.NewContext("{{.GetCloudProviderName}}", "attach", key, version).WithObject(req)
 
 	switch key.Type() {
 	case meta.Zonal:
@@ -596,28 +646,36 @@ func {{.GetGroupResourceInfo.AttachFuncName}}(gceCloud *gce.Cloud, key *meta.Key
 			return err
 		}
         klog.V(3).Infof("Attaching to alpha zonal {{.Name}} %v", key.Name)
-        return mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(ctx, key, alphareq))
+        return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(callCtx, key, alphareq))))
 	case meta.VersionBeta:
 		betareq, err := req.ToBeta()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Attaching to beta zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(ctx, key, betareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(callCtx, key, betareq))))
 	default:
 		gareq, err := req.ToGA()
 		if err != nil {
 			return err
 		}
 		  klog.V(3).Infof("Attaching to ga zonal {{.Name}} %v", key.Name)
-			return mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(ctx, key, gareq))
+			return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AttachFuncName}}(callCtx, key, gareq))))
 	}
 }
 
 func {{.GetGroupResourceInfo.DetachFuncName}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.DetachReqName}}) error {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return {{.GetGroupResourceInfo.DetachFuncName}}WithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// {{.GetGroupResourceInfo.DetachFuncName}}WithContext is like {{.GetGroupResourceInfo.DetachFuncName}}, with the span of the call a child of the span in ctx.
+func {{.GetGroupResourceInfo.DetachFuncName}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.DetachReqName}}) error {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "detach", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "detach", key, version)
+	defer span.End()
+	ac := audit.NewContext("{{.GetCloudProviderName}}", "detach", key, version).WithObject(req)
 
 	switch key.Type() {
 	case meta.Zonal:
@@ -632,28 +690,35 @@ func {{.GetGroupResourceInfo.DetachFuncName}}(gceCloud *gce.Cloud, key *meta.Key
 			return err
 		}
         klog.V(3).Infof("Detaching from alpha zonal {{.Name}} %v", key.Name)
-        return mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(ctx, key, alphareq))
+        return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(callCtx, key, alphareq))))
 	case meta.VersionBeta:
 		betareq, err := req.ToBeta()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Detaching from beta zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(ctx, key, betareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(callCtx, key, betareq))))
 	default:
 		gareq, err := req.ToGA()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Detaching from ga zonal {{.Name}} %v", key.Name)
-		return mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(ctx, key, gareq))
+		return ac.Observe(tracing.ObserveSpan(span, mc.Observe(gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.DetachFuncName}}(callCtx, key, gareq))))
 	}
 }
 
 func {{.GetGroupResourceInfo.ListFuncName}}(gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.ListReqName}}) ([]*{{.GetGroupResourceInfo.ListRespName}}, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return {{.GetGroupResourceInfo.ListFuncName}}WithContext(context.Background(), gceCloud, key, version, req)
+}
+
+// {{.GetGroupResourceInfo.ListFuncName}}WithContext is like {{.GetGroupResourceInfo.ListFuncName}}, with the span of the call a child of the span in ctx.
+func {{.GetGroupResourceInfo.ListFuncName}}WithContext(ctx context.Context, gceCloud *gce.Cloud, key *meta.Key, version meta.Version, req *{{.GetGroupResourceInfo.ListReqName}}) ([]*{{.GetGroupResourceInfo.ListRespName}}, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "list", key.Region, key.Zone, string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "list", key, version)
+	defer span.End()
 
 	var gceObjs interface{}
 	var err error
@@ -671,24 +736,24 @@ func {{.GetGroupResourceInfo.ListFuncName}}(gceCloud *gce.Cloud, key *meta.Key,
 			return nil, reqerr
 		}
         klog.V(3).Infof("Listing alpha zonal {{.Name}} %v", key.Name)
-        gceObjs, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(ctx, key, alphareq, filter.None)
+        gceObjs, err = gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(callCtx, key, alphareq, filter.None)
 	case meta.VersionBeta:
 		betareq, reqerr := req.ToBeta()
 		if reqerr != nil {
 			return nil, reqerr
 		}
 		klog.V(3).Infof("Listing beta zonal {{.Name}} %v", key.Name)
-		gceObjs, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(ctx, key, betareq, filter.None)
+		gceObjs, err = gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(callCtx, key, betareq, filter.None)
 	default:
 		gareq, reqerr := req.ToGA()
 		if reqerr != nil {
 			return nil, reqerr
 		}
 		  klog.V(3).Infof("Listing ga zonal {{.Name}} %v", key.Name)
-			gceObjs, err = gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(ctx, key, gareq, filter.None)
+			gceObjs, err = gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.ListFuncName}}(callCtx, key, gareq, filter.None)
 	}
 	if err != nil {
-		return nil, mc.Observe(err)
+		return nil, tracing.ObserveSpan(span, mc.Observe(err))
 	}
 
 	compositeObjs, err := to{{.GetGroupResourceInfo.ListRespName}}List(gceObjs)
@@ -702,9 +767,16 @@ func {{.GetGroupResourceInfo.ListFuncName}}(gceCloud *gce.Cloud, key *meta.Key,
 }
 
 func {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRespName}}(gceCloud *gce.Cloud, version meta.Version) (map[*meta.Key]*{{.GetGroupResourceInfo.AggListRespName}}, error) {
-	ctx, cancel := cloudprovider.ContextWithCallTimeout()
+	return {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRespName}}WithContext(context.Background(), gceCloud, version)
+}
+
+// {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRespName}}WithContext is like {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRespName}}, with the span of the call a child of the span in ctx.
+func {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRespName}}WithContext(ctx context.Context, gceCloud *gce.Cloud, version meta.Version) (map[*meta.Key]*{{.GetGroupResourceInfo.AggListRespName}}, error) {
+	callCtx, cancel := cloudprovider.ContextWithCallTimeout()
 	defer cancel()
 	mc := compositemetrics.NewMetricContext("{{.Name}}", "aggregateList", "", "", string(version))
+	callCtx, span := tracing.StartGCESpan(ctx, callCtx, "{{.GetCloudProviderName}}", "aggregateList", meta.GlobalKey(""), version)
+	defer span.End()
 
 	compositeMap := make(map[*meta.Key]*{{.GetGroupResourceInfo.AggListRespName}})
 	var gceObjs interface{}
@@ -712,9 +784,9 @@ func {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRes
 	switch version {
 	case meta.VersionAlpha:
 		klog.V(3).Infof("Aggregate List of alpha zonal {{.Name}}")
-		alphaMap, err := gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(ctx, filter.None)
+		alphaMap, err := gceCloud.Compute().Alpha{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		alphaList := []*computealpha.{{.GetGroupResourceInfo.AggListRespName}}{}
@@ -724,9 +796,9 @@ func {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRes
 		gceObjs = alphaList
 	case meta.VersionBeta:
 		klog.V(3).Infof("Aggregate List of beta zonal {{.Name}}")
-		betaMap, err := gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(ctx, filter.None)
+		betaMap, err := gceCloud.Compute().Beta{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		betaList := []*computebeta.{{.GetGroupResourceInfo.AggListRespName}}{}
@@ -736,9 +808,9 @@ func {{.GetGroupResourceInfo.AggListFuncName}}{{.GetGroupResourceInfo.AggListRes
 		gceObjs = betaList
 	default:
 		klog.V(3).Infof("Aggregate List of ga zonal {{.Name}}")
-		gaMap, err := gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(ctx, filter.None)
+		gaMap, err := gceCloud.Compute().{{.GetCloudProviderName}}().{{.GetGroupResourceInfo.AggListFuncName}}(callCtx, filter.None)
 		if err != nil {
-			return nil, mc.Observe(err)
+			return nil, tracing.ObserveSpan(span, mc.Observe(err))
 		}
 		// Convert from map to list
 		gaList := []*compute.{{.GetGroupResourceInfo.AggListRespName}}{}
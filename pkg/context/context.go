@@ -20,6 +20,7 @@ import (
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	informerv1 "k8s.io/client-go/informers/core/v1"
+	disinformer "k8s.io/client-go/informers/discovery/v1beta1"
 	informerv1beta1 "k8s.io/client-go/informers/networking/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -37,16 +39,22 @@ import (
 	"k8s.io/client-go/tools/record"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
 	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
+	backendgrantclient "k8s.io/ingress-gce/pkg/backendgrant/client/clientset/versioned"
+	informerbackendgrant "k8s.io/ingress-gce/pkg/backendgrant/client/informers/externalversions/backendgrant/v1alpha1"
 	"k8s.io/ingress-gce/pkg/cmconfig"
 	"k8s.io/ingress-gce/pkg/common/typed"
+	"k8s.io/ingress-gce/pkg/experimental/workload"
+	"k8s.io/ingress-gce/pkg/flags"
 	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 	informerfrontendconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/informers/externalversions/frontendconfig/v1beta1"
 	ingparamsclient "k8s.io/ingress-gce/pkg/ingparams/client/clientset/versioned"
 	informeringparams "k8s.io/ingress-gce/pkg/ingparams/client/informers/externalversions/ingparams/v1beta1"
+	lbfeatures "k8s.io/ingress-gce/pkg/loadbalancers/features"
 	"k8s.io/ingress-gce/pkg/metrics"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	informersvcneg "k8s.io/ingress-gce/pkg/svcneg/client/informers/externalversions/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -85,9 +93,17 @@ type ControllerContext struct {
 	SvcNegInformer          cache.SharedIndexInformer
 	IngClassInformer        cache.SharedIndexInformer
 	IngParamsInformer       cache.SharedIndexInformer
+	BackendGrantInformer    cache.SharedIndexInformer
+	// WorkloadEndpointSliceInformer watches the EndpointSlices of the
+	// Workloads. It is nil unless NEGs include Workloads.
+	WorkloadEndpointSliceInformer cache.SharedIndexInformer
 
 	ControllerMetrics *metrics.ControllerMetrics
 
+	// ProxyOnlySubnet tracks the proxy-only subnet used by regional L7 load
+	// balancers. It is nil if there is no cloud.
+	ProxyOnlySubnet *lbfeatures.ProxyOnlySubnet
+
 	healthChecks map[string]func() error
 
 	lock sync.Mutex
@@ -102,11 +118,17 @@ type ControllerContextConfig struct {
 	ResyncPeriod time.Duration
 	// DefaultBackendSvcPortID is the ServicePort for the system default backend.
 	DefaultBackendSvcPort utils.ServicePort
+	// ACMESolverSvcPort is the ServicePort that serves the HTTP-01 challenges
+	// of Ingresses with ACME issued certificates, nil if ACME is disabled.
+	ACMESolverSvcPort     *utils.ServicePortID
 	HealthCheckPath       string
 	FrontendConfigEnabled bool
 	EnableASMConfigMap    bool
 	ASMConfigMapNamespace string
 	ASMConfigMapName      string
+	// GCPRESTConfig configures the clients of the Google Cloud APIs which
+	// are not part of the compute API. It is nil if there is no cloud.
+	GCPRESTConfig *gcprest.Config
 }
 
 // NewControllerContext returns a new shared set of informers.
@@ -117,6 +139,7 @@ func NewControllerContext(
 	frontendConfigClient frontendconfigclient.Interface,
 	svcnegClient svcnegclient.Interface,
 	ingParamsClient ingparamsclient.Interface,
+	backendGrantClient backendgrantclient.Interface,
 	cloud *gce.Cloud,
 	clusterNamer *namer.Namer,
 	kubeSystemUID types.UID,
@@ -152,6 +175,20 @@ func NewControllerContext(
 		context.IngParamsInformer = informeringparams.NewGCPIngressParamsInformer(ingParamsClient, config.ResyncPeriod, utils.NewNamespaceIndexer())
 	}
 
+	if cloud != nil {
+		context.ProxyOnlySubnet = lbfeatures.NewProxyOnlySubnet(cloud, cloud.NetworkURL(), cloud.Region())
+	}
+
+	if backendGrantClient != nil {
+		context.BackendGrantInformer = informerbackendgrant.NewBackendGrantInformer(backendGrantClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
+
+	if flags.F.EnableNegWorkloads {
+		context.WorkloadEndpointSliceInformer = disinformer.NewFilteredEndpointSliceInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer(), func(options *metav1.ListOptions) {
+			options.LabelSelector = fmt.Sprintf("%s=%s", discovery.LabelManagedBy, workload.EndpointSliceManagedBy)
+		})
+	}
+
 	return context
 }
 
@@ -259,6 +296,13 @@ func (ctx *ControllerContext) HasSynced() bool {
 		funcs = append(funcs, ctx.IngParamsInformer.HasSynced)
 	}
 
+	if ctx.BackendGrantInformer != nil {
+		funcs = append(funcs, ctx.BackendGrantInformer.HasSynced)
+	}
+	if ctx.WorkloadEndpointSliceInformer != nil {
+		funcs = append(funcs, ctx.WorkloadEndpointSliceInformer.HasSynced)
+	}
+
 	for _, f := range funcs {
 		if !f() {
 			return false
@@ -338,6 +382,15 @@ func (ctx *ControllerContext) Start(stopCh chan struct{}) {
 	if ctx.IngParamsInformer != nil {
 		go ctx.IngParamsInformer.Run(stopCh)
 	}
+	if ctx.BackendGrantInformer != nil {
+		go ctx.BackendGrantInformer.Run(stopCh)
+	}
+	if ctx.WorkloadEndpointSliceInformer != nil {
+		go ctx.WorkloadEndpointSliceInformer.Run(stopCh)
+	}
+	if ctx.ProxyOnlySubnet != nil && (flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional) {
+		go ctx.ProxyOnlySubnet.Run(ctx.ResyncPeriod, stopCh)
+	}
 	// Export ingress usage metrics.
 	go ctx.ControllerMetrics.Run(stopCh)
 }
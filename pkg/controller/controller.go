@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	gocontext "context"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -24,6 +25,7 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +36,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/common/operator"
@@ -49,6 +52,7 @@ import (
 	"k8s.io/ingress-gce/pkg/metrics"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	ingsync "k8s.io/ingress-gce/pkg/sync"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
@@ -92,6 +96,8 @@ type LoadBalancerController struct {
 
 	// Ingress usage metrics.
 	metrics metrics.IngressMetricsCollector
+	// certExpiryWarnings are the certificate expiry warnings emitted.
+	certExpiryWarnings *certExpiryWarnings
 
 	ingClassLister  cache.Indexer
 	ingParamsLister cache.Indexer
@@ -120,11 +126,13 @@ func NewLoadBalancerController(
 		hasSynced:     ctx.HasSynced,
 		nodes:         NewNodeController(ctx, instancePool),
 		instancePool:  instancePool,
-		l7Pool:        loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID)),
-		backendSyncer: backends.NewBackendSyncer(backendPool, healthChecker, ctx.Cloud),
+		l7Pool:        loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.GCPRESTConfig, ctx.ClusterNamer, ctx, namer.NewFrontendNamerFactory(ctx.ClusterNamer, ctx.KubeSystemUID)),
+		backendSyncer: backends.NewBackendSyncer(backendPool, healthChecker, ctx.Cloud, ctx.GCPRESTConfig),
 		negLinker:     backends.NewNEGLinker(backendPool, negtypes.NewAdapter(ctx.Cloud), ctx.Cloud),
 		igLinker:      backends.NewInstanceGroupLinker(instancePool, backendPool),
 		metrics:       ctx.ControllerMetrics,
+
+		certExpiryWarnings: newCertExpiryWarnings(),
 	}
 
 	if ctx.IngClassInformer != nil {
@@ -292,9 +300,55 @@ func NewLoadBalancerController(
 		})
 	}
 
+	// Retry regional Ingresses when the proxy-only subnet changes.
+	if ctx.ProxyOnlySubnet != nil && (flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional) {
+		ctx.ProxyOnlySubnet.AddHandler(func() {
+			ings := operator.Ingresses(ctx.Ingresses().List()).Filter(utils.IsGCEL7RegionalIngress).AsList()
+			lbc.ingQueue.Enqueue(convert(ings)...)
+		})
+	}
+
+	// BackendGrant event handlers.
+	if ctx.BackendGrantInformer != nil {
+		ctx.BackendGrantInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				grant := obj.(*backendgrantv1alpha1.BackendGrant)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServiceInNamespace(grant.Namespace).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					grant := cur.(*backendgrantv1alpha1.BackendGrant)
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServiceInNamespace(grant.Namespace).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				grant, ok := obj.(*backendgrantv1alpha1.BackendGrant)
+				if !ok {
+					// This can happen if the watch is closed and misses the delete event
+					state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+					if !stateOk {
+						klog.Errorf("Wanted cache.DeleteFinalStateUnknown of backendgrant obj, got: %+v type: %T", obj, obj)
+						return
+					}
+
+					grant, ok = state.Obj.(*backendgrantv1alpha1.BackendGrant)
+					if !ok {
+						klog.Errorf("Wanted backendgrant obj, got %+v, type %T", state.Obj, state.Obj)
+						return
+					}
+				}
+
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesServiceInNamespace(grant.Namespace).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+	}
+
 	// Register health check on controller context.
 	ctx.AddHealthCheck("ingress", func() error {
-		_, err := backendPool.Get("k8s-ingress-svc-acct-permission-check-probe", meta.VersionGA, meta.Global)
+		_, err := backendPool.Get(gocontext.Background(), "k8s-ingress-svc-acct-permission-check-probe", meta.VersionGA, meta.Global)
 
 		// If this container is scheduled on a node without compute/rw it is
 		// effectively useless, but it is healthy. Reporting it as unhealthy
@@ -359,7 +413,7 @@ func (lbc *LoadBalancerController) Stop(deleteAll bool) error {
 }
 
 // SyncBackends implements Controller.
-func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
+func (lbc *LoadBalancerController) SyncBackends(ctx gocontext.Context, state interface{}) error {
 	// We expect state to be a syncState
 	syncState, ok := state.(*syncState)
 	if !ok {
@@ -378,7 +432,7 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
 	}
 
 	// Sync the backends
-	if err := lbc.backendSyncer.Sync(ingSvcPorts); err != nil {
+	if err := lbc.backendSyncer.Sync(ctx, ingSvcPorts); err != nil {
 		return err
 	}
 
@@ -396,12 +450,12 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
 	// Link backends to groups.
 	for _, sp := range ingSvcPorts {
 		var linkErr error
-		if sp.NEGEnabled {
+		if sp.NEGEnabled || sp.ExternalNEG != nil {
 			// Link backend to NEG's if the backend has NEG enabled.
-			linkErr = lbc.negLinker.Link(sp, groupKeys)
+			linkErr = lbc.negLinker.Link(ctx, sp, groupKeys)
 		} else {
 			// Otherwise, link backend to IG's.
-			linkErr = lbc.igLinker.Link(sp, groupKeys)
+			linkErr = lbc.igLinker.Link(ctx, sp, groupKeys)
 		}
 		if linkErr != nil {
 			return linkErr
@@ -470,7 +524,7 @@ func (lbc *LoadBalancerController) GCBackends(toKeep []*v1beta1.Ingress) error {
 }
 
 // SyncLoadBalancer implements Controller.
-func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}) error {
+func (lbc *LoadBalancerController) SyncLoadBalancer(ctx gocontext.Context, state interface{}) error {
 	// We expect state to be a syncState
 	syncState, ok := state.(*syncState)
 	if !ok {
@@ -481,6 +535,7 @@ func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}) error {
 	if err != nil {
 		return err
 	}
+	lb.TraceContext = ctx
 
 	// Create higher-level LB resources.
 	l7, err := lbc.l7Pool.Ensure(lb)
@@ -533,7 +588,7 @@ func (lbc *LoadBalancerController) EnsureDeleteV2Finalizer(ing *v1beta1.Ingress)
 }
 
 // PostProcess implements Controller.
-func (lbc *LoadBalancerController) PostProcess(state interface{}) error {
+func (lbc *LoadBalancerController) PostProcess(ctx gocontext.Context, state interface{}) error {
 	// We expect state to be a syncState
 	syncState, ok := state.(*syncState)
 	if !ok {
@@ -545,18 +600,36 @@ func (lbc *LoadBalancerController) PostProcess(state interface{}) error {
 }
 
 // sync manages Ingress create/updates/deletes events from queue.
-func (lbc *LoadBalancerController) sync(key string) error {
+func (lbc *LoadBalancerController) sync(key string) (err error) {
 	if !lbc.hasSynced() {
 		time.Sleep(context.StoreSyncPollPeriod)
 		return fmt.Errorf("waiting for stores to sync")
 	}
 	klog.V(3).Infof("Syncing %v", key)
 
+	traceCtx, span := tracing.StartSpan(gocontext.Background(), "LoadBalancerController.sync", trace.StringAttribute(tracing.KeyAttribute, key))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	ing, ingExists, err := lbc.ctx.Ingresses().GetByKey(key)
 	if err != nil {
 		return fmt.Errorf("error getting Ingress for key %s: %v", key, err)
 	}
 
+	// Skip all GCE mutations, including garbage collection, while
+	// reconciliation of the ingress is paused. Deletion of the ingress and
+	// removal of its finalizer proceed.
+	if ingExists && !common.IsDeletionCandidate(ing.ObjectMeta) {
+		paused := annotations.FromIngress(ing).PauseReconciliation()
+		ingClient := lbc.ctx.KubeClient.NetworkingV1beta1().Ingresses(ing.Namespace)
+		if ing, err = common.EnsureIngressPausedStatus(ing, ingClient, lbc.ctx.Recorder(ing.Namespace), paused); err != nil {
+			return err
+		}
+		if paused {
+			klog.V(2).Infof("Skipping sync of %v, reconciliation is paused", key)
+			return nil
+		}
+	}
+
 	// Capture GC state for ingress.
 	allIngresses := lbc.ctx.Ingresses().List()
 	scope := features.ScopeFromIngress(ing)
@@ -575,6 +648,9 @@ func (lbc *LoadBalancerController) sync(key string) error {
 		if err == nil && ingExists {
 			lbc.metrics.DeleteIngress(key)
 		}
+		if err == nil {
+			lbc.certExpiryWarnings.update(key, nil)
+		}
 		return err
 	}
 
@@ -594,10 +670,37 @@ func (lbc *LoadBalancerController) sync(key string) error {
 		return msg
 	}
 
+	// Check for scope change GC
+	var oldScope *meta.KeyType
+	if flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional {
+		oldScope, err = lbc.l7Pool.FrontendScopeChangeGC(ing)
+		if err != nil {
+			return err
+		}
+		// The regional resources of L7-ILB and regional XLB have the same
+		// names, so the previous load balancer is deleted before the sync.
+		if oldScope != nil && *oldScope == scope {
+			klog.V(2).Infof("Deleting regional load balancer of %v before changing its load balancing scheme", key)
+			if err := lbc.l7Pool.GCv2(ing, scope); err != nil {
+				return err
+			}
+			oldScope = nil
+		}
+	}
+
+	// Regional load balancers are not created until a proxy-only subnet
+	// exists, their forwarding rules can not be created without one.
+	var subnetReady bool
+	if ing, subnetReady, err = lbc.ensureProxyOnlySubnet(ing); err != nil {
+		return err
+	}
+
 	// Sync GCP resources.
 	syncState := &syncState{urlMap, ing, nil}
-	syncErr := lbc.ingSyncer.Sync(syncState)
-	if syncErr != nil {
+	var syncErr error
+	if !subnetReady {
+		syncErr = fmt.Errorf("proxy-only subnet required by %v not found in network %s region %s", key, lbc.ctx.ProxyOnlySubnet.Network(), lbc.ctx.ProxyOnlySubnet.Region())
+	} else if syncErr = lbc.ingSyncer.Sync(traceCtx, syncState); syncErr != nil {
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, "Error syncing to GCP: %v", syncErr.Error())
 	} else {
 		// Insert/update the ingress state for metrics after successful sync.
@@ -611,16 +714,8 @@ func (lbc *LoadBalancerController) sync(key string) error {
 		lbc.metrics.SetIngress(key, metrics.NewIngressState(ing, fc, urlMap.AllServicePorts()))
 	}
 
-	// Check for scope change GC
-	var oldScope *meta.KeyType
-	if flags.F.EnableL7Ilb {
-		oldScope, err = lbc.l7Pool.FrontendScopeChangeGC(ing)
-		if err != nil {
-			return err
-		}
-		if oldScope != nil {
-			scope = *oldScope
-		}
+	if oldScope != nil {
+		scope = *oldScope
 	}
 
 	// Garbage collection will occur regardless of an error occurring. If an error occurred,
@@ -635,6 +730,27 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	return syncErr
 }
 
+// ensureProxyOnlySubnet records on a regional Ingress whether the proxy-only
+// subnet exists. Returns the updated Ingress and whether the Ingress can be
+// synced, which is always true for Ingresses that do not need the subnet.
+func (lbc *LoadBalancerController) ensureProxyOnlySubnet(ing *v1beta1.Ingress) (*v1beta1.Ingress, bool, error) {
+	subnet := lbc.ctx.ProxyOnlySubnet
+	if subnet == nil || !utils.IsGCEL7RegionalIngress(ing) {
+		return ing, true, nil
+	}
+	_, err := subnet.SourceRange()
+	if err != nil && err != features.ErrSubnetNotFound {
+		return nil, false, err
+	}
+	ready := err == nil
+	ingClient := lbc.ctx.KubeClient.NetworkingV1beta1().Ingresses(ing.Namespace)
+	ing, err = common.EnsureIngressProxyOnlySubnetStatus(ing, ingClient, lbc.ctx.Recorder(ing.Namespace), subnet.Network(), subnet.Region(), ready)
+	if err != nil {
+		return nil, false, err
+	}
+	return ing, ready, nil
+}
+
 // updateIngressStatus updates the IP and annotations of a loadbalancer.
 // The annotations are parsed by kubectl describe.
 func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing *v1beta1.Ingress) error {
@@ -688,9 +804,11 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1beta1.Ingress, urlMap *u
 			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, msg)
 		} else {
 			klog.Errorf("Could not get certificates for ingress %s/%s: %v", ing.Namespace, ing.Name, err)
+			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, "Error reading TLS certificates: %v", err)
 			return nil, err
 		}
 	}
+	lbc.checkCertificateExpiry(ing, tls)
 
 	var feConfig *frontendconfigv1beta1.FrontendConfig
 	if lbc.ctx.FrontendConfigEnabled {
@@ -703,22 +821,59 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *v1beta1.Ingress, urlMap *u
 		feConfig = feConfig.DeepCopy()
 	}
 
+	trustBundle, err := translator.GetClientTrustBundle(lbc.ctx.KubeClient, feConfig)
+	if err != nil {
+		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.SyncIngress, "Error reading client TLS trust bundle: %v", err)
+		return nil, err
+	}
+
 	staticIPName, err := annotations.StaticIPName()
 	if err != nil {
 		return nil, err
 	}
 
 	return &loadbalancers.L7RuntimeInfo{
-		TLS:            tls,
-		TLSName:        annotations.UseNamedTLS(),
-		Ingress:        ing,
-		AllowHTTP:      annotations.AllowHTTP(),
-		StaticIPName:   staticIPName,
-		UrlMap:         urlMap,
-		FrontendConfig: feConfig,
+		TLS:               tls,
+		TLSName:           annotations.UseNamedTLS(),
+		Ingress:           ing,
+		AllowHTTP:         annotations.AllowHTTP(),
+		StaticIPName:      staticIPName,
+		UrlMap:            urlMap,
+		FrontendConfig:    feConfig,
+		ClientTrustBundle: trustBundle,
+		CertificateMap:    annotations.CertificateMap(),
 	}, nil
 }
 
+// checkCertificateExpiry exports the expiry of the certificates of the
+// ingress and warns about those which expire within one of the configured
+// thresholds. A certificate is warned about once per threshold it crosses,
+// and once when it expires.
+func (lbc *LoadBalancerController) checkCertificateExpiry(ing *v1beta1.Ingress, certs []*translator.TLSCerts) {
+	now := time.Now()
+	notAfter := make(map[string]time.Time)
+	warnings := make(map[string]certExpiryWarning)
+	for _, cert := range certs {
+		notAfter[cert.Name] = cert.NotAfter
+		if !cert.NotAfter.After(now) {
+			warnings[cert.Name] = certExpiryWarning{notAfter: cert.NotAfter}
+		} else if threshold, ok := translator.ExpiryWarningThreshold(cert.NotAfter, now, flags.F.CertExpiryWarningThresholds); ok {
+			warnings[cert.Name] = certExpiryWarning{notAfter: cert.NotAfter, threshold: threshold}
+		}
+	}
+	key := common.IngressKeyFunc(ing)
+	for _, name := range lbc.certExpiryWarnings.update(key, warnings) {
+		warning := warnings[name]
+		expiry := warning.notAfter.UTC().Format(time.RFC3339)
+		if warning.threshold == 0 {
+			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.CertificateExpiring, "Certificate of Secret %q expired at %v", name, expiry)
+		} else {
+			lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, events.CertificateExpiring, "Certificate of Secret %q expires within %v, at %v", name, warning.threshold, expiry)
+		}
+	}
+	lbc.metrics.SetIngressCertificates(key, notAfter)
+}
+
 func updateAnnotations(client kubernetes.Interface, ing *v1beta1.Ingress, newAnnotations map[string]string) error {
 	if reflect.DeepEqual(ing.Annotations, newAnnotations) {
 		return nil
@@ -18,6 +18,8 @@ package controller
 
 import (
 	context2 "context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -27,7 +29,9 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
@@ -44,6 +48,7 @@ import (
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/instances"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
+	"k8s.io/ingress-gce/pkg/loadbalancers/features"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -74,11 +79,11 @@ func newLoadBalancerController() *LoadBalancerController {
 		DefaultBackendSvcPort: test.DefaultBeSvcPort,
 		HealthCheckPath:       "/",
 	}
-	ctx := context.NewControllerContext(nil, kubeClient, backendConfigClient, nil, nil, nil, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig)
+	ctx := context.NewControllerContext(nil, kubeClient, backendConfigClient, nil, nil, nil, nil, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig)
 	lbc := NewLoadBalancerController(ctx, stopCh)
 	// TODO(rramkumar): Fix this so we don't have to override with our fake
 	lbc.instancePool = instances.NewNodePool(instances.NewFakeInstanceGroups(sets.NewString(), namer), namer, &test.FakeRecorderSource{})
-	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, ""))
+	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(fakeGCE, nil, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, ""))
 	lbc.instancePool.Init(&instances.FakeZoneLister{Zones: []string{fakeZone}})
 
 	lbc.hasSynced = func() bool { return true }
@@ -334,6 +339,120 @@ func TestIngressCreateDeleteFinalizer(t *testing.T) {
 	}
 }
 
+// TestPausedIngress asserts that `sync` does not touch the load balancer of an
+// ingress while its reconciliation is paused, but still deletes it and
+// removes the finalizer when the ingress is deleted.
+// Note: This test cannot be run in parallel as it stubs global flags.
+func TestPausedIngress(t *testing.T) {
+	flagSaver := test.NewFlagSaver()
+	flagSaver.Save(test.FinalizerAddFlag, &flags.F.FinalizerAdd)
+	defer flagSaver.Reset(test.FinalizerAddFlag, &flags.F.FinalizerAdd)
+	flagSaver.Save(test.FinalizerRemoveFlag, &flags.F.FinalizerRemove)
+	defer flagSaver.Reset(test.FinalizerRemoveFlag, &flags.F.FinalizerRemove)
+	flags.F.FinalizerAdd = true
+	flags.F.FinalizerRemove = true
+
+	lbc := newLoadBalancerController()
+	ing := ensureIngress(t, lbc, "default", "ing-1", namer_util.V2NamingScheme)
+	ingStoreKey := getKey(ing, t)
+	urlMapName := ing.Annotations[annotations.UrlMapKey]
+	if _, err := lbc.ctx.Cloud.GetURLMap(urlMapName); err != nil {
+		t.Fatalf("GetURLMap(%q) = %v, want nil", urlMapName, err)
+	}
+
+	// Removing the backends of a paused ingress must not touch its load
+	// balancer.
+	ing.Annotations[annotations.PauseReconciliationKey] = "true"
+	ing.Spec.Backend = nil
+	ing.Spec.Rules = nil
+	updateIngress(lbc, ing)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", ingStoreKey, err)
+	}
+	ing = getUpdatedIngress(t, lbc, ing)
+	if _, ok := ing.Annotations[annotations.PausedKey]; !ok {
+		t.Errorf("Paused ingress has annotations %v, want %q", ing.Annotations, annotations.PausedKey)
+	}
+	if _, err := lbc.ctx.Cloud.GetURLMap(urlMapName); err != nil {
+		t.Errorf("GetURLMap(%q) = %v, want nil for paused ingress", urlMapName, err)
+	}
+
+	// Deleting a paused ingress deletes its load balancer and removes the
+	// finalizer.
+	setDeletionTimestamp(lbc, ing)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", ingStoreKey, err)
+	}
+	ing = getUpdatedIngress(t, lbc, ing)
+	if len(ing.GetFinalizers()) != 0 {
+		t.Errorf("GetFinalizers() = %+v, want 0", ing.GetFinalizers())
+	}
+	if _, err := lbc.ctx.Cloud.GetURLMap(urlMapName); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("GetURLMap(%q) = %v, want not found", urlMapName, err)
+	}
+}
+
+// TestProxyOnlySubnetMissing asserts that `sync` does not create the load
+// balancer of a regional ingress until the proxy-only subnet exists.
+// Note: This test cannot be run in parallel as it stubs global flags.
+func TestProxyOnlySubnetMissing(t *testing.T) {
+	flags.F.EnableL7Ilb = true
+	defer func() {
+		flags.F.EnableL7Ilb = false
+	}()
+
+	lbc := newLoadBalancerController()
+	// The fake cloud has no network URL.
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	lbc.ctx.ProxyOnlySubnet = features.NewProxyOnlySubnet(lbc.ctx.Cloud, network, lbc.ctx.Cloud.Region())
+	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	addService(lbc, svc)
+	someBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "ilb-ingress", Namespace: "default"},
+		v1beta1.IngressSpec{
+			Backend: &someBackend,
+		})
+	ing.Annotations = map[string]string{annotations.IngressClassKey: annotations.GceL7ILBIngressClass}
+	addIngress(lbc, ing)
+	ingStoreKey := getKey(ing, t)
+
+	err := lbc.sync(ingStoreKey)
+	if err == nil || !strings.Contains(err.Error(), "proxy-only subnet") {
+		t.Fatalf("lbc.sync(%v) = %v, want proxy-only subnet error", ingStoreKey, err)
+	}
+	ing = getUpdatedIngress(t, lbc, ing)
+	if got := ing.Annotations[annotations.ProxyOnlySubnetReadyKey]; got != "False" {
+		t.Errorf("Annotation %q = %q, want %q", annotations.ProxyOnlySubnetReadyKey, got, "False")
+	}
+
+	// Creating the subnet unblocks the sync.
+	subnet := &computebeta.Subnetwork{
+		Name:        "proxy-only",
+		Network:     network,
+		IpCidrRange: "10.129.0.0/23",
+		Purpose:     "REGIONAL_MANAGED_PROXY",
+		Role:        "ACTIVE",
+	}
+	key := meta.RegionalKey(subnet.Name, lbc.ctx.Cloud.Region())
+	if err := lbc.ctx.Cloud.Compute().BetaSubnetworks().Insert(context2.TODO(), key, subnet); err != nil {
+		t.Fatalf("Insert(%v) = %v, want nil", key, err)
+	}
+	if !lbc.ctx.ProxyOnlySubnet.Refresh() {
+		t.Fatalf("ProxyOnlySubnet.Refresh() = false, want true")
+	}
+	updateIngress(lbc, ing)
+	if err := lbc.sync(ingStoreKey); err != nil && strings.Contains(err.Error(), "proxy-only subnet") {
+		t.Errorf("lbc.sync(%v) = %v, want no proxy-only subnet error", ingStoreKey, err)
+	}
+	ing = getUpdatedIngress(t, lbc, ing)
+	if got := ing.Annotations[annotations.ProxyOnlySubnetReadyKey]; got != "True" {
+		t.Errorf("Annotation %q = %q, want %q", annotations.ProxyOnlySubnetReadyKey, got, "True")
+	}
+}
+
 // TestIngressClassChangeWithFinalizer asserts that `sync` will not return an error for
 // a good ingress config status is updated and LB is deleted after class change.
 // Note: This test cannot be run in parallel as it stubs global flags.
@@ -346,6 +465,9 @@ func TestIngressClassChangeWithFinalizer(t *testing.T) {
 	flags.F.FinalizerAdd = true
 	flags.F.FinalizerRemove = true
 	lbc := newLoadBalancerController()
+	// The fake cloud has no network URL.
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	lbc.ctx.ProxyOnlySubnet = features.NewProxyOnlySubnet(lbc.ctx.Cloud, network, lbc.ctx.Cloud.Region())
 	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
 		Type:  api_v1.ServiceTypeNodePort,
 		Ports: []api_v1.ServicePort{{Port: 80}},
@@ -403,6 +525,9 @@ func TestIngressesWithSharedResourcesWithFinalizer(t *testing.T) {
 	flags.F.FinalizerAdd = true
 	flags.F.FinalizerRemove = true
 	lbc := newLoadBalancerController()
+	// The fake cloud has no network URL.
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	lbc.ctx.ProxyOnlySubnet = features.NewProxyOnlySubnet(lbc.ctx.Cloud, network, lbc.ctx.Cloud.Region())
 	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
 		Type:  api_v1.ServiceTypeNodePort,
 		Ports: []api_v1.ServicePort{{Port: 80}},
@@ -518,6 +643,9 @@ func TestEnableFinalizer(t *testing.T) {
 // status is updated and LB is deleted after class change.
 func TestIngressClassChange(t *testing.T) {
 	lbc := newLoadBalancerController()
+	// The fake cloud has no network URL.
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	lbc.ctx.ProxyOnlySubnet = features.NewProxyOnlySubnet(lbc.ctx.Cloud, network, lbc.ctx.Cloud.Region())
 	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
 		Type:  api_v1.ServiceTypeNodePort,
 		Ports: []api_v1.ServicePort{{Port: 80}},
@@ -675,18 +803,27 @@ func TestMCIngressIG(t *testing.T) {
 // are included in the RuntimeInfo.
 func TestToRuntimeInfoCerts(t *testing.T) {
 	lbc := newLoadBalancerController()
+	cert, key, err := test.NewSelfSignedCertificateAndKey("example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode([]byte(cert))
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
 	secretsMap := map[string]*api_v1.Secret{
 		"tlsCert": &api_v1.Secret{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name: "tlsCert",
 			},
 			Data: map[string][]byte{
-				api_v1.TLSCertKey:       []byte("cert"),
-				api_v1.TLSPrivateKeyKey: []byte("key"),
+				api_v1.TLSCertKey:       []byte(cert),
+				api_v1.TLSPrivateKeyKey: []byte(key),
 			},
 		},
 	}
-	tlsCerts := []*translator.TLSCerts{{Key: "key", Cert: "cert", Name: "tlsCert", CertHash: translator.GetCertHash("cert")}}
+	tlsCerts := []*translator.TLSCerts{{Key: key, Cert: cert, Name: "tlsCert", CertHash: translator.GetCertHash(cert), NotAfter: parsed.NotAfter}}
 
 	for _, v := range secretsMap {
 		lbc.ctx.KubeClient.CoreV1().Secrets("").Create(context2.TODO(), v, meta_v1.CreateOptions{})
@@ -720,6 +857,41 @@ func TestToRuntimeInfoCerts(t *testing.T) {
 	}
 }
 
+// TestACMEIngressWithoutSecret asserts that an Ingress with ACME enabled is
+// served over HTTP before the Secret of its certificate is created.
+func TestACMEIngressWithoutSecret(t *testing.T) {
+	lbc := newLoadBalancerController()
+	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	addService(lbc, svc)
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		v1beta1.IngressSpec{
+			Backend: &defaultBackend,
+			TLS:     []v1beta1.IngressTLS{{SecretName: "not-issued-yet"}},
+		})
+	ing.Annotations = map[string]string{annotations.ACMEKey: "true"}
+	addIngress(lbc, ing)
+
+	ingStoreKey := getKey(ing, t)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", ingStoreKey, err)
+	}
+	updatedIng, _ := lbc.ctx.KubeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Get(context2.TODO(), ing.Name, meta_v1.GetOptions{})
+	if len(updatedIng.Status.LoadBalancer.Ingress) != 1 || updatedIng.Status.LoadBalancer.Ingress[0].IP == "" {
+		t.Errorf("Get(%q) = status %+v, want non-empty", updatedIng.Name, updatedIng.Status.LoadBalancer.Ingress)
+	}
+	lbInfo, err := lbc.toRuntimeInfo(updatedIng, &utils.GCEURLMap{})
+	if err != nil {
+		t.Fatalf("lbc.toRuntimeInfo() = err %v", err)
+	}
+	if len(lbInfo.TLS) != 0 {
+		t.Errorf("lbInfo.TLS = %v, want none", lbInfo.TLS)
+	}
+}
+
 // TestIngressTagging asserts that appropriate finalizer that defines frontend naming scheme,
 // is added to ingress being synced.
 func TestIngressTagging(t *testing.T) {
@@ -48,6 +48,29 @@ func (e ErrSvcNotFound) Error() string {
 	return fmt.Sprintf("could not find service %q", e.Service)
 }
 
+// ErrSvcReferenceNotGranted is returned when an Ingress references a service
+// in another namespace that is not allowed by a BackendGrant.
+type ErrSvcReferenceNotGranted struct {
+	Service          types.NamespacedName
+	IngressNamespace string
+}
+
+// Error returns the name of the service and the namespace of the referencing Ingress.
+func (e ErrSvcReferenceNotGranted) Error() string {
+	return fmt.Sprintf("reference to service %q from namespace %q is not allowed by any BackendGrant", e.Service, e.IngressNamespace)
+}
+
+// ErrBackendNamespacesParsing is returned when the cross-namespace backends
+// annotation of an Ingress is malformed.
+type ErrBackendNamespacesParsing struct {
+	Err error
+}
+
+// Error returns the annotation key and the parsing error.
+func (e ErrBackendNamespacesParsing) Error() string {
+	return fmt.Sprintf("could not parse %q annotation on ingress, err: %v", annotations.BackendNamespacesKey, e.Err)
+}
+
 // ErrSvcPortNotFound is returned when a service's port is not found.
 type ErrSvcPortNotFound struct {
 	utils.ServicePortID
@@ -69,6 +92,18 @@ func (e ErrSvcAppProtosParsing) Error() string {
 	return fmt.Sprintf("could not parse %q annotation on service %q, err: %v", annotations.ServiceApplicationProtocolKey, e.Service, e.Err)
 }
 
+// ErrSvcExternalNEG is returned when the external NEG annotation of a service
+// is invalid or not supported by the Ingress.
+type ErrSvcExternalNEG struct {
+	Service types.NamespacedName
+	Err     error
+}
+
+// Error returns the annotation key, service name, and the underlying error.
+func (e ErrSvcExternalNEG) Error() string {
+	return fmt.Sprintf("invalid %q annotation on service %q, err: %v", annotations.ExternalNEGKey, e.Service, e.Err)
+}
+
 // ErrSvcBackendConfig is returned when there was an error getting the
 // BackendConfig for a service port.
 type ErrSvcBackendConfig struct {
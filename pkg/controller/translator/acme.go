@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/networking/v1beta1"
+	"k8s.io/ingress-gce/pkg/acme"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+// acmeChallengePath is the path rule which routes HTTP-01 challenges to the
+// ACME solver.
+const acmeChallengePath = acme.ChallengePath + "*"
+
+// putACMEChallengeRules routes the HTTP-01 challenges of the TLS hosts of
+// the Ingress to the ACME solver, if the Ingress has ACME issued
+// certificates. Hosts without a rule of their own keep the paths of the
+// default host.
+func (t *Translator) putACMEChallengeRules(ing *v1beta1.Ingress, urlMap *utils.GCEURLMap, params *getServicePortParams, namer namer_util.BackendNamer) error {
+	if t.ctx.ACMESolverSvcPort == nil || !annotations.FromIngress(ing).ACMEEnabled() {
+		return nil
+	}
+	// The solver is not referenced by the Ingress and does not require a
+	// BackendGrant.
+	solverParams := *params
+	solverParams.ingNamespace = ""
+	id := *t.ctx.ACMESolverSvcPort
+	solver, err := t.getServicePort(id, &solverParams, namer)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the ACME solver service %q with port %q: %v", id.Service.String(), id.Port.String(), err)
+	}
+
+	defaultPaths := hostPaths(urlMap, DefaultHost)
+	for _, entry := range ing.Spec.TLS {
+		for _, host := range entry.Hosts {
+			if strings.Contains(host, "*") {
+				continue
+			}
+			var paths []utils.PathRule
+			if urlMap.HostExists(host) {
+				paths = hostPaths(urlMap, host)
+			} else {
+				paths = append(paths, defaultPaths...)
+			}
+			paths = append(paths, utils.PathRule{Path: acmeChallengePath, Backend: *solver})
+			urlMap.PutPathRulesForHost(host, paths)
+		}
+	}
+	return nil
+}
+
+// hostPaths returns a copy of the path rules of host in the url map.
+func hostPaths(urlMap *utils.GCEURLMap, host string) []utils.PathRule {
+	for _, hr := range urlMap.HostRules {
+		if hr.Hostname == host {
+			return append([]utils.PathRule(nil), hr.Paths...)
+		}
+	}
+	return nil
+}
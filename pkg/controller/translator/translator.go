@@ -36,6 +36,7 @@ import (
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backendconfig"
+	"k8s.io/ingress-gce/pkg/backendgrant"
 	"k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller/errors"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -54,7 +55,11 @@ const (
 
 // getServicePortParams allows for passing parameters to getServicePort()
 type getServicePortParams struct {
-	isL7ILB bool
+	isL7ILB         bool
+	isL7XLBRegional bool
+	// ingNamespace is the namespace of the referencing Ingress. If set,
+	// references to services in other namespaces must be allowed by a BackendGrant.
+	ingNamespace string
 }
 
 // NewTranslator returns a new Translator.
@@ -104,14 +109,36 @@ func maybeEnableNEG(sp *utils.ServicePort, svc *api_v1.Service) error {
 		return errors.ErrBadSvcType{Service: sp.ID.Service, ServiceType: svc.Spec.Type}
 	}
 
-	if sp.L7ILBEnabled {
-		// L7-ILB Requires NEGs
+	if sp.L7ILBEnabled || sp.L7XLBRegionalEnabled {
+		// Regional L7 load balancers require NEGs
 		sp.NEGEnabled = true
 	}
 
 	return nil
 }
 
+// maybeEnableExternalNEG sets the external NEG of the service port if the
+// service has the external NEG annotation.
+func maybeEnableExternalNEG(sp *utils.ServicePort, svc *api_v1.Service) error {
+	if !flags.F.EnableExternalNEGs {
+		return nil
+	}
+	externalNEG, found, err := annotations.FromService(svc).ExternalNEG()
+	if !found {
+		return nil
+	}
+	if err != nil {
+		// This is a fatal error.
+		return errors.ErrSvcExternalNEG{Service: sp.ID.Service, Err: err}
+	}
+	if sp.L7ILBEnabled || sp.L7XLBRegionalEnabled {
+		// This is a fatal error.
+		return errors.ErrSvcExternalNEG{Service: sp.ID.Service, Err: fmt.Errorf("external NEGs are only supported by global external Ingresses")}
+	}
+	sp.ExternalNEG = externalNEG
+	return nil
+}
+
 // setAppProtocol sets the app protocol on the service port
 func setAppProtocol(sp *utils.ServicePort, svc *api_v1.Service, port *api_v1.ServicePort) error {
 	appProtocols, err := annotations.FromService(svc).ApplicationProtocols()
@@ -147,14 +174,36 @@ func (t *Translator) maybeEnableBackendConfig(sp *utils.ServicePort, svc *api_v1
 	if err = backendconfig.Validate(t.ctx.KubeClient, beConfig); err != nil {
 		return errors.ErrBackendConfigValidation{BackendConfig: *beConfig, Err: err}
 	}
+	backendTLSCerts, err := backendconfig.GetBackendTLSCertificates(t.ctx.KubeClient, beConfig)
+	if err != nil {
+		return errors.ErrBackendConfigValidation{BackendConfig: *beConfig, Err: err}
+	}
 
 	sp.BackendConfig = beConfig
+	sp.BackendTLSCertificates = backendTLSCerts
 	return nil
 }
 
+// isReferenceAllowed returns true if an Ingress in ingNamespace may use the
+// service of the given ServicePortID as a backend.
+func (t *Translator) isReferenceAllowed(ingNamespace string, id utils.ServicePortID) bool {
+	if ingNamespace == id.Service.Namespace {
+		return true
+	}
+	if t.ctx.BackendGrantInformer == nil {
+		return false
+	}
+	return backendgrant.IsReferenceAllowed(t.ctx.BackendGrantInformer.GetIndexer(), ingNamespace, id.Service)
+}
+
 // getServicePort looks in the svc store for a matching service:port,
 // and returns the nodeport.
 func (t *Translator) getServicePort(id utils.ServicePortID, params *getServicePortParams, namer namer_util.BackendNamer) (*utils.ServicePort, error) {
+	if params.ingNamespace != "" && !t.isReferenceAllowed(params.ingNamespace, id) {
+		// This is a fatal error.
+		return nil, errors.ErrSvcReferenceNotGranted{Service: id.Service, IngressNamespace: params.ingNamespace}
+	}
+
 	svc, err := t.getCachedService(id)
 	if err != nil {
 		return nil, err
@@ -169,17 +218,24 @@ func (t *Translator) getServicePort(id utils.ServicePortID, params *getServicePo
 	// We periodically add information to the ServicePort to ensure that we
 	// always return as much as possible, rather than nil, if there was a non-fatal error.
 	svcPort := &utils.ServicePort{
-		ID:           id,
-		NodePort:     int64(port.NodePort),
-		Port:         int32(port.Port),
-		TargetPort:   port.TargetPort.String(),
-		L7ILBEnabled: params.isL7ILB,
-		BackendNamer: namer,
+		ID:                   id,
+		NodePort:             int64(port.NodePort),
+		Port:                 int32(port.Port),
+		TargetPort:           port.TargetPort.String(),
+		L7ILBEnabled:         params.isL7ILB,
+		L7XLBRegionalEnabled: params.isL7XLBRegional,
+		BackendNamer:         namer,
 	}
 
-	if err := maybeEnableNEG(svcPort, svc); err != nil {
+	if err := maybeEnableExternalNEG(svcPort, svc); err != nil {
 		return nil, err
 	}
+	// The endpoints of services with an external NEG are not used.
+	if svcPort.ExternalNEG == nil {
+		if err := maybeEnableNEG(svcPort, svc); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := setAppProtocol(svcPort, svc, port); err != nil {
 		return svcPort, err
@@ -199,6 +255,14 @@ func (t *Translator) TranslateIngress(ing *v1beta1.Ingress, systemDefaultBackend
 
 	params := &getServicePortParams{}
 	params.isL7ILB = flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(ing)
+	params.isL7XLBRegional = flags.F.EnableL7XLBRegional && utils.IsGCEL7XLBRegionalIngress(ing)
+	params.ingNamespace = ing.Namespace
+
+	if flags.F.EnableCrossNamespaceBackends {
+		if _, err := annotations.FromIngress(ing).BackendNamespaces(); err != nil {
+			errs = append(errs, errors.ErrBackendNamespacesParsing{Err: err})
+		}
+	}
 
 	for _, rule := range ing.Spec.Rules {
 		if rule.HTTP == nil {
@@ -207,7 +271,7 @@ func (t *Translator) TranslateIngress(ing *v1beta1.Ingress, systemDefaultBackend
 
 		pathRules := []utils.PathRule{}
 		for _, p := range rule.HTTP.Paths {
-			svcPort, err := t.getServicePort(utils.BackendToServicePortID(p.Backend, ing.Namespace), params, namer)
+			svcPort, err := t.getServicePort(utils.IngressBackendToServicePortID(ing, p.Backend), params, namer)
 			if err != nil {
 				errs = append(errs, err)
 			}
@@ -237,8 +301,12 @@ func (t *Translator) TranslateIngress(ing *v1beta1.Ingress, systemDefaultBackend
 		urlMap.PutPathRulesForHost(host, pathRules)
 	}
 
+	if err := t.putACMEChallengeRules(ing, urlMap, params, namer); err != nil {
+		errs = append(errs, err)
+	}
+
 	if ing.Spec.Backend != nil {
-		svcPort, err := t.getServicePort(utils.BackendToServicePortID(*ing.Spec.Backend, ing.Namespace), params, namer)
+		svcPort, err := t.getServicePort(utils.IngressBackendToServicePortID(ing, *ing.Spec.Backend), params, namer)
 		if err == nil {
 			urlMap.DefaultBackend = svcPort
 			return urlMap, errs
@@ -248,6 +316,9 @@ func (t *Translator) TranslateIngress(ing *v1beta1.Ingress, systemDefaultBackend
 		return urlMap, errs
 	}
 
+	// The system default backend is not referenced by the Ingress and does
+	// not require a BackendGrant.
+	params.ingNamespace = ""
 	svcPort, err := t.getServicePort(systemDefaultBackend, params, namer)
 	if err == nil {
 		urlMap.DefaultBackend = svcPort
@@ -32,8 +32,12 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/ingress-gce/pkg/annotations"
 	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	backendgrantclient "k8s.io/ingress-gce/pkg/backendgrant/client/clientset/versioned/fake"
+	informerbackendgrant "k8s.io/ingress-gce/pkg/backendgrant/client/informers/externalversions/backendgrant/v1alpha1"
 	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
@@ -64,7 +68,7 @@ func fakeTranslator() *Translator {
 		DefaultBackendSvcPort: defaultBackend,
 		HealthCheckPath:       "/",
 	}
-	ctx := context.NewControllerContext(nil, client, backendConfigClient, nil, nil, nil, nil, defaultNamer, "" /*kubeSystemUID*/, ctxConfig)
+	ctx := context.NewControllerContext(nil, client, backendConfigClient, nil, nil, nil, nil, nil, defaultNamer, "" /*kubeSystemUID*/, ctxConfig)
 	gce := &Translator{
 		ctx: ctx,
 	}
@@ -185,6 +189,184 @@ func TestTranslateIngress(t *testing.T) {
 	}
 }
 
+func TestTranslateIngressCrossNamespace(t *testing.T) {
+	flags.F.EnableCrossNamespaceBackends = true
+	defer func() { flags.F.EnableCrossNamespaceBackends = false }()
+
+	translator := fakeTranslator()
+	translator.ctx.BackendGrantInformer = informerbackendgrant.NewBackendGrantInformer(backendgrantclient.NewSimpleClientset(), apiv1.NamespaceAll, 1*time.Second, utils.NewNamespaceIndexer())
+	svcLister := translator.ctx.ServiceInformer.GetIndexer()
+	grantLister := translator.ctx.BackendGrantInformer.GetIndexer()
+
+	for _, id := range []types.NamespacedName{
+		{Name: "default-http-backend", Namespace: "kube-system"},
+		{Name: "shop", Namespace: "shop-prod"},
+		{Name: "billing", Namespace: "billing-prod"},
+	} {
+		svcLister.Add(test.NewService(id, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+		}))
+	}
+	grantLister.Add(&backendgrantv1alpha1.BackendGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-edge", Namespace: "shop-prod"},
+		Spec: backendgrantv1alpha1.BackendGrantSpec{
+			From: []backendgrantv1alpha1.BackendGrantFrom{{Namespace: "edge"}},
+			To:   []backendgrantv1alpha1.BackendGrantTo{{Name: "shop"}},
+		},
+	})
+
+	newIngress := func(annotation string, svcName string) *v1beta1.Ingress {
+		ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "edge"},
+			v1beta1.IngressSpec{
+				Backend: test.Backend(svcName, intstr.FromInt(80)),
+			})
+		if annotation != "" {
+			ing.Annotations = map[string]string{annotations.BackendNamespacesKey: annotation}
+		}
+		return ing
+	}
+
+	for _, tc := range []struct {
+		desc         string
+		ing          *v1beta1.Ingress
+		wantErrCount int
+		wantBackend  *types.NamespacedName
+	}{
+		{
+			desc:         "granted cross-namespace backend",
+			ing:          newIngress(`{"shop": "shop-prod"}`, "shop"),
+			wantErrCount: 0,
+			wantBackend:  &types.NamespacedName{Name: "shop", Namespace: "shop-prod"},
+		},
+		{
+			desc:         "cross-namespace backend without grant",
+			ing:          newIngress(`{"billing": "billing-prod"}`, "billing"),
+			wantErrCount: 1,
+		},
+		{
+			desc:         "service not mapped to another namespace",
+			ing:          newIngress(`{"billing": "billing-prod"}`, "shop"),
+			wantErrCount: 1,
+		},
+		{
+			desc:         "malformed annotation",
+			ing:          newIngress(`{"shop":`, "shop"),
+			wantErrCount: 2,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotGCEURLMap, gotErrs := translator.TranslateIngress(tc.ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != tc.wantErrCount {
+				t.Errorf("TranslateIngress() = _, %+v, want %v errs", gotErrs, tc.wantErrCount)
+			}
+			if tc.wantBackend == nil {
+				return
+			}
+			if gotGCEURLMap.DefaultBackend == nil || gotGCEURLMap.DefaultBackend.ID.Service != *tc.wantBackend {
+				t.Errorf("TranslateIngress() default backend = %+v, want %v", gotGCEURLMap.DefaultBackend, tc.wantBackend)
+			}
+		})
+	}
+}
+
+func TestTranslateIngressACME(t *testing.T) {
+	translator := fakeTranslator()
+	solverID := utils.ServicePortID{Service: types.NamespacedName{Name: "glbc-acme-solver", Namespace: "kube-system"}, Port: intstr.FromString("http")}
+	translator.ctx.ACMESolverSvcPort = &solverID
+	svcLister := translator.ctx.ServiceInformer.GetIndexer()
+	for _, id := range []types.NamespacedName{
+		{Name: "default-http-backend", Namespace: "kube-system"},
+		solverID.Service,
+		{Name: "first-service", Namespace: "default"},
+	} {
+		svcLister.Add(test.NewService(id, apiv1.ServiceSpec{
+			Type:  apiv1.ServiceTypeNodePort,
+			Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+		}))
+	}
+
+	firstService := utils.ServicePort{ID: utils.ServicePortID{Service: types.NamespacedName{Name: "first-service", Namespace: "default"}, Port: intstr.FromInt(80)}}
+	solver := utils.ServicePort{ID: solverID}
+	rule := func(host string) v1beta1.IngressRule {
+		return v1beta1.IngressRule{
+			Host: host,
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{
+					Paths: []v1beta1.HTTPIngressPath{{Path: "/foo", Backend: *test.Backend("first-service", intstr.FromInt(80))}},
+				},
+			},
+		}
+	}
+	newIngress := func(acme bool, hosts []string, rules ...v1beta1.IngressRule) *v1beta1.Ingress {
+		ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+			v1beta1.IngressSpec{
+				Rules: rules,
+				TLS:   []v1beta1.IngressTLS{{Hosts: hosts, SecretName: "tls"}},
+			})
+		if acme {
+			ing.Annotations = map[string]string{annotations.ACMEKey: "true"}
+		}
+		return ing
+	}
+	newURLMap := func(hostPaths map[string][]utils.PathRule, hosts ...string) *utils.GCEURLMap {
+		urlMap := utils.NewGCEURLMap()
+		urlMap.DefaultBackend = &defaultBackend
+		for _, host := range hosts {
+			urlMap.PutPathRulesForHost(host, hostPaths[host])
+		}
+		return urlMap
+	}
+	fooPath := utils.PathRule{Path: "/foo", Backend: firstService}
+	challengePath := utils.PathRule{Path: acmeChallengePath, Backend: solver}
+
+	for _, tc := range []struct {
+		desc          string
+		ing           *v1beta1.Ingress
+		wantGCEURLMap *utils.GCEURLMap
+	}{
+		{
+			desc: "not annotated",
+			ing:  newIngress(false, []string{"foo.com"}, rule("foo.com")),
+			wantGCEURLMap: newURLMap(map[string][]utils.PathRule{
+				"foo.com": {fooPath},
+			}, "foo.com"),
+		},
+		{
+			desc: "host with rule",
+			ing:  newIngress(true, []string{"foo.com"}, rule("foo.com")),
+			wantGCEURLMap: newURLMap(map[string][]utils.PathRule{
+				"foo.com": {fooPath, challengePath},
+			}, "foo.com"),
+		},
+		{
+			desc: "host without rule keeps the paths of the default host",
+			ing:  newIngress(true, []string{"bar.com"}, rule("")),
+			wantGCEURLMap: newURLMap(map[string][]utils.PathRule{
+				DefaultHost: {fooPath},
+				"bar.com":   {fooPath, challengePath},
+			}, DefaultHost, "bar.com"),
+		},
+		{
+			desc: "wildcard host",
+			ing:  newIngress(true, []string{"*.foo.com"}, rule("*.foo.com")),
+			wantGCEURLMap: newURLMap(map[string][]utils.PathRule{
+				"*.foo.com": {fooPath},
+			}, "*.foo.com"),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotGCEURLMap, gotErrs := translator.TranslateIngress(tc.ing, defaultBackend.ID, defaultNamer)
+			if len(gotErrs) != 0 {
+				t.Errorf("TranslateIngress() = _, %+v, want no errs", gotErrs)
+			}
+			if !utils.EqualMapping(gotGCEURLMap, tc.wantGCEURLMap) {
+				t.Errorf("TranslateIngress() = %+v\nwant\n%+v", gotGCEURLMap.String(), tc.wantGCEURLMap.String())
+			}
+		})
+	}
+}
+
 func TestGetServicePort(t *testing.T) {
 	cases := []struct {
 		desc        string
@@ -19,6 +19,8 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	compute "google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
@@ -80,9 +82,51 @@ func convert(ings []*v1beta1.Ingress) (retVal []interface{}) {
 func nodePorts(svcPorts []utils.ServicePort) []int64 {
 	ports := []int64{}
 	for _, p := range uniq(svcPorts) {
-		if !p.NEGEnabled {
+		if !p.NEGEnabled && p.ExternalNEG == nil {
 			ports = append(ports, p.NodePort)
 		}
 	}
 	return ports
 }
+
+// certExpiryWarning is the expiry warning of a certificate: the smallest
+// threshold the time left until notAfter has fallen below, 0 once it expired.
+type certExpiryWarning struct {
+	notAfter  time.Time
+	threshold time.Duration
+}
+
+// certExpiryWarnings remembers the expiry warnings emitted for the
+// certificates of each ingress, so a warning is emitted once per threshold
+// crossed instead of on every sync.
+type certExpiryWarnings struct {
+	mu sync.Mutex
+	// warned are the warnings by ingress key and Secret name.
+	warned map[string]map[string]certExpiryWarning
+}
+
+func newCertExpiryWarnings() *certExpiryWarnings {
+	return &certExpiryWarnings{warned: map[string]map[string]certExpiryWarning{}}
+}
+
+// update records the current warnings of the certificates of the ingress
+// ingKey, by Secret name, and returns the Secrets whose certificate crossed a
+// threshold since the last update. A renewed certificate, with a new expiry,
+// is warned about again. nil warnings forget the ingress.
+func (w *certExpiryWarnings) update(ingKey string, warnings map[string]certExpiryWarning) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var crossed []string
+	for name, warning := range warnings {
+		prev, ok := w.warned[ingKey][name]
+		if !ok || !prev.notAfter.Equal(warning.notAfter) || warning.threshold < prev.threshold {
+			crossed = append(crossed, name)
+		}
+	}
+	if len(warnings) == 0 {
+		delete(w.warned, ingKey)
+	} else {
+		w.warned[ingKey] = warnings
+	}
+	return crossed
+}
@@ -406,3 +406,62 @@ func testServicePort(namespace, name, port string, servicePort, nodePort int, en
 		NEGEnabled: enableNEG,
 	}
 }
+
+func TestCertExpiryWarnings(t *testing.T) {
+	const ingKey = "default/ing"
+	notAfter := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	renewed := notAfter.Add(90 * 24 * time.Hour)
+	warnings := newCertExpiryWarnings()
+
+	for _, step := range []struct {
+		desc        string
+		warnings    map[string]certExpiryWarning
+		wantCrossed []string
+	}{
+		{
+			desc:     "no certificate expires soon",
+			warnings: map[string]certExpiryWarning{},
+		},
+		{
+			desc:        "first threshold crossed",
+			warnings:    map[string]certExpiryWarning{"cert": {notAfter: notAfter, threshold: 30 * 24 * time.Hour}},
+			wantCrossed: []string{"cert"},
+		},
+		{
+			desc:     "same threshold on the next sync",
+			warnings: map[string]certExpiryWarning{"cert": {notAfter: notAfter, threshold: 30 * 24 * time.Hour}},
+		},
+		{
+			desc:        "next threshold crossed",
+			warnings:    map[string]certExpiryWarning{"cert": {notAfter: notAfter, threshold: 7 * 24 * time.Hour}},
+			wantCrossed: []string{"cert"},
+		},
+		{
+			desc:        "certificate expired",
+			warnings:    map[string]certExpiryWarning{"cert": {notAfter: notAfter}},
+			wantCrossed: []string{"cert"},
+		},
+		{
+			desc:     "expired certificate on the next sync",
+			warnings: map[string]certExpiryWarning{"cert": {notAfter: notAfter}},
+		},
+		{
+			desc:        "renewed certificate crosses a threshold",
+			warnings:    map[string]certExpiryWarning{"cert": {notAfter: renewed, threshold: 30 * 24 * time.Hour}},
+			wantCrossed: []string{"cert"},
+		},
+		{
+			desc:     "ingress forgotten",
+			warnings: nil,
+		},
+		{
+			desc:        "threshold warned again after the ingress was forgotten",
+			warnings:    map[string]certExpiryWarning{"cert": {notAfter: renewed, threshold: 30 * 24 * time.Hour}},
+			wantCrossed: []string{"cert"},
+		},
+	} {
+		if crossed := warnings.update(ingKey, step.warnings); !reflect.DeepEqual(crossed, step.wantCrossed) {
+			t.Errorf("%s: update() = %v, want %v", step.desc, crossed, step.wantCrossed)
+		}
+	}
+}
@@ -31,6 +31,31 @@ const (
 	GarbageCollection = "GarbageCollection"
 
 	SyncService = "Sync"
+
+	// Paused and Resumed are recorded when reconciliation of an Ingress or
+	// Service is paused or resumed through an annotation.
+	Paused  = "Paused"
+	Resumed = "Resumed"
+
+	// InvalidGCELabel is recorded when a label of an Ingress or Service can
+	// not be propagated onto its GCE resources.
+	InvalidGCELabel = "InvalidGCELabel"
+
+	// ProxyOnlySubnetMissing and ProxyOnlySubnetReady are recorded when the
+	// proxy-only subnet required by a regional Ingress is found missing or
+	// becomes available.
+	ProxyOnlySubnetMissing = "ProxyOnlySubnetMissing"
+	ProxyOnlySubnetReady   = "ProxyOnlySubnetReady"
+
+	// CertificateExpiring is recorded when the certificate of a Secret used
+	// by an Ingress is about to expire.
+	CertificateExpiring = "CertificateExpiring"
+
+	// CertificateIssued and CertificateIssuanceFailed are recorded when a
+	// certificate of an Ingress is issued from an ACME directory, or fails to
+	// be.
+	CertificateIssued         = "CertificateIssued"
+	CertificateIssuanceFailed = "CertificateIssuanceFailed"
 )
 
 type RecorderProducer interface {
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	informerv1 "k8s.io/client-go/informers/core/v1"
@@ -43,6 +44,10 @@ import (
 
 const controllerName = "workload-controller.k8s.io"
 
+// EndpointSliceManagedBy is the value of the managed-by label of the
+// EndpointSlices created by the workload controller.
+const EndpointSliceManagedBy = controllerName
+
 // ControllerContext holds the state needed for the execution of the workload controller.
 type ControllerContext struct {
 	KubeClient     kubernetes.Interface
@@ -350,7 +355,7 @@ func workloadToEndpoint(workload *workloadv1a1.Workload, service *corev1.Service
 		Conditions: discovery.EndpointConditions{
 			Ready: &ready,
 		},
-		Topology: nil,
+		Topology: workloadTopology(workload),
 		TargetRef: &corev1.ObjectReference{
 			Kind:            "Pod",
 			Namespace:       workload.Namespace,
@@ -367,6 +372,21 @@ func workloadToEndpoint(workload *workloadv1a1.Workload, service *corev1.Service
 	return ep
 }
 
+// workloadTopology returns the topology reported by the workload in its labels,
+// nil if it reports none.
+func workloadTopology(workload *workloadv1a1.Workload) map[string]string {
+	var ret map[string]string
+	for _, key := range []string{corev1.LabelZoneRegionStable, corev1.LabelZoneFailureDomainStable} {
+		if value, ok := workload.Labels[key]; ok {
+			if ret == nil {
+				ret = map[string]string{}
+			}
+			ret[key] = value
+		}
+	}
+	return ret
+}
+
 func equalEndpoints(es1, es2 []discovery.Endpoint) bool {
 	// TODO: Find a better way to compare these
 	return apiequality.Semantic.DeepEqual(es1, es2)
@@ -393,7 +413,12 @@ func newEndpointSlice(name string, service *corev1.Service) *discovery.EndpointS
 
 func getEndpointPortsFromServicePorts(svcPorts []corev1.ServicePort) []discovery.EndpointPort {
 	ret := []discovery.EndpointPort{}
-	for _, port := range svcPorts {
+	for _, svcPort := range svcPorts {
+		port := svcPort
+		// Workloads serve on the target port, if it is a number.
+		if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0 {
+			port.Port = port.TargetPort.IntVal
+		}
 		ret = append(ret, discovery.EndpointPort{
 			Name:        &port.Name,
 			Port:        &port.Port,
@@ -139,10 +139,10 @@ func getWorkloadCR(workload daemonutils.WorkloadInfo) *workloadv1a1.Workload {
 		Status: generateHeartbeatStatus(),
 	}
 	if region, exist := workload.Region(); exist {
-		ret.ObjectMeta.Labels["topology.kubernetes.io/region"] = region
+		ret.ObjectMeta.Labels[corev1.LabelZoneRegionStable] = region
 	}
 	if zone, exist := workload.Zone(); exist {
-		ret.ObjectMeta.Labels["topology.kubernetes.io/region"] = zone
+		ret.ObjectMeta.Labels[corev1.LabelZoneFailureDomainStable] = zone
 	}
 	if hostname, exist := workload.Hostname(); exist {
 		ret.Spec.Hostname = &hostname
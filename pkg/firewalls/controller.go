@@ -119,6 +119,14 @@ func NewFirewallController(
 		},
 	})
 
+	// Update the source ranges of the firewall rule when the proxy-only subnet
+	// is created, deleted or resized.
+	if ctx.ProxyOnlySubnet != nil {
+		ctx.ProxyOnlySubnet.AddHandler(func() {
+			fwc.queue.Enqueue(queueKey)
+		})
+	}
+
 	return fwc
 }
 
@@ -174,14 +182,14 @@ func (fwc *FirewallController) sync(key string) error {
 	// if so, then need to include nodePort ranges for firewall
 	needNodePort := false
 	for _, svcPort := range gceSvcPorts {
-		if !svcPort.NEGEnabled {
+		if !svcPort.NEGEnabled && svcPort.ExternalNEG == nil {
 			needNodePort = true
 			break
 		}
 	}
 
 	var additionalRanges []string
-	if flags.F.EnableL7Ilb {
+	if flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional {
 		ilbRange, err := fwc.ilbFirewallSrcRange(gceIngresses)
 		if err != nil {
 			if err != features.ErrSubnetNotFound && err != ErrNoILBIngress {
@@ -219,14 +227,14 @@ func (fwc *FirewallController) sync(key string) error {
 func (fwc *FirewallController) ilbFirewallSrcRange(gceIngresses []*v1beta1.Ingress) (string, error) {
 	ilbEnabled := false
 	for _, ing := range gceIngresses {
-		if utils.IsGCEL7ILBIngress(ing) {
+		if utils.IsGCEL7RegionalIngress(ing) {
 			ilbEnabled = true
 			break
 		}
 	}
 
 	if ilbEnabled {
-		L7ILBSrcRange, err := features.ILBSubnetSourceRange(fwc.ctx.Cloud, fwc.ctx.Cloud.Region())
+		L7ILBSrcRange, err := fwc.ctx.ProxyOnlySubnet.SourceRange()
 		if err != nil {
 			return "", err
 		}
@@ -21,13 +21,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/google/go-cmp/cmp"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/pkg/annotations"
 	v1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/loadbalancers/features"
 	test "k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
@@ -49,7 +57,7 @@ func newFirewallController() *FirewallController {
 		DefaultBackendSvcPort: test.DefaultBeSvcPort,
 	}
 
-	ctx := context.NewControllerContext(nil, kubeClient, backendConfigClient, nil, nil, nil, fakeGCE, defaultNamer, "" /*kubeSystemUID*/, ctxConfig)
+	ctx := context.NewControllerContext(nil, kubeClient, backendConfigClient, nil, nil, nil, nil, fakeGCE, defaultNamer, "" /*kubeSystemUID*/, ctxConfig)
 	fwc := NewFirewallController(ctx, []string{"30000-32767"})
 	fwc.hasSynced = func() bool { return true }
 
@@ -106,6 +114,56 @@ func TestFirewallCreateDelete(t *testing.T) {
 	}
 }
 
+// TestFirewallProxyOnlySubnet asserts that the L7 firewall allows the range of
+// the proxy-only subnet when a regional ingress exists, and that it follows
+// changes of the range.
+// Note: This test cannot be run in parallel as it stubs global flags.
+func TestFirewallProxyOnlySubnet(t *testing.T) {
+	flags.F.EnableL7Ilb = true
+	defer func() {
+		flags.F.EnableL7Ilb = false
+	}()
+
+	fwc := newFirewallController()
+	(fwc.ctx.Cloud.Compute().(*cloud.MockGCE)).MockFirewalls.UpdateHook = mock.UpdateFirewallHook
+	// The fake cloud has no network URL.
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	fwc.ctx.ProxyOnlySubnet = features.NewProxyOnlySubnet(fwc.ctx.Cloud, network, fwc.ctx.Cloud.Region())
+
+	ing := test.NewIngress(types.NamespacedName{Name: "ilb-ingress", Namespace: "default"}, v1beta1.IngressSpec{})
+	ing.Annotations = map[string]string{annotations.IngressClassKey: annotations.GceL7ILBIngressClass}
+	fwc.ctx.KubeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Create(context2.TODO(), ing, meta_v1.CreateOptions{})
+	fwc.ctx.IngressInformer.GetIndexer().Add(ing)
+
+	key, _ := common.KeyFunc(queueKey)
+	subnetKey := meta.RegionalKey("proxy-only", fwc.ctx.Cloud.Region())
+	for _, cidr := range []string{"10.129.0.0/23", "10.130.0.0/22"} {
+		fwc.ctx.Cloud.Compute().BetaSubnetworks().Delete(context2.TODO(), subnetKey)
+		subnet := &computebeta.Subnetwork{
+			Name:        subnetKey.Name,
+			Network:     network,
+			IpCidrRange: cidr,
+			Purpose:     "REGIONAL_MANAGED_PROXY",
+			Role:        "ACTIVE",
+		}
+		if err := fwc.ctx.Cloud.Compute().BetaSubnetworks().Insert(context2.TODO(), subnetKey, subnet); err != nil {
+			t.Fatalf("Insert(%v) = %v, want nil", subnetKey, err)
+		}
+		fwc.ctx.ProxyOnlySubnet.Refresh()
+
+		if err := fwc.sync(key); err != nil {
+			t.Fatalf("fwc.sync() = %v, want nil", err)
+		}
+		fw, err := fwc.ctx.Cloud.GetFirewall(ruleName)
+		if err != nil {
+			t.Fatalf("cloud.GetFirewall(%v) = _, %v, want _, nil", ruleName, err)
+		}
+		if !sets.NewString(fw.SourceRanges...).Has(cidr) {
+			t.Errorf("Firewall source ranges = %v, want %q", fw.SourceRanges, cidr)
+		}
+	}
+}
+
 func TestGetCustomHealthCheckPorts(t *testing.T) {
 	t.Parallel()
 
@@ -21,8 +21,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/utils"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog"
@@ -131,7 +133,8 @@ func (fr *FirewallRules) GetFirewall(name string) (*compute.Firewall, error) {
 }
 
 func (fr *FirewallRules) createFirewall(f *compute.Firewall) error {
-	err := fr.cloud.CreateFirewall(f)
+	ac := audit.NewContext("Firewalls", audit.OperationCreate, meta.GlobalKey(f.Name), meta.VersionGA).WithObject(f).WithController(audit.ControllerFirewall)
+	err := ac.Observe(fr.cloud.CreateFirewall(f))
 	if utils.IsForbiddenError(err) && fr.cloud.OnXPN() {
 		gcloudCmd := gce.FirewallToGCloudCreateCmd(f, fr.cloud.NetworkProjectID())
 		klog.V(3).Infof("Could not create L7 firewall on XPN cluster: %v. Raising event for cmd: %q", err, gcloudCmd)
@@ -141,7 +144,8 @@ func (fr *FirewallRules) createFirewall(f *compute.Firewall) error {
 }
 
 func (fr *FirewallRules) updateFirewall(f *compute.Firewall) error {
-	err := fr.cloud.UpdateFirewall(f)
+	ac := audit.NewContext("Firewalls", audit.OperationUpdate, meta.GlobalKey(f.Name), meta.VersionGA).WithObject(f).WithController(audit.ControllerFirewall)
+	err := ac.Observe(fr.cloud.UpdateFirewall(f))
 	if utils.IsForbiddenError(err) && fr.cloud.OnXPN() {
 		gcloudCmd := gce.FirewallToGCloudUpdateCmd(f, fr.cloud.NetworkProjectID())
 		klog.V(3).Infof("Could not update L7 firewall on XPN cluster: %v. Raising event for cmd: %q", err, gcloudCmd)
@@ -151,7 +155,8 @@ func (fr *FirewallRules) updateFirewall(f *compute.Firewall) error {
 }
 
 func (fr *FirewallRules) deleteFirewall(name string) error {
-	err := fr.cloud.DeleteFirewall(name)
+	ac := audit.NewContext("Firewalls", audit.OperationDelete, meta.GlobalKey(name), meta.VersionGA).WithController(audit.ControllerFirewall)
+	err := ac.Observe(fr.cloud.DeleteFirewall(name))
 	if utils.IsNotFoundError(err) {
 		klog.Infof("Firewall with name %v didn't exist when attempting delete.", name)
 		return nil
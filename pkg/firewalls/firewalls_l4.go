@@ -21,6 +21,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -56,7 +57,8 @@ func EnsureL4InternalFirewallRule(cloud *gce.Cloud, fwName, lbIP, nsName string,
 	}
 	if existingFw == nil {
 		klog.V(2).Infof("EnsureL4InternalFirewallRule(%v): creating firewall", fwName)
-		err = cloud.CreateFirewall(expectedFw)
+		ac := audit.NewContext("Firewalls", audit.OperationCreate, meta.GlobalKey(fwName), meta.VersionGA).WithObject(expectedFw).WithController(audit.ControllerL4)
+		err = ac.Observe(cloud.CreateFirewall(expectedFw))
 		if utils.IsForbiddenError(err) && cloud.OnXPN() {
 			gcloudCmd := gce.FirewallToGCloudCreateCmd(expectedFw, cloud.NetworkProjectID())
 
@@ -69,7 +71,8 @@ func EnsureL4InternalFirewallRule(cloud *gce.Cloud, fwName, lbIP, nsName string,
 		return nil
 	}
 	klog.V(2).Infof("EnsureL4InternalFirewallRule(%v): updating firewall", fwName)
-	err = cloud.UpdateFirewall(expectedFw)
+	ac := audit.NewContext("Firewalls", audit.OperationUpdate, meta.GlobalKey(fwName), meta.VersionGA).WithObject(expectedFw).WithController(audit.ControllerL4)
+	err = ac.Observe(cloud.UpdateFirewall(expectedFw))
 	if utils.IsForbiddenError(err) && cloud.OnXPN() {
 		gcloudCmd := gce.FirewallToGCloudUpdateCmd(expectedFw, cloud.NetworkProjectID())
 		klog.V(3).Infof("EnsureL4InternalFirewallRule(%v): Could not update L4 firewall on XPN cluster: %v. Raising event for cmd: %q", fwName, err, gcloudCmd)
@@ -79,7 +82,8 @@ func EnsureL4InternalFirewallRule(cloud *gce.Cloud, fwName, lbIP, nsName string,
 }
 
 func EnsureL4InternalFirewallRuleDeleted(cloud *gce.Cloud, fwName string) error {
-	if err := utils.IgnoreHTTPNotFound(cloud.DeleteFirewall(fwName)); err != nil {
+	ac := audit.NewContext("Firewalls", audit.OperationDelete, meta.GlobalKey(fwName), meta.VersionGA).WithController(audit.ControllerL4)
+	if err := utils.IgnoreHTTPNotFound(ac.Observe(cloud.DeleteFirewall(fwName))); err != nil {
 		if utils.IsForbiddenError(err) && cloud.OnXPN() {
 			gcloudCmd := gce.FirewallToGCloudDeleteCmd(fwName, cloud.NetworkProjectID())
 			klog.V(3).Infof("EnsureL4InternalFirewallRuleDeleted(%v): could not delete traffic firewall on XPN cluster. Raising event.", fwName)
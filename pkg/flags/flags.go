@@ -60,37 +60,67 @@ const (
 var (
 	// F are global flags for the controller.
 	F = struct {
+		ACMEAccountSecret                string
+		ACMEDirectoryCAFile              string
+		ACMEDirectoryURL                 string
+		ACMEEmail                        string
+		ACMERenewBefore                  time.Duration
+		ACMESelfCheck                    bool
+		ACMESolverPort                   int
+		ACMESolverSvc                    string
+		ACMESolverSvcPortName            string
 		APIServerHost                    string
 		ASMConfigMapBasedConfigCMName    string
 		ASMConfigMapBasedConfigNamespace string
+		AuditLogFile                     string
+		AuditLogURL                      string
+		CertExpiryWarningThresholds      []time.Duration
 		ClusterName                      string
 		ConfigFilePath                   string
 		DefaultSvc                       string
 		DefaultSvcHealthCheckPath        string
 		DefaultSvcPortName               string
 		DeleteAllOnQuit                  bool
+		GCELabelAllowlist                []string
 		GCEOperationPollInterval         time.Duration
 		GCERateLimit                     RateLimitSpecs
+		GCEStaticLabels                  map[string]string
 		HealthCheckPath                  string
 		HealthzPort                      int
 		InCluster                        bool
 		IngressClass                     string
 		KubeConfigFile                   string
+		L4ILBMaxSubsetSize               int
 		NegGCPeriod                      time.Duration
+		NegMaxBatchSize                  int
+		NegOperationConcurrency          int
+		NegReadinessBackendServices      []string
+		NegReadinessPolicy               string
 		NodePortRanges                   PortRanges
 		ResyncPeriod                     time.Duration
 		RunIngressController             bool
 		RunL4Controller                  bool
+		TracingOTLPEndpoint              string
+		TracingSampleFraction            float64
 		Version                          bool
 		WatchNamespace                   string
 		LeaderElection                   LeaderElectionConfiguration
 
 		// Feature flags should be named Enablexxx.
+		EnableACME                     bool
 		EnableASMConfigMapBasedConfig  bool
 		EnableBackendConfigHealthCheck bool
+		EnableBackendTLS               bool
+		EnableCrossNamespaceBackends   bool
 		EnableDeleteUnusedFrontends    bool
+		EnableExternalNEGs             bool
 		EnableFrontendConfig           bool
+		EnableIGReadinessGate          bool
 		EnableL7Ilb                    bool
+		EnableL7XLBRegional            bool
+		EnableNegCheckpoint            bool
+		EnableNegGracefulRemoval       bool
+		EnableNegWorkloads             bool
 		EnableNonGCPMode               bool
 		EnableReadinessReflector       bool
 		EnableV2FrontendNamer          bool
@@ -165,6 +195,8 @@ normal environments the controller should only delete a loadbalancer if the
 associated Ingress is deleted.`)
 	flag.BoolVar(&F.EnableFrontendConfig, "enable-frontend-config", false,
 		`Optional, whether or not to enable FrontendConfig.`)
+	flag.BoolVar(&F.EnableBackendTLS, "enable-backend-tls", false,
+		`Optional, whether or not to apply the backendTLS of BackendConfigs to the backend services of global external load balancers.`)
 	flag.Var(&F.GCERateLimit, "gce-ratelimit",
 		`Optional, can be used to rate limit certain GCE API calls. Example usage:
 --gce-ratelimit=ga.Addresses.Get,qps,1.5,5
@@ -202,23 +234,81 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.StringVar(&F.LeaderElection.LockObjectName, "lock-object-name", F.LeaderElection.LockObjectName, "Define the name of the lock object.")
 	flag.DurationVar(&F.NegGCPeriod, "neg-gc-period", 120*time.Second,
 		`Relist and garbage collect NEGs this often.`)
+	flag.IntVar(&F.NegOperationConcurrency, "neg-operation-concurrency", 0,
+		`Maximum number of NEG attach and detach operations running at the same time, across all services. Services take turns, and operations queued for the same NEG are merged. If 0, each operation runs as soon as it is ready.`)
+	flag.IntVar(&F.NegMaxBatchSize, "neg-max-batch-size", 500,
+		`Maximum number of endpoints attached or detached by a single NEG operation, up to 500. Only used with --neg-operation-concurrency.`)
 	flag.BoolVar(&F.EnableReadinessReflector, "enable-readiness-reflector", true, "Enable NEG Readiness Reflector")
+	flag.BoolVar(&F.EnableIGReadinessGate, "enable-ig-readiness-gate", false,
+		`Optional, whether or not to report the health of nodes in instance group backend services in the "cloud.google.com/load-balancer-ig-ready" readiness gate of their pods.`)
+	flag.StringVar(&F.NegReadinessPolicy, "neg-readiness-policy", "any",
+		`Backend services a pod must be healthy in to pass its NEG readiness gate, when its NEG is attached to several: "any" of them, "all" of them, or all of the "named" ones in --neg-readiness-backend-services.`)
+	flag.StringSliceVar(&F.NegReadinessBackendServices, "neg-readiness-backend-services", nil,
+		`Comma separated list of the backend services a pod must be healthy in with --neg-readiness-policy=named. Other backend services are ignored, unless none of the listed ones checks the health of the pod.`)
+	flag.BoolVar(&F.EnableNegCheckpoint, "enable-neg-checkpoint", false,
+		`Optional, whether or not to checkpoint the endpoints of NEGs in the status of their ServiceNetworkEndpointGroup, so that new NEG syncers start without listing the endpoints of their NEGs.`)
+	flag.BoolVar(&F.EnableNegGracefulRemoval, "enable-neg-graceful-removal", false,
+		`Optional, whether or not to detach terminating pods from NEGs as soon as their deletion starts, and report with the NEG drained condition when they are detached. The condition is informational only: it does not delay the deletion of pods, which a preStop hook of the pods may wait on.`)
 	flag.BoolVar(&F.FinalizerAdd, "enable-finalizer-add",
 		F.FinalizerAdd, "Enable adding Finalizer to Ingress.")
 	flag.BoolVar(&F.FinalizerRemove, "enable-finalizer-remove",
 		F.FinalizerRemove, "Enable removing Finalizer from Ingress.")
 	flag.BoolVar(&F.EnableL7Ilb, "enable-l7-ilb", false,
 		`Optional, whether or not to enable L7-ILB.`)
+	flag.BoolVar(&F.EnableL7XLBRegional, "enable-l7-regional-xlb", false,
+		`Optional, whether or not to enable regional external L7 load balancers for Ingresses of class "gce-regional-external".`)
 	flag.BoolVar(&F.EnableASMConfigMapBasedConfig, "enable-asm-config-map-config", false, "Enable ASMConfigMapBasedConfig")
 	flag.StringVar(&F.ASMConfigMapBasedConfigNamespace, "asm-configmap-based-config-namespace", "kube-system", "ASM Configmap based config: configmap namespace")
 	flag.StringVar(&F.ASMConfigMapBasedConfigCMName, "asm-configmap-based-config-cmname", "ingress-controller-asm-cm-config", "ASM Configmap based config: configmap name")
 	flag.BoolVar(&F.EnableNonGCPMode, "enable-non-gcp-mode", false, "Set to true when running on a non-GCP cluster.")
+	flag.BoolVar(&F.EnableNegWorkloads, "enable-neg-workloads", false,
+		`Optional, whether or not to add the Workloads selected by a service to its NEGs, in the zones reported by the Workloads. Requires the workload controller.`)
+	flag.BoolVar(&F.EnableExternalNEGs, "enable-external-negs", false,
+		`Optional, whether or not to route Ingress backends to serverless and Internet NEGs declared by the cloud.google.com/external-neg Service annotation.`)
 	flag.BoolVar(&F.EnableDeleteUnusedFrontends, "enable-delete-unused-frontends", false, "Enable deleting unused gce frontend resources.")
 	flag.BoolVar(&F.EnableV2FrontendNamer, "enable-v2-frontend-namer", false, "Enable v2 ingress frontend naming policy.")
 	flag.BoolVar(&F.RunIngressController, "run-ingress-controller", true, `Optional, whether or not to run IngressController as part of glbc. If set to false, ingress resources will not be processed. Only the L4 Service controller will be run, if that flag is set to true.`)
 	flag.BoolVar(&F.RunL4Controller, "run-l4-controller", false, `Optional, whether or not to run L4 Service Controller as part of glbc. If set to true, services of Type:LoadBalancer with Internal annotation will be processed by this controller.`)
+	flag.IntVar(&F.L4ILBMaxSubsetSize, "l4-ilb-max-subset-size", 250,
+		`Maximum number of nodes in the NEGs of an L4 ILB service, across zones. It caps the subset size of all services, including the ones set with the "networking.gke.io/l4-ilb-subset-size" annotation.`)
 	flag.BoolVar(&F.EnableBackendConfigHealthCheck, "enable-backendconfig-healthcheck", false, "Enable configuration of HealthChecks from the BackendConfig")
 	flag.BoolVar(&F.EnablePSC, "enable-psc", false, "Enable PSC controller")
+	flag.BoolVar(&F.EnableCrossNamespaceBackends, "enable-cross-namespace-backends", false,
+		`Optional, whether or not to allow Ingresses to reference Services in other namespaces that are granted by a BackendGrant.`)
+	flag.StringVar(&F.AuditLogFile, "audit-log-file", "",
+		`Optional, path of a file to which a JSON record of every mutation of a GCE resource is appended.`)
+	flag.StringVar(&F.AuditLogURL, "audit-log-url", "",
+		`Optional, URL of an HTTP endpoint to which a JSON record of every mutation of a GCE resource is POSTed.`)
+	flag.StringVar(&F.TracingOTLPEndpoint, "tracing-otlp-endpoint", "",
+		`Optional, OTLP/HTTP traces endpoint of an OpenTelemetry collector, e.g. http://localhost:4318/v1/traces. If set, spans for controller syncs and the GCE API calls made through the composite layer, on load balancer frontends, backend services and NEGs, are exported to it. Calls on instance groups, health checks and firewalls are not traced.`)
+	flag.Float64Var(&F.TracingSampleFraction, "tracing-sample-fraction", 1.0,
+		`Fraction of syncs that are traced when tracing is enabled, between 0 and 1.`)
+	flag.StringSliceVar(&F.GCELabelAllowlist, "gce-label-allowlist", nil,
+		`Comma separated list of Ingress and Service label keys which are copied as GCE labels onto the forwarding rules and static addresses created for them.`)
+	flag.StringToStringVar(&F.GCEStaticLabels, "gce-static-labels", nil,
+		`Comma separated list of key=value GCE labels which are set on all forwarding rules and static addresses created by the controller.`)
+	flag.DurationSliceVar(&F.CertExpiryWarningThresholds, "cert-expiry-warning-thresholds", []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour},
+		`Comma separated list of durations before the expiry of an Ingress certificate from a Secret at which warning events are emitted.`)
+	flag.BoolVar(&F.EnableACME, "enable-acme", false,
+		`Optional, whether or not to issue certificates for the TLS hosts of Ingresses annotated with networking.gke.io/acme from an ACME directory, using HTTP-01 challenges.`)
+	flag.StringVar(&F.ACMEDirectoryURL, "acme-directory-url", "https://acme-v02.api.letsencrypt.org/directory",
+		`URL of the directory of the ACME server certificates are issued by.`)
+	flag.StringVar(&F.ACMEDirectoryCAFile, "acme-directory-ca-file", "",
+		`Optional, path of a PEM file with the CA certificates the ACME server is verified with, e.g. those of a local test server such as Pebble. The system roots are used if empty.`)
+	flag.StringVar(&F.ACMEEmail, "acme-email", "",
+		`Optional, contact email of the ACME account.`)
+	flag.StringVar(&F.ACMEAccountSecret, "acme-account-secret", "kube-system/glbc-acme-account",
+		`Namespace/name of the Secret the private key of the ACME account is stored in. It is created if it does not exist.`)
+	flag.DurationVar(&F.ACMERenewBefore, "acme-renew-before", 30*24*time.Hour,
+		`Time before the expiry of an issued certificate at which it is renewed.`)
+	flag.StringVar(&F.ACMESolverSvc, "acme-solver-service", "kube-system/glbc-acme-solver",
+		`Service used to serve HTTP-01 challenges, in the format namespace/name. It must select the controller, which answers challenges on --acme-solver-port, and is only served while the controller holds the leader lock.`)
+	flag.StringVar(&F.ACMESolverSvcPortName, "acme-solver-service-port", "http",
+		`Specify the port name or number of the ACME solver service.`)
+	flag.IntVar(&F.ACMESolverPort, "acme-solver-port", 8089,
+		`Port the controller answers HTTP-01 challenges on.`)
+	flag.BoolVar(&F.ACMESelfCheck, "acme-self-check", true,
+		`Whether or not to verify that a challenge is reachable through the load balancer before asking the ACME server to validate it.`)
 }
 
 type RateLimitSpecs struct {
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"k8s.io/api/networking/v1beta1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/fuzz"
 )
 
@@ -31,6 +32,7 @@ var HTTPSRedirects = &HTTPSRedirectsFeature{}
 var responseCodeMap = map[string]int{
 	"MOVED_PERMANENTLY_DEFAULT": 301,
 	"FOUND":                     302,
+	"SEE_OTHER":                 303,
 	"TEMPORARY_REDIRECT":        307,
 	"PERMANENT_REDIRECT":        308,
 }
@@ -40,6 +42,7 @@ type HTTPSRedirectsFeature struct {
 	fuzz.NullValidator
 
 	expectedResponseCode int
+	redirectRules        []frontendconfigv1beta1.RedirectRule
 }
 
 // Name implements fuzz.Feature.
@@ -58,10 +61,12 @@ func (v *HTTPSRedirectsFeature) ConfigureAttributes(env fuzz.ValidatorEnv, ing *
 	if err != nil {
 		return err
 	}
+	v.redirectRules = nil
 	if fc == nil || fc.Spec.RedirectToHttps == nil || !fc.Spec.RedirectToHttps.Enabled {
 		v.expectedResponseCode = 200
 		return nil
 	}
+	v.redirectRules = fc.Spec.RedirectRules
 
 	// Default
 	responseCodeName := "MOVED_PERMANENTLY_DEFAULT"
@@ -81,6 +86,14 @@ func (v *HTTPSRedirectsFeature) ConfigureAttributes(env fuzz.ValidatorEnv, ing *
 // CheckResponse implements fuzz.FeatureValidator.
 func (v *HTTPSRedirectsFeature) CheckResponse(host, path string, resp *http.Response, body []byte) (fuzz.CheckResponseAction, error) {
 	if v.expectedResponseCode != 200 && resp.Request.URL.Scheme == "http" {
+		// Paths of redirect rules are checked by the RedirectRules validator,
+		// their exceptions are served over HTTP.
+		if rule, ok := matchRedirectRule(v.redirectRules, host, path); ok {
+			if isRedirectException(rule, path) {
+				return fuzz.CheckResponseContinue, nil
+			}
+			return fuzz.CheckResponseSkip, nil
+		}
 		if resp.StatusCode == v.expectedResponseCode {
 			return fuzz.CheckResponseSkip, nil
 		} else {
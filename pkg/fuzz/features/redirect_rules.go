@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/api/networking/v1beta1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/fuzz"
+)
+
+// RedirectRules is the redirectRules field of the FrontendConfig.
+var RedirectRules = &RedirectRulesFeature{}
+
+// RedirectRulesFeature implements the associated feature.
+type RedirectRulesFeature struct {
+	fuzz.NullValidator
+
+	rules           []frontendconfigv1beta1.RedirectRule
+	redirectToHttps bool
+}
+
+// Name implements fuzz.Feature.
+func (*RedirectRulesFeature) Name() string {
+	return "RedirectRules"
+}
+
+// NewValidator implements fuzz.Feature.
+func (f *RedirectRulesFeature) NewValidator() fuzz.FeatureValidator {
+	return f
+}
+
+// ConfigureAttributes implements fuzz.Feature.
+func (v *RedirectRulesFeature) ConfigureAttributes(env fuzz.ValidatorEnv, ing *v1beta1.Ingress, a *fuzz.IngressValidatorAttributes) error {
+	fc, err := fuzz.FrontendConfigForIngress(ing, env)
+	if err != nil {
+		return err
+	}
+	v.rules, v.redirectToHttps = nil, false
+	if fc == nil {
+		return nil
+	}
+	for _, rule := range fc.Spec.RedirectRules {
+		code := rule.ResponseCodeName
+		if code == "" {
+			code = "MOVED_PERMANENTLY_DEFAULT"
+		}
+		if _, ok := responseCodeMap[code]; !ok {
+			return fmt.Errorf("error: not a valid response code name for redirect rules: %v", code)
+		}
+	}
+	v.rules = fc.Spec.RedirectRules
+	v.redirectToHttps = fc.Spec.RedirectToHttps != nil && fc.Spec.RedirectToHttps.Enabled
+	return nil
+}
+
+// CheckResponse implements fuzz.FeatureValidator.
+func (v *RedirectRulesFeature) CheckResponse(host, path string, resp *http.Response, body []byte) (fuzz.CheckResponseAction, error) {
+	rule, ok := matchRedirectRule(v.rules, host, path)
+	if !ok || isRedirectException(rule, path) {
+		return fuzz.CheckResponseContinue, nil
+	}
+
+	code := rule.ResponseCodeName
+	if code == "" {
+		code = "MOVED_PERMANENTLY_DEFAULT"
+	}
+	if want := responseCodeMap[code]; resp.StatusCode != want {
+		return fuzz.CheckResponseContinue, fmt.Errorf("want status code %d, got %d", want, resp.StatusCode)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return fuzz.CheckResponseContinue, fmt.Errorf("redirect has no location: %v", err)
+	}
+	wantScheme := resp.Request.URL.Scheme
+	if v.redirectToHttps {
+		wantScheme = "https"
+	}
+	wantHost := resp.Request.Host
+	if wantHost == "" {
+		wantHost = resp.Request.URL.Host
+	}
+	if rule.TargetHost != "" {
+		wantHost = rule.TargetHost
+	}
+	wantPath := path
+	if rule.TargetPathPrefix != "" {
+		wantPath = rule.TargetPathPrefix + strings.TrimPrefix(path, strings.TrimSuffix(rule.PathPrefix, "/"))
+	}
+	if location.Scheme != wantScheme || location.Host != wantHost || location.Path != wantPath {
+		return fuzz.CheckResponseContinue, fmt.Errorf("want location %s://%s%s, got %s", wantScheme, wantHost, wantPath, location)
+	}
+	return fuzz.CheckResponseSkip, nil
+}
+
+// matchRedirectRule returns the redirect rule for host and path, the one with
+// the longest path prefix wins. The rules for "*" apply to the paths of the
+// Ingress without a host.
+func matchRedirectRule(rules []frontendconfigv1beta1.RedirectRule, host, path string) (frontendconfigv1beta1.RedirectRule, bool) {
+	if host == "" {
+		host = "*"
+	}
+	path = strings.TrimSuffix(path, "/*")
+	var (
+		match   frontendconfigv1beta1.RedirectRule
+		longest = -1
+	)
+	for _, rule := range rules {
+		prefix := strings.TrimSuffix(rule.PathPrefix, "/")
+		if rule.Host != host || !pathBelowPrefix(path, prefix) || len(prefix) <= longest {
+			continue
+		}
+		match, longest = rule, len(prefix)
+	}
+	return match, longest >= 0
+}
+
+// isRedirectException returns true if path is served instead of redirected by
+// the rule.
+func isRedirectException(rule frontendconfigv1beta1.RedirectRule, path string) bool {
+	path = strings.TrimSuffix(path, "/*")
+	for _, exception := range rule.Exceptions {
+		if pathBelowPrefix(path, strings.TrimSuffix(exception, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathBelowPrefix returns true if path is the prefix or below it.
+func pathBelowPrefix(path, prefix string) bool {
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
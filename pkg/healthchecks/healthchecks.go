@@ -25,6 +25,7 @@ import (
 	computealpha "google.golang.org/api/compute/v0.alpha"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/ingress-gce/pkg/audit"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
@@ -55,9 +56,11 @@ func NewHealthChecker(cloud HealthCheckProvider, healthCheckPath string, default
 // new returns a *HealthCheck with default settings and specified port/protocol
 func (h *HealthChecks) new(sp utils.ServicePort) *translator.HealthCheck {
 	var hc *translator.HealthCheck
-	if sp.NEGEnabled && !sp.L7ILBEnabled {
+	if sp.NEGEnabled && !sp.L7ILBEnabled && !sp.L7XLBRegionalEnabled {
 		hc = translator.DefaultNEGHealthCheck(sp.Protocol)
-	} else if sp.L7ILBEnabled {
+	} else if sp.L7ILBEnabled || sp.L7XLBRegionalEnabled {
+		// Regional health checks are shared by internal and regional
+		// external load balancers.
 		hc = translator.DefaultILBHealthCheck(sp.Protocol)
 	} else {
 		hc = translator.DefaultHealthCheck(sp.NodePort, sp.Protocol)
@@ -181,21 +184,25 @@ func (h *HealthChecks) create(hc *translator.HealthCheck, bchcc *backendconfigv1
 	switch hc.Version() {
 	case meta.VersionAlpha:
 		klog.V(2).Infof("Creating alpha health check with protocol %v", hc.Type)
-		return h.cloud.CreateAlphaHealthCheck(hc.ToAlphaComputeHealthCheck())
+		alphaHC := hc.ToAlphaComputeHealthCheck()
+		ac := audit.NewContext("HealthChecks", audit.OperationCreate, meta.GlobalKey(alphaHC.Name), meta.VersionAlpha).WithObject(alphaHC)
+		return ac.Observe(h.cloud.CreateAlphaHealthCheck(alphaHC))
 	case meta.VersionBeta:
 		klog.V(2).Infof("Creating beta health check with protocol %v", hc.Type)
 		betaHC, err := hc.ToBetaComputeHealthCheck()
 		if err != nil {
 			return err
 		}
-		return h.cloud.CreateBetaHealthCheck(betaHC)
+		ac := audit.NewContext("HealthChecks", audit.OperationCreate, meta.GlobalKey(betaHC.Name), meta.VersionBeta).WithObject(betaHC)
+		return ac.Observe(h.cloud.CreateBetaHealthCheck(betaHC))
 	case meta.VersionGA:
 		klog.V(2).Infof("Creating health check for port %v with protocol %v", hc.Port, hc.Type)
 		v1hc, err := hc.ToComputeHealthCheck()
 		if err != nil {
 			return err
 		}
-		return h.cloud.CreateHealthCheck(v1hc)
+		ac := audit.NewContext("HealthChecks", audit.OperationCreate, meta.GlobalKey(v1hc.Name), meta.VersionGA).WithObject(v1hc)
+		return ac.Observe(h.cloud.CreateHealthCheck(v1hc))
 	default:
 		return fmt.Errorf("unknown Version: %q", hc.Version())
 	}
@@ -225,21 +232,25 @@ func (h *HealthChecks) update(hc *translator.HealthCheck) error {
 	switch hc.Version() {
 	case meta.VersionAlpha:
 		klog.V(2).Infof("Updating alpha health check with protocol %v", hc.Type)
-		return h.cloud.UpdateAlphaHealthCheck(hc.ToAlphaComputeHealthCheck())
+		alphaHC := hc.ToAlphaComputeHealthCheck()
+		ac := audit.NewContext("HealthChecks", audit.OperationUpdate, meta.GlobalKey(alphaHC.Name), meta.VersionAlpha).WithObject(alphaHC)
+		return ac.Observe(h.cloud.UpdateAlphaHealthCheck(alphaHC))
 	case meta.VersionBeta:
 		klog.V(2).Infof("Updating beta health check with protocol %v", hc.Type)
 		beta, err := hc.ToBetaComputeHealthCheck()
 		if err != nil {
 			return err
 		}
-		return h.cloud.UpdateBetaHealthCheck(beta)
+		ac := audit.NewContext("HealthChecks", audit.OperationUpdate, meta.GlobalKey(beta.Name), meta.VersionBeta).WithObject(beta)
+		return ac.Observe(h.cloud.UpdateBetaHealthCheck(beta))
 	case meta.VersionGA:
 		klog.V(2).Infof("Updating health check %q for port %v with protocol %v", hc.Name, hc.Port, hc.Type)
 		ga, err := hc.ToComputeHealthCheck()
 		if err != nil {
 			return err
 		}
-		return h.cloud.UpdateHealthCheck(ga)
+		ac := audit.NewContext("HealthChecks", audit.OperationUpdate, meta.GlobalKey(ga.Name), meta.VersionGA).WithObject(ga)
+		return ac.Observe(h.cloud.UpdateHealthCheck(ga))
 	default:
 		return fmt.Errorf("unknown Version: %q", hc.Version())
 	}
@@ -277,7 +288,8 @@ func (h *HealthChecks) Delete(name string, scope meta.KeyType) error {
 
 	klog.V(2).Infof("Deleting health check %v", name)
 	// Not using composite here since the tests still rely on the fake health check interface
-	if err := h.cloud.DeleteHealthCheck(name); err != nil {
+	ac := audit.NewContext("HealthChecks", audit.OperationDelete, meta.GlobalKey(name), meta.VersionGA)
+	if err := ac.Observe(h.cloud.DeleteHealthCheck(name)); err != nil {
 		// Ignore error if the deletion candidate does not exist or is being used
 		// by another resource.
 		if utils.IsHTTPErrorCode(err, http.StatusNotFound) || utils.IsInUsedByError(err) {
@@ -21,8 +21,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog"
@@ -97,7 +99,9 @@ func (i *Instances) ensureInstanceGroupAndPorts(name, zone string, ports []int64
 
 	if ig == nil {
 		klog.V(3).Infof("Creating instance group %v/%v.", zone, name)
-		if err = i.cloud.CreateInstanceGroup(&compute.InstanceGroup{Name: name}, zone); err != nil {
+		newIG := &compute.InstanceGroup{Name: name}
+		ac := audit.NewContext("InstanceGroups", audit.OperationCreate, meta.ZonalKey(name, zone), meta.VersionGA).WithObject(newIG).WithController(audit.ControllerInstances)
+		if err = ac.Observe(i.cloud.CreateInstanceGroup(newIG, zone)); err != nil {
 			// Error may come back with StatusConflict meaning the instance group was created by another controller
 			// possibly the Service Controller for internal load balancers.
 			if utils.IsHTTPErrorCode(err, http.StatusConflict) {
@@ -140,7 +144,9 @@ func (i *Instances) ensureInstanceGroupAndPorts(name, zone string, ports []int64
 
 	if len(newNamedPorts) > 0 {
 		klog.V(3).Infof("Instance group %v/%v does not have ports %+v, adding them now.", zone, name, newPorts)
-		if err := i.cloud.SetNamedPortsOfInstanceGroup(ig.Name, zone, append(ig.NamedPorts, newNamedPorts...)); err != nil {
+		namedPorts := append(ig.NamedPorts, newNamedPorts...)
+		ac := audit.NewContext("InstanceGroups", "setNamedPorts", meta.ZonalKey(ig.Name, zone), meta.VersionGA).WithFields(map[string]interface{}{"namedPorts": namedPorts}).WithController(audit.ControllerInstances)
+		if err := ac.Observe(i.cloud.SetNamedPortsOfInstanceGroup(ig.Name, zone, namedPorts)); err != nil {
 			return nil, err
 		}
 	}
@@ -157,7 +163,8 @@ func (i *Instances) DeleteInstanceGroup(name string) error {
 		return err
 	}
 	for _, zone := range zones {
-		if err := i.cloud.DeleteInstanceGroup(name, zone); err != nil {
+		ac := audit.NewContext("InstanceGroups", audit.OperationDelete, meta.ZonalKey(name, zone), meta.VersionGA).WithController(audit.ControllerInstances)
+		if err := ac.Observe(i.cloud.DeleteInstanceGroup(name, zone)); err != nil {
 			if utils.IsNotFoundError(err) {
 				klog.V(3).Infof("Instance group %v in zone %v did not exist", name, zone)
 			} else if utils.IsInUsedByError(err) {
@@ -263,7 +270,9 @@ func (i *Instances) Add(groupName string, names []string) error {
 	var errs []error
 	for zone, nodeNames := range i.splitNodesByZone(names) {
 		klog.V(1).Infof("Adding nodes %v to %v in zone %v", nodeNames, groupName, zone)
-		if err := i.cloud.AddInstancesToInstanceGroup(groupName, zone, i.cloud.ToInstanceReferences(zone, nodeNames)); err != nil {
+		refs := i.cloud.ToInstanceReferences(zone, nodeNames)
+		ac := audit.NewContext("InstanceGroups", "addInstances", meta.ZonalKey(groupName, zone), meta.VersionGA).WithObject(refs).WithController(audit.ControllerInstances)
+		if err := ac.Observe(i.cloud.AddInstancesToInstanceGroup(groupName, zone, refs)); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -282,7 +291,9 @@ func (i *Instances) Remove(groupName string, names []string) error {
 	var errs []error
 	for zone, nodeNames := range i.splitNodesByZone(names) {
 		klog.V(1).Infof("Removing nodes %v from %v in zone %v", nodeNames, groupName, zone)
-		if err := i.cloud.RemoveInstancesFromInstanceGroup(groupName, zone, i.cloud.ToInstanceReferences(zone, nodeNames)); err != nil {
+		refs := i.cloud.ToInstanceReferences(zone, nodeNames)
+		ac := audit.NewContext("InstanceGroups", "removeInstances", meta.ZonalKey(groupName, zone), meta.VersionGA).WithObject(refs).WithController(audit.ControllerInstances)
+		if err := ac.Observe(i.cloud.RemoveInstancesFromInstanceGroup(groupName, zone, refs)); err != nil {
 			errs = append(errs, err)
 		}
 	}
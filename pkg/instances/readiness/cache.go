@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"sync"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// backendServiceCache caches the backend services and the health of their
+// instance groups for ttl. Errors are not cached.
+type backendServiceCache struct {
+	cloud BackendServiceHealth
+	now   func() time.Time
+	ttl   time.Duration
+
+	lock            sync.Mutex
+	backendServices map[string]cachedBackendService
+	// health is keyed by backend service and instance group.
+	health map[healthKey]cachedHealth
+}
+
+type cachedBackendService struct {
+	bs      *compute.BackendService
+	expires time.Time
+}
+
+type healthKey struct {
+	backendService string
+	instanceGroup  string
+}
+
+type cachedHealth struct {
+	health  *compute.BackendServiceGroupHealth
+	expires time.Time
+}
+
+func newBackendServiceCache(cloud BackendServiceHealth, now func() time.Time, ttl time.Duration) *backendServiceCache {
+	return &backendServiceCache{
+		cloud:           cloud,
+		now:             now,
+		ttl:             ttl,
+		backendServices: map[string]cachedBackendService{},
+		health:          map[healthKey]cachedHealth{},
+	}
+}
+
+// backendService returns the global backend service of the name.
+func (c *backendServiceCache) backendService(name string) (*compute.BackendService, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	if cached, ok := c.backendServices[name]; ok && now.Before(cached.expires) {
+		return cached.bs, nil
+	}
+	bs, err := c.cloud.GetGlobalBackendService(name)
+	if err != nil {
+		delete(c.backendServices, name)
+		return nil, err
+	}
+	c.backendServices[name] = cachedBackendService{bs: bs, expires: now.Add(c.ttl)}
+	return bs, nil
+}
+
+// backendServiceHealth returns the health of the instance group in the global
+// backend service of the name.
+func (c *backendServiceCache) backendServiceHealth(name, instanceGroupLink string) (*compute.BackendServiceGroupHealth, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	key := healthKey{backendService: name, instanceGroup: instanceGroupLink}
+	if cached, ok := c.health[key]; ok && now.Before(cached.expires) {
+		return cached.health, nil
+	}
+	health, err := c.cloud.GetGlobalBackendServiceHealth(name, instanceGroupLink)
+	if err != nil {
+		delete(c.health, key)
+		return nil, err
+	}
+	c.health[key] = cachedHealth{health: health, expires: now.Add(c.ttl)}
+	return health, nil
+}
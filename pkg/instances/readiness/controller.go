@@ -0,0 +1,421 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	compute "google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	unversionedcore "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ingctx "k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/instances"
+	negreadiness "k8s.io/ingress-gce/pkg/neg/readiness"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+	"k8s.io/klog"
+)
+
+const (
+	// IGReadinessGate is the readiness gate of pods served by instance group
+	// backends. The condition becomes True once the node of the pod is
+	// healthy in the backend services of the pod.
+	IGReadinessGate = "cloud.google.com/load-balancer-ig-ready"
+
+	maxRetries   = 15
+	healthyState = "HEALTHY"
+	// pollInterval is the interval at which the health of the node of a pod
+	// waiting for the readiness gate is polled.
+	pollInterval = 10 * time.Second
+	// unreadyTimeout is the timeout after which a pod is marked ready even if
+	// its node is not healthy in its backend services yet, as a fail-safe
+	// against backend services that never report health.
+	unreadyTimeout = 10 * time.Minute
+
+	// igReadyReason is the pod condition reason when the node of the pod is
+	// healthy in all backend services, or the pod has no backend service.
+	igReadyReason = "LoadBalancerIGReady"
+	// igReadyTimedOutReason is the pod condition reason when the timeout is
+	// reached but the node of the pod is still not healthy.
+	igReadyTimedOutReason = "LoadBalancerIGTimeout"
+	// igNotReadyReason is the pod condition reason when the node of the pod
+	// is not healthy in all backend services yet.
+	igNotReadyReason = "LoadBalancerIGNotReady"
+)
+
+// BackendServiceHealth is the interface to the backend services of
+// instance groups.
+type BackendServiceHealth interface {
+	GetGlobalBackendService(name string) (*compute.BackendService, error)
+	GetGlobalBackendServiceHealth(name string, instanceGroupLink string) (*compute.BackendServiceGroupHealth, error)
+}
+
+// Controller reflects the health of nodes in the backend services of
+// instance groups into the IG readiness condition of the pods running on
+// them. Traffic of instance group backends reaches pods through the node port
+// of their services on every node, so the condition ensures that a pod is not
+// marked ready, and an old pod not replaced, before the load balancer sends
+// traffic to the node of the pod.
+//
+// With the Cluster external traffic policy the health of a node does not
+// depend on the pods running on it, only on the service having ready
+// endpoints. Services without ready endpoints are skipped, since their node
+// ports cannot become healthy before a pod of the service is ready.
+type Controller struct {
+	// podUpdateLock ensures that at any time there is only one pod status
+	// update.
+	podUpdateLock sync.Mutex
+	client        kubernetes.Interface
+	clock         clock.Clock
+
+	podLister      cache.Indexer
+	serviceLister  cache.Indexer
+	endpointLister cache.Indexer
+	zoneLister     instances.ZoneLister
+	cloud          BackendServiceHealth
+	namer          *namer_util.Namer
+	// cache holds the backend services and their health for a poll
+	// interval, so that the pods polled together share the calls to GCE.
+	cache *backendServiceCache
+
+	eventRecorder record.EventRecorder
+	queue         workqueue.RateLimitingInterface
+}
+
+// NewController returns a new IG readiness controller.
+func NewController(ctx *ingctx.ControllerContext, zoneLister instances.ZoneLister) *Controller {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartLogging(klog.Infof)
+	broadcaster.StartRecordingToSink(&unversionedcore.EventSinkImpl{
+		Interface: ctx.KubeClient.CoreV1().Events(""),
+	})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "ig-readiness-controller"})
+
+	c := newController(ctx.KubeClient, ctx.PodInformer.GetIndexer(), ctx.ServiceInformer.GetIndexer(), ctx.EndpointInformer.GetIndexer(), zoneLister, ctx.Cloud, ctx.ClusterNamer, recorder)
+	ctx.PodInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueuePod,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueuePod(cur)
+		},
+	})
+	return c
+}
+
+func newController(client kubernetes.Interface, podLister, serviceLister, endpointLister cache.Indexer, zoneLister instances.ZoneLister, cloud BackendServiceHealth, namer *namer_util.Namer, recorder record.EventRecorder) *Controller {
+	c := &Controller{
+		client:         client,
+		clock:          clock.RealClock{},
+		podLister:      podLister,
+		serviceLister:  serviceLister,
+		endpointLister: endpointLister,
+		zoneLister:     zoneLister,
+		cloud:          cloud,
+		namer:          namer,
+		eventRecorder:  recorder,
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	c.cache = newBackendServiceCache(cloud, func() time.Time { return c.clock.Now() }, pollInterval)
+	return c
+}
+
+// Run starts the controller and blocks until stopCh is closed.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+	klog.V(2).Infof("Starting IG readiness controller")
+	defer klog.V(2).Infof("Shutting down IG readiness controller")
+
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (c *Controller) enqueuePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok || !needToProcess(pod) {
+		return
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(pod)
+	if err != nil {
+		klog.Errorf("Failed to generate pod key: %v", err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	poll, err := c.syncPod(key.(string))
+	if err != nil {
+		if c.queue.NumRequeues(key) < maxRetries {
+			klog.V(2).Infof("Error syncing pod %q, retrying. Error: %v", key, err)
+			c.queue.AddRateLimited(key)
+			return true
+		}
+		klog.Warningf("Dropping pod %q out of the queue: %v", key, err)
+	}
+	c.queue.Forget(key)
+	if poll {
+		c.queue.AddAfter(key, pollInterval)
+	}
+	return true
+}
+
+// syncPod patches the IG readiness condition of the pod if needed. It returns
+// true if the health of the node of the pod needs to be polled again.
+func (c *Controller) syncPod(podKey string) (bool, error) {
+	c.podUpdateLock.Lock()
+	defer c.podUpdateLock.Unlock()
+
+	obj, exists, err := c.podLister.GetByKey(podKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve pod %q from store: %v", podKey, err)
+	}
+	if !exists {
+		klog.V(5).Infof("Pod %q no longer exists. Skipping", podKey)
+		return false, nil
+	}
+	pod := obj.(*v1.Pod)
+	// The pod may have been updated after being added to the queue. A pod
+	// that is not scheduled is enqueued again once it is.
+	if !needToProcess(pod) || pod.Spec.NodeName == "" {
+		return false, nil
+	}
+
+	expectedCondition, err := c.getExpectedCondition(pod)
+	if err != nil {
+		return false, err
+	}
+	if err := c.ensurePodCondition(pod, expectedCondition); err != nil {
+		return false, err
+	}
+	return expectedCondition.Status != v1.ConditionTrue, nil
+}
+
+// getExpectedCondition returns the expected IG readiness condition of the pod.
+func (c *Controller) getExpectedCondition(pod *v1.Pod) (v1.PodCondition, error) {
+	expectedCondition := v1.PodCondition{Type: IGReadinessGate}
+
+	backendServices, err := c.backendServices(pod)
+	if err != nil {
+		return expectedCondition, err
+	}
+	if len(backendServices) == 0 {
+		expectedCondition.Status = v1.ConditionTrue
+		expectedCondition.Reason = igReadyReason
+		expectedCondition.Message = fmt.Sprintf("Pod is not served by any instance group backend service of a service with ready endpoints. Marking condition %q to True.", IGReadinessGate)
+		return expectedCondition, nil
+	}
+
+	zone, err := c.zoneLister.GetZoneForNode(pod.Spec.NodeName)
+	if err != nil {
+		return expectedCondition, fmt.Errorf("failed to get zone of node %q: %v", pod.Spec.NodeName, err)
+	}
+	var names, unhealthy []string
+	for _, bs := range backendServices {
+		healthy, err := c.nodeHealthy(bs, pod.Spec.NodeName, zone)
+		if err != nil {
+			return expectedCondition, err
+		}
+		names = append(names, bs.Name)
+		if !healthy {
+			unhealthy = append(unhealthy, bs.Name)
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		expectedCondition.Status = v1.ConditionTrue
+		expectedCondition.Reason = igReadyReason
+		expectedCondition.Message = fmt.Sprintf("Node %q of pod has become Healthy in BackendServices %q. Marking condition %q to True.", pod.Spec.NodeName, names, IGReadinessGate)
+		return expectedCondition, nil
+	}
+	if c.clock.Now().After(pod.CreationTimestamp.Add(unreadyTimeout)) {
+		expectedCondition.Status = v1.ConditionTrue
+		expectedCondition.Reason = igReadyTimedOutReason
+		expectedCondition.Message = fmt.Sprintf("Timeout waiting for node %q of pod to become healthy in BackendServices %q. Marking condition %q to True.", pod.Spec.NodeName, unhealthy, IGReadinessGate)
+		return expectedCondition, nil
+	}
+	expectedCondition.Status = v1.ConditionFalse
+	expectedCondition.Reason = igNotReadyReason
+	expectedCondition.Message = fmt.Sprintf("Waiting for node %q of pod to become healthy in BackendServices %q", pod.Spec.NodeName, unhealthy)
+	return expectedCondition, nil
+}
+
+// backendServices returns the instance group backend services of the node
+// ports of the services selecting the pod, sorted by name.
+//
+// Services with the Local external traffic policy are skipped: their node
+// ports only serve the ready pods of the node, so the node could not become
+// healthy before the pod is ready. Services without ready endpoints, on a
+// first rollout or a scale from zero, are skipped for the same reason.
+func (c *Controller) backendServices(pod *v1.Pod) ([]*compute.BackendService, error) {
+	services, err := c.serviceLister.ByIndex(cache.NamespaceIndex, pod.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in namespace %q: %v", pod.Namespace, err)
+	}
+	backendServices := map[string]*compute.BackendService{}
+	for _, obj := range services {
+		svc := obj.(*v1.Service)
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if svc.Spec.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal {
+			continue
+		}
+		ready, err := c.hasReadyEndpoints(svc)
+		if err != nil {
+			return nil, err
+		}
+		if !ready {
+			klog.V(4).Infof("Service %s/%s has no ready endpoints, skipping its backend services for pod %s/%s", svc.Namespace, svc.Name, pod.Namespace, pod.Name)
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort == 0 {
+				continue
+			}
+			bsName := c.namer.IGBackend(int64(port.NodePort))
+			if _, ok := backendServices[bsName]; ok {
+				continue
+			}
+			bs, err := c.cache.backendService(bsName)
+			if err != nil {
+				if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get backend service %q: %v", bsName, err)
+			}
+			backendServices[bsName] = bs
+		}
+	}
+
+	var ret []*compute.BackendService
+	for _, bsName := range sets.StringKeySet(backendServices).List() {
+		ret = append(ret, backendServices[bsName])
+	}
+	return ret, nil
+}
+
+// hasReadyEndpoints returns true if the service has at least one ready
+// endpoint.
+func (c *Controller) hasReadyEndpoints(svc *v1.Service) (bool, error) {
+	obj, exists, err := c.endpointLister.GetByKey(utils.ServiceKeyFunc(svc.Namespace, svc.Name))
+	if err != nil {
+		return false, fmt.Errorf("failed to get endpoints of service %s/%s: %v", svc.Namespace, svc.Name, err)
+	}
+	if !exists {
+		return false, nil
+	}
+	for _, subset := range obj.(*v1.Endpoints).Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nodeHealthy returns true if the node is healthy in the instance group of
+// its zone in the backend service.
+func (c *Controller) nodeHealthy(bs *compute.BackendService, nodeName, zone string) (bool, error) {
+	for _, backend := range bs.Backends {
+		id, err := cloud.ParseResourceURL(backend.Group)
+		if err != nil || id.Key.Name != c.namer.InstanceGroup() || id.Key.Zone != zone {
+			continue
+		}
+		health, err := c.cache.backendServiceHealth(bs.Name, backend.Group)
+		if err != nil {
+			return false, fmt.Errorf("failed to get health of instance group %q in backend service %q: %v", backend.Group, bs.Name, err)
+		}
+		for _, status := range health.HealthStatus {
+			if status == nil {
+				continue
+			}
+			instance, err := cloud.ParseResourceURL(status.Instance)
+			if err != nil || instance.Key.Name != nodeName {
+				continue
+			}
+			return status.HealthState == healthyState, nil
+		}
+	}
+	return false, nil
+}
+
+// ensurePodCondition ensures the IG readiness condition of the pod is as
+// expected.
+func (c *Controller) ensurePodCondition(pod *v1.Pod, expectedCondition v1.PodCondition) error {
+	condition, ok := negreadiness.GetPodCondition(pod, expectedCondition.Type)
+	if ok && reflect.DeepEqual(expectedCondition, condition) {
+		klog.V(4).Infof("Condition %q for pod %s/%s is expected, skip patching", expectedCondition.Type, pod.Namespace, pod.Name)
+		return nil
+	}
+
+	newPod := pod.DeepCopy()
+	negreadiness.SetPodCondition(newPod, expectedCondition)
+	patchBytes, err := patch.StrategicMergePatchBytes(v1.Pod{Status: pod.Status}, v1.Pod{Status: newPod.Status}, v1.Pod{})
+	if err != nil {
+		return fmt.Errorf("failed to prepare patch bytes for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	if c.eventRecorder != nil {
+		c.eventRecorder.Eventf(pod, v1.EventTypeNormal, expectedCondition.Reason, expectedCondition.Message)
+	}
+	if _, err := c.client.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{}, "status"); err != nil {
+		return fmt.Errorf("failed to patch status %q for pod %s/%s: %v", patchBytes, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// needToProcess returns true if the pod has the IG readiness gate and the
+// condition is not True yet.
+func needToProcess(pod *v1.Pod) bool {
+	if pod == nil || pod.DeletionTimestamp != nil {
+		return false
+	}
+	gateExists := false
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == IGReadinessGate {
+			gateExists = true
+		}
+	}
+	condition, ok := negreadiness.GetPodCondition(pod, IGReadinessGate)
+	return gateExists && !(ok && condition.Status == v1.ConditionTrue)
+}
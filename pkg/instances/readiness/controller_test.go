@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/instances"
+	negreadiness "k8s.io/ingress-gce/pkg/neg/readiness"
+	"k8s.io/ingress-gce/pkg/utils"
+	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
+)
+
+const (
+	testNamespace = "ns"
+	testNode      = "node1"
+	testZone      = "zone1"
+	testNodePort  = 30001
+)
+
+type fakeBackendServiceHealth struct {
+	backendServices map[string]*compute.BackendService
+	// healthStates holds the health state of each instance, keyed by
+	// backend service and instance group.
+	healthStates map[string]map[string]map[string]string
+	// getCalls and healthCalls count the calls to the methods.
+	getCalls    int
+	healthCalls int
+}
+
+func (f *fakeBackendServiceHealth) GetGlobalBackendService(name string) (*compute.BackendService, error) {
+	f.getCalls++
+	bs, ok := f.backendServices[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return bs, nil
+}
+
+func (f *fakeBackendServiceHealth) GetGlobalBackendServiceHealth(name string, instanceGroupLink string) (*compute.BackendServiceGroupHealth, error) {
+	f.healthCalls++
+	health := &compute.BackendServiceGroupHealth{}
+	for instance, state := range f.healthStates[name][instanceGroupLink] {
+		health.HealthStatus = append(health.HealthStatus, &compute.HealthStatus{
+			Instance:    cloud.SelfLink(meta.VersionGA, "test-project", "instances", meta.ZonalKey(instance, testZone)),
+			HealthState: state,
+		})
+	}
+	return health, nil
+}
+
+func newTestPod(name string, withGate bool) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         testNamespace,
+			Name:              name,
+			Labels:            map[string]string{"app": "test"},
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: v1.PodSpec{NodeName: testNode},
+	}
+	if withGate {
+		pod.Spec.ReadinessGates = []v1.PodReadinessGate{{ConditionType: IGReadinessGate}}
+	}
+	return pod
+}
+
+func newTestService(name string, trafficPolicy v1.ServiceExternalTrafficPolicyType) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Spec: v1.ServiceSpec{
+			Type:                  v1.ServiceTypeNodePort,
+			Selector:              map[string]string{"app": "test"},
+			Ports:                 []v1.ServicePort{{Port: 80, NodePort: testNodePort}},
+			ExternalTrafficPolicy: trafficPolicy,
+		},
+	}
+}
+
+// newTestEndpoints returns the endpoints of the service, with a ready address
+// if ready.
+func newTestEndpoints(name string, ready bool) *v1.Endpoints {
+	subset := v1.EndpointSubset{Ports: []v1.EndpointPort{{Port: 80}}}
+	address := v1.EndpointAddress{IP: "10.0.0.1"}
+	if ready {
+		subset.Addresses = []v1.EndpointAddress{address}
+	} else {
+		subset.NotReadyAddresses = []v1.EndpointAddress{address}
+	}
+	return &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Subsets:    []v1.EndpointSubset{subset},
+	}
+}
+
+func TestSyncPod(t *testing.T) {
+	t.Parallel()
+
+	namer := namer_util.NewNamer("uid1", "fw1")
+	bsName := namer.IGBackend(testNodePort)
+	igLink := cloud.SelfLink(meta.VersionGA, "test-project", "instanceGroups", meta.ZonalKey(namer.InstanceGroup(), testZone))
+	fakeCloud := &fakeBackendServiceHealth{
+		backendServices: map[string]*compute.BackendService{
+			bsName: {Name: bsName, Backends: []*compute.Backend{{Group: igLink}}},
+		},
+		healthStates: map[string]map[string]map[string]string{
+			bsName: {igLink: {testNode: "UNHEALTHY"}},
+		},
+	}
+	client := fake.NewSimpleClientset()
+	podLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	serviceLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	endpointLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newController(client, podLister, serviceLister, endpointLister, &instances.FakeZoneLister{Zones: []string{testZone}}, fakeCloud, namer, nil)
+	c.clock = fakeClock
+
+	// syncAndValidate syncs the pod and checks its condition in the API
+	// server.
+	syncAndValidate := func(desc string, pod *v1.Pod, expectPoll bool, expectStatus v1.ConditionStatus, expectReason string) {
+		t.Helper()
+		podLister.Add(pod)
+		if _, err := client.CoreV1().Pods(testNamespace).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+			client.CoreV1().Pods(testNamespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+		}
+		poll, err := c.syncPod(fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		if err != nil {
+			t.Fatalf("For test case %q, syncPod() = %v, want nil", desc, err)
+		}
+		if poll != expectPoll {
+			t.Errorf("For test case %q, expect poll = %v, but got %v", desc, expectPoll, poll)
+		}
+		updated, err := client.CoreV1().Pods(testNamespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("For test case %q, failed to get pod: %v", desc, err)
+		}
+		condition, ok := negreadiness.GetPodCondition(updated, IGReadinessGate)
+		if expectStatus == "" {
+			if ok {
+				t.Errorf("For test case %q, expect no condition, but got %+v", desc, condition)
+			}
+			return
+		}
+		if !ok || condition.Status != expectStatus || condition.Reason != expectReason {
+			t.Errorf("For test case %q, expect condition status %q with reason %q, but got %+v", desc, expectStatus, expectReason, condition)
+		}
+	}
+
+	syncAndValidate("pod without readiness gate", newTestPod("pod1", false), false, "", "")
+	syncAndValidate("pod without backend service", newTestPod("pod2", true), false, v1.ConditionTrue, igReadyReason)
+
+	serviceLister.Add(newTestService("local", v1.ServiceExternalTrafficPolicyTypeLocal))
+	syncAndValidate("pod of service with local traffic policy", newTestPod("pod3", true), false, v1.ConditionTrue, igReadyReason)
+
+	// The node port of a service without ready endpoints cannot become
+	// healthy, as on a first rollout.
+	serviceLister.Add(newTestService("cluster", v1.ServiceExternalTrafficPolicyTypeCluster))
+	syncAndValidate("service without endpoints", newTestPod("pod4", true), false, v1.ConditionTrue, igReadyReason)
+	endpointLister.Add(newTestEndpoints("cluster", false))
+	syncAndValidate("service without ready endpoints", newTestPod("pod5", true), false, v1.ConditionTrue, igReadyReason)
+	if fakeCloud.getCalls != 0 || fakeCloud.healthCalls != 0 {
+		t.Errorf("Got %d backend service and %d health calls for services without ready endpoints, want none", fakeCloud.getCalls, fakeCloud.healthCalls)
+	}
+
+	endpointLister.Update(newTestEndpoints("cluster", true))
+	pod := newTestPod("pod6", true)
+	syncAndValidate("node is unhealthy", pod, true, v1.ConditionFalse, igNotReadyReason)
+
+	// The pods polled within a poll interval share the calls to GCE.
+	syncAndValidate("node is unhealthy for another pod", newTestPod("pod7", true), true, v1.ConditionFalse, igNotReadyReason)
+	if fakeCloud.getCalls != 1 || fakeCloud.healthCalls != 1 {
+		t.Errorf("Got %d backend service and %d health calls within a poll interval, want 1 and 1", fakeCloud.getCalls, fakeCloud.healthCalls)
+	}
+
+	fakeCloud.healthStates[bsName][igLink][testNode] = healthyState
+	fakeClock.Step(pollInterval)
+	syncAndValidate("node is healthy", pod, false, v1.ConditionTrue, igReadyReason)
+	if fakeCloud.getCalls != 2 || fakeCloud.healthCalls != 2 {
+		t.Errorf("Got %d backend service and %d health calls after a poll interval, want 2 and 2", fakeCloud.getCalls, fakeCloud.healthCalls)
+	}
+
+	fakeCloud.healthStates[bsName][igLink][testNode] = "UNHEALTHY"
+	pod = newTestPod("pod8", true)
+	fakeClock.Step(unreadyTimeout + time.Second)
+	syncAndValidate("timeout", pod, false, v1.ConditionTrue, igReadyTimedOutReason)
+}
+
+func TestNeedToProcess(t *testing.T) {
+	t.Parallel()
+
+	pod := newTestPod("pod", true)
+	if !needToProcess(pod) {
+		t.Errorf("needToProcess() = false for a pod with the readiness gate, want true")
+	}
+	negreadiness.SetPodCondition(pod, v1.PodCondition{Type: IGReadinessGate, Status: v1.ConditionFalse})
+	if !needToProcess(pod) {
+		t.Errorf("needToProcess() = false for a pod with a False condition, want true")
+	}
+	negreadiness.SetPodCondition(pod, v1.PodCondition{Type: IGReadinessGate, Status: v1.ConditionTrue})
+	if needToProcess(pod) {
+		t.Errorf("needToProcess() = true for a pod with a True condition, want false")
+	}
+	if needToProcess(newTestPod("pod", false)) {
+		t.Errorf("needToProcess() = true for a pod without the readiness gate, want false")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package l4
 
 import (
+	gocontext "context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -279,7 +280,7 @@ func (l4c *L4Controller) linkNEG(l4 *loadbalancers.L4) error {
 	for _, zone := range zones {
 		groupKeys = append(groupKeys, backends.GroupKey{Zone: zone})
 	}
-	return l4c.NegLinker.Link(l4.ServicePort, groupKeys)
+	return l4c.NegLinker.Link(gocontext.Background(), l4.ServicePort, groupKeys)
 }
 
 func (l4c *L4Controller) sync(key string) error {
@@ -294,6 +295,18 @@ func (l4c *L4Controller) sync(key string) error {
 		klog.V(3).Infof("Ignoring delete of service %s not managed by L4 controller", key)
 		return nil
 	}
+	// Skip all GCE mutations while reconciliation of the service is paused.
+	// Deletion of the ILB and removal of the finalizer proceed.
+	if wantsILB, _ := annotations.WantsL4ILB(svc); wantsILB && !needsDeletion(svc) {
+		paused := annotations.FromService(svc).PauseReconciliation()
+		if err := common.EnsureServicePausedStatus(svc, l4c.ctx.KubeClient, l4c.ctx.Recorder(svc.Namespace), paused); err != nil {
+			return err
+		}
+		if paused {
+			klog.V(2).Infof("Skipping sync of service %s, reconciliation is paused", key)
+			return nil
+		}
+	}
 	if needsDeletion(svc) {
 		klog.V(2).Infof("Deleting ILB resources for service %s managed by L4 controller", key)
 		return l4c.processServiceDeletion(key, svc)
@@ -412,6 +425,14 @@ func (l4c *L4Controller) needsUpdate(oldService *v1.Service, newService *v1.Serv
 			return true
 		}
 	}
+	if utils.GCELabelsEnabled() {
+		oldLabels, _ := utils.GCELabels(oldService.Labels)
+		newLabels, _ := utils.GCELabels(newService.Labels)
+		if !reflect.DeepEqual(oldLabels, newLabels) {
+			recorder.Eventf(newService, v1.EventTypeNormal, "Labels", "%v -> %v", oldLabels, newLabels)
+			return true
+		}
+	}
 	if oldService.UID != newService.UID {
 		recorder.Eventf(newService, v1.EventTypeNormal, "UID", "%v -> %v",
 			oldService.UID, newService.UID)
@@ -31,6 +31,7 @@ import (
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -56,7 +57,7 @@ func newServiceController(t *testing.T) *L4Controller {
 		Namespace:    api_v1.NamespaceAll,
 		ResyncPeriod: 1 * time.Minute,
 	}
-	ctx := context.NewControllerContext(nil, kubeClient, nil, nil, nil, nil, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig)
+	ctx := context.NewControllerContext(nil, kubeClient, nil, nil, nil, nil, nil, fakeGCE, namer, "" /*kubeSystemUID*/, ctxConfig)
 	// Add some nodes so that NEG linker kicks in during ILB creation.
 	nodes, err := test.CreateAndInsertNodes(ctx.Cloud, []string{"instance-1"}, vals.ZoneName)
 	if err != nil {
@@ -306,6 +307,59 @@ func TestProcessDeletion(t *testing.T) {
 	}
 }
 
+// TestProcessPausedService verifies that no load balancer is created for a service while its
+// reconciliation is paused, that it is created once reconciliation is resumed, and that it is
+// deleted with the service even while paused.
+func TestProcessPausedService(t *testing.T) {
+	l4c := newServiceController(t)
+	newSvc := test.NewL4ILBService(false, 8080)
+	newSvc.Annotations[annotations.PauseReconciliationKey] = "true"
+	addILBService(l4c, newSvc)
+	addNEG(l4c, newSvc)
+	err := l4c.sync(getKeyForSvc(newSvc, t))
+	if err != nil {
+		t.Errorf("Failed to sync paused service %s, err %v", newSvc.Name, err)
+	}
+	newSvc, err = l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Errorf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	validateSvcStatus(newSvc, false, t)
+	if _, ok := newSvc.Annotations[annotations.ServicePausedKey]; !ok {
+		t.Errorf("Expected annotation %q on paused service, Got %v", annotations.ServicePausedKey, newSvc.Annotations)
+	}
+
+	// Resume reconciliation.
+	delete(newSvc.Annotations, annotations.PauseReconciliationKey)
+	updateILBService(l4c, newSvc)
+	err = l4c.sync(getKeyForSvc(newSvc, t))
+	if err != nil {
+		t.Errorf("Failed to sync resumed service %s, err %v", newSvc.Name, err)
+	}
+	newSvc, err = l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Errorf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	validateSvcStatus(newSvc, true, t)
+	if _, ok := newSvc.Annotations[annotations.ServicePausedKey]; ok {
+		t.Errorf("Unexpected annotation %q on resumed service", annotations.ServicePausedKey)
+	}
+
+	// Deleting a paused service deletes the load balancer and removes the finalizer.
+	newSvc.Annotations[annotations.PauseReconciliationKey] = "true"
+	newSvc.DeletionTimestamp = &v1.Time{}
+	updateILBService(l4c, newSvc)
+	err = l4c.sync(getKeyForSvc(newSvc, t))
+	if err != nil {
+		t.Errorf("Failed to sync deleted service %s, err %v", newSvc.Name, err)
+	}
+	newSvc, err = l4c.client.CoreV1().Services(newSvc.Namespace).Get(context2.TODO(), newSvc.Name, v1.GetOptions{})
+	if err != nil {
+		t.Errorf("Failed to lookup service %s, err: %v", newSvc.Name, err)
+	}
+	validateSvcStatus(newSvc, false, t)
+}
+
 func TestProcessCreateLegacyService(t *testing.T) {
 	l4c := newServiceController(t)
 	prevMetrics := getLatencyMetric(t)
@@ -365,3 +419,22 @@ func TestProcessUpdateClusterIPToILBService(t *testing.T) {
 	// this will be a create metric since an ILB IP is being assigned for the first time.
 	prevMetrics.ValidateDiff(getLatencyMetric(t), &latencyMetricInfo{createCount: 1, upperBoundSeconds: 1}, t)
 }
+
+func TestNeedsUpdateGCELabels(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"team"}
+	defer func() { flags.F.GCELabelAllowlist = nil }()
+
+	l4c := newServiceController(t)
+	oldSvc := test.NewL4ILBService(false, 8080)
+	oldSvc.Labels = map[string]string{"team": "payments", "version": "1"}
+
+	newSvc := oldSvc.DeepCopy()
+	newSvc.Labels["version"] = "2"
+	if l4c.needsUpdate(oldSvc, newSvc) {
+		t.Errorf("Incorrectly marked service %v as needing update after a change of a label that is not allowlisted", newSvc)
+	}
+	newSvc.Labels["team"] = "search"
+	if !l4c.needsUpdate(oldSvc, newSvc) {
+		t.Errorf("Incorrectly marked service %v as not needing update after a change of an allowlisted label", newSvc)
+	}
+}
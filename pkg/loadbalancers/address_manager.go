@@ -18,20 +18,25 @@ package loadbalancers
 
 import (
 	"fmt"
+	"k8s.io/ingress-gce/pkg/audit"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/legacy-cloud-providers/gce"
 	"net/http"
+	"reflect"
 
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/klog"
 )
 
 // Original file in https://github.com/kubernetes/legacy-cloud-providers/blob/6aa80146c33550e908aed072618bd7f9998837f6/gce/gce_address_manager.go
 type addressManager struct {
 	logPrefix   string
-	svc         gce.CloudAddressService
+	svc         *gce.Cloud
 	name        string
 	serviceName string
 	targetIP    string
@@ -39,9 +44,12 @@ type addressManager struct {
 	region      string
 	subnetURL   string
 	tryRelease  bool
+	// labels are the GCE labels of the address reserved by the controller,
+	// nil if labels are not propagated.
+	labels map[string]string
 }
 
-func newAddressManager(svc gce.CloudAddressService, serviceName, region, subnetURL, name, targetIP string, addressType cloud.LbScheme) *addressManager {
+func newAddressManager(svc *gce.Cloud, serviceName, region, subnetURL, name, targetIP string, addressType cloud.LbScheme, labels map[string]string) *addressManager {
 	return &addressManager{
 		svc:         svc,
 		logPrefix:   fmt.Sprintf("AddressManager(%q)", name),
@@ -52,6 +60,7 @@ func newAddressManager(svc gce.CloudAddressService, serviceName, region, subnetU
 		addressType: addressType,
 		tryRelease:  true,
 		subnetURL:   subnetURL,
+		labels:      labels,
 	}
 }
 
@@ -76,12 +85,16 @@ func (am *addressManager) HoldAddress() (string, error) {
 		// If address exists, check if the address had the expected attributes.
 		validationError := am.validateAddress(addr)
 		if validationError == nil {
+			if err := am.ensureLabels(); err != nil {
+				return "", err
+			}
 			klog.V(4).Infof("%v: address %q already reserves IP %q Type %q. No further action required.", am.logPrefix, addr.Name, addr.Address, addr.AddressType)
 			return addr.Address, nil
 		}
 
 		klog.V(2).Infof("%v: deleting existing address because %v", am.logPrefix, validationError)
-		err := am.svc.DeleteRegionAddress(addr.Name, am.region)
+		ac := audit.NewContext("Addresses", audit.OperationDelete, meta.RegionalKey(addr.Name, am.region), meta.VersionGA)
+		err := ac.Observe(am.svc.DeleteRegionAddress(addr.Name, am.region))
 		if err != nil {
 			if utils.IsNotFoundError(err) {
 				klog.V(4).Infof("%v: address %q was not found. Ignoring.", am.logPrefix, addr.Name)
@@ -105,7 +118,8 @@ func (am *addressManager) ReleaseAddress() error {
 
 	klog.V(4).Infof("%v: releasing address %q named %q", am.logPrefix, am.targetIP, am.name)
 	// Controller only ever tries to unreserve the address named with the load balancer's name.
-	err := am.svc.DeleteRegionAddress(am.name, am.region)
+	ac := audit.NewContext("Addresses", audit.OperationDelete, meta.RegionalKey(am.name, am.region), meta.VersionGA)
+	err := ac.Observe(am.svc.DeleteRegionAddress(am.name, am.region))
 	if err != nil {
 		if utils.IsNotFoundError(err) {
 			klog.Warningf("%v: address %q was not found. Ignoring.", am.logPrefix, am.name)
@@ -130,7 +144,7 @@ func (am *addressManager) ensureAddressReservation() (string, error) {
 		Subnetwork:  am.subnetURL,
 	}
 
-	reserveErr := am.svc.ReserveRegionAddress(newAddr, am.region)
+	reserveErr := am.reserve(newAddr)
 	if reserveErr == nil {
 		if newAddr.Address != "" {
 			klog.V(4).Infof("%v: successfully reserved IP %q with name %q", am.logPrefix, newAddr.Address, newAddr.Name)
@@ -185,6 +199,48 @@ func (am *addressManager) ensureAddressReservation() (string, error) {
 	return addr.Address, nil
 }
 
+// reserve reserves newAddr, with the labels of the address manager. Labels
+// can only be set through the beta API.
+func (am *addressManager) reserve(newAddr *compute.Address) error {
+	if len(am.labels) == 0 {
+		ac := audit.NewContext("Addresses", audit.OperationCreate, meta.RegionalKey(newAddr.Name, am.region), meta.VersionGA).WithObject(newAddr)
+		return ac.Observe(am.svc.ReserveRegionAddress(newAddr, am.region))
+	}
+	betaAddr := &computebeta.Address{
+		Name:        newAddr.Name,
+		Description: newAddr.Description,
+		Address:     newAddr.Address,
+		AddressType: newAddr.AddressType,
+		Subnetwork:  newAddr.Subnetwork,
+		Labels:      am.labels,
+	}
+	ac := audit.NewContext("Addresses", audit.OperationCreate, meta.RegionalKey(newAddr.Name, am.region), meta.VersionBeta).WithObject(betaAddr)
+	return ac.Observe(am.svc.ReserveBetaRegionAddress(betaAddr, am.region))
+}
+
+// ensureLabels updates the labels of the existing address of the address
+// manager to its labels, keeping labels which are not managed by the
+// controller.
+func (am *addressManager) ensureLabels() error {
+	if am.labels == nil {
+		return nil
+	}
+	key := meta.RegionalKey(am.name, am.region)
+	addr, err := composite.GetAddress(am.svc, key, meta.VersionBeta)
+	if err != nil {
+		return err
+	}
+	labels, err := utils.MergeGCELabels(addr.Labels, am.labels)
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 && len(addr.Labels) == 0 || reflect.DeepEqual(labels, addr.Labels) {
+		return nil
+	}
+	klog.V(2).Infof("%v: updating labels of address %q from %v to %v", am.logPrefix, am.name, addr.Labels, labels)
+	return composite.SetLabelsForAddress(am.svc, key, labels, addr.LabelFingerprint)
+}
+
 func (am *addressManager) validateAddress(addr *compute.Address) error {
 	if am.targetIP != "" && am.targetIP != addr.Address {
 		return fmt.Errorf("address %q does not have the expected IP %q, actual: %q", addr.Name, am.targetIP, addr.Address)
@@ -201,5 +257,6 @@ func (am *addressManager) isManagedAddress(addr *compute.Address) bool {
 }
 
 func ensureAddressDeleted(svc gce.CloudAddressService, name, region string) error {
-	return utils.IgnoreHTTPNotFound(svc.DeleteRegionAddress(name, region))
+	ac := audit.NewContext("Addresses", audit.OperationDelete, meta.RegionalKey(name, region), meta.VersionGA)
+	return utils.IgnoreHTTPNotFound(ac.Observe(svc.DeleteRegionAddress(name, region)))
 }
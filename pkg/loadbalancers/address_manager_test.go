@@ -17,13 +17,19 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 	"k8s.io/legacy-cloud-providers/gce"
 )
@@ -40,7 +46,7 @@ func TestAddressManagerNoRequestedIP(t *testing.T) {
 	require.NoError(t, err)
 	targetIP := ""
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	testHoldAddress(t, mgr, svc, testLBName, vals.Region, targetIP, string(cloud.SchemeInternal))
 	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
 }
@@ -51,11 +57,47 @@ func TestAddressManagerBasic(t *testing.T) {
 	require.NoError(t, err)
 	targetIP := "1.1.1.1"
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	testHoldAddress(t, mgr, svc, testLBName, vals.Region, targetIP, string(cloud.SchemeInternal))
 	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
 }
 
+// TestAddressManagerLabels tests that the address is reserved with the labels
+// of the address manager.
+func TestAddressManagerLabels(t *testing.T) {
+	svc, err := fakeGCECloud(vals)
+	require.NoError(t, err)
+	labels := map[string]string{"team": "payments"}
+
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, "", cloud.SchemeInternal, labels)
+	testHoldAddress(t, mgr, svc, testLBName, vals.Region, "", string(cloud.SchemeInternal))
+	addr, err := svc.GetBetaRegionAddress(testLBName, vals.Region)
+	require.NoError(t, err)
+	assert.Equal(t, labels, addr.Labels)
+	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
+}
+
+// TestAddressManagerOrphanedLabels tests that the labels of an orphaned
+// address are updated when it is reused, keeping labels set by others.
+func TestAddressManagerOrphanedLabels(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"team"}
+	defer func() { flags.F.GCELabelAllowlist = nil }()
+	svc, err := fakeGCECloud(vals)
+	require.NoError(t, err)
+	targetIP := "1.1.1.1"
+
+	addr := &computebeta.Address{Name: testLBName, Address: targetIP, AddressType: string(cloud.SchemeInternal), Labels: map[string]string{"team": "payments", "owner": "alice"}}
+	err = svc.ReserveBetaRegionAddress(addr, vals.Region)
+	require.NoError(t, err)
+
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, map[string]string{"team": "search"})
+	testHoldAddress(t, mgr, svc, testLBName, vals.Region, targetIP, string(cloud.SchemeInternal))
+	got, err := svc.GetBetaRegionAddress(testLBName, vals.Region)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "search", "owner": "alice"}, got.Labels)
+	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
+}
+
 // TestAddressManagerOrphaned tests the case where the address exists with the IP being equal
 // to the requested address (forwarding rule or loadbalancer IP).
 func TestAddressManagerOrphaned(t *testing.T) {
@@ -67,7 +109,7 @@ func TestAddressManagerOrphaned(t *testing.T) {
 	err = svc.ReserveRegionAddress(addr, vals.Region)
 	require.NoError(t, err)
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	testHoldAddress(t, mgr, svc, testLBName, vals.Region, targetIP, string(cloud.SchemeInternal))
 	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
 }
@@ -84,7 +126,7 @@ func TestAddressManagerOutdatedOrphan(t *testing.T) {
 	err = svc.ReserveRegionAddress(addr, vals.Region)
 	require.NoError(t, err)
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	testHoldAddress(t, mgr, svc, testLBName, vals.Region, targetIP, string(cloud.SchemeInternal))
 	testReleaseAddress(t, mgr, svc, testLBName, vals.Region)
 }
@@ -100,7 +142,7 @@ func TestAddressManagerExternallyOwned(t *testing.T) {
 	err = svc.ReserveRegionAddress(addr, vals.Region)
 	require.NoError(t, err)
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	ipToUse, err := mgr.HoldAddress()
 	require.NoError(t, err)
 	assert.NotEmpty(t, ipToUse)
@@ -123,7 +165,7 @@ func TestAddressManagerBadExternallyOwned(t *testing.T) {
 	err = svc.ReserveRegionAddress(addr, vals.Region)
 	require.NoError(t, err)
 
-	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal)
+	mgr := newAddressManager(svc, testSvcName, vals.Region, testSubnet, testLBName, targetIP, cloud.SchemeInternal, nil)
 	ad, err := mgr.HoldAddress()
 	assert.NotNil(t, err) // FIXME
 	require.Equal(t, ad, "")
@@ -158,7 +200,18 @@ func fakeGCECloud(vals gce.TestClusterValues) (*gce.Cloud, error) {
 	mockGCE.MockForwardingRules.InsertHook = mock.InsertFwdRuleHook
 	mockGCE.MockAddresses.InsertHook = mock.InsertAddressHook
 	mockGCE.MockAlphaAddresses.InsertHook = mock.InsertAlphaAddressHook
+	mockGCE.MockBetaAddresses.InsertHook = insertBetaAddressHook
+	mockGCE.MockBetaAddresses.X = mock.AddressAttributes{}
 	mockGCE.MockAlphaAddresses.X = mock.AddressAttributes{}
 	mockGCE.MockAddresses.X = mock.AddressAttributes{}
+	composite.RegisterFakeExtension(gce, mockGCE)
 	return gce, nil
 }
+
+// insertBetaAddressHook mocks inserting a beta address. The hook of the mock
+// package takes the GA mock, which shares its objects with the beta mock.
+func insertBetaAddressHook(ctx context.Context, key *meta.Key, obj *computebeta.Address, m *cloud.MockBetaAddresses) (bool, error) {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	return mock.InsertBetaAddressHook(ctx, key, obj, &cloud.MockAddresses{Objects: m.Objects, ProjectRouter: m.ProjectRouter, X: m.X})
+}
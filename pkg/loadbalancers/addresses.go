@@ -56,12 +56,21 @@ func (l *L7) checkStaticIP() (err error) {
 		return err
 	}
 
-	ip, _ := composite.GetAddress(l.cloud, key, meta.VersionGA)
+	ip, _ := composite.GetAddressWithContext(l.traceCtx, l.cloud, key, labelsVersion(meta.VersionGA))
+	if ip != nil && !l.hasNetworkTier(ip.NetworkTier) {
+		// The forwarding rules were recreated in the new network tier, with
+		// a new IP.
+		klog.V(3).Infof("Deleting static ip %v of network tier %v", managedStaticIPName, ip.NetworkTier)
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteAddressWithContext(l.traceCtx, l.cloud, key, meta.VersionGA)); err != nil {
+			return err
+		}
+		ip = nil
+	}
 	if ip == nil {
 		klog.V(3).Infof("Creating static ip %v", managedStaticIPName)
 		address := l.newStaticAddress(managedStaticIPName)
 
-		err = composite.CreateAddress(l.cloud, key, address)
+		err = composite.CreateAddressWithContext(l.traceCtx, l.cloud, key, address)
 		if err != nil {
 			if utils.IsHTTPErrorCode(err, http.StatusConflict) ||
 				utils.IsHTTPErrorCode(err, http.StatusBadRequest) {
@@ -71,18 +80,31 @@ func (l *L7) checkStaticIP() (err error) {
 			}
 			return err
 		}
-		ip, err = composite.GetAddress(l.cloud, key, meta.VersionGA)
+		ip, err = composite.GetAddressWithContext(l.traceCtx, l.cloud, key, labelsVersion(meta.VersionGA))
 		if err != nil {
 			return err
 		}
 	}
+	if err := ensureAddressLabels(l.traceCtx, l.cloud, key, ip, l.runtimeInfo.Ingress, l.recorder); err != nil {
+		return err
+	}
 	l.ip = ip
 	return nil
 }
 
+// getManagedStaticIP returns the ingress managed static IP with the given name
+// in the frontend scope.
+func (l *L7) getManagedStaticIP(name string) (*composite.Address, error) {
+	key, err := l.CreateKey(name)
+	if err != nil {
+		return nil, err
+	}
+	return composite.GetAddressWithContext(l.traceCtx, l.cloud, key, meta.VersionGA)
+}
+
 func (l *L7) newStaticAddress(name string) *composite.Address {
 	isInternal := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(&l.ingress)
-	address := &composite.Address{Name: name, Address: l.fw.IPAddress, Version: meta.VersionGA}
+	address := &composite.Address{Name: name, Address: l.fw.IPAddress, NetworkTier: l.frontendNetworkTier(), Version: meta.VersionGA}
 	if isInternal {
 		// Used for L7 ILB
 		address.AddressType = "INTERNAL"
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+// certificateMapVersion is the API version of target https proxies with a
+// certificate map, which is read, created and patched with it. A certificate
+// map replaces the SSL certificates of the proxy.
+const certificateMapVersion = meta.VersionAlpha
+
+// certificateMapInUse returns the certificate map of the target https proxy,
+// which is read with the alpha API if proxy was read with another version.
+// Proxies with SSL certificates are known to have no certificate map.
+func (l *L7) certificateMapInUse(proxy *composite.TargetHttpsProxy) (string, error) {
+	if proxy.Version == certificateMapVersion || len(proxy.SslCertificates) > 0 {
+		return proxy.CertificateMap, nil
+	}
+	key, err := l.CreateKey(proxy.Name)
+	if err != nil {
+		return "", err
+	}
+	alphaProxy, err := composite.GetTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, certificateMapVersion)
+	if err != nil {
+		return "", err
+	}
+	return alphaProxy.CertificateMap, nil
+}
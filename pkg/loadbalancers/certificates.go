@@ -21,7 +21,6 @@ import (
 	"strings"
 
 	"k8s.io/ingress-gce/pkg/composite"
-	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
@@ -30,8 +29,7 @@ import (
 const SslCertificateMissing = "SslCertificateMissing"
 
 func (l *L7) checkSSLCert() error {
-	isL7ILB := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(l.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, l.namer)
+	tr := l.newTranslator()
 	env := &translator.Env{Region: l.cloud.Region(), Project: l.cloud.ProjectID()}
 	translatorCerts := tr.ToCompositeSSLCertificates(env, l.runtimeInfo.TLSName, l.runtimeInfo.TLS, l.Versions().SslCertificate)
 
@@ -48,6 +46,15 @@ func (l *L7) checkSSLCert() error {
 	}
 
 	l.oldSSLCerts = existingSecretsSslCerts
+	// The certificate map replaces the SSL certificates of the target proxy,
+	// those created for the load balancer are deleted once it is served.
+	if l.certificateMap != "" {
+		if len(translatorCerts) > 0 {
+			klog.V(2).Infof("Ignoring %d SSL certificates of %v in favor of certificate map %v", len(translatorCerts), l, l.certificateMap)
+		}
+		l.sslCerts = nil
+		return nil
+	}
 	sslCerts, err := l.createSslCertificates(existingSecretsSslCerts, translatorCerts)
 	if err != nil {
 		errs = append(errs, err)
@@ -103,7 +110,7 @@ func (l *L7) createSslCertificates(existingCerts, translatorCerts []*composite.S
 			klog.Errorf("l.CreateKey(%s) = %v", translatorCert.Name, err)
 			return nil, err
 		}
-		err = composite.CreateSslCertificate(l.cloud, key, translatorCert)
+		err = composite.CreateSslCertificateWithContext(l.traceCtx, l.cloud, key, translatorCert)
 		if err != nil {
 			klog.Errorf("Failed to create new sslCertificate %q for %q - %v", translatorCert.Name, l, err)
 			failedCerts = append(failedCerts, translatorCert.Name+" Error:"+err.Error())
@@ -112,7 +119,7 @@ func (l *L7) createSslCertificates(existingCerts, translatorCerts []*composite.S
 		visitedCertMap[translatorCert.Name] = fmt.Sprintf("secret cert:%q", translatorCert.Certificate)
 
 		// Get SSLCert
-		cert, err := composite.GetSslCertificate(l.cloud, key, translatorCert.Version)
+		cert, err := composite.GetSslCertificateWithContext(l.traceCtx, l.cloud, key, translatorCert.Version)
 		if err != nil {
 			klog.Errorf("GetSslCertificate(_, %v, %v) = %v", key, translatorCert.Version, err)
 			return nil, err
@@ -154,7 +161,7 @@ func (l *L7) getIngressManagedSslCerts() ([]*composite.SslCertificate, error) {
 		return nil, err
 	}
 	version := l.Versions().SslCertificate
-	certs, err := composite.ListSslCertificates(l.cloud, key, version)
+	certs, err := composite.ListSslCertificatesWithContext(l.traceCtx, l.cloud, key, version)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +194,7 @@ func (l *L7) getIngressManagedSslCerts() ([]*composite.SslCertificate, error) {
 			if err != nil {
 				return nil, err
 			}
-			cert, _ := composite.GetSslCertificate(l.cloud, key, version)
+			cert, _ := composite.GetSslCertificateWithContext(l.traceCtx, l.cloud, key, version)
 			if cert != nil {
 				klog.V(4).Infof("Populating legacy ssl cert %s for l7 %s", cert.Name, l)
 				result = append(result, cert)
@@ -213,7 +220,7 @@ func (l *L7) deleteOldSSLCerts() {
 		}
 		klog.V(3).Infof("Cleaning up old SSL Certificate %s", cert.Name)
 		key, _ := l.CreateKey(cert.Name)
-		if certErr := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificate(l.cloud, key, l.Versions().SslCertificate)); certErr != nil {
+		if certErr := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificateWithContext(l.traceCtx, l.cloud, key, l.Versions().SslCertificate)); certErr != nil {
 			klog.Errorf("Old cert %s delete failed - %v", cert.Name, certErr)
 		}
 	}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/translator"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// ensureServerTlsPolicy ensures the trust config and the server TLS policy of
+// the client TLS config of the frontend config. It returns the link of the
+// policy, empty if client TLS is not configured. Both are updated in place,
+// so rotating the CA certificates does not touch the target HTTPS proxy.
+func (l *L7) ensureServerTlsPolicy() (string, error) {
+	tr := l.newTranslator()
+	env := &translator.Env{FrontendConfig: l.runtimeInfo.FrontendConfig}
+	trustConfig, err := tr.ToTrustConfig(env, l.runtimeInfo.ClientTrustBundle)
+	if err != nil || trustConfig == nil {
+		return "", err
+	}
+	if l.networkSecurity == nil {
+		return "", fmt.Errorf("client TLS is not supported without the Network Security and Certificate Manager clients")
+	}
+	if err := l.ensureTrustConfig(trustConfig); err != nil {
+		return "", err
+	}
+	policy, err := tr.ToServerTlsPolicy(env, l.certificateManager.TrustConfigLink(trustConfig.Name))
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := l.networkSecurity.GetServerTlsPolicy(policy.Name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return "", err
+	}
+	switch {
+	case existing == nil:
+		klog.V(2).Infof("Creating server TLS policy %v for %v", policy.Name, l)
+		if err := l.networkSecurity.CreateServerTlsPolicy(policy.Name, policy); err != nil {
+			return "", err
+		}
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "ServerTlsPolicy %q created", policy.Name)
+	case existing.Description != policy.Description || !reflect.DeepEqual(existing.MtlsPolicy, policy.MtlsPolicy):
+		klog.V(2).Infof("Updating server TLS policy %v for %v", policy.Name, l)
+		if err := l.networkSecurity.UpdateServerTlsPolicy(policy.Name, policy); err != nil {
+			return "", err
+		}
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "ServerTlsPolicy %q updated", policy.Name)
+	}
+	// The policy is in use again if its deletion did not finish.
+	l.pendingServerTlsPolicies.remove(policy.Name)
+	return l.networkSecurity.ServerTlsPolicyLink(policy.Name), nil
+}
+
+// ensureTrustConfig creates the trust config, or updates it if its CA
+// certificates changed.
+func (l *L7) ensureTrustConfig(trustConfig *certificatemanager.TrustConfig) error {
+	existing, err := l.certificateManager.GetTrustConfig(trustConfig.Name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+	switch {
+	case existing == nil:
+		klog.V(2).Infof("Creating trust config %v for %v", trustConfig.Name, l)
+		if err := l.certificateManager.CreateTrustConfig(trustConfig.Name, trustConfig); err != nil {
+			return err
+		}
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TrustConfig %q created", trustConfig.Name)
+	case existing.Description != trustConfig.Description || !reflect.DeepEqual(existing.TrustStores, trustConfig.TrustStores):
+		klog.V(2).Infof("Updating trust config %v for %v", trustConfig.Name, l)
+		if err := l.certificateManager.UpdateTrustConfig(trustConfig.Name, trustConfig); err != nil {
+			return err
+		}
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TrustConfig %q updated", trustConfig.Name)
+	}
+	return nil
+}
+
+// deleteServerTlsPolicy deletes the server TLS policy referred to by
+// policyLink, and its trust config, if they are managed for this load
+// balancer. A deletion which did not finish is retried even if policyLink is
+// empty.
+func (l *L7) deleteServerTlsPolicy(policyLink string) error {
+	name, ok := l.namer.ServerTLSPolicy()
+	if !ok || l.networkSecurity == nil {
+		return nil
+	}
+	if policyLink == "" || path.Base(policyLink) != name {
+		if !l.pendingServerTlsPolicies.has(name) {
+			return nil
+		}
+	}
+	l.pendingServerTlsPolicies.add(name)
+	klog.V(2).Infof("Deleting server TLS policy %v", name)
+	if err := utils.IgnoreHTTPNotFound(l.networkSecurity.DeleteServerTlsPolicy(name)); err != nil {
+		return err
+	}
+	klog.V(2).Infof("Deleting trust config %v", name)
+	if err := utils.IgnoreHTTPNotFound(l.certificateManager.DeleteTrustConfig(name)); err != nil {
+		return err
+	}
+	l.pendingServerTlsPolicies.remove(name)
+	return nil
+}
+
+// pendingDeletions are the names of resources whose deletion did not finish,
+// so that it is retried once nothing refers to them anymore.
+type pendingDeletions struct {
+	mu    sync.Mutex
+	names sets.String
+}
+
+func newPendingDeletions() *pendingDeletions {
+	return &pendingDeletions{names: sets.NewString()}
+}
+
+func (p *pendingDeletions) has(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.names.Has(name)
+}
+
+func (p *pendingDeletions) add(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.names.Insert(name)
+}
+
+func (p *pendingDeletions) remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.names.Delete(name)
+}
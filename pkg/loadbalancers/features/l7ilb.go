@@ -24,6 +24,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
 )
@@ -31,24 +32,37 @@ import (
 var ErrSubnetNotFound = errors.New("active subnet not found")
 
 // ILBSubnetSourceRange gets Subnet source range for ILB
-// TODO: (shance) refactor to use filter
 func ILBSubnetSourceRange(cloud *gce.Cloud, region string) (string, error) {
+	subnet, err := findProxyOnlySubnet(cloud, cloud.NetworkURL(), region)
+	if err != nil {
+		return "", err
+	}
+	return subnet.IpCidrRange, nil
+}
+
+// findProxyOnlySubnet returns the active proxy-only subnet of network in
+// region.
+// TODO: (shance) refactor to use filter
+func findProxyOnlySubnet(cloud *gce.Cloud, network, region string) (*computebeta.Subnetwork, error) {
 	subnets, err := cloud.Compute().BetaSubnetworks().List(context.Background(), region, filter.None)
 	if err != nil {
-		return "", fmt.Errorf("error obtaining subnets for region %s, %v", region, err)
+		return nil, fmt.Errorf("error obtaining subnets for region %s, %v", region, err)
 	}
 
 	for _, subnet := range subnets {
-		sameNetwork, err := isSameNetwork(subnet.Network, cloud.NetworkURL())
+		sameNetwork, err := isSameNetwork(subnet.Network, network)
 		if err != nil {
-			return "", fmt.Errorf("error comparing subnets: %v", err)
+			return nil, fmt.Errorf("error comparing subnets: %v", err)
 		}
-		if subnet.Role == "ACTIVE" && subnet.Purpose == "INTERNAL_HTTPS_LOAD_BALANCER" && sameNetwork {
+		// Proxy-only subnets are shared by internal and regional external
+		// L7 load balancers.
+		isProxyOnly := subnet.Purpose == "INTERNAL_HTTPS_LOAD_BALANCER" || subnet.Purpose == "REGIONAL_MANAGED_PROXY"
+		if subnet.Role == "ACTIVE" && isProxyOnly && sameNetwork {
 			klog.V(3).Infof("Found L7-ILB Subnet %s - %s", subnet.Name, subnet.IpCidrRange)
-			return subnet.IpCidrRange, nil
+			return subnet, nil
 		}
 	}
-	return "", ErrSubnetNotFound
+	return nil, ErrSubnetNotFound
 }
 
 // isSameNetwork() is a helper for comparing networks across API versions
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+// ProxyOnlySubnet tracks the active proxy-only subnet of the cluster network
+// in the region of the cluster. The proxies of regional L7 load balancers
+// connect to backends from the range of this subnet.
+type ProxyOnlySubnet struct {
+	cloud   *gce.Cloud
+	network string
+	region  string
+
+	lock sync.Mutex
+	// synced is true once the subnet has been looked up at least once.
+	synced bool
+	name   string
+	cidr   string
+	// err is the error of the last lookup that did not find the subnet.
+	err      error
+	handlers []func()
+}
+
+// NewProxyOnlySubnet returns a new ProxyOnlySubnet for the network URL and
+// region.
+func NewProxyOnlySubnet(cloud *gce.Cloud, network, region string) *ProxyOnlySubnet {
+	return &ProxyOnlySubnet{cloud: cloud, network: network, region: region}
+}
+
+// Run looks up the proxy-only subnet immediately and then every period until
+// stopCh is closed.
+func (p *ProxyOnlySubnet) Run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() { p.Refresh() }, period, stopCh)
+}
+
+// AddHandler registers f to be called whenever the range of the proxy-only
+// subnet changes, including when the subnet is created or deleted.
+func (p *ProxyOnlySubnet) AddHandler(f func()) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.handlers = append(p.handlers, f)
+}
+
+// SourceRange returns the CIDR of the proxy-only subnet. It returns
+// ErrSubnetNotFound if there is no active proxy-only subnet.
+func (p *ProxyOnlySubnet) SourceRange() (string, error) {
+	p.lock.Lock()
+	synced := p.synced
+	p.lock.Unlock()
+	if !synced {
+		p.Refresh()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.cidr == "" {
+		return "", p.err
+	}
+	return p.cidr, nil
+}
+
+// Network returns the URL of the network the proxy-only subnet is looked up in.
+func (p *ProxyOnlySubnet) Network() string {
+	return p.network
+}
+
+// Region returns the region the proxy-only subnet is looked up in.
+func (p *ProxyOnlySubnet) Region() string {
+	return p.region
+}
+
+// Refresh looks up the proxy-only subnet and calls the registered handlers if
+// its range changed. Returns true if the range changed. A failed lookup keeps
+// the previously known range.
+func (p *ProxyOnlySubnet) Refresh() bool {
+	subnet, err := findProxyOnlySubnet(p.cloud, p.network, p.region)
+
+	p.lock.Lock()
+	p.synced = true
+	oldCIDR := p.cidr
+	switch {
+	case err == nil:
+		p.name, p.cidr, p.err = subnet.Name, subnet.IpCidrRange, nil
+	case err == ErrSubnetNotFound:
+		p.name, p.cidr, p.err = "", "", err
+	default:
+		klog.Errorf("Failed to look up proxy-only subnet in region %s: %v", p.region, err)
+		if p.cidr == "" {
+			p.err = err
+		}
+	}
+	changed := oldCIDR != p.cidr
+	handlers := append([]func(){}, p.handlers...)
+	name, cidr := p.name, p.cidr
+	p.lock.Unlock()
+
+	if !changed {
+		return false
+	}
+	if cidr == "" {
+		klog.Warningf("No active proxy-only subnet in network %s region %s", p.network, p.region)
+	} else {
+		klog.V(2).Infof("Proxy-only subnet %s in region %s has range %s (was %q)", name, p.region, cidr, oldCIDR)
+	}
+	for _, f := range handlers {
+		f()
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+func TestProxyOnlySubnet(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	region := fakeGCE.Region()
+	network := cloud.ResourcePath("network", meta.GlobalKey("test-network"))
+	otherNetwork := cloud.ResourcePath("network", meta.GlobalKey("other-network"))
+	subnets := fakeGCE.Compute().BetaSubnetworks()
+
+	p := NewProxyOnlySubnet(fakeGCE, network, region)
+	var changes int
+	p.AddHandler(func() { changes++ })
+
+	for _, tc := range []struct {
+		desc        string
+		subnets     []*computebeta.Subnetwork
+		wantRange   string
+		wantErr     error
+		wantChanges int
+	}{
+		{
+			desc:        "No subnets",
+			wantErr:     ErrSubnetNotFound,
+			wantChanges: 0,
+		},
+		{
+			desc: "Proxy-only subnet in another network and backup subnet",
+			subnets: []*computebeta.Subnetwork{
+				{Name: "other", Network: otherNetwork, IpCidrRange: "10.1.0.0/23", Purpose: "REGIONAL_MANAGED_PROXY", Role: "ACTIVE"},
+				{Name: "backup", Network: network, IpCidrRange: "10.2.0.0/23", Purpose: "REGIONAL_MANAGED_PROXY", Role: "BACKUP"},
+				{Name: "private", Network: network, IpCidrRange: "10.3.0.0/20", Purpose: "PRIVATE"},
+			},
+			wantErr:     ErrSubnetNotFound,
+			wantChanges: 0,
+		},
+		{
+			desc: "Active proxy-only subnet",
+			subnets: []*computebeta.Subnetwork{
+				{Name: "proxy", Network: network, IpCidrRange: "10.4.0.0/23", Purpose: "INTERNAL_HTTPS_LOAD_BALANCER", Role: "ACTIVE"},
+			},
+			wantRange:   "10.4.0.0/23",
+			wantChanges: 1,
+		},
+		{
+			desc: "Unchanged range",
+			subnets: []*computebeta.Subnetwork{
+				{Name: "proxy", Network: network, IpCidrRange: "10.4.0.0/23", Purpose: "INTERNAL_HTTPS_LOAD_BALANCER", Role: "ACTIVE"},
+			},
+			wantRange:   "10.4.0.0/23",
+			wantChanges: 1,
+		},
+		{
+			desc: "Replaced subnet",
+			subnets: []*computebeta.Subnetwork{
+				{Name: "proxy-2", Network: network, IpCidrRange: "10.5.0.0/22", Purpose: "REGIONAL_MANAGED_PROXY", Role: "ACTIVE"},
+			},
+			wantRange:   "10.5.0.0/22",
+			wantChanges: 2,
+		},
+		{
+			desc:        "Deleted subnet",
+			wantErr:     ErrSubnetNotFound,
+			wantChanges: 3,
+		},
+	} {
+		existing, err := subnets.List(context.Background(), region, nil)
+		if err != nil {
+			t.Fatalf("%s: List() = %v, want nil", tc.desc, err)
+		}
+		for _, subnet := range existing {
+			if err := subnets.Delete(context.Background(), meta.RegionalKey(subnet.Name, region)); err != nil {
+				t.Fatalf("%s: Delete(%s) = %v, want nil", tc.desc, subnet.Name, err)
+			}
+		}
+		for _, subnet := range tc.subnets {
+			if err := subnets.Insert(context.Background(), meta.RegionalKey(subnet.Name, region), subnet); err != nil {
+				t.Fatalf("%s: Insert(%s) = %v, want nil", tc.desc, subnet.Name, err)
+			}
+		}
+
+		p.Refresh()
+		gotRange, err := p.SourceRange()
+		if gotRange != tc.wantRange || err != tc.wantErr {
+			t.Errorf("%s: SourceRange() = %q, %v, want %q, %v", tc.desc, gotRange, err, tc.wantRange, tc.wantErr)
+		}
+		if changes != tc.wantChanges {
+			t.Errorf("%s: got %d changes, want %d", tc.desc, changes, tc.wantChanges)
+		}
+	}
+}
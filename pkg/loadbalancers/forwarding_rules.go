@@ -17,6 +17,7 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -28,7 +29,6 @@ import (
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
-	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -85,16 +85,15 @@ func (l *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		return nil, err
 	}
 
-	isL7ILB := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(l.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, l.namer)
-	env := &translator.Env{VIP: ip, Network: l.cloud.NetworkURL(), Subnetwork: l.cloud.SubnetworkURL()}
+	tr := l.newTranslator()
+	env := &translator.Env{VIP: ip, Network: l.cloud.NetworkURL(), Subnetwork: l.cloud.SubnetworkURL(), NetworkTier: l.frontendNetworkTier()}
 	fr := tr.ToCompositeForwardingRule(env, protocol, version, proxyLink, description, l.runtimeInfo.StaticIPSubnet)
 
-	existing, _ = composite.GetForwardingRule(l.cloud, key, version)
-	if existing != nil && (fr.IPAddress != "" && existing.IPAddress != fr.IPAddress || existing.PortRange != fr.PortRange) {
-		klog.Warningf("Recreating forwarding rule %v(%v), so it has %v(%v)",
-			existing.IPAddress, existing.PortRange, fr.IPAddress, fr.PortRange)
-		if err = utils.IgnoreHTTPNotFound(composite.DeleteForwardingRule(l.cloud, key, version)); err != nil {
+	existing, _ = composite.GetForwardingRuleWithContext(l.traceCtx, l.cloud, key, labelsVersion(version))
+	if existing != nil && (fr.IPAddress != "" && existing.IPAddress != fr.IPAddress || existing.PortRange != fr.PortRange || !l.hasNetworkTier(existing.NetworkTier)) {
+		klog.Warningf("Recreating forwarding rule %v(%v, %v), so it has %v(%v, %v)",
+			existing.IPAddress, existing.PortRange, existing.NetworkTier, fr.IPAddress, fr.PortRange, l.tier)
+		if err = utils.IgnoreHTTPNotFound(composite.DeleteForwardingRuleWithContext(l.traceCtx, l.cloud, key, version)); err != nil {
 			return nil, err
 		}
 		existing = nil
@@ -110,8 +109,8 @@ func (l *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 			// Get static IP address if ingress has static IP annotation.
 			// Note that this Static IP annotation is applied by ingress controller.
 			if currentIPName, exists := l.ingress.Annotations[annotations.StaticIPKey]; exists && currentIPName == managedStaticIPName {
-				currentIP, _ := l.cloud.GetGlobalAddress(managedStaticIPName)
-				if currentIP != nil {
+				currentIP, _ := l.getManagedStaticIP(managedStaticIPName)
+				if currentIP != nil && l.hasNetworkTier(currentIP.NetworkTier) {
 					klog.V(3).Infof("Ingress managed static IP %s(%s) exists, using it to create forwarding rule %s", currentIPName, currentIP.Address, name)
 					fr.IPAddress = currentIP.Address
 				}
@@ -119,7 +118,7 @@ func (l *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		}
 		klog.V(3).Infof("Creating forwarding rule for proxy %q and ip %v:%v", proxyLink, ip, protocol)
 
-		if err = composite.CreateForwardingRule(l.cloud, key, fr); err != nil {
+		if err = composite.CreateForwardingRuleWithContext(l.traceCtx, l.cloud, key, fr); err != nil {
 			return nil, err
 		}
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "ForwardingRule %q created", key.Name)
@@ -128,7 +127,7 @@ func (l *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		if err != nil {
 			return nil, err
 		}
-		existing, err = composite.GetForwardingRule(l.cloud, key, version)
+		existing, err = composite.GetForwardingRuleWithContext(l.traceCtx, l.cloud, key, labelsVersion(version))
 		if err != nil {
 			return nil, err
 		}
@@ -143,10 +142,13 @@ func (l *L7) checkForwardingRule(protocol namer.NamerProtocol, name, proxyLink,
 		if err != nil {
 			return nil, err
 		}
-		if err := composite.SetProxyForForwardingRule(l.cloud, key, existing, proxyLink); err != nil {
+		if err := composite.SetProxyForForwardingRuleWithContext(l.traceCtx, l.cloud, key, existing, proxyLink); err != nil {
 			return nil, err
 		}
 	}
+	if err := ensureForwardingRuleLabels(l.traceCtx, l.cloud, key, existing, l.runtimeInfo.Ingress, l.recorder); err != nil {
+		return nil, err
+	}
 	return existing, nil
 }
 
@@ -180,9 +182,16 @@ func (l *L7) getEffectiveIP() (string, bool, error) {
 		// Existing static IPs allocated to forwarding rules will get orphaned
 		// till the Ingress is torn down.
 		// TODO(shance): Replace version
-		if ip, err := composite.GetAddress(l.cloud, key, meta.VersionGA); err != nil || ip == nil {
+		if ip, err := composite.GetAddressWithContext(l.traceCtx, l.cloud, key, meta.VersionGA); err != nil || ip == nil {
+			if l.tier == cloud.NetworkTierStandard {
+				return "", false, fmt.Errorf("the given static IP name %v doesn't translate to an existing static IP in region %s, STANDARD network tier requires a regional static IP.",
+					l.runtimeInfo.StaticIPName, l.cloud.Region())
+			}
 			return "", false, fmt.Errorf("the given static IP name %v doesn't translate to an existing static IP.",
 				l.runtimeInfo.StaticIPName)
+		} else if !l.isL7ILB() && (ip.NetworkTier == cloud.NetworkTierStandard.ToGCEValue()) != (l.tier == cloud.NetworkTierStandard) {
+			return "", false, fmt.Errorf("the given static IP %v has network tier %s, which does not match the network tier of the Ingress",
+				l.runtimeInfo.StaticIPName, ip.NetworkTier)
 		} else {
 			l.runtimeInfo.StaticIPSubnet = ip.Subnetwork
 			return ip.Address, false, nil
@@ -227,7 +236,13 @@ func (l *L4) ensureForwardingRule(loadBalancerName, bsLink string, options gce.I
 	// If the network is not a legacy network, use the address manager
 	if !l.cloud.IsLegacyNetwork() {
 		nm := types.NamespacedName{Namespace: l.Service.Namespace, Name: l.Service.Name}.String()
-		addrMgr = newAddressManager(l.cloud, nm, l.cloud.Region(), subnetworkURL, loadBalancerName, ipToUse, cloud.SchemeInternal)
+		var labels map[string]string
+		if utils.GCELabelsEnabled() {
+			if labels, err = desiredGCELabels(nil, l.Service, l.recorder); err != nil {
+				return nil, err
+			}
+		}
+		addrMgr = newAddressManager(l.cloud, nm, l.cloud.Region(), subnetworkURL, loadBalancerName, ipToUse, cloud.SchemeInternal, labels)
 		ipToUse, err = addrMgr.HoldAddress()
 		if err != nil {
 			return nil, err
@@ -277,7 +292,7 @@ func (l *L4) ensureForwardingRule(loadBalancerName, bsLink string, options gce.I
 		if equal {
 			// nothing to do
 			klog.V(2).Infof("ensureForwardingRule: Skipping update of unchanged forwarding rule - %s", fr.Name)
-			return existingFwdRule, nil
+			return existingFwdRule, ensureForwardingRuleLabels(context.Background(), l.cloud, key, existingFwdRule, l.Service, l.recorder)
 		}
 		frDiff := cmp.Diff(existingFwdRule, fr)
 		// If the forwarding rule pointed to a backend service which does not match the controller naming scheme,
@@ -292,7 +307,14 @@ func (l *L4) ensureForwardingRule(loadBalancerName, bsLink string, options gce.I
 	if err = composite.CreateForwardingRule(l.cloud, key, fr); err != nil {
 		return nil, err
 	}
-	return composite.GetForwardingRule(l.cloud, key, fr.Version)
+	created, err := composite.GetForwardingRule(l.cloud, key, labelsVersion(fr.Version))
+	if err != nil {
+		return nil, err
+	}
+	if err = ensureForwardingRuleLabels(context.Background(), l.cloud, key, created, l.Service, l.recorder); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 func (l *L4) getForwardingRule(name string, version meta.Version) *composite.ForwardingRule {
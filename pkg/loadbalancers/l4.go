@@ -17,6 +17,7 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/cloud-provider/service/helpers"
 	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/firewalls"
@@ -94,6 +96,9 @@ func (l *L4) EnsureInternalLoadBalancerDeleted(svc *corev1.Service) error {
 		return fmt.Errorf("Namer does not support L4 VMIPNEGs")
 	}
 	frName := l.GetFRName()
+	hcName, hcFwName := l.namer.L4HealthCheck(svc.Namespace, svc.Name, sharedHC)
+	l.setAuditOwner(name, frName, hcName)
+	forgetGCELabelErrors(svc)
 	key, err := l.CreateKey(frName)
 	if err != nil {
 		klog.Errorf("Failed to create key for LoadBalancer resources with name %s for service %s, err %v", frName, l.NamespacedName.String(), err)
@@ -109,7 +114,6 @@ func (l *L4) EnsureInternalLoadBalancerDeleted(svc *corev1.Service) error {
 		klog.Errorf("Failed to delete address for internal loadbalancer service %s, err %v", l.NamespacedName.String(), err)
 		retErr = err
 	}
-	hcName, hcFwName := l.namer.L4HealthCheck(svc.Namespace, svc.Name, sharedHC)
 	// delete fw rules
 	deleteFunc := func(name string) error {
 		err := firewalls.EnsureL4InternalFirewallRuleDeleted(l.cloud, name)
@@ -136,7 +140,7 @@ func (l *L4) EnsureInternalLoadBalancerDeleted(svc *corev1.Service) error {
 		retErr = err
 	}
 	// delete backend service
-	err = utils.IgnoreHTTPNotFound(l.backendPool.Delete(name, meta.VersionGA, meta.Regional))
+	err = utils.IgnoreHTTPNotFound(l.backendPool.Delete(context.Background(), name, meta.VersionGA, meta.Regional))
 	if err != nil {
 		klog.Errorf("Failed to delete backends for internal loadbalancer service %s, err  %v", l.NamespacedName.String(), err)
 		retErr = err
@@ -157,9 +161,19 @@ func (l *L4) EnsureInternalLoadBalancerDeleted(svc *corev1.Service) error {
 		// This will be hit if this is a shared healthcheck.
 		klog.V(2).Infof("Failed to delete healthcheck %s: health check in use.", hcName)
 	}
+	if retErr == nil {
+		audit.RemoveOwner(audit.ControllerL4, l.NamespacedName.String())
+	}
 	return retErr
 }
 
+// setAuditOwner attributes the backend service, address, forwarding rule and
+// health check of the load balancer to the service.
+func (l *L4) setAuditOwner(name, frName, hcName string) {
+	region := l.cloud.Region()
+	audit.SetOwner(audit.ControllerL4, l.NamespacedName.String(), meta.RegionalKey(name, region), meta.RegionalKey(frName, region), meta.GlobalKey(hcName))
+}
+
 // GetFRName returns the name of the forwarding rule for the given ILB service.
 // This appends the protocol to the forwarding rule name, which will help supporting multiple protocols in the same ILB
 // service.
@@ -187,6 +201,7 @@ func (l *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service,
 	// create healthcheck
 	sharedHC := !helpers.RequestsOnlyLocalTraffic(l.Service)
 	hcName, hcFwName := l.namer.L4HealthCheck(svc.Namespace, svc.Name, sharedHC)
+	l.setAuditOwner(name, l.GetFRName(), hcName)
 	hcPath, hcPort := gce.GetNodesHealthCheckPath(), gce.GetNodesHealthCheckPort()
 	if !sharedHC {
 		hcPath, hcPort = helpers.GetServiceHealthCheckPathPort(l.Service)
@@ -240,16 +255,16 @@ func (l *L4) EnsureInternalLoadBalancer(nodeNames []string, svc *corev1.Service,
 
 	// Check if protocol has changed for this service. In this case, forwarding rule should be deleted before
 	// the backend service can be updated.
-	existingBS, err := l.backendPool.Get(name, meta.VersionGA, l.scope)
+	existingBS, err := l.backendPool.Get(context.Background(), name, meta.VersionGA, l.scope)
 	err = utils.IgnoreHTTPNotFound(err)
 	if err != nil {
 		klog.Errorf("Failed to lookup existing backend service, ignoring err: %v", err)
 	}
-	existingFR := l.getForwardingRule(l.GetFRName(), meta.VersionGA)
+	existingFR := l.getForwardingRule(l.GetFRName(), labelsVersion(meta.VersionGA))
 	if existingBS != nil && existingBS.Protocol != string(protocol) {
 		klog.Infof("Protocol changed from %q to %q for service %s", existingBS.Protocol, string(protocol), l.NamespacedName)
 		// Delete forwarding rule if it exists
-		existingFR = l.getForwardingRule(l.getFRNameWithProtocol(existingBS.Protocol), meta.VersionGA)
+		existingFR = l.getForwardingRule(l.getFRNameWithProtocol(existingBS.Protocol), labelsVersion(meta.VersionGA))
 		l.deleteForwardingRule(l.getFRNameWithProtocol(existingBS.Protocol), meta.VersionGA)
 	}
 
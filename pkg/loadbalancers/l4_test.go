@@ -27,6 +27,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/firewalls"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/metrics"
 	"k8s.io/ingress-gce/pkg/utils"
 
@@ -38,6 +39,7 @@ import (
 	servicehelper "k8s.io/cloud-provider/service/helpers"
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	"k8s.io/ingress-gce/pkg/test"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
@@ -53,6 +55,8 @@ func getFakeGCECloud(vals gce.TestClusterValues) *gce.Cloud {
 	fakeGCE := gce.NewFakeGCECloud(vals)
 	// InsertHook required to assign an IP Address for forwarding rule
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockAddresses.InsertHook = mock.InsertAddressHook
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockBetaAddresses.InsertHook = insertBetaAddressHook
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockBetaAddresses.X = mock.AddressAttributes{}
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaAddresses.X = mock.AddressAttributes{}
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockAddresses.X = mock.AddressAttributes{}
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockForwardingRules.InsertHook = mock.InsertFwdRuleHook
@@ -60,6 +64,7 @@ func getFakeGCECloud(vals gce.TestClusterValues) *gce.Cloud {
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockRegionBackendServices.UpdateHook = mock.UpdateRegionBackendServiceHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockHealthChecks.UpdateHook = mock.UpdateHealthCheckHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockFirewalls.UpdateHook = mock.UpdateFirewallHook
+	composite.RegisterFakeExtension(fakeGCE, fakeGCE.Compute().(*cloud.MockGCE))
 	return fakeGCE
 }
 
@@ -142,6 +147,76 @@ func TestEnsureInternalLoadBalancer(t *testing.T) {
 	assertInternalLbResources(t, svc, l, nodeNames, annotations)
 }
 
+func TestEnsureInternalLoadBalancerGCELabels(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"team"}
+	defer func() { flags.F.GCELabelAllowlist = nil }()
+
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.Labels = map[string]string{"team": "payments"}
+	namer := namer_util.NewL4Namer(kubeSystemUID, nil)
+	l := NewL4Handler(svc, fakeGCE, meta.Regional, namer, record.NewFakeRecorder(100), &sync.Mutex{})
+	if _, err := test.CreateAndInsertNodes(l.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Errorf("Unexpected error when adding nodes %v", err)
+	}
+	key, err := l.CreateKey(l.GetFRName())
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+
+	for _, team := range []string{"payments", "search"} {
+		svc.Labels["team"] = team
+		if _, _, err := l.EnsureInternalLoadBalancer(nodeNames, svc, &metrics.L4ILBServiceState{}); err != nil {
+			t.Fatalf("Failed to ensure loadBalancer, err %v", err)
+		}
+		fr, err := composite.GetForwardingRule(l.cloud, key, meta.VersionBeta)
+		if err != nil {
+			t.Fatalf("Failed to get forwarding rule: %v", err)
+		}
+		if want := map[string]string{"team": team}; !reflect.DeepEqual(fr.Labels, want) {
+			t.Errorf("Got forwarding rule labels %v, want %v", fr.Labels, want)
+		}
+	}
+}
+
+func TestEnsureInternalLoadBalancerInvalidGCELabelEvent(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"cost-center"}
+	defer func() { flags.F.GCELabelAllowlist = nil }()
+
+	nodeNames := []string{"test-node-1"}
+	vals := gce.DefaultTestClusterValues()
+	fakeGCE := getFakeGCECloud(vals)
+
+	svc := test.NewL4ILBService(false, 8080)
+	svc.UID = "invalid-gce-label-uid"
+	svc.Labels = map[string]string{"cost-center": "This value is invalid as it is way too long to be used as a GCE label value"}
+	namer := namer_util.NewL4Namer(kubeSystemUID, nil)
+	recorder := record.NewFakeRecorder(100)
+	l := NewL4Handler(svc, fakeGCE, meta.Regional, namer, recorder, &sync.Mutex{})
+	if _, err := test.CreateAndInsertNodes(l.cloud, nodeNames, vals.ZoneName); err != nil {
+		t.Errorf("Unexpected error when adding nodes %v", err)
+	}
+
+	// The invalid label is reported once, not on every sync.
+	for i := 0; i < 3; i++ {
+		if _, _, err := l.EnsureInternalLoadBalancer(nodeNames, svc, &metrics.L4ILBServiceState{}); err != nil {
+			t.Fatalf("Failed to ensure loadBalancer, err %v", err)
+		}
+	}
+	var invalidLabelEvents int
+	for len(recorder.Events) > 0 {
+		if strings.Contains(<-recorder.Events, events.InvalidGCELabel) {
+			invalidLabelEvents++
+		}
+	}
+	if invalidLabelEvents != 1 {
+		t.Errorf("Got %d %s events, want 1", invalidLabelEvents, events.InvalidGCELabel)
+	}
+}
+
 func TestEnsureInternalLoadBalancerTypeChange(t *testing.T) {
 	t.Parallel()
 	nodeNames := []string{"test-node-1"}
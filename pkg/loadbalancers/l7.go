@@ -17,6 +17,7 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -24,16 +25,23 @@ import (
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/translator"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/networksecurity"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog"
@@ -67,6 +75,14 @@ type L7RuntimeInfo struct {
 	UrlMap *utils.GCEURLMap
 	// FrontendConfig is the type which encapsulates features for the load balancer.
 	FrontendConfig *frontendconfigv1beta1.FrontendConfig
+	// TraceContext holds the span of the sync that ensures the load balancer.
+	TraceContext context.Context
+	// ClientTrustBundle is the PEM encoded trust bundle of the client TLS
+	// config of the FrontendConfig.
+	ClientTrustBundle string
+	// CertificateMap is the certificate map of the certificate map annotation
+	// of the Ingress.
+	CertificateMap string
 }
 
 // L7 represents a single L7 loadbalancer.
@@ -104,6 +120,21 @@ type L7 struct {
 	recorder record.EventRecorder
 	// resource type stores the KeyType of the resources in the loadbalancer (e.g. Regional)
 	scope meta.KeyType
+	// tier is the network tier of the forwarding rules and the static IP.
+	tier cloud.NetworkTier
+	// certificateMap is the link of the certificate map served by the
+	// targetHTTPSProxy instead of sslCerts, empty if there is none.
+	certificateMap string
+	// traceCtx holds the span that GCE calls on the resources of this L7 are
+	// traced under, if any. It is passed to the composite calls.
+	traceCtx context.Context
+	// certificateManager and networkSecurity manage the trust config and the
+	// server TLS policy of client TLS. They are nil if it is not supported.
+	certificateManager certificatemanager.Interface
+	networkSecurity    networksecurity.Interface
+	// pendingServerTlsPolicies are the server TLS policies of the pool whose
+	// deletion did not finish yet.
+	pendingServerTlsPolicies *pendingDeletions
 }
 
 // String returns the name of the loadbalancer.
@@ -120,7 +151,32 @@ func (l *L7) Versions() *features.ResourceVersions {
 
 // CreateKey creates a meta.Key for use with composite types
 func (l *L7) CreateKey(name string) (*meta.Key, error) {
-	return composite.CreateKey(l.cloud, name, l.scope)
+	key, err := composite.CreateKey(l.cloud, name, l.scope)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		audit.SetOwner(audit.ControllerIngress, types.NamespacedName{Namespace: l.ingress.Namespace, Name: l.ingress.Name}.String(), key)
+	}
+	return key, nil
+}
+
+// isL7ILB returns true if the load balancer is an L7-ILB.
+func (l *L7) isL7ILB() bool {
+	return flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(&l.ingress)
+}
+
+// isL7XLBRegional returns true if the load balancer is a regional external
+// L7 load balancer.
+func (l *L7) isL7XLBRegional() bool {
+	return flags.F.EnableL7XLBRegional && utils.IsGCEL7XLBRegionalIngress(&l.ingress)
+}
+
+// newTranslator returns a translator for the type of the load balancer.
+func (l *L7) newTranslator() *translator.Translator {
+	tr := translator.NewTranslator(l.isL7ILB(), l.namer)
+	tr.IsL7XLBRegional = l.isL7XLBRegional()
+	return tr
 }
 
 // Regional returns true if the l7 scope is regional
@@ -138,8 +194,31 @@ func (l *L7) UrlMap() *composite.UrlMap {
 	return l.um
 }
 
+// tracedEdgeHop runs edgeHop in a child span of the runtime info trace context.
+func (l *L7) tracedEdgeHop() (err error) {
+	parent := l.runtimeInfo.TraceContext
+	if parent == nil {
+		// Runtime info built outside of a sync has no trace context.
+		parent = context.Background()
+	}
+	ctx, span := tracing.StartSpan(parent, "L7.edgeHop", trace.StringAttribute("loadbalancer", l.String()))
+	l.traceCtx = ctx
+	defer func() {
+		l.traceCtx = nil
+		tracing.EndSpan(span, err)
+	}()
+	return l.edgeHop()
+}
+
 func (l *L7) edgeHop() error {
-	sslConfigured := l.runtimeInfo.TLS != nil || l.runtimeInfo.TLSName != ""
+	certificateMap, err := l.newTranslator().ToCertificateMap(&translator.Env{FrontendConfig: l.runtimeInfo.FrontendConfig, Project: l.cloud.ProjectID()}, l.runtimeInfo.CertificateMap)
+	if err != nil {
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeWarning, events.SyncIngress, "Invalid certificate map: %v", err)
+		return err
+	}
+	l.certificateMap = certificateMap
+
+	sslConfigured := l.runtimeInfo.TLS != nil || l.runtimeInfo.TLSName != "" || l.certificateMap != ""
 	// Return an error if user configuration species that both HTTP & HTTPS are not to be configured.
 	if !l.runtimeInfo.AllowHTTP && !sslConfigured {
 		return fmt.Errorf(invalidConfigErrorMessage)
@@ -151,6 +230,13 @@ func (l *L7) edgeHop() error {
 		return fmt.Errorf("error invalid internal ingress https config")
 	}
 
+	tier, err := l.networkTier()
+	if err != nil {
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeWarning, events.SyncIngress, "Invalid network tier: %v", err)
+		return err
+	}
+	l.tier = tier
+
 	if err := l.ensureComputeURLMap(); err != nil {
 		return err
 	}
@@ -254,12 +340,12 @@ func (l *L7) GetIP() string {
 // deleteForwardingRule deletes forwarding rule for given protocol.
 func (l *L7) deleteForwardingRule(versions *features.ResourceVersions, protocol namer.NamerProtocol) error {
 	frName := l.namer.ForwardingRule(protocol)
-	klog.V(2).Infof("Deleting global forwarding rule %v", frName)
+	klog.V(2).Infof("Deleting forwarding rule %v", frName)
 	key, err := l.CreateKey(frName)
 	if err != nil {
 		return err
 	}
-	if err := utils.IgnoreHTTPNotFound(composite.DeleteForwardingRule(l.cloud, key, versions.ForwardingRule)); err != nil {
+	if err := utils.IgnoreHTTPNotFound(composite.DeleteForwardingRuleWithContext(l.traceCtx, l.cloud, key, versions.ForwardingRule)); err != nil {
 		return err
 	}
 	return nil
@@ -275,11 +361,11 @@ func (l *L7) deleteTargetProxy(versions *features.ResourceVersions, protocol nam
 	}
 	switch protocol {
 	case namer.HTTPProtocol:
-		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpProxy(l.cloud, key, versions.TargetHttpProxy)); err != nil {
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpProxyWithContext(l.traceCtx, l.cloud, key, versions.TargetHttpProxy)); err != nil {
 			return err
 		}
 	case namer.HTTPSProtocol:
-		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpsProxy(l.cloud, key, versions.TargetHttpsProxy)); err != nil {
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, versions.TargetHttpsProxy)); err != nil {
 			return err
 		}
 	default:
@@ -312,10 +398,27 @@ func (l *L7) deleteHttps(versions *features.ResourceVersions) error {
 	if err != nil {
 		return err
 	}
+	// The SSL and server TLS policies in use also need to be read before
+	// deleting the proxy.
+	proxy, err := l.getHttpsProxyInUse()
+	if err != nil {
+		return err
+	}
 	// Delete target https proxy.
 	if err := l.deleteTargetProxy(versions, namer.HTTPSProtocol); err != nil {
 		return err
 	}
+	var serverTlsPolicy string
+	if proxy != nil {
+		// Delete the managed SSL policy unless other proxies use it.
+		if err := l.deleteManagedSslPolicy(proxy.SslPolicy); err != nil {
+			return err
+		}
+		serverTlsPolicy = proxy.ServerTlsPolicy
+	}
+	if err := l.deleteServerTlsPolicy(serverTlsPolicy); err != nil {
+		return err
+	}
 	// Delete ingress managed ssl certificates those created from a secret,
 	// not referencing a pre-created GCE cert or managed certificates.
 	return l.deleteSSLCertificates(secretsSslCerts, versions)
@@ -333,7 +436,7 @@ func (l *L7) deleteSSLCertificates(sslCertificates []*composite.SslCertificate,
 		if err != nil {
 			return err
 		}
-		if err := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificate(l.cloud, key, versions.SslCertificate)); err != nil {
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteSslCertificateWithContext(l.traceCtx, l.cloud, key, versions.SslCertificate)); err != nil {
 			klog.Errorf("Old cert delete failed - %v", err)
 			certErr = err
 		}
@@ -345,10 +448,19 @@ func (l *L7) deleteSSLCertificates(sslCertificates []*composite.SslCertificate,
 // deleteStaticIP deletes ingress managed static ip.
 func (l *L7) deleteStaticIP() error {
 	frName := l.namer.ForwardingRule(namer.HTTPProtocol)
+	if l.scope == meta.Regional {
+		key, err := l.CreateKey(frName)
+		if err != nil {
+			return err
+		}
+		klog.V(2).Infof("Deleting static IP %v", key)
+		return utils.IgnoreHTTPNotFound(composite.DeleteAddressWithContext(l.traceCtx, l.cloud, key, meta.VersionGA))
+	}
 	ip, err := l.cloud.GetGlobalAddress(frName)
 	if ip != nil && utils.IgnoreHTTPNotFound(err) == nil {
 		klog.V(2).Infof("Deleting static IP %v(%v)", ip.Name, ip.Address)
-		if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalAddress(ip.Name)); err != nil {
+		ac := audit.NewContext("Addresses", audit.OperationDelete, meta.GlobalKey(ip.Name), meta.VersionGA)
+		if err := utils.IgnoreHTTPNotFound(ac.Observe(l.cloud.DeleteGlobalAddress(ip.Name))); err != nil {
 			return err
 		}
 	}
@@ -360,6 +472,7 @@ func (l *L7) deleteStaticIP() error {
 // This leaves backends and health checks, which are shared across loadbalancers.
 func (l *L7) Cleanup(versions *features.ResourceVersions) error {
 	var err error
+	forgetGCELabelErrors(&l.ingress)
 	// Delete http frontend resources.
 	if err := l.deleteHttp(versions); err != nil {
 		return err
@@ -379,7 +492,7 @@ func (l *L7) Cleanup(versions *features.ResourceVersions) error {
 	if err != nil {
 		return err
 	}
-	if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMap(l.cloud, key, versions.UrlMap)); err != nil {
+	if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMapWithContext(l.traceCtx, l.cloud, key, versions.UrlMap)); err != nil {
 		return err
 	}
 
@@ -395,7 +508,7 @@ func (l *L7) Cleanup(versions *features.ResourceVersions) error {
 		if err != nil {
 			return err
 		}
-		if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMap(l.cloud, key, versions.UrlMap)); err != nil {
+		if err := utils.IgnoreHTTPNotFound(composite.DeleteUrlMapWithContext(l.traceCtx, l.cloud, key, versions.UrlMap)); err != nil {
 			return err
 		}
 	}
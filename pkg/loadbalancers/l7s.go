@@ -22,13 +22,16 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/api/networking/v1beta1"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
 	"k8s.io/ingress-gce/pkg/common/operator"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/networksecurity"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
 	"k8s.io/klog"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -42,18 +45,34 @@ type L7s struct {
 	recorderProducer events.RecorderProducer
 	// namerFactory creates frontend naming policy for ingress/ load balancer.
 	namerFactory namer_util.IngressFrontendNamerFactory
+	// certificateManager and networkSecurity manage the resources of client
+	// TLS, which are not part of the compute API. They are nil unless
+	// FrontendConfigs are enabled.
+	certificateManager certificatemanager.Interface
+	networkSecurity    networksecurity.Interface
+	// pendingServerTlsPolicies are the server TLS policies whose deletion
+	// did not finish yet.
+	pendingServerTlsPolicies *pendingDeletions
 }
 
 // NewLoadBalancerPool returns a new loadbalancer pool.
 // - cloud: implements LoadBalancers. Used to sync L7 loadbalancer resources
 //	 with the cloud.
-func NewLoadBalancerPool(cloud *gce.Cloud, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory) LoadBalancerPool {
-	return &L7s{
-		cloud:            cloud,
-		v1NamerHelper:    v1NamerHelper,
-		recorderProducer: recorderProducer,
-		namerFactory:     namerFactory,
+// - restConfig: configures the clients of client TLS, which is not supported
+//	 if it is nil.
+func NewLoadBalancerPool(cloud *gce.Cloud, restConfig *gcprest.Config, v1NamerHelper namer_util.V1FrontendNamer, recorderProducer events.RecorderProducer, namerFactory namer_util.IngressFrontendNamerFactory) LoadBalancerPool {
+	pool := &L7s{
+		cloud:                    cloud,
+		v1NamerHelper:            v1NamerHelper,
+		recorderProducer:         recorderProducer,
+		namerFactory:             namerFactory,
+		pendingServerTlsPolicies: newPendingDeletions(),
 	}
+	if flags.F.EnableFrontendConfig && restConfig != nil {
+		pool.certificateManager = certificatemanager.NewClient(restConfig, cloud.ProjectID())
+		pool.networkSecurity = networksecurity.NewClient(restConfig, cloud.ProjectID())
+	}
+	return pool
 }
 
 // Ensure implements LoadBalancerPool.
@@ -65,6 +84,10 @@ func (l *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
 		recorder:    l.recorderProducer.Recorder(ri.Ingress.Namespace),
 		scope:       features.ScopeFromIngress(ri.Ingress),
 		ingress:     *ri.Ingress,
+
+		certificateManager:       l.certificateManager,
+		networkSecurity:          l.networkSecurity,
+		pendingServerTlsPolicies: l.pendingServerTlsPolicies,
 	}
 
 	if !lb.namer.IsValidLoadBalancer() {
@@ -73,7 +96,7 @@ func (l *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
 		return nil, err
 	}
 
-	if err := lb.edgeHop(); err != nil {
+	if err := lb.tracedEdgeHop(); err != nil {
 		return nil, fmt.Errorf("loadbalancer %v does not exist: %v", lb.String(), err)
 	}
 	return lb, nil
@@ -90,6 +113,10 @@ func (l *L7s) delete(namer namer_util.IngressFrontendNamer, versions *features.R
 		cloud:       l.cloud,
 		namer:       namer,
 		scope:       scope,
+
+		certificateManager:       l.certificateManager,
+		networkSecurity:          l.networkSecurity,
+		pendingServerTlsPolicies: l.pendingServerTlsPolicies,
 	}
 
 	klog.V(2).Infof("Deleting loadbalancer %s", lb.String())
@@ -130,6 +157,9 @@ func (l *L7s) GCv2(ing *v1beta1.Ingress, scope meta.KeyType) error {
 
 // FrontendScopeChangeGC returns the scope to GC if the LB has changed scopes
 // (e.g. when a user migrates from ILB to ELB on the same ingress or vice versa.)
+// A move between L7-ILB and regional XLB keeps the regional scope, but the
+// regional resources of the previous load balancer must be GC'd before the
+// new one is created, as they have the same names.
 // This only applies to the V2 Naming Scheme
 // TODO(shance): Refactor to avoid calling GCE every sync loop
 func (l *L7s) FrontendScopeChangeGC(ing *v1beta1.Ingress) (*meta.KeyType, error) {
@@ -159,6 +189,37 @@ func (l *L7s) FrontendScopeChangeGC(ing *v1beta1.Ingress) (*meta.KeyType, error)
 			}
 		}
 	}
+	if currentScope == meta.Regional {
+		return l.regionalSchemeChangeGC(ing, namer)
+	}
+	return nil, nil
+}
+
+// regionalSchemeChangeGC returns the regional scope if the regional forwarding
+// rules of ing have a different load balancing scheme than ing requires.
+func (l *L7s) regionalSchemeChangeGC(ing *v1beta1.Ingress, namer namer_util.IngressFrontendNamer) (*meta.KeyType, error) {
+	scheme := "INTERNAL_MANAGED"
+	if utils.IsGCEL7XLBRegionalIngress(ing) {
+		scheme = "EXTERNAL_MANAGED"
+	}
+	for _, protocol := range []namer_util.NamerProtocol{namer_util.HTTPProtocol, namer_util.HTTPSProtocol} {
+		key, err := composite.CreateKey(l.cloud, namer.ForwardingRule(protocol), meta.Regional)
+		if err != nil {
+			return nil, err
+		}
+		fr, err := composite.GetForwardingRule(l.cloud, key, features.VersionsFromIngress(ing).ForwardingRule)
+		if err != nil {
+			if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if fr.LoadBalancingScheme != scheme {
+			klog.V(2).Infof("GC'ing ing %v for load balancing scheme %q", ing, fr.LoadBalancingScheme)
+			scope := meta.KeyType(meta.Regional)
+			return &scope, nil
+		}
+	}
 	return nil, nil
 }
 
@@ -172,8 +233,8 @@ func (l *L7s) GCv1(names []string) error {
 		knownLoadBalancers[l.v1NamerHelper.LoadBalancer(n)] = true
 	}
 
-	// GC L7-ILB LBs if enabled
-	if flags.F.EnableL7Ilb {
+	// GC L7-ILB and regional L7-XLB LBs if enabled
+	if flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional {
 		key, err := composite.CreateKey(l.cloud, "", meta.Regional)
 		if err != nil {
 			return fmt.Errorf("error getting regional key: %v", err)
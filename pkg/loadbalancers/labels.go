@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+	"k8s.io/legacy-cloud-providers/gce"
+)
+
+// labelErrors remembers the invalid labels reported for each object, so
+// that each is reported once rather than on every sync.
+var labelErrors = struct {
+	sync.Mutex
+	reported map[types.UID]string
+}{reported: map[types.UID]string{}}
+
+// labelsVersion returns the API version to get a forwarding rule or address
+// with, which is created with version. The GA API does not return labels.
+func labelsVersion(version meta.Version) meta.Version {
+	if utils.GCELabelsEnabled() && version == meta.VersionGA {
+		return meta.VersionBeta
+	}
+	return version
+}
+
+// desiredGCELabels returns the labels of a GCE resource with existing labels
+// which is created for obj. Invalid labels of obj are reported as events when
+// they first become invalid.
+func desiredGCELabels(existing map[string]string, obj runtime.Object, recorder record.EventRecorder) (map[string]string, error) {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	labels, errs := utils.GCELabels(accessor.GetLabels())
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	msg := strings.Join(msgs, "; ")
+
+	labelErrors.Lock()
+	reported := labelErrors.reported[accessor.GetUID()]
+	if msg == "" {
+		delete(labelErrors.reported, accessor.GetUID())
+	} else {
+		labelErrors.reported[accessor.GetUID()] = msg
+	}
+	labelErrors.Unlock()
+	if msg != reported {
+		for _, err := range errs {
+			recorder.Eventf(obj, corev1.EventTypeWarning, events.InvalidGCELabel, "Label not propagated: %v", err)
+		}
+	}
+	return utils.MergeGCELabels(existing, labels)
+}
+
+// forgetGCELabelErrors forgets the invalid labels reported for obj, once its
+// resources are deleted.
+func forgetGCELabelErrors(obj runtime.Object) {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	labelErrors.Lock()
+	defer labelErrors.Unlock()
+	delete(labelErrors.reported, accessor.GetUID())
+}
+
+// ensureForwardingRuleLabels sets the GCE labels derived from obj on the
+// forwarding rule fr with key, tracing the calls under ctx. fr must have been
+// fetched with labelsVersion.
+func ensureForwardingRuleLabels(ctx context.Context, cloud *gce.Cloud, key *meta.Key, fr *composite.ForwardingRule, obj runtime.Object, recorder record.EventRecorder) error {
+	if !utils.GCELabelsEnabled() {
+		return nil
+	}
+	labels, err := desiredGCELabels(fr.Labels, obj, recorder)
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 && len(fr.Labels) == 0 || reflect.DeepEqual(labels, fr.Labels) {
+		return nil
+	}
+	klog.V(2).Infof("Updating labels of forwarding rule %s from %v to %v", key.Name, fr.Labels, labels)
+	if err := composite.SetLabelsForForwardingRuleWithContext(ctx, cloud, key, labels, fr.LabelFingerprint); err != nil {
+		return err
+	}
+	fr.Labels = labels
+	return nil
+}
+
+// ensureAddressLabels sets the GCE labels derived from obj on the address
+// addr with key, tracing the calls under ctx. addr must have been fetched with
+// labelsVersion.
+func ensureAddressLabels(ctx context.Context, cloud *gce.Cloud, key *meta.Key, addr *composite.Address, obj runtime.Object, recorder record.EventRecorder) error {
+	if !utils.GCELabelsEnabled() {
+		return nil
+	}
+	labels, err := desiredGCELabels(addr.Labels, obj, recorder)
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 && len(addr.Labels) == 0 || reflect.DeepEqual(labels, addr.Labels) {
+		return nil
+	}
+	klog.V(2).Infof("Updating labels of address %s from %v to %v", key.Name, addr.Labels, labels)
+	if err := composite.SetLabelsForAddressWithContext(ctx, cloud, key, labels, addr.LabelFingerprint); err != nil {
+		return err
+	}
+	addr.Labels = labels
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/google/go-cmp/cmp"
@@ -35,11 +36,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/instances"
 	"k8s.io/ingress-gce/pkg/loadbalancers/features"
+	"k8s.io/ingress-gce/pkg/networksecurity"
 	"k8s.io/ingress-gce/pkg/test"
 	"k8s.io/ingress-gce/pkg/translator"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -125,6 +128,7 @@ func newTestJig(t *testing.T) *testJig {
 		return nil
 	}
 	mockGCE.MockGlobalForwardingRules.InsertHook = InsertGlobalForwardingRuleHook
+	composite.RegisterFakeExtension(fakeGCE, mockGCE)
 
 	ing := newIngress()
 	feNamer := namer_util.NewFrontendNamerFactory(namer, "").Namer(ing)
@@ -159,7 +163,7 @@ func newFakeLoadBalancerPool(cloud *gce.Cloud, t *testing.T, namer *namer_util.N
 	nodePool := instances.NewNodePool(fakeIGs, namer, &test.FakeRecorderSource{})
 	nodePool.Init(&instances.FakeZoneLister{Zones: []string{defaultZone}})
 
-	return L7s{cloud, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, "")}
+	return L7s{cloud, namer, events.RecorderProducerMock{}, namer_util.NewFrontendNamerFactory(namer, ""), certificatemanager.NewFakeClient(), networksecurity.NewFakeClient(), newPendingDeletions()}
 }
 
 func newILBIngress() *v1beta1.Ingress {
@@ -172,6 +176,16 @@ func newILBIngress() *v1beta1.Ingress {
 	}
 }
 
+func newL7XLBRegionalIngress() *v1beta1.Ingress {
+	return &v1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ingressName,
+			Namespace:   namespace,
+			Annotations: map[string]string{annotations.IngressClassKey: annotations.GceL7XLBRegionalIngressClass},
+		},
+	}
+}
+
 func TestCreateHTTPLoadBalancer(t *testing.T) {
 	// This should NOT create the forwarding rule and target proxy
 	// associated with the HTTPS branch of this loadbalancer.
@@ -290,6 +304,124 @@ func TestCreateHTTPSILBLoadBalancerAllowHTTP(t *testing.T) {
 	}
 }
 
+func TestCreateL7XLBRegionalLoadBalancer(t *testing.T) {
+	flags.F.EnableL7XLBRegional = true
+	defer func() { flags.F.EnableL7XLBRegional = false }()
+
+	j := newTestJig(t)
+	j.mock.MockForwardingRules.InsertHook = InsertForwardingRuleHook
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000, BackendNamer: j.namer}}})
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		TLS:       []*translator.TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:    gceUrlMap,
+		Ingress:   newL7XLBRegionalIngress(),
+	}
+
+	l7, err := j.pool.Ensure(lbInfo)
+	if err != nil || l7 == nil {
+		t.Fatalf("j.pool.Ensure() = %v, %v", l7, err)
+	}
+	if l7.scope != meta.Regional {
+		t.Fatalf("l7.scope = %v, want %v", l7.scope, meta.Regional)
+	}
+	verifyHTTPForwardingRuleAndProxyLinks(t, j, l7, "")
+	verifyHTTPSForwardingRuleAndProxyLinks(t, j, l7)
+
+	for _, protocol := range []namer_util.NamerProtocol{namer_util.HTTPProtocol, namer_util.HTTPSProtocol} {
+		key, _ := composite.CreateKey(j.fakeGCE, l7.namer.ForwardingRule(protocol), meta.Regional)
+		fr, err := composite.GetForwardingRule(j.fakeGCE, key, meta.VersionGA)
+		if err != nil {
+			t.Fatalf("composite.GetForwardingRule(_, %v, _) = %v", key, err)
+		}
+		if fr.LoadBalancingScheme != "EXTERNAL_MANAGED" {
+			t.Errorf("Forwarding rule %v has load balancing scheme %q, want EXTERNAL_MANAGED", key, fr.LoadBalancingScheme)
+		}
+	}
+	key, _ := composite.CreateKey(j.fakeGCE, l7.namer.ForwardingRule(namer_util.HTTPProtocol), meta.Regional)
+	addr, err := composite.GetAddress(j.fakeGCE, key, meta.VersionGA)
+	if err != nil {
+		t.Fatalf("composite.GetAddress(_, %v, _) = %v", key, err)
+	}
+	if addr.AddressType == "INTERNAL" {
+		t.Errorf("Managed static IP %v is internal, want external", key)
+	}
+}
+
+func TestFrontendScopeChangeGC(t *testing.T) {
+	flags.F.EnableL7Ilb = true
+	flags.F.EnableL7XLBRegional = true
+	defer func() {
+		flags.F.EnableL7Ilb = false
+		flags.F.EnableL7XLBRegional = false
+	}()
+
+	j := newTestJig(t)
+	j.mock.MockForwardingRules.InsertHook = InsertForwardingRuleHook
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	setClass := func(class string) {
+		lbInfo.Ingress.Annotations = map[string]string{annotations.IngressClassKey: class}
+	}
+	checkScopeChange := func(want *meta.KeyType) {
+		t.Helper()
+		got, err := j.pool.FrontendScopeChangeGC(lbInfo.Ingress)
+		if err != nil {
+			t.Fatalf("FrontendScopeChangeGC() = %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("FrontendScopeChangeGC() returned unexpected scope, diff (-want +got):\n%s", diff)
+		}
+	}
+	gcAndEnsure := func(scope meta.KeyType) {
+		t.Helper()
+		if err := j.pool.GCv2(lbInfo.Ingress, scope); err != nil {
+			t.Fatalf("GCv2() = %v", err)
+		}
+		if _, err := j.pool.Ensure(lbInfo); err != nil {
+			t.Fatalf("j.pool.Ensure() = %v", err)
+		}
+	}
+	global, regional := meta.KeyType(meta.Global), meta.KeyType(meta.Regional)
+
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v", err)
+	}
+	checkScopeChange(nil)
+
+	// Global external to regional external.
+	setClass(annotations.GceL7XLBRegionalIngressClass)
+	checkScopeChange(&global)
+	gcAndEnsure(global)
+	checkScopeChange(nil)
+
+	// Regional external to internal keeps the scope, but the load balancing
+	// scheme of the forwarding rules changes.
+	setClass(annotations.GceL7ILBIngressClass)
+	checkScopeChange(&regional)
+	gcAndEnsure(regional)
+	checkScopeChange(nil)
+
+	// Internal to regional external.
+	setClass(annotations.GceL7XLBRegionalIngressClass)
+	checkScopeChange(&regional)
+	gcAndEnsure(regional)
+	checkScopeChange(nil)
+
+	// Regional external to global external.
+	setClass(annotations.GceIngressClass)
+	checkScopeChange(&regional)
+	gcAndEnsure(regional)
+	checkScopeChange(nil)
+}
+
 func TestCreateHTTPSLoadBalancer(t *testing.T) {
 	// This should NOT create the forwarding rule and target proxy
 	// associated with the HTTP branch of this loadbalancer.
@@ -1071,6 +1203,163 @@ func TestStaticIP(t *testing.T) {
 	}
 }
 
+func TestGCELabels(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"team", "app.kubernetes.io/name", "cost-center"}
+	flags.F.GCEStaticLabels = map[string]string{"env": "prod"}
+	defer func() {
+		flags.F.GCELabelAllowlist = nil
+		flags.F.GCEStaticLabels = nil
+	}()
+
+	j := newTestJig(t)
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	ing := newIngress()
+	ing.Labels = map[string]string{
+		"team":                   "Payments",
+		"app.kubernetes.io/name": "store",
+		"cost-center":            "This value is invalid as it is way too long to be used as a GCE label value",
+		"not-allowlisted":        "foo",
+	}
+	// The static IP is only created for HTTPS load balancers.
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: true,
+		TLS:       []*translator.TLSCerts{{Key: "key", Cert: "cert"}},
+		UrlMap:    gceUrlMap,
+		Ingress:   ing,
+	}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v", err)
+	}
+
+	frKey := meta.GlobalKey(j.feNamer.ForwardingRule(namer_util.HTTPProtocol))
+	want := map[string]string{"team": "payments", "app_kubernetes_io_name": "store", "env": "prod"}
+	verifyLabels := func(want map[string]string) {
+		t.Helper()
+		fr, err := composite.GetForwardingRule(j.fakeGCE, frKey, meta.VersionBeta)
+		if err != nil {
+			t.Fatalf("composite.GetForwardingRule(_, %v, _) = %v", frKey, err)
+		}
+		if diff := cmp.Diff(want, fr.Labels); diff != "" {
+			t.Errorf("Got forwarding rule labels, diff (-want +got):\n%s", diff)
+		}
+		addr, err := composite.GetAddress(j.fakeGCE, frKey, meta.VersionBeta)
+		if err != nil {
+			t.Fatalf("composite.GetAddress(_, %v, _) = %v", frKey, err)
+		}
+		if diff := cmp.Diff(want, addr.Labels); diff != "" {
+			t.Errorf("Got address labels, diff (-want +got):\n%s", diff)
+		}
+	}
+	verifyLabels(want)
+
+	// Labels set by others are kept, removed labels are deleted.
+	for _, set := range []func(*gce.Cloud, *meta.Key, map[string]string, string) error{composite.SetLabelsForForwardingRule, composite.SetLabelsForAddress} {
+		if err := set(j.fakeGCE, frKey, map[string]string{"team": "payments", "app_kubernetes_io_name": "store", "env": "prod", "owner": "alice"}, ""); err != nil {
+			t.Fatalf("Failed to set labels: %v", err)
+		}
+	}
+	ing.Labels = map[string]string{"team": "search"}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v", err)
+	}
+	verifyLabels(map[string]string{"team": "search", "env": "prod", "owner": "alice"})
+}
+
+func TestNetworkTier(t *testing.T) {
+	flags.F.EnableFrontendConfig = true
+	flags.F.EnableL7XLBRegional = true
+	defer func() {
+		flags.F.EnableFrontendConfig = false
+		flags.F.EnableL7XLBRegional = false
+	}()
+
+	j := newTestJig(t)
+	j.mock.MockForwardingRules.InsertHook = InsertForwardingRuleHook
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	standard := "STANDARD"
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP:      true,
+		TLS:            []*translator.TLSCerts{{Key: "key", Cert: "cert"}},
+		UrlMap:         gceUrlMap,
+		Ingress:        newIngress(),
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{NetworkTier: &standard}},
+	}
+	// The frontends of global load balancers are PREMIUM tier.
+	if _, err := j.pool.Ensure(lbInfo); err == nil {
+		t.Errorf("j.pool.Ensure() of a global Ingress with STANDARD tier returned no error")
+	}
+
+	lbInfo.Ingress = newL7XLBRegionalIngress()
+	l7, err := j.pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("j.pool.Ensure() = %v", err)
+	}
+	if l7.scope != meta.Regional {
+		t.Fatalf("l7.scope = %v, want %v", l7.scope, meta.Regional)
+	}
+	verifyHTTPForwardingRuleAndProxyLinks(t, j, l7, "")
+	verifyHTTPSForwardingRuleAndProxyLinks(t, j, l7)
+
+	region := j.fakeGCE.Region()
+	httpName := l7.namer.ForwardingRule(namer_util.HTTPProtocol)
+	httpsName := l7.namer.ForwardingRule(namer_util.HTTPSProtocol)
+	verifyFrontends := func(wantTier string) {
+		t.Helper()
+		for _, name := range []string{httpName, httpsName} {
+			key := meta.RegionalKey(name, region)
+			fr, err := composite.GetForwardingRule(j.fakeGCE, key, meta.VersionGA)
+			if err != nil {
+				t.Fatalf("composite.GetForwardingRule(_, %v, _) = %v", key, err)
+			}
+			if fr.NetworkTier != wantTier {
+				t.Errorf("Forwarding rule %v has network tier %q, want %q", key, fr.NetworkTier, wantTier)
+			}
+		}
+		key := meta.RegionalKey(httpName, region)
+		addr, err := composite.GetAddress(j.fakeGCE, key, meta.VersionGA)
+		if err != nil {
+			t.Fatalf("composite.GetAddress(_, %v, _) = %v", key, err)
+		}
+		if addr.NetworkTier != wantTier {
+			t.Errorf("Address %v has network tier %q, want %q", key, addr.NetworkTier, wantTier)
+		}
+	}
+	verifyFrontends("STANDARD")
+
+	// Switching to the default tier recreates the regional frontends, as the
+	// tier of a forwarding rule or an address cannot be changed.
+	lbInfo.FrontendConfig = &frontendconfigv1beta1.FrontendConfig{}
+	if _, err = j.pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("j.pool.Ensure() = %v", err)
+	}
+	verifyFrontends("")
+
+	// Static IPs must be in the tier of the Ingress.
+	lbInfo.FrontendConfig.Spec.NetworkTier = &standard
+	lbInfo.StaticIPName = "premium-ip"
+	if err := composite.CreateAddress(j.fakeGCE, meta.RegionalKey("premium-ip", region), &composite.Address{Name: "premium-ip", Address: "1.2.3.5", NetworkTier: "PREMIUM"}); err != nil {
+		t.Fatalf("composite.CreateAddress() = %v", err)
+	}
+	if _, err := j.pool.Ensure(lbInfo); err == nil {
+		t.Errorf("j.pool.Ensure() with a PREMIUM static IP and STANDARD tier returned no error")
+	}
+	lbInfo.StaticIPName = "standard-ip"
+	if err := composite.CreateAddress(j.fakeGCE, meta.RegionalKey("standard-ip", region), &composite.Address{Name: "standard-ip", Address: "1.2.3.6", NetworkTier: "STANDARD"}); err != nil {
+		t.Fatalf("composite.CreateAddress() = %v", err)
+	}
+	if _, err := j.pool.Ensure(lbInfo); err != nil {
+		t.Errorf("j.pool.Ensure() with a STANDARD static IP = %v", err)
+	}
+
+	invalid := "GOLD"
+	lbInfo.FrontendConfig.Spec.NetworkTier = &invalid
+	if _, err := j.pool.Ensure(lbInfo); err == nil {
+		t.Errorf("j.pool.Ensure() with network tier %q returned no error", invalid)
+	}
+}
+
 // Test setting frontendconfig Ssl policy
 func TestFrontendConfigSslPolicy(t *testing.T) {
 	flags.F.EnableFrontendConfig = true
@@ -1110,6 +1399,307 @@ func TestFrontendConfigSslPolicy(t *testing.T) {
 	}
 }
 
+// Test the lifecycle of SSL policies declared in FrontendConfig managedSslPolicy.
+func TestFrontendConfigManagedSslPolicy(t *testing.T) {
+	flags.F.EnableFrontendConfig = true
+	defer func() { flags.F.EnableFrontendConfig = false }()
+
+	j := newTestJig(t)
+	// Deleting an SSL policy fails while a target proxy uses it.
+	j.mock.MockSslPolicies.DeleteHook = func(ctx context.Context, key *meta.Key, m *cloud.MockSslPolicies) (bool, error) {
+		proxies, err := j.mock.TargetHttpsProxies().List(ctx, filter.None)
+		if err != nil {
+			return true, err
+		}
+		for _, tps := range proxies {
+			if name, _ := utils.KeyName(tps.SslPolicy); name == key.Name {
+				return true, &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("The ssl_policy resource '%s' is already being used by '%s'", key.Name, tps.Name)}
+			}
+		}
+		return false, nil
+	}
+
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	fc := &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+		ManagedSslPolicy: &frontendconfigv1beta1.ManagedSslPolicy{Profile: "MODERN"},
+	}}
+	newLBInfo := func(name string) *L7RuntimeInfo {
+		ing := newIngress()
+		ing.Name = name
+		ing.ObjectMeta.Finalizers = []string{common.FinalizerKeyV2}
+		return &L7RuntimeInfo{
+			AllowHTTP:      false,
+			TLS:            []*translator.TLSCerts{createCert("key", "cert", "name")},
+			UrlMap:         gceUrlMap,
+			Ingress:        ing,
+			FrontendConfig: fc,
+		}
+	}
+	ensure := func(lbInfo *L7RuntimeInfo) string {
+		t.Helper()
+		l7, err := j.pool.Ensure(lbInfo)
+		if err != nil {
+			t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+		}
+		tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionGA)
+		if err != nil {
+			t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", l7.tps.Name, err)
+		}
+		if tps.SslPolicy == "" {
+			return ""
+		}
+		name, err := utils.KeyName(tps.SslPolicy)
+		if err != nil {
+			t.Fatalf("KeyName(%q) = %v, want nil", tps.SslPolicy, err)
+		}
+		return name
+	}
+	verifyPolicy := func(name string, wantExists bool) {
+		t.Helper()
+		policy, err := composite.GetSslPolicy(j.fakeGCE, meta.GlobalKey(name))
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("SSL policy %q exists = %v, want %v (err: %v)", name, exists, wantExists, err)
+		}
+		if policy != nil && policy.Profile != "MODERN" {
+			t.Errorf("SSL policy %q has profile %q, want MODERN", name, policy.Profile)
+		}
+	}
+
+	lbInfo1, lbInfo2 := newLBInfo("ing-1"), newLBInfo("ing-2")
+	feNamer := namer_util.NewFrontendNamerFactory(j.namer, "").Namer(lbInfo1.Ingress)
+	policy := ensure(lbInfo1)
+	if !feNamer.IsSSLPolicy(policy) {
+		t.Fatalf("Target proxy uses SSL policy %q, want a managed policy", policy)
+	}
+	verifyPolicy(policy, true)
+	// The second ingress shares the policy.
+	if got := ensure(lbInfo2); got != policy {
+		t.Errorf("Second target proxy uses SSL policy %q, want %q", got, policy)
+	}
+
+	// Changing the policy creates a new one, the old one is kept while the
+	// second ingress uses it.
+	fc.Spec.ManagedSslPolicy.MinTlsVersion = "TLS_1_2"
+	newPolicy := ensure(lbInfo1)
+	if newPolicy == policy || !feNamer.IsSSLPolicy(newPolicy) {
+		t.Fatalf("Target proxy uses SSL policy %q after update, want a new managed policy", newPolicy)
+	}
+	verifyPolicy(newPolicy, true)
+	verifyPolicy(policy, true)
+	if got := ensure(lbInfo2); got != newPolicy {
+		t.Errorf("Second target proxy uses SSL policy %q, want %q", got, newPolicy)
+	}
+	verifyPolicy(policy, false)
+
+	// Deleting one ingress keeps the shared policy.
+	if err := j.pool.GCv2(lbInfo1.Ingress, meta.Global); err != nil {
+		t.Fatalf("j.pool.GCv2(%v) = %v, want nil", lbInfo1.Ingress, err)
+	}
+	verifyPolicy(newPolicy, true)
+
+	// Removing the policy from the FrontendConfig detaches and deletes it.
+	fc.Spec.ManagedSslPolicy = nil
+	if got := ensure(lbInfo2); got != "" {
+		t.Errorf("Target proxy uses SSL policy %q, want none", got)
+	}
+	verifyPolicy(newPolicy, false)
+}
+
+func TestFrontendConfigClientTls(t *testing.T) {
+	flags.F.EnableFrontendConfig = true
+	defer func() { flags.F.EnableFrontendConfig = false }()
+
+	j := newTestJig(t)
+	certificateManager := j.pool.certificateManager.(*certificatemanager.FakeClient)
+	networkSecurity := j.pool.networkSecurity.(*networksecurity.FakeClient)
+
+	ca1, err := test.NewSelfSignedCertificate("ca-1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca2, err := test.NewSelfSignedCertificate("ca-2", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ing := newIngress()
+	// Use v2 naming scheme since v1 is not supported
+	ing.ObjectMeta.Finalizers = []string{common.FinalizerKeyV2}
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	fc := &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+		ClientTls: &frontendconfigv1beta1.ClientTlsConfig{
+			TrustBundle: frontendconfigv1beta1.TrustBundleReference{SecretName: "ca"},
+		},
+	}}
+	lbInfo := &L7RuntimeInfo{
+		TLS:               []*translator.TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:            gceUrlMap,
+		Ingress:           ing,
+		FrontendConfig:    fc,
+		ClientTrustBundle: ca1,
+	}
+	name, _ := namer_util.NewFrontendNamerFactory(j.namer, "").Namer(ing).ServerTLSPolicy()
+
+	ensure := func() *composite.TargetHttpsProxy {
+		t.Helper()
+		l7, err := j.pool.Ensure(lbInfo)
+		if err != nil {
+			t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+		}
+		tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionGA)
+		if err != nil {
+			t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", l7.tps.Name, err)
+		}
+		return tps
+	}
+	verifyResources := func(wantExists bool, wantCA string) {
+		t.Helper()
+		policy, err := networkSecurity.GetServerTlsPolicy(name)
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("Server TLS policy %q exists = %v, want %v (err: %v)", name, exists, wantExists, err)
+		}
+		if policy != nil && policy.MtlsPolicy.ClientValidationTrustConfig != certificateManager.TrustConfigLink(name) {
+			t.Errorf("Server TLS policy %q validates against %q, want %q", name, policy.MtlsPolicy.ClientValidationTrustConfig, certificateManager.TrustConfigLink(name))
+		}
+		trustConfig, err := certificateManager.GetTrustConfig(name)
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("Trust config %q exists = %v, want %v (err: %v)", name, exists, wantExists, err)
+		}
+		if trustConfig != nil && trustConfig.TrustStores[0].TrustAnchors[0].PemCertificate != wantCA {
+			t.Errorf("Trust config %q has trust anchors %+v, want %q", name, trustConfig.TrustStores[0].TrustAnchors, wantCA)
+		}
+	}
+
+	tps := ensure()
+	if tps.ServerTlsPolicy != networkSecurity.ServerTlsPolicyLink(name) {
+		t.Errorf("Target proxy uses server TLS policy %q, want %q", tps.ServerTlsPolicy, networkSecurity.ServerTlsPolicyLink(name))
+	}
+	verifyResources(true, ca1)
+
+	// Rotating the CA updates the trust config in place.
+	lbInfo.ClientTrustBundle = ca2
+	if got := ensure(); got.ServerTlsPolicy != tps.ServerTlsPolicy {
+		t.Errorf("Target proxy uses server TLS policy %q after a CA rotation, want %q", got.ServerTlsPolicy, tps.ServerTlsPolicy)
+	}
+	verifyResources(true, ca2)
+
+	// Removing client TLS patches the policy out of the proxy, and keeps
+	// the forwarding rule.
+	j.mock.MockGlobalForwardingRules.DeleteHook = func(ctx context.Context, key *meta.Key, m *cloud.MockGlobalForwardingRules) (bool, error) {
+		t.Errorf("Forwarding rule %v deleted, want it kept", key)
+		return false, nil
+	}
+	fc.Spec.ClientTls = nil
+	lbInfo.ClientTrustBundle = ""
+	if got := ensure(); got.ServerTlsPolicy != "" {
+		t.Errorf("Target proxy uses server TLS policy %q, want none", got.ServerTlsPolicy)
+	}
+	verifyResources(false, "")
+
+	// Enabling client TLS again patches the policy into the proxy.
+	fc.Spec.ClientTls = &frontendconfigv1beta1.ClientTlsConfig{
+		TrustBundle: frontendconfigv1beta1.TrustBundleReference{SecretName: "ca"},
+	}
+	lbInfo.ClientTrustBundle = ca1
+	if got := ensure(); got.ServerTlsPolicy != tps.ServerTlsPolicy {
+		t.Errorf("Target proxy uses server TLS policy %q, want %q", got.ServerTlsPolicy, tps.ServerTlsPolicy)
+	}
+	verifyResources(true, ca1)
+	j.mock.MockGlobalForwardingRules.DeleteHook = nil
+
+	// Deleting the ingress deletes the policy and the trust config.
+	if err := j.pool.GCv2(ing, meta.Global); err != nil {
+		t.Fatalf("j.pool.GCv2(%v) = %v, want nil", ing, err)
+	}
+	verifyResources(false, "")
+}
+
+func TestCertificateMap(t *testing.T) {
+	j := newTestJig(t)
+
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234, BackendNamer: j.namer}
+	ing := newIngress()
+	lbInfo := &L7RuntimeInfo{
+		AllowHTTP: false,
+		TLS:       []*translator.TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:    gceUrlMap,
+		Ingress:   ing,
+	}
+	wantMap := fmt.Sprintf("//certificatemanager.googleapis.com/projects/%s/locations/global/certificateMaps/my-map", j.fakeGCE.ProjectID())
+
+	ensure := func(wantMap string, wantCerts int) {
+		t.Helper()
+		l7, err := j.pool.Ensure(lbInfo)
+		if err != nil {
+			t.Fatalf("j.pool.Ensure(%v) = %v, want nil", lbInfo, err)
+		}
+		tps, err := composite.GetTargetHttpsProxy(j.fakeGCE, meta.GlobalKey(l7.tps.Name), meta.VersionAlpha)
+		if err != nil {
+			t.Fatalf("GetTargetHttpsProxy(%q) = %v, want nil", l7.tps.Name, err)
+		}
+		if tps.CertificateMap != wantMap {
+			t.Errorf("Target proxy uses certificate map %q, want %q", tps.CertificateMap, wantMap)
+		}
+		if len(tps.SslCertificates) != wantCerts {
+			t.Errorf("Target proxy uses SSL certificates %v, want %d", tps.SslCertificates, wantCerts)
+		}
+		certs, err := composite.ListSslCertificates(j.fakeGCE, meta.GlobalKey(""), meta.VersionGA)
+		if err != nil {
+			t.Fatalf("ListSslCertificates() = %v, want nil", err)
+		}
+		if len(certs) != wantCerts {
+			t.Errorf("Got SSL certificates %v, want %d", toCertNames(certs), wantCerts)
+		}
+		fws, err := composite.GetForwardingRule(j.fakeGCE, meta.GlobalKey(l7.fws.Name), meta.VersionGA)
+		if err != nil {
+			t.Fatalf("GetForwardingRule(%q) = %v, want nil", l7.fws.Name, err)
+		}
+		if !utils.EqualResourceIDs(fws.Target, tps.SelfLink) {
+			t.Errorf("Forwarding rule targets %q, want %q", fws.Target, tps.SelfLink)
+		}
+	}
+
+	ensure("", 1)
+
+	// Switching between SSL certificates and certificate maps patches the
+	// proxy, and keeps it and the forwarding rule.
+	j.mock.MockGlobalForwardingRules.DeleteHook = func(ctx context.Context, key *meta.Key, m *cloud.MockGlobalForwardingRules) (bool, error) {
+		t.Errorf("Forwarding rule %v deleted, want it kept", key)
+		return false, nil
+	}
+	j.mock.MockTargetHttpsProxies.DeleteHook = func(ctx context.Context, key *meta.Key, m *cloud.MockTargetHttpsProxies) (bool, error) {
+		t.Errorf("Target proxy %v deleted, want it kept", key)
+		return false, nil
+	}
+	defer func() {
+		j.mock.MockGlobalForwardingRules.DeleteHook = nil
+		j.mock.MockTargetHttpsProxies.DeleteHook = nil
+	}()
+
+	// The certificate map replaces the SSL certificates.
+	lbInfo.CertificateMap = "my-map"
+	ensure(wantMap, 0)
+	ensure(wantMap, 0)
+
+	// The map of the FrontendConfig must not conflict with the annotation.
+	flags.F.EnableFrontendConfig = true
+	defer func() { flags.F.EnableFrontendConfig = false }()
+	otherMap := "other-map"
+	lbInfo.FrontendConfig = &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{CertificateMap: &otherMap}}
+	if _, err := j.pool.Ensure(lbInfo); err == nil {
+		t.Errorf("j.pool.Ensure(%v) = nil, want error for conflicting certificate maps", lbInfo)
+	}
+	lbInfo.CertificateMap = ""
+	ensure(strings.Replace(wantMap, "my-map", otherMap, 1), 0)
+
+	// Removing the map goes back to the SSL certificates.
+	lbInfo.FrontendConfig = nil
+	ensure("", 1)
+}
+
 func TestFrontendConfigRedirects(t *testing.T) {
 	flags.F.EnableFrontendConfig = true
 	defer func() { flags.F.EnableFrontendConfig = false }()
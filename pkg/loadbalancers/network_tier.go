@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/flags"
+)
+
+// networkTier returns the network tier of the frontend of the load balancer
+// as configured in its FrontendConfig. The default is PREMIUM. STANDARD is only
+// supported by regional external load balancers. Changing the tier recreates
+// the forwarding rules and the managed static IP.
+func (l *L7) networkTier() (cloud.NetworkTier, error) {
+	fc := l.runtimeInfo.FrontendConfig
+	if !flags.F.EnableFrontendConfig || fc == nil || fc.Spec.NetworkTier == nil {
+		return cloud.NetworkTierDefault, nil
+	}
+	switch tier := *fc.Spec.NetworkTier; tier {
+	case cloud.NetworkTierPremium.ToGCEValue():
+		return cloud.NetworkTierPremium, nil
+	case cloud.NetworkTierStandard.ToGCEValue():
+		if !l.isL7XLBRegional() {
+			return "", fmt.Errorf("network tier %s of FrontendConfig %s/%s is only supported for Ingress class %q", tier, fc.Namespace, fc.Name, annotations.GceL7XLBRegionalIngressClass)
+		}
+		return cloud.NetworkTierStandard, nil
+	default:
+		return "", fmt.Errorf("invalid network tier %q in FrontendConfig %s/%s, must be %s or %s", tier, fc.Namespace, fc.Name,
+			cloud.NetworkTierPremium.ToGCEValue(), cloud.NetworkTierStandard.ToGCEValue())
+	}
+}
+
+// frontendNetworkTier returns the GCE value of the network tier of the
+// forwarding rules and the static IP, empty for the default tier.
+func (l *L7) frontendNetworkTier() string {
+	if l.tier == cloud.NetworkTierStandard {
+		return l.tier.ToGCEValue()
+	}
+	return ""
+}
+
+// hasNetworkTier returns true if tier, the GCE value of the network tier of a
+// forwarding rule or an address, is the network tier of the load balancer.
+// An empty value is the default tier.
+func (l *L7) hasNetworkTier(tier string) bool {
+	if tier == "" {
+		return l.tier == cloud.NetworkTierDefault
+	}
+	return cloud.NetworkTierGCEValueToType(tier) == l.tier
+}
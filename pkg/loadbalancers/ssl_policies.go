@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// ensureManagedSslPolicy creates the managed SSL policy if it does not exist.
+// Managed policies are named after the hash of their configuration, so they
+// are shared by Ingresses with the same configuration and never updated.
+func (l *L7) ensureManagedSslPolicy(policy *compute.SslPolicy) error {
+	key := meta.GlobalKey(policy.Name)
+	existing, err := composite.GetSslPolicyWithContext(l.traceCtx, l.cloud, key)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	klog.V(2).Infof("Creating SSL policy %v for %v", policy.Name, l)
+	if err := composite.CreateSslPolicyWithContext(l.traceCtx, l.cloud, key, policy); err != nil {
+		return err
+	}
+	l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "SSLPolicy %q created", policy.Name)
+	return nil
+}
+
+// isManagedSslPolicy returns true if policyLink refers to an SSL policy
+// managed by the controller.
+func (l *L7) isManagedSslPolicy(policyLink string) bool {
+	if policyLink == "" {
+		return false
+	}
+	name, err := utils.KeyName(policyLink)
+	if err != nil {
+		klog.Warningf("Error parsing SSL policy link %q: %v", policyLink, err)
+		return false
+	}
+	return l.namer.IsSSLPolicy(name)
+}
+
+// deleteManagedSslPolicy deletes the managed SSL policy referred to by
+// policyLink if it is not used by any other target proxy.
+func (l *L7) deleteManagedSslPolicy(policyLink string) error {
+	if !l.isManagedSslPolicy(policyLink) {
+		return nil
+	}
+	name, err := utils.KeyName(policyLink)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Deleting SSL policy %v", name)
+	err = utils.IgnoreHTTPNotFound(composite.DeleteSslPolicyWithContext(l.traceCtx, l.cloud, meta.GlobalKey(name)))
+	if utils.IsInUsedByError(err) {
+		klog.V(2).Infof("SSL policy %v is still in use, skipping deletion", name)
+		return nil
+	}
+	return err
+}
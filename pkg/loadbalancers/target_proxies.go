@@ -17,6 +17,8 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
@@ -53,8 +55,7 @@ func (l *L7) checkProxy() (err error) {
 		return err
 	}
 
-	isL7ILB := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(l.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, l.namer)
+	tr := l.newTranslator()
 
 	description, err := l.description()
 	if err != nil {
@@ -69,17 +70,17 @@ func (l *L7) checkProxy() (err error) {
 		return err
 	}
 
-	currentProxy, _ := composite.GetTargetHttpProxy(l.cloud, key, version)
+	currentProxy, _ := composite.GetTargetHttpProxyWithContext(l.traceCtx, l.cloud, key, version)
 	if currentProxy == nil {
 		klog.V(3).Infof("Creating new http proxy for urlmap %v", l.um.Name)
 		key, err := l.CreateKey(proxy.Name)
 		if err != nil {
 			return err
 		}
-		if err = composite.CreateTargetHttpProxy(l.cloud, key, proxy); err != nil {
+		if err = composite.CreateTargetHttpProxyWithContext(l.traceCtx, l.cloud, key, proxy); err != nil {
 			return err
 		}
-		currentProxy, err = composite.GetTargetHttpProxy(l.cloud, key, version)
+		currentProxy, err = composite.GetTargetHttpProxyWithContext(l.traceCtx, l.cloud, key, version)
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q created", key.Name)
 		if err != nil {
 			return err
@@ -94,7 +95,7 @@ func (l *L7) checkProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetUrlMapForTargetHttpProxy(l.cloud, key, currentProxy, proxy.UrlMap); err != nil {
+		if err := composite.SetUrlMapForTargetHttpProxyWithContext(l.traceCtx, l.cloud, key, currentProxy, proxy.UrlMap); err != nil {
 			return err
 		}
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
@@ -104,11 +105,10 @@ func (l *L7) checkProxy() (err error) {
 }
 
 func (l *L7) checkHttpsProxy() (err error) {
-	isL7ILB := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(l.runtimeInfo.Ingress)
-	tr := translator.NewTranslator(isL7ILB, l.namer)
+	tr := l.newTranslator()
 	env := &translator.Env{FrontendConfig: l.runtimeInfo.FrontendConfig}
 
-	if len(l.sslCerts) == 0 {
+	if len(l.sslCerts) == 0 && l.certificateMap == "" {
 		klog.V(2).Infof("No SSL certificates for %q, will not create HTTPS Proxy.", l)
 		return nil
 	}
@@ -119,17 +119,35 @@ func (l *L7) checkHttpsProxy() (err error) {
 	}
 	description, err := l.description()
 	version := l.Versions().TargetHttpProxy
+	if l.certificateMap != "" {
+		version = certificateMapVersion
+	}
 	proxy, sslPolicySet, err := tr.ToCompositeTargetHttpsProxy(env, description, version, urlMapKey, l.sslCerts)
 	if err != nil {
 		return err
 	}
+	proxy.CertificateMap = l.certificateMap
+	if flags.F.EnableFrontendConfig {
+		policy, err := tr.ToSslPolicy(env)
+		if err != nil {
+			return err
+		}
+		if policy != nil {
+			if err := l.ensureManagedSslPolicy(policy); err != nil {
+				return err
+			}
+		}
+		if proxy.ServerTlsPolicy, err = l.ensureServerTlsPolicy(); err != nil {
+			return err
+		}
+	}
 
 	key, err := l.CreateKey(proxy.Name)
 	if err != nil {
 		return err
 	}
 
-	currentProxy, _ := composite.GetTargetHttpsProxy(l.cloud, key, version)
+	currentProxy, _ := composite.GetTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, version)
 	if err != nil {
 		return err
 	}
@@ -137,7 +155,7 @@ func (l *L7) checkHttpsProxy() (err error) {
 	if currentProxy == nil {
 		klog.V(3).Infof("Creating new https Proxy for urlmap %q", l.um.Name)
 
-		if err = composite.CreateTargetHttpsProxy(l.cloud, key, proxy); err != nil {
+		if err = composite.CreateTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, proxy); err != nil {
 			return err
 		}
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q created", key.Name)
@@ -146,11 +164,10 @@ func (l *L7) checkHttpsProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		currentProxy, err = composite.GetTargetHttpsProxy(l.cloud, key, version)
+		currentProxy, err = composite.GetTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, version)
 		if err != nil {
 			return err
 		}
-
 		l.tps = currentProxy
 		return nil
 	}
@@ -161,13 +178,33 @@ func (l *L7) checkHttpsProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetUrlMapForTargetHttpsProxy(l.cloud, key, currentProxy, proxy.UrlMap); err != nil {
+		if err := composite.SetUrlMapForTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, currentProxy, proxy.UrlMap); err != nil {
 			return err
 		}
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q updated", key.Name)
 	}
 
-	if !l.compareCerts(currentProxy.SslCertificates) {
+	// Switching between SSL certificates and a certificate map, or between
+	// maps, patches the proxy in place.
+	currentCertificateMap, err := l.certificateMapInUse(currentProxy)
+	if err != nil {
+		return err
+	}
+	if !utils.EqualLocationResourcePaths(currentCertificateMap, proxy.CertificateMap) {
+		klog.V(2).Infof("Https Proxy %v has the wrong certificate map %q, setting %q", currentProxy.Name, currentCertificateMap, proxy.CertificateMap)
+		key, err := l.CreateKey(currentProxy.Name)
+		if err != nil {
+			return err
+		}
+		if err := composite.SetCertificateMapForTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, proxy.CertificateMap, proxy.SslCertificates); err != nil {
+			return err
+		}
+		currentProxy.CertificateMap = proxy.CertificateMap
+		currentProxy.SslCertificates = proxy.SslCertificates
+		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certificate map updated", key.Name)
+	}
+
+	if l.certificateMap == "" && !l.compareCerts(currentProxy.SslCertificates) {
 		klog.V(2).Infof("Https Proxy %q has the wrong ssl certs, setting %v overwriting %v",
 			currentProxy.Name, toCertNames(l.sslCerts), currentProxy.SslCertificates)
 		var sslCertURLs []string
@@ -178,15 +215,46 @@ func (l *L7) checkHttpsProxy() (err error) {
 		if err != nil {
 			return err
 		}
-		if err := composite.SetSslCertificateForTargetHttpsProxy(l.cloud, key, currentProxy, sslCertURLs); err != nil {
+		if err := composite.SetSslCertificateForTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, currentProxy, sslCertURLs); err != nil {
 			return err
 		}
 		l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q certs updated", key.Name)
 	}
 
-	if flags.F.EnableFrontendConfig && sslPolicySet {
-		if err := l.ensureSslPolicy(env, currentProxy, proxy.SslPolicy); err != nil {
-			return err
+	if flags.F.EnableFrontendConfig {
+		oldServerTlsPolicy := currentProxy.ServerTlsPolicy
+		if !utils.EqualLocationResourcePaths(oldServerTlsPolicy, proxy.ServerTlsPolicy) {
+			klog.V(2).Infof("Https Proxy %v has the wrong server TLS policy %q, setting %q", currentProxy.Name, oldServerTlsPolicy, proxy.ServerTlsPolicy)
+			key, err := l.CreateKey(currentProxy.Name)
+			if err != nil {
+				return err
+			}
+			if err := composite.SetServerTlsPolicyForTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, proxy.ServerTlsPolicy); err != nil {
+				return err
+			}
+			currentProxy.ServerTlsPolicy = proxy.ServerTlsPolicy
+			l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q server TLS policy updated", key.Name)
+		}
+		if proxy.ServerTlsPolicy == "" {
+			if err := l.deleteServerTlsPolicy(oldServerTlsPolicy); err != nil {
+				return fmt.Errorf("error deleting server TLS policy %v: %v", oldServerTlsPolicy, err)
+			}
+		}
+
+		oldPolicy := currentProxy.SslPolicy
+		// Detach a managed SSL policy which is no longer declared.
+		if !sslPolicySet && l.isManagedSslPolicy(oldPolicy) {
+			sslPolicySet = true
+		}
+		if sslPolicySet {
+			if err := l.ensureSslPolicy(env, currentProxy, proxy.SslPolicy); err != nil {
+				return err
+			}
+			if !utils.EqualResourceIDs(proxy.SslPolicy, oldPolicy) {
+				if err := l.deleteManagedSslPolicy(oldPolicy); err != nil {
+					klog.Errorf("Failed to delete SSL policy %v: %v", oldPolicy, err)
+				}
+			}
 		}
 	}
 
@@ -200,7 +268,7 @@ func (l *L7) getSslCertLinkInUse() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	proxy, err := composite.GetTargetHttpsProxy(l.cloud, key, l.Versions().TargetHttpsProxy)
+	proxy, err := composite.GetTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, l.Versions().TargetHttpsProxy)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +276,19 @@ func (l *L7) getSslCertLinkInUse() ([]string, error) {
 	return proxy.SslCertificates, nil
 }
 
+// getHttpsProxyInUse returns the target https proxy, nil if it does not exist.
+func (l *L7) getHttpsProxyInUse() (*composite.TargetHttpsProxy, error) {
+	key, err := l.CreateKey(l.namer.TargetProxy(namer.HTTPSProtocol))
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := composite.GetTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, l.Versions().TargetHttpsProxy)
+	if err != nil {
+		return nil, utils.IgnoreHTTPNotFound(err)
+	}
+	return proxy, nil
+}
+
 // ensureSslPolicy ensures that the SslPolicy described in the frontendconfig is
 // properly applied to the proxy.
 func (l *L7) ensureSslPolicy(env *translator.Env, currentProxy *composite.TargetHttpsProxy, policyLink string) error {
@@ -216,7 +297,7 @@ func (l *L7) ensureSslPolicy(env *translator.Env, currentProxy *composite.Target
 		if err != nil {
 			return err
 		}
-		if err := composite.SetSslPolicyForTargetHttpsProxy(l.cloud, key, currentProxy, policyLink); err != nil {
+		if err := composite.SetSslPolicyForTargetHttpsProxyWithContext(l.traceCtx, l.cloud, key, currentProxy, policyLink); err != nil {
 			l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, events.SyncIngress, "TargetProxy %q SSLPolicy updated", key.Name)
 			return err
 		}
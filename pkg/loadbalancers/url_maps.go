@@ -46,9 +46,17 @@ func (l *L7) ensureComputeURLMap() error {
 	}
 	expectedMap := translator.ToCompositeURLMap(l.runtimeInfo.UrlMap, l.namer, key)
 	key.Name = expectedMap.Name
+	if flags.F.EnableFrontendConfig {
+		env := &translator.Env{FrontendConfig: l.runtimeInfo.FrontendConfig}
+		tr := l.newTranslator()
+		if err := tr.ApplyRedirectRules(env, expectedMap); err != nil {
+			return err
+		}
+		tr.ApplyClientCertHeaders(env, expectedMap)
+	}
 
 	expectedMap.Version = l.Versions().UrlMap
-	currentMap, err := composite.GetUrlMap(l.cloud, key, expectedMap.Version)
+	currentMap, err := composite.GetUrlMapWithContext(l.traceCtx, l.cloud, key, expectedMap.Version)
 	if utils.IgnoreHTTPNotFound(err) != nil {
 		return err
 	}
@@ -57,7 +65,7 @@ func (l *L7) ensureComputeURLMap() error {
 		// Check for transitions between elb and ilb
 
 		klog.V(2).Infof("Creating URLMap %q", expectedMap.Name)
-		if err := composite.CreateUrlMap(l.cloud, key, expectedMap); err != nil {
+		if err := composite.CreateUrlMapWithContext(l.traceCtx, l.cloud, key, expectedMap); err != nil {
 			return fmt.Errorf("CreateUrlMap: %v", err)
 		}
 		l.recorder.Eventf(&l.ingress, apiv1.EventTypeNormal, events.SyncIngress, "UrlMap %q created", key.Name)
@@ -74,7 +82,7 @@ func (l *L7) ensureComputeURLMap() error {
 
 	klog.V(2).Infof("Updating URLMap for %q", l)
 	expectedMap.Fingerprint = currentMap.Fingerprint
-	if err := composite.UpdateUrlMap(l.cloud, key, expectedMap); err != nil {
+	if err := composite.UpdateUrlMapWithContext(l.traceCtx, l.cloud, key, expectedMap); err != nil {
 		return fmt.Errorf("UpdateURLMap: %v", err)
 	}
 
@@ -88,11 +96,16 @@ func (l *L7) ensureRedirectURLMap() error {
 	feConfig := l.runtimeInfo.FrontendConfig
 	isL7ILB := flags.F.EnableL7Ilb && utils.IsGCEL7ILBIngress(&l.ingress)
 
-	t := translator.NewTranslator(isL7ILB, l.namer)
+	t := l.newTranslator()
 	env := &translator.Env{FrontendConfig: feConfig, Ing: &l.ingress}
 
 	name, namerSupported := l.namer.RedirectUrlMap()
 	expectedMap := t.ToRedirectUrlMap(env, l.Versions().UrlMap)
+	if expectedMap != nil && l.um != nil {
+		if err := t.ApplyRedirectRulesToRedirectUrlMap(env, expectedMap, l.um); err != nil {
+			return err
+		}
+	}
 
 	// Cannot enable for internal ingress
 	if expectedMap != nil && isL7ILB {
@@ -119,25 +132,25 @@ func (l *L7) ensureRedirectURLMap() error {
 		if !ok || status == "" {
 			return nil
 		} else {
-			if err := composite.DeleteUrlMap(l.cloud, key, l.Versions().UrlMap); err != nil {
+			if err := composite.DeleteUrlMapWithContext(l.traceCtx, l.cloud, key, l.Versions().UrlMap); err != nil {
 				return err
 			}
 		}
 		return nil
 	}
 
-	currentMap, err := composite.GetUrlMap(l.cloud, key, l.Versions().UrlMap)
+	currentMap, err := composite.GetUrlMapWithContext(l.traceCtx, l.cloud, key, l.Versions().UrlMap)
 	if utils.IgnoreHTTPNotFound(err) != nil {
 		return err
 	}
 
 	if currentMap == nil {
-		if err := composite.CreateUrlMap(l.cloud, key, expectedMap); err != nil {
+		if err := composite.CreateUrlMapWithContext(l.traceCtx, l.cloud, key, expectedMap); err != nil {
 			return err
 		}
 	} else if compareRedirectUrlMaps(expectedMap, currentMap) {
 		expectedMap.Fingerprint = currentMap.Fingerprint
-		if err := composite.UpdateUrlMap(l.cloud, key, expectedMap); err != nil {
+		if err := composite.UpdateUrlMapWithContext(l.traceCtx, l.cloud, key, expectedMap); err != nil {
 			return err
 		}
 	}
@@ -153,21 +166,28 @@ func compareRedirectUrlMaps(a, b *composite.UrlMap) bool {
 		a.DefaultUrlRedirect.RedirectResponseCode != b.DefaultUrlRedirect.RedirectResponseCode {
 		return true
 	}
-	return false
+	// Host and path rules of the redirect rules.
+	return !mapsEqual(a, b)
 }
 
 // getBackendNames returns the names of backends in this L7 urlmap.
 func getBackendNames(computeURLMap *composite.UrlMap) ([]string, error) {
 	beNames := sets.NewString()
 	for _, pathMatcher := range computeURLMap.PathMatchers {
-		name, err := utils.KeyName(pathMatcher.DefaultService)
-		if err != nil {
-			return nil, err
+		// Path matchers and path rules of redirect rules have no service.
+		if pathMatcher.DefaultService != "" {
+			name, err := utils.KeyName(pathMatcher.DefaultService)
+			if err != nil {
+				return nil, err
+			}
+			beNames.Insert(name)
 		}
-		beNames.Insert(name)
 
 		for _, pathRule := range pathMatcher.PathRules {
-			name, err = utils.KeyName(pathRule.Service)
+			if pathRule.Service == "" {
+				continue
+			}
+			name, err := utils.KeyName(pathRule.Service)
 			if err != nil {
 				return nil, err
 			}
@@ -189,7 +209,10 @@ func getBackendNames(computeURLMap *composite.UrlMap) ([]string, error) {
 // The service strings are parsed and compared as resource paths (such as
 // "global/backendServices/my-service") to ignore variables: endpoint, version, and project.
 func mapsEqual(a, b *composite.UrlMap) bool {
-	if !utils.EqualResourcePaths(a.DefaultService, b.DefaultService) {
+	if !servicesEqual(a.DefaultService, b.DefaultService) {
+		return false
+	}
+	if !headerActionsEqual(a.HeaderAction, b.HeaderAction) {
 		return false
 	}
 	if len(a.HostRules) != len(b.HostRules) {
@@ -219,7 +242,10 @@ func mapsEqual(a, b *composite.UrlMap) bool {
 	for i := range a.PathMatchers {
 		a := a.PathMatchers[i]
 		b := b.PathMatchers[i]
-		if !utils.EqualResourcePaths(a.DefaultService, b.DefaultService) {
+		if !servicesEqual(a.DefaultService, b.DefaultService) {
+			return false
+		}
+		if !redirectsEqual(a.DefaultUrlRedirect, b.DefaultUrlRedirect) {
 			return false
 		}
 		if a.Description != b.Description {
@@ -242,10 +268,57 @@ func mapsEqual(a, b *composite.UrlMap) bool {
 					return false
 				}
 			}
-			if !utils.EqualResourcePaths(a.Service, b.Service) {
+			if !servicesEqual(a.Service, b.Service) {
+				return false
+			}
+			if !redirectsEqual(a.UrlRedirect, b.UrlRedirect) {
 				return false
 			}
 		}
 	}
 	return true
 }
+
+// servicesEqual compares two service links of a url map, which are empty for
+// redirects.
+func servicesEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return utils.EqualResourcePaths(a, b)
+}
+
+// headerActionsEqual compares the headers a url map adds to requests.
+func headerActionsEqual(a, b *composite.HttpHeaderAction) bool {
+	var aHeaders, bHeaders []*composite.HttpHeaderOption
+	if a != nil {
+		aHeaders = a.RequestHeadersToAdd
+	}
+	if b != nil {
+		bHeaders = b.RequestHeadersToAdd
+	}
+	if len(aHeaders) != len(bHeaders) {
+		return false
+	}
+	for i := range aHeaders {
+		if aHeaders[i].HeaderName != bHeaders[i].HeaderName ||
+			aHeaders[i].HeaderValue != bHeaders[i].HeaderValue ||
+			aHeaders[i].Replace != bHeaders[i].Replace {
+			return false
+		}
+	}
+	return true
+}
+
+// redirectsEqual compares the redirects of a url map.
+func redirectsEqual(a, b *composite.HttpRedirectAction) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.HostRedirect == b.HostRedirect &&
+		a.HttpsRedirect == b.HttpsRedirect &&
+		a.PathRedirect == b.PathRedirect &&
+		a.PrefixRedirect == b.PrefixRedirect &&
+		a.RedirectResponseCode == b.RedirectResponseCode &&
+		a.StripQuery == b.StripQuery
+}
@@ -39,6 +39,24 @@ func TestComputeURLMapEquals(t *testing.T) {
 	if mapsEqual(m, diffDefault) {
 		t.Errorf("mapsEqual(%+v, %+v) = true, want false", m, diffDefault)
 	}
+
+	// Test redirects.
+	redirectMap := func(host string) *composite.UrlMap {
+		m := testCompositeURLMap()
+		m.PathMatchers[0].DefaultService = ""
+		m.PathMatchers[0].DefaultUrlRedirect = &composite.HttpRedirectAction{HostRedirect: host}
+		return m
+	}
+	redirect := redirectMap("www.abc.com")
+	if same := redirectMap("www.abc.com"); !mapsEqual(redirect, same) {
+		t.Errorf("mapsEqual(%+v, %+v) = false, want true", redirect, same)
+	}
+	if diffRedirect := redirectMap("abc.org"); mapsEqual(redirect, diffRedirect) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", redirect, diffRedirect)
+	}
+	if mapsEqual(m, redirect) {
+		t.Errorf("mapsEqual(%+v, %+v) = true, want false", m, redirect)
+	}
 }
 
 func testCompositeURLMap() *composite.UrlMap {
@@ -113,6 +131,27 @@ func TestGetBackendNames(t *testing.T) {
 			},
 			wantNames: []string{"service-A", "service-B", "service-C"},
 		},
+		"UrlMap with redirects": {
+			urlMap: &composite.UrlMap{
+				DefaultService: "global/backendServices/service-A",
+				PathMatchers: []*composite.PathMatcher{
+					{
+						DefaultUrlRedirect: &composite.HttpRedirectAction{HostRedirect: "www.abc.com"},
+						PathRules: []*composite.PathRule{
+							{
+								Paths:   []string{"/.well-known/acme-challenge/*"},
+								Service: "global/backendServices/service-B",
+							},
+							{
+								Paths:       []string{"/old", "/old/*"},
+								UrlRedirect: &composite.HttpRedirectAction{PrefixRedirect: "/new"},
+							},
+						},
+					},
+				},
+			},
+			wantNames: []string{"service-A", "service-B"},
+		},
 		"Invalid DefaultService": {
 			urlMap: &composite.UrlMap{
 				DefaultService: "/global/backendServices/service-A",
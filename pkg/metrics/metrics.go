@@ -65,6 +65,13 @@ var (
 		},
 		[]string{label},
 	)
+	certificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ingress_certificate_expiry_timestamp_seconds",
+			Help: "Expiry of the certificates of Ingresses from Secrets, as a Unix timestamp",
+		},
+		[]string{"ingress", "secret"},
+	)
 	l4ILBSyncLatencyMetricsLabels = []string{
 		"sync_result", // result of the sync
 		"sync_type",   // whether this is a new service or an update
@@ -85,6 +92,9 @@ func init() {
 	klog.V(3).Infof("Registering Ingress usage metrics %v and %v, NEG usage metrics %v", ingressCount, servicePortCount, networkEndpointGroupCount)
 	prometheus.MustRegister(ingressCount, servicePortCount, networkEndpointGroupCount)
 
+	klog.V(3).Infof("Registering Ingress certificate metrics %v", certificateExpiry)
+	prometheus.MustRegister(certificateExpiry)
+
 	klog.V(3).Infof("Registering L4 ILB usage metrics %v", l4ILBCount)
 	prometheus.MustRegister(l4ILBCount, l4ILBSyncLatency)
 }
@@ -111,6 +121,9 @@ type ControllerMetrics struct {
 	negMap map[string]NegServiceState
 	// l4ILBServiceMap is a map between service key and L4 ILB service state.
 	l4ILBServiceMap map[string]L4ILBServiceState
+	// certificateMap is a map between ingress key and the names of the
+	// Secrets of its certificates.
+	certificateMap map[string][]string
 	sync.Mutex
 }
 
@@ -120,6 +133,7 @@ func NewControllerMetrics() *ControllerMetrics {
 		ingressMap:      make(map[string]IngressState),
 		negMap:          make(map[string]NegServiceState),
 		l4ILBServiceMap: make(map[string]L4ILBServiceState),
+		certificateMap:  make(map[string][]string),
 	}
 }
 
@@ -169,6 +183,36 @@ func (im *ControllerMetrics) DeleteIngress(ingKey string) {
 	defer im.Unlock()
 
 	delete(im.ingressMap, ingKey)
+	im.setIngressCertificates(ingKey, nil)
+}
+
+// SetIngressCertificates implements IngressMetricsCollector.
+func (im *ControllerMetrics) SetIngressCertificates(ingKey string, notAfter map[string]time.Time) {
+	im.Lock()
+	defer im.Unlock()
+
+	im.setIngressCertificates(ingKey, notAfter)
+}
+
+// setIngressCertificates exports the expiry of the certificates of the
+// ingress and removes those of Secrets it no longer uses. It must be called
+// with the lock held.
+func (im *ControllerMetrics) setIngressCertificates(ingKey string, notAfter map[string]time.Time) {
+	for _, secret := range im.certificateMap[ingKey] {
+		if _, ok := notAfter[secret]; !ok {
+			certificateExpiry.DeleteLabelValues(ingKey, secret)
+		}
+	}
+	if len(notAfter) == 0 {
+		delete(im.certificateMap, ingKey)
+		return
+	}
+	var secrets []string
+	for secret, t := range notAfter {
+		certificateExpiry.WithLabelValues(ingKey, secret).Set(float64(t.Unix()))
+		secrets = append(secrets, secret)
+	}
+	im.certificateMap[ingKey] = secrets
 }
 
 // SetNegService implements NegMetricsCollector.
@@ -17,6 +17,8 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"k8s.io/api/networking/v1beta1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -72,6 +74,9 @@ type IngressMetricsCollector interface {
 	SetIngress(ingKey string, ing IngressState)
 	// DeleteIngress removes the given ingress key.
 	DeleteIngress(ingKey string)
+	// SetIngressCertificates sets the expiry of the certificates of the
+	// given ingress key, keyed by the name of their Secret.
+	SetIngressCertificates(ingKey string, notAfter map[string]time.Time)
 }
 
 // NegMetricsCollector is an interface to update/delete Neg states in the cache
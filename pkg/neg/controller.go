@@ -23,9 +23,11 @@ import (
 
 	istioV1alpha3 "istio.io/api/networking/v1alpha3"
 	apiv1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	"k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
@@ -40,12 +42,15 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/cloud-provider/service/helpers"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
 	svcnegv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/backendgrant"
 	"k8s.io/ingress-gce/pkg/controller/translator"
 	"k8s.io/ingress-gce/pkg/flags"
 	usage "k8s.io/ingress-gce/pkg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
+	negsyncer "k8s.io/ingress-gce/pkg/neg/syncers"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -74,6 +79,8 @@ type Controller struct {
 	hasSynced                   func() bool
 	ingressLister               cache.Indexer
 	serviceLister               cache.Indexer
+	backendGrantLister          cache.Indexer
+	svcNegLister                cache.Indexer
 	client                      kubernetes.Interface
 	defaultBackendService       utils.ServicePort
 	destinationRuleLister       cache.Indexer
@@ -99,6 +106,14 @@ type Controller struct {
 	// reflector handles NEG readiness gate and conditions for pods in NEG.
 	reflector readiness.Reflector
 
+	// operationScheduler runs the NEG operations of all syncers. It is nil
+	// unless the concurrency of NEG operations is limited.
+	operationScheduler *negsyncer.OperationScheduler
+
+	// externalNEGs manages the serverless and Internet NEGs of services. It
+	// is nil unless external NEGs are enabled.
+	externalNEGs *externalNEGManager
+
 	// collector collects NEG usage metrics
 	collector usage.NegMetricsCollector
 
@@ -119,11 +134,14 @@ func NewController(
 	endpointInformer cache.SharedIndexInformer,
 	destinationRuleInformer cache.SharedIndexInformer,
 	svcNegInformer cache.SharedIndexInformer,
+	backendGrantInformer cache.SharedIndexInformer,
+	workloadEndpointSliceInformer cache.SharedIndexInformer,
 	hasSynced func() bool,
 	controllerMetrics *usage.ControllerMetrics,
 	l4Namer namer2.L4ResourcesNamer,
 	defaultBackendService utils.ServicePort,
 	cloud negtypes.NetworkEndpointGroupCloud,
+	externalNegCloud negtypes.ExternalNetworkEndpointGroupCloud,
 	zoneGetter negtypes.ZoneGetter,
 	namer negtypes.NetworkEndpointGroupNamer,
 	resyncPeriod time.Duration,
@@ -178,6 +196,12 @@ func NewController(
 		reflector = &readiness.NoopReflector{}
 	}
 	manager.reflector = reflector
+	if workloadEndpointSliceInformer != nil {
+		manager.workloadEndpointSliceLister = workloadEndpointSliceInformer.GetIndexer()
+	}
+	if flags.F.NegOperationConcurrency > 0 {
+		manager.operationScheduler = negsyncer.NewOperationScheduler(cloud, flags.F.NegOperationConcurrency, flags.F.NegMaxBatchSize)
+	}
 
 	negController := &Controller{
 		client:                kubeClient,
@@ -197,9 +221,13 @@ func NewController(
 		nodeQueue:             workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
 		syncTracker:           utils.NewTimeTracker(),
 		reflector:             reflector,
+		operationScheduler:    manager.operationScheduler,
 		collector:             controllerMetrics,
 		runL4:                 runL4Controller,
 	}
+	if externalNegCloud != nil {
+		negController.externalNEGs = newExternalNEGManager(externalNegCloud, namer, serviceInformer.GetIndexer(), string(kubeSystemUID))
+	}
 	if runIngress {
 		ingressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -233,6 +261,17 @@ func NewController(
 			},
 		})
 
+		if backendGrantInformer != nil {
+			negController.backendGrantLister = backendGrantInformer.GetIndexer()
+			backendGrantInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+				AddFunc:    negController.enqueueBackendGrantServices,
+				DeleteFunc: negController.enqueueBackendGrantServices,
+				UpdateFunc: func(old, cur interface{}) {
+					negController.enqueueBackendGrantServices(cur)
+				},
+			})
+		}
+
 		podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 				pod := obj.(*apiv1.Pod)
@@ -240,6 +279,9 @@ func NewController(
 			},
 			UpdateFunc: func(old, cur interface{}) {
 				pod := cur.(*apiv1.Pod)
+				if flags.F.EnableNegGracefulRemoval && old.(*apiv1.Pod).DeletionTimestamp == nil && pod.DeletionTimestamp != nil {
+					negController.enqueuePodEndpoints(pod)
+				}
 				negController.reflector.SyncPod(pod)
 			},
 		})
@@ -249,6 +291,12 @@ func NewController(
 		DeleteFunc: negController.enqueueService,
 		UpdateFunc: func(old, cur interface{}) {
 			negController.enqueueService(cur)
+			// The subsets of L4 ILB NEGs are recalculated when the endpoints
+			// of the service are synced. Changes to the endpoints were not
+			// synced while reconciliation of the service was paused.
+			if l4ILBSubsetChanged(old.(*apiv1.Service), cur.(*apiv1.Service)) || reconciliationResumed(old.(*apiv1.Service), cur.(*apiv1.Service)) {
+				negController.enqueueEndpoint(cur)
+			}
 		},
 	})
 
@@ -260,6 +308,28 @@ func NewController(
 		},
 	})
 
+	if workloadEndpointSliceInformer != nil {
+		workloadEndpointSliceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    negController.enqueueWorkloadEndpointSlice,
+			DeleteFunc: negController.enqueueWorkloadEndpointSlice,
+			UpdateFunc: func(old, cur interface{}) {
+				negController.enqueueWorkloadEndpointSlice(cur)
+			},
+		})
+	}
+
+	if svcNegClient != nil {
+		negController.svcNegLister = svcNegInformer.GetIndexer()
+		svcNegInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    negController.enqueueSvcNegService,
+			DeleteFunc: negController.enqueueSvcNegService,
+			UpdateFunc: func(old, cur interface{}) {
+				negController.enqueueSvcNegService(old)
+				negController.enqueueSvcNegService(cur)
+			},
+		})
+	}
+
 	if negController.runL4 {
 		nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -311,6 +381,9 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 		wait.Until(c.gc, c.gcPeriod, stopCh)
 	}()
 	go c.reflector.Run(stopCh)
+	if c.operationScheduler != nil {
+		go c.operationScheduler.Run(stopCh)
+	}
 	<-stopCh
 }
 
@@ -426,6 +499,14 @@ func (c *Controller) processService(key string) error {
 	if service == nil {
 		return fmt.Errorf("cannot convert to Service (%T)", obj)
 	}
+	if paused, err := c.syncPausedStatus(service); err != nil || paused {
+		return err
+	}
+	if c.externalNEGs != nil {
+		if err := c.externalNEGs.EnsureNEGs(service); err != nil {
+			return err
+		}
+	}
 	negUsage := usage.NegServiceState{}
 	svcPortInfoMap := make(negtypes.PortInfoMap)
 	if err := c.mergeDefaultBackendServicePortInfoMap(key, service, svcPortInfoMap); err != nil {
@@ -445,6 +526,8 @@ func (c *Controller) processService(key string) error {
 		return err
 	}
 	negUsage.AsmNeg = len(csmSVCPortInfoMap) + len(destinationRulesPortInfoMap)
+	declaredPortInfoMap := c.getDeclaredPortInfoMap(service, svcPortInfoMap)
+	negUsage.StandaloneNeg += len(declaredPortInfoMap)
 
 	// merges csmSVCPortInfoMap, because eventually those NEG will sync with the service annotation.
 	// merges destinationRulesPortInfoMap later, because we only want them sync with the DestinationRule annotation.
@@ -456,7 +539,7 @@ func (c *Controller) processService(key string) error {
 			return err
 		}
 	}
-	if len(svcPortInfoMap) != 0 || len(destinationRulesPortInfoMap) != 0 {
+	if len(svcPortInfoMap) != 0 || len(destinationRulesPortInfoMap) != 0 || len(declaredPortInfoMap) != 0 {
 		klog.V(2).Infof("Syncing service %q", key)
 		if err = c.syncNegStatusAnnotation(namespace, name, svcPortInfoMap); err != nil {
 			return err
@@ -465,6 +548,10 @@ func (c *Controller) processService(key string) error {
 		if err := svcPortInfoMap.Merge(destinationRulesPortInfoMap); err != nil {
 			return fmt.Errorf("failed to merge service ports referenced by Istio:DestinationRule (%v): %v", destinationRulesPortInfoMap, err)
 		}
+		// NEGs declared by ServiceNetworkEndpointGroups report their status in the object, not in the service.
+		if err := svcPortInfoMap.Merge(declaredPortInfoMap); err != nil {
+			return fmt.Errorf("failed to merge service ports declared by ServiceNetworkEndpointGroups (%v): %v", declaredPortInfoMap, err)
+		}
 		c.collector.SetNegService(key, negUsage)
 		return c.manager.EnsureSyncers(namespace, name, svcPortInfoMap)
 	}
@@ -479,6 +566,23 @@ func (c *Controller) processService(key string) error {
 	return c.syncNegStatusAnnotation(namespace, name, make(negtypes.PortInfoMap))
 }
 
+// syncPausedStatus records whether reconciliation of a service with NEGs is
+// paused and returns true if it is. This is the only pause check of service
+// processing; endpoint syncs are skipped by the syncers themselves, and are
+// enqueued again when the service is resumed. The status of L4 ILB services
+// is recorded by the L4 controller.
+func (c *Controller) syncPausedStatus(service *apiv1.Service) (bool, error) {
+	paused := annotations.FromService(service).PauseReconciliation()
+	if wantsILB, _ := annotations.WantsL4ILB(service); c.runL4 && wantsILB {
+		return paused, nil
+	}
+	if _, hasNEGs := service.Annotations[annotations.NEGStatusKey]; paused && !hasNEGs {
+		// The service does not use NEGs.
+		return paused, nil
+	}
+	return paused, common.EnsureServicePausedStatus(service, c.client, c.recorder, paused)
+}
+
 // mergeIngressPortInfo merges Ingress PortInfo into portInfoMap if the service has Enable Ingress annotation.
 func (c *Controller) mergeIngressPortInfo(service *apiv1.Service, name types.NamespacedName, portInfoMap negtypes.PortInfoMap) error {
 	negAnnotation, foundNEGAnnotation, err := annotations.FromService(service).NEGAnnotation()
@@ -492,7 +596,7 @@ func (c *Controller) mergeIngressPortInfo(service *apiv1.Service, name types.Nam
 	// handle NEGs used by ingress
 	if negAnnotation != nil && negAnnotation.NEGEnabledForIngress() {
 		// Only service ports referenced by ingress are synced for NEG
-		ings := getIngressServicesFromStore(c.ingressLister, service)
+		ings := getIngressServicesFromStore(c.ingressLister, c.backendGrantLister, service)
 		ingressSvcPortTuples := gatherPortMappingUsedByIngress(ings, service)
 		ingressPortInfoMap := negtypes.NewPortInfoMap(name.Namespace, name.Name, ingressSvcPortTuples, c.namer, true, nil)
 		if err := portInfoMap.Merge(ingressPortInfoMap); err != nil {
@@ -542,6 +646,63 @@ func (c *Controller) mergeStandaloneNEGsPortInfo(service *apiv1.Service, name ty
 	return nil
 }
 
+// getDeclaredPortInfoMap returns the PortInfoMap of the NEGs declared for the
+// service by ServiceNetworkEndpointGroups. Objects which do not declare a valid
+// NEG, or whose NEG name is already used by a NEG in portInfoMap, are skipped
+// with a warning event.
+func (c *Controller) getDeclaredPortInfoMap(service *apiv1.Service, portInfoMap negtypes.PortInfoMap) negtypes.PortInfoMap {
+	declaredPortInfoMap := make(negtypes.PortInfoMap)
+	if c.svcNegLister == nil {
+		return declaredPortInfoMap
+	}
+	negNames := sets.NewString()
+	for _, portInfo := range portInfoMap {
+		negNames.Insert(portInfo.NegName)
+	}
+
+	objs, err := c.svcNegLister.ByIndex(cache.NamespaceIndex, service.Namespace)
+	if err != nil {
+		klog.Errorf("Failed to list ServiceNetworkEndpointGroups in namespace %s: %v", service.Namespace, err)
+		return declaredPortInfoMap
+	}
+	for _, obj := range objs {
+		svcNeg := obj.(*svcnegv1beta1.ServiceNetworkEndpointGroup)
+		if svcNeg.Spec.ServiceRef == nil || svcNeg.Spec.ServiceRef.Name != service.Name || !svcNeg.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if negNames.Has(svcNeg.Name) {
+			c.recorder.Eventf(svcNeg, apiv1.EventTypeWarning, negtypes.InvalidNegCR, "NEG name %q is already used by service %s/%s", svcNeg.Name, service.Namespace, service.Name)
+			continue
+		}
+		tuple, ok := findSvcPortTuple(service, svcNeg.Spec.Port)
+		if !ok {
+			c.recorder.Eventf(svcNeg, apiv1.EventTypeWarning, negtypes.InvalidNegCR, "Port %d is not found in service %s/%s", svcNeg.Spec.Port, service.Namespace, service.Name)
+			continue
+		}
+		svcNegPortInfoMap, err := negtypes.NewPortInfoMapForServiceNetworkEndpointGroup(tuple, svcNeg)
+		if err != nil {
+			c.recorder.Eventf(svcNeg, apiv1.EventTypeWarning, negtypes.InvalidNegCR, err.Error())
+			continue
+		}
+		if err := declaredPortInfoMap.Merge(svcNegPortInfoMap); err != nil {
+			c.recorder.Eventf(svcNeg, apiv1.EventTypeWarning, negtypes.InvalidNegCR, err.Error())
+			continue
+		}
+		negNames.Insert(svcNeg.Name)
+	}
+	return declaredPortInfoMap
+}
+
+// findSvcPortTuple returns the tuple of the service port with the given port number.
+func findSvcPortTuple(service *apiv1.Service, port int32) (negtypes.SvcPortTuple, bool) {
+	for _, sp := range service.Spec.Ports {
+		if sp.Port == port {
+			return negtypes.SvcPortTuple{Port: sp.Port, Name: sp.Name, TargetPort: sp.TargetPort.String()}, true
+		}
+	}
+	return negtypes.SvcPortTuple{}, false
+}
+
 // mergeVmIpNEGsPortInfo merges the PortInfo for ILB services using GCE_VM_IP NEGs into portInfoMap
 func (c *Controller) mergeVmIpNEGsPortInfo(service *apiv1.Service, name types.NamespacedName, portInfoMap negtypes.PortInfoMap, negUsage *usage.NegServiceState) error {
 	if wantsILB, _ := annotations.WantsL4ILB(service); !wantsILB {
@@ -588,9 +749,9 @@ func (c *Controller) mergeDefaultBackendServicePortInfoMap(key string, service *
 		return nil
 	}
 
-	// process default backend service for L7 ILB
-	if flags.F.EnableL7Ilb {
-		if err := scanIngress(utils.IsGCEL7ILBIngress); err != nil {
+	// process default backend service for L7 ILB and regional L7 XLB
+	if flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional {
+		if err := scanIngress(utils.IsGCEL7RegionalIngress); err != nil {
 			return err
 		}
 	}
@@ -756,6 +917,23 @@ func (c *Controller) enqueueEndpoint(obj interface{}) {
 	c.endpointQueue.Add(key)
 }
 
+// l4ILBSubsetChanged returns true if the annotations selecting the subset of
+// the L4 ILB NEGs of the service changed.
+func l4ILBSubsetChanged(old, cur *apiv1.Service) bool {
+	for _, key := range []string{annotations.L4ILBSubsetSizeKey, annotations.L4ILBSubsetModeKey} {
+		if old.Annotations[key] != cur.Annotations[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// reconciliationResumed returns true if reconciliation of the service was
+// paused and no longer is.
+func reconciliationResumed(old, cur *apiv1.Service) bool {
+	return annotations.FromService(old).PauseReconciliation() && !annotations.FromService(cur).PauseReconciliation()
+}
+
 func (c *Controller) enqueueNode(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -774,6 +952,23 @@ func (c *Controller) enqueueService(obj interface{}) {
 	c.serviceQueue.Add(key)
 }
 
+// enqueuePodEndpoints enqueues the endpoints of all services selecting the
+// pod, so that the pod is detached from NEGs as soon as it starts terminating.
+func (c *Controller) enqueuePodEndpoints(pod *apiv1.Pod) {
+	objs, err := c.serviceLister.ByIndex(cache.NamespaceIndex, pod.Namespace)
+	if err != nil {
+		klog.Errorf("Failed to list services in namespace %q: %v", pod.Namespace, err)
+		return
+	}
+	for _, obj := range objs {
+		svc := obj.(*apiv1.Service)
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		c.enqueueEndpoint(svc)
+	}
+}
+
 func (c *Controller) enqueueIngressServices(ing *v1beta1.Ingress) {
 	// enqueue services referenced by ingress
 	keys := gatherIngressServiceKeys(ing)
@@ -782,11 +977,87 @@ func (c *Controller) enqueueIngressServices(ing *v1beta1.Ingress) {
 	}
 
 	// enqueue default backend service
-	if flags.F.EnableL7Ilb && ing.Spec.Backend == nil {
+	if (flags.F.EnableL7Ilb || flags.F.EnableL7XLBRegional) && ing.Spec.Backend == nil {
 		c.enqueueService(cache.ExplicitKey(c.defaultBackendService.ID.Service.String()))
 	}
 }
 
+// enqueueBackendGrantServices enqueues all services in the namespace of the
+// BackendGrant that are referenced by ingresses in other namespaces.
+func (c *Controller) enqueueBackendGrantServices(obj interface{}) {
+	grant, ok := obj.(*backendgrantv1alpha1.BackendGrant)
+	if !ok {
+		state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+		if !stateOk {
+			klog.Errorf("Wanted BackendGrant, got %T", obj)
+			return
+		}
+		if grant, ok = state.Obj.(*backendgrantv1alpha1.BackendGrant); !ok {
+			klog.Errorf("Wanted BackendGrant, got %T", state.Obj)
+			return
+		}
+	}
+	keys := sets.NewString()
+	for _, m := range c.ingressLister.List() {
+		ing := m.(*v1beta1.Ingress)
+		if ing.Namespace == grant.Namespace || !utils.IsGLBCIngress(ing) {
+			continue
+		}
+		utils.TraverseIngressBackends(ing, func(id utils.ServicePortID) bool {
+			if id.Service.Namespace == grant.Namespace {
+				keys.Insert(utils.ServiceKeyFunc(id.Service.Namespace, id.Service.Name))
+			}
+			return false
+		})
+	}
+	for _, key := range keys.List() {
+		c.enqueueService(cache.ExplicitKey(key))
+	}
+}
+
+// enqueueSvcNegService enqueues the service of a ServiceNetworkEndpointGroup
+// declared by the user.
+func (c *Controller) enqueueSvcNegService(obj interface{}) {
+	svcNeg, ok := obj.(*svcnegv1beta1.ServiceNetworkEndpointGroup)
+	if !ok {
+		state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+		if !stateOk {
+			klog.Errorf("Wanted ServiceNetworkEndpointGroup, got %T", obj)
+			return
+		}
+		if svcNeg, ok = state.Obj.(*svcnegv1beta1.ServiceNetworkEndpointGroup); !ok {
+			klog.Errorf("Wanted ServiceNetworkEndpointGroup, got %T", state.Obj)
+			return
+		}
+	}
+	if svcNeg.Spec.ServiceRef == nil {
+		return
+	}
+	c.enqueueService(cache.ExplicitKey(utils.ServiceKeyFunc(svcNeg.Namespace, svcNeg.Spec.ServiceRef.Name)))
+}
+
+// enqueueWorkloadEndpointSlice enqueues the endpoints of the service of a
+// workload EndpointSlice, so that its NEGs are synced.
+func (c *Controller) enqueueWorkloadEndpointSlice(obj interface{}) {
+	slice, ok := obj.(*discovery.EndpointSlice)
+	if !ok {
+		state, stateOk := obj.(cache.DeletedFinalStateUnknown)
+		if !stateOk {
+			klog.Errorf("Wanted EndpointSlice, got %T", obj)
+			return
+		}
+		if slice, ok = state.Obj.(*discovery.EndpointSlice); !ok {
+			klog.Errorf("Wanted EndpointSlice, got %T", state.Obj)
+			return
+		}
+	}
+	serviceName, ok := slice.Labels[discovery.LabelServiceName]
+	if !ok {
+		return
+	}
+	c.endpointQueue.Add(utils.ServiceKeyFunc(slice.Namespace, serviceName))
+}
+
 // enqueueDestinationRule will enqueue the service used by obj.
 func (c *Controller) enqueueDestinationRule(obj interface{}) {
 	drus, ok := obj.(*unstructured.Unstructured)
@@ -807,6 +1078,11 @@ func (c *Controller) gc() {
 	if err := c.manager.GC(); err != nil {
 		klog.Errorf("NEG controller garbage collection failed: %v", err)
 	}
+	if c.externalNEGs != nil {
+		if err := c.externalNEGs.GC(); err != nil {
+			klog.Errorf("NEG controller garbage collection of external NEGs failed: %v", err)
+		}
+	}
 }
 
 // gatherPortMappingUsedByIngress returns a map containing port:targetport
@@ -848,16 +1124,19 @@ func gatherIngressServiceKeys(ing *v1beta1.Ingress) sets.String {
 	return set
 }
 
-func getIngressServicesFromStore(store cache.Store, svc *apiv1.Service) (ings []v1beta1.Ingress) {
+// getIngressServicesFromStore returns all ingresses that reference svc. Ingresses
+// in other namespaces are only returned if the reference is allowed by a BackendGrant.
+func getIngressServicesFromStore(store cache.Store, grantLister cache.Indexer, svc *apiv1.Service) (ings []v1beta1.Ingress) {
+	svcName := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
 	for _, m := range store.List() {
 		ing := *m.(*v1beta1.Ingress)
-		if ing.Namespace != svc.Namespace {
+		if ing.Namespace != svc.Namespace && !backendgrant.IsReferenceAllowed(grantLister, ing.Namespace, svcName) {
 			continue
 		}
 
 		if utils.IsGLBCIngress(&ing) {
 			utils.TraverseIngressBackends(&ing, func(id utils.ServicePortID) bool {
-				if id.Service.Name == svc.Name {
+				if id.Service == svcName {
 					ings = append(ings, ing)
 					return true
 				}
@@ -46,10 +46,13 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendgrantv1alpha1 "k8s.io/ingress-gce/pkg/apis/backendgrant/v1alpha1"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/legacy-cloud-providers/gce"
 )
 
@@ -135,11 +138,14 @@ func newTestController(kubeClient kubernetes.Interface) *Controller {
 		testContext.EndpointInformer,
 		drDynamicInformer.Informer(),
 		testContext.SvcNegInformer,
+		nil, // backendGrantInformer
+		nil, // workloadEndpointSliceInformer
 		func() bool { return true },
 		metrics.NewControllerMetrics(),
 		testContext.L4Namer,
 		defaultBackend,
 		negtypes.NewAdapter(testContext.Cloud),
+		nil, // externalNegCloud
 		negtypes.NewFakeZoneGetter(),
 		testContext.NegNamer,
 		testContext.ResyncPeriod,
@@ -573,6 +579,53 @@ func TestGatherPortMappingUsedByIngress(t *testing.T) {
 	}
 }
 
+func TestGetIngressServicesFromStoreCrossNamespace(t *testing.T) {
+	flags.F.EnableCrossNamespaceBackends = true
+	defer func() { flags.F.EnableCrossNamespaceBackends = false }()
+
+	controller := newTestController(fake.NewSimpleClientset())
+	defer controller.stop()
+	svc := newTestService(controller, true, []int32{})
+
+	sameNamespaceIng := newTestIngress("same-namespace")
+	crossNamespaceIng := newTestIngress("cross-namespace")
+	crossNamespaceIng.Namespace = "edge"
+	crossNamespaceIng.Annotations = map[string]string{
+		annotations.BackendNamespacesKey: fmt.Sprintf(`{%q: %q}`, testServiceName, testServiceNamespace),
+	}
+	unmappedIng := newTestIngress("unmapped")
+	unmappedIng.Namespace = "edge"
+
+	ingStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, ing := range []*v1beta1.Ingress{sameNamespaceIng, crossNamespaceIng, unmappedIng} {
+		ingStore.Add(ing)
+	}
+
+	grantLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	if got := getIngressServicesFromStore(ingStore, grantLister, svc); len(got) != 1 || got[0].Name != sameNamespaceIng.Name {
+		t.Errorf("getIngressServicesFromStore() without grant = %v, want only ingress %q", ingressNames(got), sameNamespaceIng.Name)
+	}
+
+	grantLister.Add(&backendgrantv1alpha1.BackendGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-edge", Namespace: testServiceNamespace},
+		Spec: backendgrantv1alpha1.BackendGrantSpec{
+			From: []backendgrantv1alpha1.BackendGrantFrom{{Namespace: "edge"}},
+		},
+	})
+	want := sets.NewString(sameNamespaceIng.Name, crossNamespaceIng.Name)
+	if got := getIngressServicesFromStore(ingStore, grantLister, svc); !sets.NewString(ingressNames(got)...).Equal(want) {
+		t.Errorf("getIngressServicesFromStore() with grant = %v, want %v", ingressNames(got), want.List())
+	}
+}
+
+func ingressNames(ings []v1beta1.Ingress) []string {
+	var names []string
+	for _, ing := range ings {
+		names = append(names, ing.Name)
+	}
+	return names
+}
+
 func TestSyncNegAnnotation(t *testing.T) {
 	t.Parallel()
 	// TODO: test that c.serviceLister.Update is called whenever the annotation
@@ -1161,6 +1214,156 @@ func TestEnableNegCRD(t *testing.T) {
 	}
 }
 
+func TestDeclaredNegCRD(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		// name is the name of the ServiceNetworkEndpointGroup, declared-neg
+		// if empty.
+		name         string
+		spec         negv1beta1.ServiceNetworkEndpointGroupSpec
+		expectSyncer bool
+		expectKey    negtypes.NegSyncerKey
+	}{
+		{
+			desc: "declared neg with zones, type and subset labels",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef:          &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:                8081,
+				NetworkEndpointType: negv1beta1.NonGCPPrivateEndpointType,
+				Zones:               []string{negtypes.TestZone2, negtypes.TestZone1},
+				SubsetLabels:        map[string]string{"version": "v1"},
+			},
+			expectSyncer: true,
+			expectKey: negtypes.NegSyncerKey{
+				Namespace:        testServiceNamespace,
+				Name:             testServiceName,
+				NegName:          "declared-neg",
+				PortTuple:        negtypes.SvcPortTuple{Port: 8081, Name: testNamedPort, TargetPort: "8081"},
+				Subset:           "declared-neg",
+				SubsetLabels:     "version=v1",
+				NegType:          negtypes.NonGCPPrivateEndpointType,
+				EpCalculatorMode: negtypes.L7Mode,
+				Zones:            negtypes.TestZone1 + "," + negtypes.TestZone2,
+			},
+		},
+		{
+			desc: "declared neg with defaults",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:       80,
+			},
+			expectSyncer: true,
+			expectKey: negtypes.NegSyncerKey{
+				Namespace:        testServiceNamespace,
+				Name:             testServiceName,
+				NegName:          "declared-neg",
+				PortTuple:        negtypes.SvcPortTuple{Port: 80, TargetPort: "8080"},
+				Subset:           "declared-neg",
+				NegType:          negtypes.VmIpPortEndpointType,
+				EpCalculatorMode: negtypes.L7Mode,
+			},
+		},
+		{
+			desc: "declared neg with unknown port",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:       1234,
+			},
+		},
+		{
+			desc: "declared neg with unsupported type",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef:          &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:                80,
+				NetworkEndpointType: negv1beta1.VmIpEndpointType,
+			},
+		},
+		{
+			desc: "declared neg with a name too long for GCE",
+			name: "declared-neg-" + strings.Repeat("a", 51),
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:       80,
+			},
+		},
+		{
+			desc: "declared neg with a dot in its name",
+			name: "declared.neg",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:       80,
+			},
+		},
+		{
+			desc: "declared neg for another service",
+			spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: "other-service"},
+				Port:       80,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			controller := newTestController(fake.NewSimpleClientset())
+			defer controller.stop()
+			svcNegClient := controller.manager.(*syncerManager).svcNegClient
+			svcKey := utils.ServiceKeyFunc(testServiceNamespace, testServiceName)
+			service := newTestService(controller, false, []int32{})
+			controller.serviceLister.Add(service)
+
+			name := tc.name
+			if name == "" {
+				name = "declared-neg"
+			}
+			svcNeg := &negv1beta1.ServiceNetworkEndpointGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testServiceNamespace},
+				Spec:       tc.spec,
+			}
+			svcNeg, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Create(context.TODO(), svcNeg, metav1.CreateOptions{})
+			if err != nil {
+				t.Fatalf("Failed to create ServiceNetworkEndpointGroup: %v", err)
+			}
+			controller.svcNegLister.Add(svcNeg)
+
+			if err := controller.processService(svcKey); err != nil {
+				t.Fatalf("Failed to process service: %v", err)
+			}
+			if !tc.expectSyncer {
+				validateSyncers(t, controller, 0, false)
+				return
+			}
+			ValidateSyncerByKey(t, controller, 1, tc.expectKey, false)
+
+			svcNeg, err = svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Get(context.TODO(), svcNeg.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get ServiceNetworkEndpointGroup: %v", err)
+			}
+			if len(svcNeg.Finalizers) != 1 || svcNeg.Finalizers[0] != common.NegFinalizerKey {
+				t.Errorf("Expected finalizer %q on ServiceNetworkEndpointGroup, got %v", common.NegFinalizerKey, svcNeg.Finalizers)
+			}
+			svc, err := controller.client.CoreV1().Services(testServiceNamespace).Get(context.TODO(), testServiceName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get service: %v", err)
+			}
+			if _, ok := svc.Annotations[annotations.NEGStatusKey]; ok {
+				t.Errorf("Expected no NEG status annotation on service, got %q", svc.Annotations[annotations.NEGStatusKey])
+			}
+
+			// Deleting the ServiceNetworkEndpointGroup stops the syncer.
+			now := metav1.Now()
+			svcNeg.DeletionTimestamp = &now
+			controller.svcNegLister.Update(svcNeg)
+			if err := controller.processService(svcKey); err != nil {
+				t.Fatalf("Failed to process service: %v", err)
+			}
+			validateSyncers(t, controller, 1, true)
+		})
+	}
+}
+
 func validateNegCRs(t *testing.T, svc *v1.Service, svcNegClient svcnegclient.Interface, namer negtypes.NetworkEndpointGroupNamer, negPortNameMap map[int32]string) {
 	t.Helper()
 
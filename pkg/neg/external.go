@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package neg
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	apiv1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/namer"
+	"k8s.io/klog"
+)
+
+// externalNEGManager manages the serverless and Internet NEGs declared by the
+// external NEG annotation of services, one per service port. Unlike the NEGs
+// of pods, they do not need a syncer: they are ensured when their service is
+// processed, and garbage collected once the annotation or the service is gone.
+// NEGs cannot be updated, so their name has a hash of their target, see
+// namer.ExternalNEGName: a new target gets a new NEG, to which the backend
+// linker moves the backend service, and the NEG of the old target is garbage
+// collected once the backend service no longer uses it.
+type externalNEGManager struct {
+	cloud         negtypes.ExternalNetworkEndpointGroupCloud
+	namer         negtypes.NetworkEndpointGroupNamer
+	serviceLister cache.Indexer
+	kubeSystemUID string
+}
+
+func newExternalNEGManager(cloud negtypes.ExternalNetworkEndpointGroupCloud, namer negtypes.NetworkEndpointGroupNamer, serviceLister cache.Indexer, kubeSystemUID string) *externalNEGManager {
+	return &externalNEGManager{
+		cloud:         cloud,
+		namer:         namer,
+		serviceLister: serviceLister,
+		kubeSystemUID: kubeSystemUID,
+	}
+}
+
+// EnsureNEGs ensures the external NEGs of the ports of service, if it has the
+// external NEG annotation.
+func (m *externalNEGManager) EnsureNEGs(service *apiv1.Service) error {
+	externalNEG, found, err := annotations.FromService(service).ExternalNEG()
+	if !found || err != nil {
+		return err
+	}
+	var errList []error
+	for _, port := range service.Spec.Ports {
+		if err := m.ensureNEG(service, port, externalNEG); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return utilerrors.NewAggregate(errList)
+}
+
+func (m *externalNEGManager) ensureNEG(service *apiv1.Service, port apiv1.ServicePort, externalNEG *annotations.ExternalNEG) error {
+	negName := m.negName(service, port, externalNEG)
+	key := negtypes.ExternalNEGKey(negName, m.cloud.Region(), externalNEG)
+	desc := utils.NegDescription{
+		ClusterUID:  m.kubeSystemUID,
+		Namespace:   service.Namespace,
+		ServiceName: service.Name,
+		Port:        strconv.Itoa(int(port.Port)),
+	}
+	expectedNEG := newExternalNEG(negName, desc, externalNEG)
+
+	neg, err := m.cloud.GetExternalNetworkEndpointGroup(key)
+	if err != nil && !utils.IsNotFoundError(err) {
+		return err
+	}
+	if err == nil {
+		if matches, err := utils.VerifyDescription(desc, neg.Description, negName, key.String()); !matches {
+			return fmt.Errorf("NEG %v is not owned by service %s/%s: %v", key, service.Namespace, service.Name, err)
+		}
+		if !equalExternalNEGs(neg, expectedNEG) {
+			return fmt.Errorf("NEG %v of service %s/%s has another target than its annotation", key, service.Namespace, service.Name)
+		}
+	} else {
+		klog.V(2).Infof("Creating %s NEG %v for service %s/%s", externalNEG.NetworkEndpointType, key, service.Namespace, service.Name)
+		if err := m.cloud.CreateExternalNetworkEndpointGroup(expectedNEG, key); err != nil {
+			return err
+		}
+	}
+
+	if externalNEG.IsServerless() {
+		return nil
+	}
+	return m.ensureInternetEndpoint(key, newInternetEndpoint(port, externalNEG))
+}
+
+// negName returns the name of the external NEG of the service port, which
+// matches utils.ServicePort.NEGName.
+func (m *externalNEGManager) negName(service *apiv1.Service, port apiv1.ServicePort, externalNEG *annotations.ExternalNEG) string {
+	return namer.ExternalNEGName(m.namer.NEG(service.Namespace, service.Name, port.Port), externalNEG.Target())
+}
+
+// ensureInternetEndpoint ensures that endpoint is the only endpoint of the
+// Internet NEG of key.
+func (m *externalNEGManager) ensureInternetEndpoint(key *meta.Key, endpoint *composite.NetworkEndpoint) error {
+	endpoints, err := m.cloud.ListExternalNetworkEndpoints(key)
+	if err != nil {
+		return err
+	}
+	var found bool
+	var toDetach []*composite.NetworkEndpoint
+	for _, ep := range endpoints {
+		if ep.Fqdn == endpoint.Fqdn && ep.IpAddress == endpoint.IpAddress && ep.Port == endpoint.Port {
+			found = true
+			continue
+		}
+		toDetach = append(toDetach, ep)
+	}
+	if len(toDetach) > 0 {
+		klog.V(2).Infof("Detaching %d stale endpoints from NEG %v", len(toDetach), key)
+		if err := m.cloud.DetachExternalNetworkEndpoints(key, toDetach); err != nil {
+			return err
+		}
+	}
+	if !found {
+		klog.V(2).Infof("Attaching endpoint %s%s:%d to NEG %v", endpoint.Fqdn, endpoint.IpAddress, endpoint.Port, key)
+		return m.cloud.AttachExternalNetworkEndpoints(key, []*composite.NetworkEndpoint{endpoint})
+	}
+	return nil
+}
+
+// GC deletes the external NEGs created by this cluster that are no longer
+// declared by a service, including those of a previous target. NEGs which are
+// still used by a backend service are deleted on a later run.
+func (m *externalNEGManager) GC() error {
+	expectedKeys := sets.NewString()
+	// keptServices are the services with an invalid annotation, whose NEGs
+	// are all kept since their target is unknown.
+	keptServices := sets.NewString()
+	for _, obj := range m.serviceLister.List() {
+		service := obj.(*apiv1.Service)
+		externalNEG, found, err := annotations.FromService(service).ExternalNEG()
+		if !found {
+			continue
+		}
+		if err != nil {
+			keptServices.Insert(utils.ServiceKeyFunc(service.Namespace, service.Name))
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			expectedKeys.Insert(negtypes.ExternalNEGKey(m.negName(service, port, externalNEG), m.cloud.Region(), externalNEG).String())
+		}
+	}
+
+	var errList []error
+	for _, listKey := range []*meta.Key{meta.RegionalKey("", m.cloud.Region()), meta.GlobalKey("")} {
+		negs, err := m.cloud.ListExternalNetworkEndpointGroups(listKey)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		for _, neg := range negs {
+			if !isExternalNEGType(neg.NetworkEndpointType) {
+				continue
+			}
+			desc, err := utils.NegDescriptionFromString(neg.Description)
+			if err != nil || desc.ClusterUID != m.kubeSystemUID {
+				continue
+			}
+			key := *listKey
+			key.Name = neg.Name
+			if expectedKeys.Has(key.String()) || keptServices.Has(utils.ServiceKeyFunc(desc.Namespace, desc.ServiceName)) {
+				continue
+			}
+			klog.V(2).Infof("Deleting NEG %v of service %s/%s", key, desc.Namespace, desc.ServiceName)
+			if err := m.cloud.DeleteExternalNetworkEndpointGroup(&key); err != nil {
+				if utils.IsInUsedByError(err) {
+					klog.V(2).Infof("NEG %v is still in use, retrying later: %v", key, err)
+					continue
+				}
+				if !utils.IsNotFoundError(err) {
+					errList = append(errList, err)
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errList)
+}
+
+// newExternalNEG returns the NEG of the external NEG annotation.
+func newExternalNEG(negName string, desc utils.NegDescription, externalNEG *annotations.ExternalNEG) *composite.NetworkEndpointGroup {
+	neg := &composite.NetworkEndpointGroup{
+		Version:             meta.VersionGA,
+		Name:                negName,
+		NetworkEndpointType: externalNEG.NetworkEndpointType,
+		Description:         desc.String(),
+	}
+	if run := externalNEG.CloudRun; run != nil {
+		neg.CloudRun = &composite.NetworkEndpointGroupCloudRun{Service: run.Service, Tag: run.Tag, UrlMask: run.URLMask}
+	}
+	if app := externalNEG.AppEngine; app != nil {
+		neg.AppEngine = &composite.NetworkEndpointGroupAppEngine{Service: app.Service, Version: app.Version, UrlMask: app.URLMask}
+	}
+	if function := externalNEG.CloudFunction; function != nil {
+		neg.CloudFunction = &composite.NetworkEndpointGroupCloudFunction{Function: function.Function, UrlMask: function.URLMask}
+	}
+	return neg
+}
+
+// newInternetEndpoint returns the endpoint of the Internet NEG of port. The
+// port of the endpoint is the target port of the service port, if it is a
+// number.
+func newInternetEndpoint(port apiv1.ServicePort, externalNEG *annotations.ExternalNEG) *composite.NetworkEndpoint {
+	endpointPort := int64(port.Port)
+	if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0 {
+		endpointPort = int64(port.TargetPort.IntVal)
+	}
+	return &composite.NetworkEndpoint{
+		Fqdn:      externalNEG.FQDN,
+		IpAddress: externalNEG.IPAddress,
+		Port:      endpointPort,
+	}
+}
+
+// equalExternalNEGs returns true if the NEGs have the same type and target.
+func equalExternalNEGs(a, b *composite.NetworkEndpointGroup) bool {
+	if a.NetworkEndpointType != b.NetworkEndpointType {
+		return false
+	}
+	if (a.CloudRun == nil) != (b.CloudRun == nil) || (a.AppEngine == nil) != (b.AppEngine == nil) || (a.CloudFunction == nil) != (b.CloudFunction == nil) {
+		return false
+	}
+	if a.CloudRun != nil && (a.CloudRun.Service != b.CloudRun.Service || a.CloudRun.Tag != b.CloudRun.Tag || a.CloudRun.UrlMask != b.CloudRun.UrlMask) {
+		return false
+	}
+	if a.AppEngine != nil && (a.AppEngine.Service != b.AppEngine.Service || a.AppEngine.Version != b.AppEngine.Version || a.AppEngine.UrlMask != b.AppEngine.UrlMask) {
+		return false
+	}
+	if a.CloudFunction != nil && (a.CloudFunction.Function != b.CloudFunction.Function || a.CloudFunction.UrlMask != b.CloudFunction.UrlMask) {
+		return false
+	}
+	return true
+}
+
+func isExternalNEGType(networkEndpointType string) bool {
+	switch networkEndpointType {
+	case annotations.ServerlessNEGType, annotations.InternetFQDNNEGType, annotations.InternetIPNEGType:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package neg
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const testExternalNEGRegion = "us-central1"
+
+func newTestExternalNEGManager() (*externalNEGManager, *negtypes.FakeExternalNetworkEndpointGroupCloud, *negtypes.TestContext) {
+	testContext := negtypes.NewTestContext()
+	fakeCloud := negtypes.NewFakeExternalNetworkEndpointGroupCloud(testExternalNEGRegion)
+	manager := newExternalNEGManager(fakeCloud, testContext.NegNamer, testContext.ServiceInformer.GetIndexer(), string(testContext.KubeSystemUID))
+	return manager, fakeCloud, testContext
+}
+
+func newExternalNEGService(annotation string) *apiv1.Service {
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testServiceName,
+			Namespace:   testServiceNamespace,
+			Annotations: map[string]string{annotations.ExternalNEGKey: annotation},
+		},
+		Spec: apiv1.ServiceSpec{
+			Type: apiv1.ServiceTypeClusterIP,
+			Ports: []apiv1.ServicePort{
+				{Port: 443, TargetPort: intstr.FromInt(8443)},
+			},
+		},
+	}
+}
+
+// externalNEGKey returns the key of the external NEG of port 443 of service.
+func externalNEGKey(t *testing.T, manager *externalNEGManager, service *apiv1.Service) *meta.Key {
+	t.Helper()
+	externalNEG, _, err := annotations.FromService(service).ExternalNEG()
+	if err != nil {
+		t.Fatalf("ExternalNEG() = %v, want nil", err)
+	}
+	return negtypes.ExternalNEGKey(manager.negName(service, service.Spec.Ports[0], externalNEG), testExternalNEGRegion, externalNEG)
+}
+
+func TestExternalNEGManagerEnsureNEGs(t *testing.T) {
+	manager, fakeCloud, _ := newTestExternalNEGManager()
+
+	// A serverless NEG is regional.
+	service := newExternalNEGService(`{"networkEndpointType":"SERVERLESS","cloudRun":{"service":"hello"}}`)
+	if err := manager.EnsureNEGs(service); err != nil {
+		t.Fatalf("EnsureNEGs() = %v, want nil", err)
+	}
+	regionalKey := externalNEGKey(t, manager, service)
+	if regionalKey.Region != testExternalNEGRegion {
+		t.Errorf("Got NEG key %v, want a key in region %s", regionalKey, testExternalNEGRegion)
+	}
+	neg, err := fakeCloud.GetExternalNetworkEndpointGroup(regionalKey)
+	if err != nil {
+		t.Fatalf("Failed to get NEG %v: %v", regionalKey, err)
+	}
+	if neg.NetworkEndpointType != annotations.ServerlessNEGType || neg.CloudRun == nil || neg.CloudRun.Service != "hello" {
+		t.Errorf("Got NEG %+v, want a serverless NEG of Cloud Run service hello", neg)
+	}
+
+	// An Internet NEG is global, with a single endpoint on the target port.
+	service = newExternalNEGService(`{"networkEndpointType":"INTERNET_FQDN_PORT","fqdn":"example.com"}`)
+	for i := 0; i < 2; i++ {
+		if err := manager.EnsureNEGs(service); err != nil {
+			t.Fatalf("EnsureNEGs() = %v, want nil", err)
+		}
+	}
+	globalKey := externalNEGKey(t, manager, service)
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(globalKey); err != nil {
+		t.Fatalf("Failed to get NEG %v: %v", globalKey, err)
+	}
+	endpoints, err := fakeCloud.ListExternalNetworkEndpoints(globalKey)
+	if err != nil {
+		t.Fatalf("Failed to list endpoints of NEG %v: %v", globalKey, err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Fqdn != "example.com" || endpoints[0].Port != 8443 {
+		t.Errorf("Got endpoints %+v, want example.com:8443", endpoints)
+	}
+
+	// Another endpoint replaces the endpoint of the same NEG.
+	service = newExternalNEGService(`{"networkEndpointType":"INTERNET_FQDN_PORT","fqdn":"example.org"}`)
+	if err := manager.EnsureNEGs(service); err != nil {
+		t.Fatalf("EnsureNEGs() = %v, want nil", err)
+	}
+	if key := externalNEGKey(t, manager, service); *key != *globalKey {
+		t.Errorf("Got NEG key %v for another endpoint, want %v", key, globalKey)
+	}
+	if endpoints, err = fakeCloud.ListExternalNetworkEndpoints(globalKey); err != nil {
+		t.Fatalf("Failed to list endpoints of NEG %v: %v", globalKey, err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Fqdn != "example.org" {
+		t.Errorf("Got endpoints %+v, want example.org:8443", endpoints)
+	}
+
+	// A NEG owned by another service is left alone.
+	service.Name = "other"
+	otherKey := externalNEGKey(t, manager, service)
+	otherNEG := &composite.NetworkEndpointGroup{
+		Name:                otherKey.Name,
+		NetworkEndpointType: annotations.InternetFQDNNEGType,
+		Description:         utils.NegDescription{ClusterUID: "other-cluster", Namespace: testServiceNamespace, ServiceName: "other", Port: "443"}.String(),
+	}
+	if err := fakeCloud.CreateExternalNetworkEndpointGroup(otherNEG, otherKey); err != nil {
+		t.Fatalf("Failed to create NEG %v: %v", otherKey, err)
+	}
+	if err := manager.EnsureNEGs(service); err == nil {
+		t.Errorf("EnsureNEGs() = nil, want an error for a NEG owned by another cluster")
+	}
+}
+
+func TestExternalNEGManagerChangeTarget(t *testing.T) {
+	manager, fakeCloud, testContext := newTestExternalNEGManager()
+
+	service := newExternalNEGService(`{"networkEndpointType":"SERVERLESS","cloudRun":{"service":"hello"}}`)
+	testContext.ServiceInformer.GetIndexer().Add(service)
+	if err := manager.EnsureNEGs(service); err != nil {
+		t.Fatalf("EnsureNEGs() = %v, want nil", err)
+	}
+	oldKey := externalNEGKey(t, manager, service)
+	fakeCloud.UsedBy[*oldKey] = "backend-service"
+
+	// Another target gets a new NEG, alongside the NEG still in use.
+	service = newExternalNEGService(`{"networkEndpointType":"SERVERLESS","cloudRun":{"service":"world"}}`)
+	testContext.ServiceInformer.GetIndexer().Update(service)
+	if err := manager.EnsureNEGs(service); err != nil {
+		t.Fatalf("EnsureNEGs() = %v, want nil", err)
+	}
+	newKey := externalNEGKey(t, manager, service)
+	if *newKey == *oldKey {
+		t.Fatalf("Got NEG key %v for another target, want another key", newKey)
+	}
+	neg, err := fakeCloud.GetExternalNetworkEndpointGroup(newKey)
+	if err != nil {
+		t.Fatalf("Failed to get NEG %v: %v", newKey, err)
+	}
+	if neg.CloudRun == nil || neg.CloudRun.Service != "world" {
+		t.Errorf("Got NEG %+v, want a serverless NEG of Cloud Run service world", neg)
+	}
+	if neg, err = fakeCloud.GetExternalNetworkEndpointGroup(oldKey); err != nil || neg.CloudRun.Service != "hello" {
+		t.Errorf("Got NEG %+v and error %v for the old target, want the NEG of Cloud Run service hello", neg, err)
+	}
+
+	// The NEG of the old target is kept while the backend service uses it.
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v, want nil", err)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(oldKey); err != nil {
+		t.Errorf("NEG %v in use was deleted: %v", oldKey, err)
+	}
+
+	// Once the backend service moved to the new NEG, the old one is deleted.
+	delete(fakeCloud.UsedBy, *oldKey)
+	fakeCloud.UsedBy[*newKey] = "backend-service"
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v, want nil", err)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(oldKey); !utils.IsNotFoundError(err) {
+		t.Errorf("Got error %v getting NEG %v, want not found", err, oldKey)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(newKey); err != nil {
+		t.Errorf("NEG %v of the current target was deleted: %v", newKey, err)
+	}
+}
+
+func TestExternalNEGManagerGC(t *testing.T) {
+	manager, fakeCloud, testContext := newTestExternalNEGManager()
+
+	service := newExternalNEGService(`{"networkEndpointType":"INTERNET_IP_PORT","ipAddress":"1.2.3.4"}`)
+	testContext.ServiceInformer.GetIndexer().Add(service)
+	if err := manager.EnsureNEGs(service); err != nil {
+		t.Fatalf("EnsureNEGs() = %v, want nil", err)
+	}
+	key := externalNEGKey(t, manager, service)
+
+	// A NEG of another cluster is never deleted.
+	foreignKey := meta.RegionalKey("foreign", testExternalNEGRegion)
+	foreignNEG := &composite.NetworkEndpointGroup{
+		Name:                foreignKey.Name,
+		NetworkEndpointType: annotations.ServerlessNEGType,
+		Description:         utils.NegDescription{ClusterUID: "other-cluster", Namespace: testServiceNamespace, ServiceName: "foreign", Port: "80"}.String(),
+	}
+	if err := fakeCloud.CreateExternalNetworkEndpointGroup(foreignNEG, foreignKey); err != nil {
+		t.Fatalf("Failed to create NEG %v: %v", foreignKey, err)
+	}
+
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v, want nil", err)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(key); err != nil {
+		t.Errorf("NEG %v of an annotated service was deleted: %v", key, err)
+	}
+
+	// The NEGs of an invalid annotation are kept.
+	service.Annotations[annotations.ExternalNEGKey] = `{"networkEndpointType":"INTERNET_IP_PORT"}`
+	testContext.ServiceInformer.GetIndexer().Update(service)
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v, want nil", err)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(key); err != nil {
+		t.Errorf("NEG %v of a service with an invalid annotation was deleted: %v", key, err)
+	}
+
+	// Removing the annotation releases the NEG.
+	delete(service.Annotations, annotations.ExternalNEGKey)
+	testContext.ServiceInformer.GetIndexer().Update(service)
+	if err := manager.GC(); err != nil {
+		t.Fatalf("GC() = %v, want nil", err)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(key); !utils.IsNotFoundError(err) {
+		t.Errorf("Got error %v getting NEG %v, want not found", err, key)
+	}
+	if _, err := fakeCloud.GetExternalNetworkEndpointGroup(foreignKey); err != nil {
+		t.Errorf("NEG %v of another cluster was deleted: %v", foreignKey, err)
+	}
+}
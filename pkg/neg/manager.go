@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
@@ -70,6 +71,9 @@ type syncerManager struct {
 	serviceLister  cache.Indexer
 	endpointLister cache.Indexer
 	svcNegLister   cache.Indexer
+	// workloadEndpointSliceLister lists the EndpointSlices of the Workloads.
+	// It is nil unless NEGs include Workloads.
+	workloadEndpointSliceLister cache.Indexer
 
 	// TODO: lock per service instead of global lock
 	mu sync.Mutex
@@ -82,6 +86,9 @@ type syncerManager struct {
 	syncerMap map[negtypes.NegSyncerKey]negtypes.NegSyncer
 	// reflector handles NEG readiness gate and conditions for pods in NEG.
 	reflector readiness.Reflector
+	// operationScheduler runs the NEG operations of all syncers. If nil,
+	// syncers run their operations right away.
+	operationScheduler *negsyncer.OperationScheduler
 	//svcNegClient handles lifecycle operations for NEG CRs
 	svcNegClient svcnegclient.Interface
 
@@ -181,26 +188,31 @@ func (manager *syncerManager) EnsureSyncers(namespace, name string, newPorts neg
 				continue
 			}
 
+			// NEGs declared by a ServiceNetworkEndpointGroup may be restricted to some zones.
+			zoneGetter := negtypes.NewFilteredZoneGetter(manager.zoneGetter, syncerKey.ZoneList())
 			// determine the implementation that calculates NEG endpoints on each sync.
-			epc := negsyncer.GetEndpointsCalculator(manager.nodeLister, manager.podLister, manager.zoneGetter,
+			epc := negsyncer.GetEndpointsCalculator(manager.nodeLister, manager.podLister, manager.serviceLister, zoneGetter,
 				syncerKey, portInfo.EpCalculatorMode)
 			syncer = negsyncer.NewTransactionSyncer(
 				syncerKey,
 				manager.recorder,
 				manager.cloud,
-				manager.zoneGetter,
+				zoneGetter,
 				manager.podLister,
 				manager.serviceLister,
 				manager.endpointLister,
 				manager.nodeLister,
 				manager.svcNegLister,
+				manager.workloadEndpointSliceLister,
 				manager.reflector,
 				epc,
 				string(manager.kubeSystemUID),
 				manager.svcNegClient,
 				!manager.namer.IsNEG(portInfo.NegName),
+				manager.operationScheduler,
 			)
 			manager.syncerMap[syncerKey] = syncer
+			metrics.AddSyncerMetrics(syncerKey.NegName)
 		}
 
 		if syncer.IsStopped() {
@@ -350,6 +362,10 @@ func (manager *syncerManager) ensureDeleteSvcNegCR(namespace, negName string) er
 		return nil
 	}
 	neg := obj.(*negv1beta1.ServiceNetworkEndpointGroup)
+	if isDeclaredSvcNegCR(neg) {
+		klog.V(2).Infof("Skipping deletion of neg cr %s/%s declared by the user", namespace, negName)
+		return nil
+	}
 
 	if neg.GetDeletionTimestamp().IsZero() {
 		if err = manager.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(namespace).Delete(context.Background(), negName, metav1.DeleteOptions{}); err != nil {
@@ -367,6 +383,7 @@ func (manager *syncerManager) garbageCollectSyncer() {
 	for key, syncer := range manager.syncerMap {
 		if syncer.IsStopped() && !syncer.IsShuttingDown() {
 			delete(manager.syncerMap, key)
+			metrics.DeleteSyncerMetrics(key.NegName)
 		}
 	}
 }
@@ -402,6 +419,9 @@ func (manager *syncerManager) garbageCollectNEG() error {
 				delete(deleteCandidates, portInfo.NegName)
 			}
 		}
+		for negName := range manager.pausedNEGs() {
+			delete(deleteCandidates, negName)
+		}
 	}()
 
 	// This section includes a potential race condition between deleting neg here and users adds the neg annotation.
@@ -427,6 +447,10 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 	negCRs := manager.svcNegLister.List()
 	for _, obj := range negCRs {
 		neg := obj.(*negv1beta1.ServiceNetworkEndpointGroup)
+		// NEGs declared by the user are only deleted along with their CR.
+		if isDeclaredSvcNegCR(neg) && neg.GetDeletionTimestamp().IsZero() {
+			continue
+		}
 		deletionCandidates[neg.Name] = neg
 	}
 
@@ -446,6 +470,9 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 				}
 			}
 		}
+		for negName := range manager.pausedNEGs() {
+			delete(deletionCandidates, negName)
+		}
 	}()
 
 	// This section includes a potential race condition between deleting neg here and users adds the neg annotation.
@@ -462,11 +489,12 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 	// occurs, it will report an error as an event on the given CR. If an error does occur, false will
 	// be returned to indicate that the CR should not be deleted.
 	deleteNegOrReportErr := func(name, zone string, cr *negv1beta1.ServiceNetworkEndpointGroup) bool {
+		serviceName, port := svcNegCRServicePort(cr)
 		expectedDesc := &utils.NegDescription{
 			ClusterUID:  string(manager.kubeSystemUID),
 			Namespace:   cr.Namespace,
-			ServiceName: cr.GetLabels()[negtypes.NegCRServiceNameKey],
-			Port:        cr.GetLabels()[negtypes.NegCRServicePortKey],
+			ServiceName: serviceName,
+			Port:        port,
 		}
 		if err := manager.ensureDeleteNetworkEndpointGroup(name, zone, expectedDesc); err != nil {
 			err = fmt.Errorf("failed to delete NEG %s in %s: %s", name, zone, err)
@@ -512,7 +540,8 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 
 			// Verify that the NEG is still not wanted before deleting the CR. Mitigates the possibility of the race
 			// condition mentioned above
-			svcKey := getServiceKey(cr.Namespace, cr.GetLabels()[negtypes.NegCRServiceNameKey])
+			serviceName, _ := svcNegCRServicePort(cr)
+			svcKey := getServiceKey(cr.Namespace, serviceName)
 			portInfoMap := manager.svcPortMap[svcKey]
 			for _, portInfo := range portInfoMap {
 				if portInfo.NegName == cr.Name {
@@ -531,6 +560,35 @@ func (manager *syncerManager) garbageCollectNEGWithCRD() error {
 	return utilerrors.NewAggregate(errList)
 }
 
+// pausedNEGs returns the names of the NEGs of services whose reconciliation
+// is paused. These NEGs must not be garbage collected. Caller must hold
+// manager.mu.
+func (manager *syncerManager) pausedNEGs() sets.String {
+	ret := sets.NewString()
+	for _, obj := range manager.serviceLister.List() {
+		svc := obj.(*v1.Service)
+		if !annotations.FromService(svc).PauseReconciliation() {
+			continue
+		}
+		for _, portInfo := range manager.svcPortMap[getServiceKey(svc.Namespace, svc.Name)] {
+			ret.Insert(portInfo.NegName)
+		}
+		// NEGs of a service paused before the controller started are only
+		// known from its NEG status.
+		if val, ok := svc.Annotations[annotations.NEGStatusKey]; ok {
+			negStatus, err := annotations.ParseNegStatus(val)
+			if err != nil {
+				klog.Errorf("Failed to parse NEG status of paused service %s/%s: %v", svc.Namespace, svc.Name, err)
+				continue
+			}
+			for _, negName := range negStatus.NetworkEndpointGroups {
+				ret.Insert(negName)
+			}
+		}
+	}
+	return ret
+}
+
 // ensureDeleteNetworkEndpointGroup ensures neg is delete from zone
 func (manager *syncerManager) ensureDeleteNetworkEndpointGroup(name, zone string, expectedDesc *utils.NegDescription) error {
 	neg, err := manager.cloud.GetNetworkEndpointGroup(name, zone, meta.VersionGA)
@@ -609,6 +667,10 @@ func (manager *syncerManager) ensureSvcNegCR(svcKey serviceKey, portInfo negtype
 		return err
 	}
 
+	if isDeclaredSvcNegCR(negCR) {
+		return manager.ensureDeclaredSvcNegCR(negCR, service)
+	}
+
 	needUpdate, err := ensureNegCRLabels(negCR, labels)
 	if err != nil {
 		klog.Errorf("failed to ensure labels for neg %s/%s for service %s: %s", negCR.Namespace, negCR.Name, service.Name, err)
@@ -623,6 +685,36 @@ func (manager *syncerManager) ensureSvcNegCR(svcKey serviceKey, portInfo negtype
 	return nil
 }
 
+// ensureDeclaredSvcNegCR ensures that the Neg CR declared by the user refers
+// to the service and carries the neg finalizer. Labels and owner references of
+// declared Neg CRs are left to the user.
+func (manager *syncerManager) ensureDeclaredSvcNegCR(negCR *negv1beta1.ServiceNetworkEndpointGroup, service *v1.Service) error {
+	if negCR.Spec.ServiceRef.Name != service.Name {
+		return fmt.Errorf("Neg %s/%s is declared for service %s, not %s", negCR.Namespace, negCR.Name, negCR.Spec.ServiceRef.Name, service.Name)
+	}
+	if !negCR.GetDeletionTimestamp().IsZero() || common.HasGivenFinalizer(negCR.ObjectMeta, common.NegFinalizerKey) {
+		return nil
+	}
+	updatedCR := negCR.DeepCopy()
+	updatedCR.Finalizers = append(updatedCR.Finalizers, common.NegFinalizerKey)
+	_, err := manager.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(negCR.Namespace).Update(context.Background(), updatedCR, metav1.UpdateOptions{})
+	return err
+}
+
+// isDeclaredSvcNegCR returns true if the Neg CR was created by the user to
+// declare a standalone NEG.
+func isDeclaredSvcNegCR(negCR *negv1beta1.ServiceNetworkEndpointGroup) bool {
+	return negCR.Spec.ServiceRef != nil
+}
+
+// svcNegCRServicePort returns the name and port of the service of the Neg CR.
+func svcNegCRServicePort(negCR *negv1beta1.ServiceNetworkEndpointGroup) (string, string) {
+	if isDeclaredSvcNegCR(negCR) {
+		return negCR.Spec.ServiceRef.Name, fmt.Sprint(negCR.Spec.Port)
+	}
+	return negCR.GetLabels()[negtypes.NegCRServiceNameKey], negCR.GetLabels()[negtypes.NegCRServicePortKey]
+}
+
 func ensureNegCRLabels(negCR *negv1beta1.ServiceNetworkEndpointGroup, labels map[string]string) (bool, error) {
 	needsUpdate := false
 	existingLabels := negCR.GetLabels()
@@ -693,6 +785,9 @@ func (manager *syncerManager) getSyncerKey(namespace, name string, servicePortKe
 		networkEndpointType = negtypes.VmIpEndpointType
 		calculatorMode = portInfo.EpCalculatorMode
 	}
+	if portInfo.NegType != "" {
+		networkEndpointType = portInfo.NegType
+	}
 
 	return negtypes.NegSyncerKey{
 		Namespace:        namespace,
@@ -703,6 +798,7 @@ func (manager *syncerManager) getSyncerKey(namespace, name string, servicePortKe
 		SubsetLabels:     portInfo.SubsetLabels,
 		NegType:          networkEndpointType,
 		EpCalculatorMode: calculatorMode,
+		Zones:            portInfo.Zones,
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/neg/types"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
@@ -419,6 +420,52 @@ func TestGarbageCollectionNEG(t *testing.T) {
 	manager.StopSyncer(testServiceNamespace, testServiceName)
 }
 
+func TestPausedServiceNEG(t *testing.T) {
+	t.Parallel()
+	manager, _ := NewTestSyncerManager(fake.NewSimpleClientset())
+	svcPort := int32(80)
+	negName := manager.namer.NEG(testServiceNamespace, testServiceName, svcPort)
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{
+		Namespace: testServiceNamespace,
+		Name:      testServiceName,
+		Annotations: map[string]string{
+			annotations.PauseReconciliationKey: "true",
+			annotations.NEGStatusKey:           fmt.Sprintf(`{"network_endpoint_groups":{"80":%q}}`, negName),
+		},
+	}}
+	manager.serviceLister.Add(svc)
+
+	// The NEG of a paused service is known only from its NEG status, as the
+	// controller does not ensure syncers while the service is paused.
+	manager.cloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{
+		Version:             meta.VersionGA,
+		Name:                negName,
+		NetworkEndpointType: string(negtypes.VmIpPortEndpointType),
+	}, negtypes.TestZone1)
+	svcNeg := &negv1beta1.ServiceNetworkEndpointGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testServiceNamespace, Name: negName},
+	}
+	manager.svcNegLister.Add(svcNeg)
+	manager.svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testServiceNamespace).Create(context2.Background(), svcNeg, metav1.CreateOptions{})
+
+	if err := manager.GC(); err != nil {
+		t.Fatalf("Failed to GC: %v", err)
+	}
+	if _, err := manager.cloud.GetNetworkEndpointGroup(negName, negtypes.TestZone1, meta.VersionGA); err != nil {
+		t.Errorf("Expect NEG %q of paused service to be kept, but got error: %v", negName, err)
+	}
+
+	// Deleting the service garbage collects the NEG even while paused.
+	manager.serviceLister.Delete(svc)
+	manager.StopSyncer(testServiceNamespace, testServiceName)
+	if err := manager.GC(); err != nil {
+		t.Fatalf("Failed to GC: %v", err)
+	}
+	if _, err := manager.cloud.GetNetworkEndpointGroup(negName, negtypes.TestZone1, meta.VersionGA); err == nil {
+		t.Errorf("Expect NEG %q of deleted service to be garbage collected", negName)
+	}
+}
+
 func TestReadinessGateEnabledNegs(t *testing.T) {
 	t.Parallel()
 
@@ -1412,6 +1459,80 @@ func TestGarbageCollectionNegCrdEnabled(t *testing.T) {
 }
 
 // getNegObjectRefs generates the NegObjectReference list of all negs with the specified negName in the specified zones
+func TestGarbageCollectionDeclaredNegCR(t *testing.T) {
+	t.Parallel()
+
+	zones := []string{negtypes.TestZone1, negtypes.TestZone2}
+	negName := "declared-neg"
+	desc := utils.NegDescription{
+		ClusterUID:  KubeSystemUID,
+		Namespace:   testServiceNamespace,
+		ServiceName: testServiceName,
+		Port:        "80",
+	}
+
+	for _, markedForDeletion := range []bool{false, true} {
+		kubeClient := fake.NewSimpleClientset()
+		manager, _ := NewTestSyncerManager(kubeClient)
+		svcNegClient := manager.svcNegClient
+
+		for _, zone := range zones {
+			manager.cloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{
+				Version:             meta.VersionGA,
+				Name:                negName,
+				NetworkEndpointType: string(negtypes.VmIpPortEndpointType),
+				Description:         desc.String(),
+			}, zone)
+		}
+
+		cr := negv1beta1.ServiceNetworkEndpointGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       negName,
+				Namespace:  testServiceNamespace,
+				Finalizers: []string{common.NegFinalizerKey},
+			},
+			Spec: negv1beta1.ServiceNetworkEndpointGroupSpec{
+				ServiceRef: &negv1beta1.ServiceReference{Name: testServiceName},
+				Port:       80,
+			},
+		}
+		cr.Status.NetworkEndpointGroups = getNegObjectRefs(t, manager.cloud, zones, negName, meta.VersionGA)
+		if markedForDeletion {
+			now := metav1.Now()
+			cr.SetDeletionTimestamp(&now)
+		}
+		if _, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(cr.Namespace).Create(context2.TODO(), &cr, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create neg cr: %v", err)
+		}
+		populateSvcNegCache(t, manager, svcNegClient, testServiceNamespace)
+
+		if err := manager.GC(); err != nil {
+			t.Fatalf("failed to GC: %v", err)
+		}
+
+		negs, err := manager.cloud.AggregatedListNetworkEndpointGroup(meta.VersionGA)
+		if err != nil {
+			t.Errorf("failed getting negs from cloud: %s", err)
+		}
+		numExistingNegs, negsDeleted := checkForNegDeletions(negs, negName)
+		if markedForDeletion && !negsDeleted {
+			t.Errorf("expected negs of deleted declared neg cr to be GCed, but found %d", numExistingNegs)
+		} else if !markedForDeletion && numExistingNegs != len(zones) {
+			t.Errorf("expected %d negs of declared neg cr in the cloud, but found %d", len(zones), numExistingNegs)
+		}
+
+		gotCR, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(cr.Namespace).Get(context2.TODO(), negName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get neg cr: %v", err)
+		}
+		if markedForDeletion && len(gotCR.Finalizers) != 0 {
+			t.Errorf("expected finalizer of deleted declared neg cr to be removed, got %v", gotCR.Finalizers)
+		} else if !markedForDeletion && !gotCR.GetDeletionTimestamp().IsZero() {
+			t.Errorf("expected declared neg cr to not be deleted")
+		}
+	}
+}
+
 func getNegObjectRefs(t *testing.T, cloud negtypes.NetworkEndpointGroupCloud, zones []string, negName string, version meta.Version) []negv1beta1.NegObjectReference {
 	var negRefs []negv1beta1.NegObjectReference
 	for _, zone := range zones {
@@ -30,6 +30,9 @@ const (
 	initLatencyKey           = "neg_initialization_duration_seconds"
 	negOpLatencyKey          = "neg_operation_duration_seconds"
 	negOpEndpointsKey        = "neg_operation_endpoints"
+	negOpQueueDepthKey       = "neg_operation_queue_depth"
+	negOpQueueLatencyKey     = "neg_operation_queue_duration_seconds"
+	subsetChurnKey           = "l4_ilb_subset_churn"
 	lastSyncTimestampKey     = "sync_timestamp"
 
 	resultSuccess = "success"
@@ -55,6 +58,15 @@ var (
 		"result",    // result of the sync
 	}
 
+	negOpQueueMetricsLabels = []string{
+		"operation", // endpoint operation
+	}
+
+	subsetChurnMetricsLabels = []string{
+		"endpoint_calculator_mode", // type of endpoint calculator used
+		"change",                   // whether nodes were added to or removed from the subset
+	}
+
 	negProcessMetricsLabels = []string{
 		"process", // type of manager process loop
 		"result",  // result of the process
@@ -84,6 +96,33 @@ var (
 		negOpEndpointsMetricsLabels,
 	)
 
+	NegOperationQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: negControllerSubsystem,
+			Name:      negOpQueueDepthKey,
+			Help:      "Number of NEG Operations waiting to be run",
+		},
+		negOpQueueMetricsLabels,
+	)
+
+	NegOperationQueueLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: negControllerSubsystem,
+			Name:      negOpQueueLatencyKey,
+			Help:      "Time a NEG Operation waited before being run",
+		},
+		negOpQueueMetricsLabels,
+	)
+
+	SubsetChurn = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: negControllerSubsystem,
+			Name:      subsetChurnKey,
+			Help:      "Number of nodes added to and removed from the subsets of L4 ILB NEGs",
+		},
+		subsetChurnMetricsLabels,
+	)
+
 	SyncerSyncLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Subsystem: negControllerSubsystem,
@@ -125,10 +164,15 @@ func RegisterMetrics() {
 	register.Do(func() {
 		prometheus.MustRegister(NegOperationLatency)
 		prometheus.MustRegister(NegOperationEndpoints)
+		prometheus.MustRegister(NegOperationQueueDepth)
+		prometheus.MustRegister(NegOperationQueueLatency)
+		prometheus.MustRegister(SubsetChurn)
 		prometheus.MustRegister(ManagerProcessLatency)
 		prometheus.MustRegister(SyncerSyncLatency)
 		prometheus.MustRegister(LastSyncTimestamp)
 		prometheus.MustRegister(InitializationLatency)
+		prometheus.MustRegister(EndpointAttachLatency)
+		prometheus.MustRegister(SyncerMetrics)
 	})
 }
 
@@ -140,6 +184,23 @@ func PublishNegOperationMetrics(operation, negType, apiVersion string, err error
 	NegOperationEndpoints.WithLabelValues(operation, negType, result).Observe(float64(numEndpoints))
 }
 
+// PublishNegOperationQueueDepth publishes the number of queued neg operations
+func PublishNegOperationQueueDepth(operation string, depth int) {
+	NegOperationQueueDepth.WithLabelValues(operation).Set(float64(depth))
+}
+
+// PublishNegOperationQueueLatency publishes the time a neg operation was queued for
+func PublishNegOperationQueueLatency(operation string, queuedAt time.Time) {
+	NegOperationQueueLatency.WithLabelValues(operation).Observe(time.Since(queuedAt).Seconds())
+}
+
+// PublishSubsetChurn publishes the number of nodes added to and removed from
+// the subsets of L4 ILB NEGs in a sync
+func PublishSubsetChurn(mode string, added, removed int) {
+	SubsetChurn.WithLabelValues(mode, "added").Add(float64(added))
+	SubsetChurn.WithLabelValues(mode, "removed").Add(float64(removed))
+}
+
 // PublishNegSyncMetrics publishes collected metrics for the sync of NEG
 func PublishNegSyncMetrics(negType, endpointCalculator string, err error, start time.Time) {
 	result := getResult(err)
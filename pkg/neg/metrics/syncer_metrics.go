@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	syncerEndpointsKey            = "syncer_endpoints"
+	syncerOperationsKey           = "syncer_operations"
+	syncerLastSyncAgeKey          = "syncer_last_successful_sync_age_seconds"
+	syncerTransactionEndpointsKey = "syncer_transaction_endpoints"
+	endpointAttachLatencyKey      = "endpoint_attach_duration_seconds"
+
+	// TransactionPending is the state of the endpoints with an operation in
+	// progress.
+	TransactionPending = "pending"
+	// TransactionStuck is the state of the endpoints with an operation in
+	// progress for longer than stuckTransactionThreshold.
+	TransactionStuck = "stuck"
+
+	// stuckTransactionThreshold is the duration after which an operation
+	// still in progress is reported as stuck.
+	stuckTransactionThreshold = 5 * time.Minute
+)
+
+var (
+	syncerEndpointsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("", negControllerSubsystem, syncerEndpointsKey),
+		"Number of endpoints in the NEG of a syncer, by zone",
+		[]string{
+			"neg_name", // name of the NEG
+			"zone",     // zone of the NEG
+		},
+		nil,
+	)
+
+	syncerOperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("", negControllerSubsystem, syncerOperationsKey),
+		"Number of NEG Operations run by a syncer",
+		[]string{
+			"neg_name",  // name of the NEG
+			"operation", // endpoint operation
+			"result",    // result of the operation
+		},
+		nil,
+	)
+
+	syncerLastSyncAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("", negControllerSubsystem, syncerLastSyncAgeKey),
+		"Time since the last successful sync of a syncer",
+		[]string{
+			"neg_name", // name of the NEG
+		},
+		nil,
+	)
+
+	syncerTransactionEndpointsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("", negControllerSubsystem, syncerTransactionEndpointsKey),
+		"Number of endpoints of a syncer with a NEG Operation in progress",
+		[]string{
+			"neg_name", // name of the NEG
+			"state",    // whether the operation is pending or stuck
+		},
+		nil,
+	)
+
+	EndpointAttachLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: negControllerSubsystem,
+			Name:      endpointAttachLatencyKey,
+			Help:      "Latency between a change of the Endpoints and the attachment of its endpoints to the NEG",
+			// From 1 second to about 1 hour.
+			Buckets: prometheus.ExponentialBuckets(1, 2, 13),
+		},
+		[]string{
+			"neg_type", // type of neg
+		},
+	)
+
+	// SyncerMetrics collects the metrics of each syncer, until the syncer
+	// is removed.
+	SyncerMetrics = newSyncerCollector()
+)
+
+// operationKey identifies the operation counts of a syncer.
+type operationKey struct {
+	operation string
+	result    string
+}
+
+// syncerState is the state of a syncer exported as metrics.
+type syncerState struct {
+	endpoints   map[string]int
+	operations  map[operationKey]int
+	lastSuccess time.Time
+	// transactionStarts are the start times of the operations in progress,
+	// one per endpoint. The zero time is an unknown start time.
+	transactionStarts []time.Time
+}
+
+// syncerCollector is a prometheus.Collector of the metrics of each syncer,
+// keyed by NEG name. Metrics are collected from a syncer once it is added, and
+// dropped all together when it is removed.
+type syncerCollector struct {
+	mu     sync.Mutex
+	states map[string]*syncerState
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+func newSyncerCollector() *syncerCollector {
+	return &syncerCollector{
+		states: map[string]*syncerState{},
+		now:    time.Now,
+	}
+}
+
+// state returns the state of the syncer of the NEG, nil if the syncer was
+// not added or was removed. It must be called with the lock held.
+func (c *syncerCollector) state(negName string) *syncerState {
+	return c.states[negName]
+}
+
+// Describe implements prometheus.Collector.
+func (c *syncerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- syncerEndpointsDesc
+	ch <- syncerOperationsDesc
+	ch <- syncerLastSyncAgeDesc
+	ch <- syncerTransactionEndpointsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *syncerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for negName, state := range c.states {
+		for zone, count := range state.endpoints {
+			ch <- prometheus.MustNewConstMetric(syncerEndpointsDesc, prometheus.GaugeValue, float64(count), negName, zone)
+		}
+		for key, count := range state.operations {
+			ch <- prometheus.MustNewConstMetric(syncerOperationsDesc, prometheus.CounterValue, float64(count), negName, key.operation, key.result)
+		}
+		if !state.lastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(syncerLastSyncAgeDesc, prometheus.GaugeValue, now.Sub(state.lastSuccess).Seconds(), negName)
+		}
+		var stuck int
+		for _, start := range state.transactionStarts {
+			if !start.IsZero() && now.Sub(start) > stuckTransactionThreshold {
+				stuck++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(syncerTransactionEndpointsDesc, prometheus.GaugeValue, float64(len(state.transactionStarts)), negName, TransactionPending)
+		ch <- prometheus.MustNewConstMetric(syncerTransactionEndpointsDesc, prometheus.GaugeValue, float64(stuck), negName, TransactionStuck)
+	}
+}
+
+// AddSyncerMetrics starts collecting the metrics of a syncer
+func AddSyncerMetrics(negName string) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	if _, ok := SyncerMetrics.states[negName]; !ok {
+		SyncerMetrics.states[negName] = &syncerState{operations: map[operationKey]int{}}
+	}
+}
+
+// PublishSyncerEndpoints publishes the number of endpoints in each zone of the NEG of a syncer
+func PublishSyncerEndpoints(negName string, endpoints map[string]int) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	if state := SyncerMetrics.state(negName); state != nil {
+		state.endpoints = endpoints
+	}
+}
+
+// PublishSyncerOperation publishes the result of a neg operation of a syncer
+func PublishSyncerOperation(negName, operation string, err error) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	if state := SyncerMetrics.state(negName); state != nil {
+		state.operations[operationKey{operation: operation, result: getResult(err)}]++
+	}
+}
+
+// PublishSyncerSuccess publishes the time of the last successful sync of a syncer
+func PublishSyncerSuccess(negName string) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	if state := SyncerMetrics.state(negName); state != nil {
+		state.lastSuccess = SyncerMetrics.now()
+	}
+}
+
+// PublishSyncerTransactions publishes the start times of the operations in
+// progress of a syncer, one per endpoint. Whether they are stuck is evaluated
+// when the metrics are collected.
+func PublishSyncerTransactions(negName string, startTimes []time.Time) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	if state := SyncerMetrics.state(negName); state != nil {
+		state.transactionStarts = startTimes
+	}
+}
+
+// DeleteSyncerMetrics removes the metrics of a syncer
+func DeleteSyncerMetrics(negName string) {
+	SyncerMetrics.mu.Lock()
+	defer SyncerMetrics.mu.Unlock()
+	delete(SyncerMetrics.states, negName)
+}
+
+// PublishEndpointAttachLatency publishes the time from a change of the Endpoints to the attachment of its endpoints
+func PublishEndpointAttachLatency(negType string, changedAt time.Time) {
+	EndpointAttachLatency.WithLabelValues(negType).Observe(time.Since(changedAt).Seconds())
+}
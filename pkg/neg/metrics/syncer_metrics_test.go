@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// collectSyncerMetrics returns the value of each metric of the syncers, keyed
+// by metric name and label values.
+func collectSyncerMetrics(t *testing.T) map[string]float64 {
+	t.Helper()
+	ch := make(chan prometheus.Metric, 100)
+	SyncerMetrics.Collect(ch)
+	close(ch)
+
+	values := map[string]float64{}
+	for metric := range ch {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Fatalf("Failed to write metric: %v", err)
+		}
+		var labels []string
+		for _, label := range m.GetLabel() {
+			labels = append(labels, label.GetValue())
+		}
+		sort.Strings(labels)
+		desc := metric.Desc().String()
+		name := desc[strings.Index(desc, `"`)+1:]
+		name = name[:strings.Index(name, `"`)]
+		key := fmt.Sprintf("%s%v", name, labels)
+		switch {
+		case m.Gauge != nil:
+			values[key] = m.GetGauge().GetValue()
+		case m.Counter != nil:
+			values[key] = m.GetCounter().GetValue()
+		}
+	}
+	return values
+}
+
+func TestSyncerMetrics(t *testing.T) {
+	now := time.Now()
+	SyncerMetrics.now = func() time.Time { return now }
+	defer func() { SyncerMetrics.now = time.Now }()
+
+	AddSyncerMetrics("neg1")
+	PublishSyncerEndpoints("neg1", map[string]int{"zone1": 3, "zone2": 1})
+	PublishSyncerOperation("neg1", "Attach", nil)
+	PublishSyncerOperation("neg1", "Attach", nil)
+	PublishSyncerOperation("neg1", "Detach", fmt.Errorf("error"))
+	// The transaction which started 4 minutes ago is stuck once collected.
+	PublishSyncerTransactions("neg1", []time.Time{now, now, now.Add(-4 * time.Minute), {}})
+	PublishSyncerSuccess("neg1")
+	now = now.Add(2 * time.Minute)
+
+	expected := map[string]float64{
+		"neg_controller_syncer_endpoints[neg1 zone1]":                  3,
+		"neg_controller_syncer_endpoints[neg1 zone2]":                  1,
+		"neg_controller_syncer_operations[Attach neg1 success]":        2,
+		"neg_controller_syncer_operations[Detach error neg1]":          1,
+		"neg_controller_syncer_transaction_endpoints[neg1 pending]":    4,
+		"neg_controller_syncer_transaction_endpoints[neg1 stuck]":      1,
+		"neg_controller_syncer_last_successful_sync_age_seconds[neg1]": 120,
+	}
+	if got := collectSyncerMetrics(t); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got syncer metrics %v, want %v", got, expected)
+	}
+
+	// Metrics published by a syncer which is being stopped are dropped.
+	DeleteSyncerMetrics("neg1")
+	PublishSyncerOperation("neg1", "Attach", nil)
+	PublishSyncerTransactions("neg1", []time.Time{now})
+	if got := collectSyncerMetrics(t); len(got) != 0 {
+		t.Errorf("Got syncer metrics %v after the syncer was deleted, want none", got)
+	}
+}
@@ -34,6 +34,10 @@ type Reflector interface {
 	// zone is the corresponding zone of the NEG resource (e.g. us-central1-b)
 	// endpointMap contains mapping from all network endpoints to pods which have been added into the NEG
 	CommitPods(syncerKey negtypes.NegSyncerKey, negName string, zone string, endpointMap negtypes.EndpointPodMap)
+	// SyncAttachedEndpoints signals the reflector which network endpoints are in a NEG, including the ones being detached.
+	// It is used to report whether terminating pods have been detached from their NEGs.
+	// endpoints contains all network endpoints of the NEG in the zone.
+	SyncAttachedEndpoints(syncerKey negtypes.NegSyncerKey, negName string, zone string, endpoints negtypes.NetworkEndpointSet)
 }
 
 // NegLookup defines an interface for looking up pod membership.
@@ -51,3 +55,6 @@ func (*NoopReflector) Run(<-chan struct{}) {}
 func (*NoopReflector) SyncPod(*v1.Pod) {}
 
 func (*NoopReflector) CommitPods(negtypes.NegSyncerKey, string, string, negtypes.EndpointPodMap) {}
+
+func (*NoopReflector) SyncAttachedEndpoints(negtypes.NegSyncerKey, string, string, negtypes.NetworkEndpointSet) {
+}
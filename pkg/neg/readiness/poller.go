@@ -17,13 +17,16 @@ limitations under the License.
 package readiness
 
 import (
+	"context"
 	"fmt"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/klog"
 	"strconv"
@@ -34,6 +37,19 @@ const (
 	healthyState = "HEALTHY"
 )
 
+// Policies deciding which backend services a pod must be healthy in, when its
+// NEG is attached to several backend services.
+const (
+	// AnyBackendServicePolicy requires the pod to be healthy in any of them.
+	AnyBackendServicePolicy = "any"
+	// AllBackendServicesPolicy requires the pod to be healthy in all of them.
+	AllBackendServicesPolicy = "all"
+	// NamedBackendServicesPolicy requires the pod to be healthy in all of
+	// the named ones. The other backend services are ignored, unless none of
+	// the named ones checks the health of the pod.
+	NamedBackendServicesPolicy = "named"
+)
+
 // negMeta references a GCE NEG resource
 type negMeta struct {
 	SyncerKey negtypes.NegSyncerKey
@@ -52,8 +68,8 @@ type podStatusPatcher interface {
 	// syncPod syncs the NEG readiness gate condition of the given pod.
 	// podKey is the key to the pod. It is the namespaced name in the format of "namespace/name"
 	// neg is the key of the NEG resource
-	// backendService is the key of the BackendService resource.
-	syncPod(podKey string, neg, backendService *meta.Key) error
+	// backendServices are the keys of the BackendService resources the pod is healthy in.
+	syncPod(podKey string, neg *meta.Key, backendServices []*meta.Key) error
 }
 
 // pollTarget is the target for polling
@@ -75,15 +91,22 @@ type poller struct {
 	lookup    NegLookup
 	patcher   podStatusPatcher
 	negCloud  negtypes.NetworkEndpointGroupCloud
+
+	// policy is the backend service policy of the pods, and
+	// namedBackendServices the backend services named by the policy.
+	policy               string
+	namedBackendServices sets.String
 }
 
 func NewPoller(podLister cache.Indexer, lookup NegLookup, patcher podStatusPatcher, negCloud negtypes.NetworkEndpointGroupCloud) *poller {
 	return &poller{
-		pollMap:   make(map[negMeta]*pollTarget),
-		podLister: podLister,
-		lookup:    lookup,
-		patcher:   patcher,
-		negCloud:  negCloud,
+		pollMap:              make(map[negMeta]*pollTarget),
+		podLister:            podLister,
+		lookup:               lookup,
+		patcher:              patcher,
+		negCloud:             negCloud,
+		policy:               flags.F.NegReadinessPolicy,
+		namedBackendServices: sets.NewString(flags.F.NegReadinessBackendServices...),
 	}
 }
 
@@ -139,7 +162,7 @@ func (p *poller) Poll(key negMeta) (retry bool, err error) {
 
 	klog.V(2).Infof("polling NEG %q in zone %q", key.Name, key.Zone)
 	// TODO(freehan): filter the NEs that are in interest once the API supports it
-	res, err := p.negCloud.ListNetworkEndpoints(key.Name, key.Zone /*showHealthStatus*/, true, key.SyncerKey.GetAPIVersion())
+	res, err := p.negCloud.ListNetworkEndpoints(context.Background(), key.Name, key.Zone /*showHealthStatus*/, true, key.SyncerKey.GetAPIVersion())
 	if err != nil {
 		return true, err
 	}
@@ -150,7 +173,7 @@ func (p *poller) Poll(key negMeta) (retry bool, err error) {
 // processHealthStatus updates Pod readiness gates based on the input health status response.
 //
 // We update the pod (using the patcher) when:
-// 1. if the endpoint considered healthy with one of the backend service health check
+// 1. if the endpoint considered healthy in the backend services required by the policy
 // 2. if the NEG is not associated with any health checks
 // It returns true if retry is needed.
 func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.NetworkEndpointWithHealthStatus) (bool, error) {
@@ -196,13 +219,13 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 			continue
 		}
 
-		bsKey := getHealthyBackendService(healthStatus)
-		if bsKey == nil {
+		bsKeys := getHealthyBackendServices(healthStatus, p.policy, p.namedBackendServices)
+		if len(bsKeys) == 0 {
 			unhealthyPods = append(unhealthyPods, podName)
 			continue
 		}
 
-		err := p.patcher.syncPod(keyFunc(podName.Namespace, podName.Name), meta.ZonalKey(key.Name, key.Zone), bsKey)
+		err := p.patcher.syncPod(keyFunc(podName.Namespace, podName.Name), meta.ZonalKey(key.Name, key.Zone), bsKeys)
 		if err != nil {
 			errList = append(errList, err)
 			continue
@@ -228,8 +251,16 @@ func (p *poller) processHealthStatus(key negMeta, healthStatuses []*composite.Ne
 	return patchCount < len(p.pollMap[key].endpointMap), utilerrors.NewAggregate(errList)
 }
 
-// getHealthyBackendService returns one of the first backend service key where the endpoint is considered healthy.
-func getHealthyBackendService(healthStatus *composite.NetworkEndpointWithHealthStatus) *meta.Key {
+// getHealthyBackendServices returns the keys of the backend services where the
+// endpoint is considered healthy, if they are the ones required by the policy.
+// It returns nil otherwise.
+func getHealthyBackendServices(healthStatus *composite.NetworkEndpointWithHealthStatus, policy string, namedBackendServices sets.String) []*meta.Key {
+	var (
+		healthy []*meta.Key
+		// checked is the number of backend services considered by the
+		// policy which check the health of the endpoint.
+		checked int
+	)
 	for _, hs := range healthStatus.Healths {
 		if hs == nil {
 			klog.Errorf("Health status is nil in health status of network endpoint %v ", healthStatus)
@@ -239,19 +270,37 @@ func getHealthyBackendService(healthStatus *composite.NetworkEndpointWithHealthS
 			klog.Errorf("Backend service is nil in health status of network endpoint %v", healthStatus)
 			continue
 		}
-
+		id, err := cloud.ParseResourceURL(hs.BackendService.BackendService)
+		if err != nil {
+			klog.Errorf("Failed to parse backend service reference from a Network Endpoint health status %v: %v", healthStatus, err)
+			continue
+		}
+		if id == nil {
+			continue
+		}
+		if policy == NamedBackendServicesPolicy && !namedBackendServices.Has(id.Key.Name) {
+			continue
+		}
+		checked++
 		if hs.HealthState == healthyState {
-			id, err := cloud.ParseResourceURL(hs.BackendService.BackendService)
-			if err != nil {
-				klog.Errorf("Failed to parse backend service reference from a Network Endpoint health status %v: %v", healthStatus, err)
-				continue
-			}
-			if id != nil {
-				return id.Key
-			}
+			healthy = append(healthy, id.Key)
+		}
+	}
+
+	switch policy {
+	case AllBackendServicesPolicy:
+		if len(healthy) < checked {
+			return nil
+		}
+	case NamedBackendServicesPolicy:
+		if checked == 0 {
+			return getHealthyBackendServices(healthStatus, AnyBackendServicePolicy, nil)
+		}
+		if len(healthy) < checked {
+			return nil
 		}
 	}
-	return nil
+	return healthy
 }
 
 // hasHealthStatus returns true if there is at least 1 health status associated with the endpoint.
@@ -17,6 +17,7 @@ limitations under the License.
 package readiness
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/composite"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	namer_util "k8s.io/ingress-gce/pkg/utils/namer"
@@ -34,18 +36,18 @@ type testPatcher struct {
 	count      int
 	lastPod    string
 	lastNegKey *meta.Key
-	lastBsKey  *meta.Key
+	lastBsKeys []*meta.Key
 }
 
-func (p *testPatcher) syncPod(pod string, negKey, bsKey *meta.Key) error {
+func (p *testPatcher) syncPod(pod string, negKey *meta.Key, bsKeys []*meta.Key) error {
 	p.count++
 	p.lastPod = pod
 	p.lastNegKey = negKey
-	p.lastBsKey = bsKey
+	p.lastBsKeys = bsKeys
 	return nil
 }
 
-func (p *testPatcher) Eval(t *testing.T, pod string, negKey, bsKey *meta.Key) {
+func (p *testPatcher) Eval(t *testing.T, pod string, negKey *meta.Key, bsKeys []*meta.Key) {
 	if p.lastPod != pod {
 		t.Errorf("expect pod = %q, but got %q", pod, p.lastPod)
 	}
@@ -54,8 +56,8 @@ func (p *testPatcher) Eval(t *testing.T, pod string, negKey, bsKey *meta.Key) {
 		t.Errorf("expect neg key = %v, but got %v", negKey, p.lastNegKey)
 	}
 
-	if !reflect.DeepEqual(p.lastBsKey, bsKey) {
-		t.Errorf("expect backend service key = %v, but got %v", bsKey, p.lastBsKey)
+	if !reflect.DeepEqual(p.lastBsKeys, bsKeys) {
+		t.Errorf("expect backend service keys = %v, but got %v", bsKeys, p.lastBsKeys)
 	}
 }
 
@@ -422,7 +424,7 @@ func TestPoll(t *testing.T) {
 		Instance:  instance,
 	}
 
-	negCloud.AttachNetworkEndpoints(negName, zone, []*composite.NetworkEndpoint{ne}, meta.VersionGA)
+	negCloud.AttachNetworkEndpoints(context.Background(), negName, zone, []*composite.NetworkEndpoint{ne}, meta.VersionGA)
 	// add NE with empty healthy status
 	negtypes.GetNetworkEndpointStore(negCloud).AddNetworkEndpointHealthStatus(*meta.ZonalKey(negName, zone), []negtypes.NetworkEndpointEntry{
 		{
@@ -499,7 +501,79 @@ func TestPoll(t *testing.T) {
 		irrelevantEntry,
 	})
 	pollAndValidate(step, false, false, 3)
-	pacherTester.Eval(t, fmt.Sprintf("%v/%v", ns, podName), meta.ZonalKey(negName, zone), meta.GlobalKey(bsName))
+	pacherTester.Eval(t, fmt.Sprintf("%v/%v", ns, podName), meta.ZonalKey(negName, zone), []*meta.Key{meta.GlobalKey(bsName)})
 	pollAndValidate(step, false, false, 4)
-	pacherTester.Eval(t, fmt.Sprintf("%v/%v", ns, podName), meta.ZonalKey(negName, zone), meta.GlobalKey(bsName))
+	pacherTester.Eval(t, fmt.Sprintf("%v/%v", ns, podName), meta.ZonalKey(negName, zone), []*meta.Key{meta.GlobalKey(bsName)})
+}
+
+func TestGetHealthyBackendServices(t *testing.T) {
+	t.Parallel()
+
+	health := func(bsName, state string) *composite.HealthStatusForNetworkEndpoint {
+		return &composite.HealthStatusForNetworkEndpoint{
+			BackendService: &composite.BackendServiceReference{
+				BackendService: fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/foo/global/backendServices/%v", bsName),
+			},
+			HealthState: state,
+		}
+	}
+	bothHealthy := []*composite.HealthStatusForNetworkEndpoint{health("bs1", healthyState), health("bs2", healthyState)}
+	oneHealthy := []*composite.HealthStatusForNetworkEndpoint{health("bs1", healthyState), health("bs2", "UNHEALTHY"), nil}
+
+	for _, tc := range []struct {
+		desc    string
+		healths []*composite.HealthStatusForNetworkEndpoint
+		policy  string
+		named   []string
+		expect  []*meta.Key
+	}{
+		{
+			desc:    "any policy, one healthy",
+			healths: oneHealthy,
+			policy:  AnyBackendServicePolicy,
+			expect:  []*meta.Key{meta.GlobalKey("bs1")},
+		},
+		{
+			desc:    "any policy, none healthy",
+			healths: []*composite.HealthStatusForNetworkEndpoint{health("bs1", "UNKNOWN")},
+			policy:  AnyBackendServicePolicy,
+		},
+		{
+			desc:    "all policy, one healthy",
+			healths: oneHealthy,
+			policy:  AllBackendServicesPolicy,
+		},
+		{
+			desc:    "all policy, all healthy",
+			healths: bothHealthy,
+			policy:  AllBackendServicesPolicy,
+			expect:  []*meta.Key{meta.GlobalKey("bs1"), meta.GlobalKey("bs2")},
+		},
+		{
+			desc:    "named policy, named one healthy",
+			healths: oneHealthy,
+			policy:  NamedBackendServicesPolicy,
+			named:   []string{"bs1"},
+			expect:  []*meta.Key{meta.GlobalKey("bs1")},
+		},
+		{
+			desc:    "named policy, named one unhealthy",
+			healths: oneHealthy,
+			policy:  NamedBackendServicesPolicy,
+			named:   []string{"bs1", "bs2"},
+		},
+		{
+			desc:    "named policy, none named health checks the endpoint",
+			healths: oneHealthy,
+			policy:  NamedBackendServicesPolicy,
+			named:   []string{"bs3"},
+			expect:  []*meta.Key{meta.GlobalKey("bs1")},
+		},
+	} {
+		healthStatus := &composite.NetworkEndpointWithHealthStatus{Healths: tc.healths}
+		got := getHealthyBackendServices(healthStatus, tc.policy, sets.NewString(tc.named...))
+		if !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("For test case %q, expect backend services %v, but got %v", tc.desc, tc.expect, got)
+		}
+	}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/neg/types/shared"
 	"k8s.io/klog"
@@ -47,6 +49,10 @@ const (
 	negReadyUnhealthCheckedReason = "LoadBalancerNegWithoutHealthCheck"
 	// negNotReadyReason is the pod condition reason when pod is not healthy in NEG
 	negNotReadyReason = "LoadBalancerNegNotReady"
+	// negDrainingReason is the drained condition reason when a terminating pod is still in a NEG
+	negDrainingReason = "LoadBalancerNegDraining"
+	// negDrainedReason is the drained condition reason when a terminating pod is no longer in any NEG
+	negDrainedReason = "LoadBalancerNegDrained"
 	// unreadyTimeout is the timeout for health status feedback for pod readiness. If load balancer health
 	// check is still not showing as Healthy for long than the time out since the pod is created. Skip wating and mark
 	// the pod as load balancer ready.
@@ -68,6 +74,11 @@ type readinessReflector struct {
 	podLister cache.Indexer
 	lookup    NegLookup
 
+	// attachedLock protects attached
+	attachedLock sync.Mutex
+	// attached contains the IPs of the network endpoints in each NEG, including the ones being detached.
+	attached map[negMeta]sets.String
+
 	eventBroadcaster record.EventBroadcaster
 	eventRecorder    record.EventRecorder
 
@@ -86,6 +97,7 @@ func NewReadinessReflector(kubeClient kubernetes.Interface, podLister cache.Inde
 		podLister:        podLister,
 		clock:            clock.RealClock{},
 		lookup:           lookup,
+		attached:         make(map[negMeta]sets.String),
 		eventBroadcaster: broadcaster,
 		eventRecorder:    recorder,
 		queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
@@ -140,7 +152,7 @@ func (r *readinessReflector) handleErr(err error, key interface{}) {
 
 // syncPod process pod and patch the NEG readiness condition if needed
 // if neg and backendService is specified, it means pod is Healthy in the NEG attached to backendService.
-func (r *readinessReflector) syncPod(podKey string, neg, backendService *meta.Key) (err error) {
+func (r *readinessReflector) syncPod(podKey string, neg *meta.Key, backendServices []*meta.Key) (err error) {
 	// podUpdateLock to ensure there is no race in pod status update
 	r.podUpdateLock.Lock()
 	defer r.podUpdateLock.Unlock()
@@ -159,18 +171,23 @@ func (r *readinessReflector) syncPod(podKey string, neg, backendService *meta.Ke
 		return nil
 	}
 
+	// Terminating pods only need the drained condition
+	if needToDrain(pod) {
+		return r.ensurePodCondition(pod, r.getExpectedDrainedCondition(pod))
+	}
+
 	// This is to prevent if the pod got updated after being added to the queue
 	if !needToProcess(pod) {
 		return nil
 	}
 
-	klog.V(4).Infof("syncPod(%q, %v, %v)", podKey, neg, backendService)
-	expectedCondition := r.getExpectedNegCondition(pod, neg, backendService)
+	klog.V(4).Infof("syncPod(%q, %v, %v)", podKey, neg, backendServices)
+	expectedCondition := r.getExpectedNegCondition(pod, neg, backendServices)
 	return r.ensurePodNegCondition(pod, expectedCondition)
 }
 
 // getExpectedCondition returns the expected NEG readiness condition for the given pod
-func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg, backendService *meta.Key) v1.PodCondition {
+func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg *meta.Key, backendServices []*meta.Key) v1.PodCondition {
 	expectedCondition := v1.PodCondition{Type: shared.NegReadinessGate}
 	if pod == nil {
 		expectedCondition.Message = fmt.Sprintf("Unkown status for unkown pod.")
@@ -178,10 +195,18 @@ func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg, backendSe
 	}
 
 	if neg != nil {
-		if backendService != nil {
+		if len(backendServices) == 1 {
+			expectedCondition.Status = v1.ConditionTrue
+			expectedCondition.Reason = negReadyReason
+			expectedCondition.Message = fmt.Sprintf("Pod has become Healthy in NEG %q attached to BackendService %q. Marking condition %q to True.", neg.String(), backendServices[0].String(), shared.NegReadinessGate)
+		} else if len(backendServices) > 1 {
+			var names []string
+			for _, backendService := range backendServices {
+				names = append(names, backendService.String())
+			}
 			expectedCondition.Status = v1.ConditionTrue
 			expectedCondition.Reason = negReadyReason
-			expectedCondition.Message = fmt.Sprintf("Pod has become Healthy in NEG %q attached to BackendService %q. Marking condition %q to True.", neg.String(), backendService.String(), shared.NegReadinessGate)
+			expectedCondition.Message = fmt.Sprintf("Pod has become Healthy in NEG %q attached to BackendServices %q. Marking condition %q to True.", neg.String(), names, shared.NegReadinessGate)
 		} else {
 			expectedCondition.Status = v1.ConditionTrue
 			expectedCondition.Reason = negReadyUnhealthCheckedReason
@@ -216,6 +241,37 @@ func (r *readinessReflector) getExpectedNegCondition(pod *v1.Pod, neg, backendSe
 	return expectedCondition
 }
 
+// getExpectedDrainedCondition returns the expected NEG drained condition for the given terminating pod
+func (r *readinessReflector) getExpectedDrainedCondition(pod *v1.Pod) v1.PodCondition {
+	if negs := r.attachedNegs(pod); len(negs) > 0 {
+		return v1.PodCondition{
+			Type:    shared.NegDrainedCondition,
+			Status:  v1.ConditionFalse,
+			Reason:  negDrainingReason,
+			Message: fmt.Sprintf("Waiting for pod to be detached from NEG(s): %v", negs),
+		}
+	}
+	return v1.PodCondition{
+		Type:    shared.NegDrainedCondition,
+		Status:  v1.ConditionTrue,
+		Reason:  negDrainedReason,
+		Message: fmt.Sprintf("Pod has been detached from all NEGs. Marking condition %q to True.", shared.NegDrainedCondition),
+	}
+}
+
+// attachedNegs returns the names of the NEGs which still contain a network endpoint of the pod
+func (r *readinessReflector) attachedNegs(pod *v1.Pod) []string {
+	r.attachedLock.Lock()
+	defer r.attachedLock.Unlock()
+	negs := sets.NewString()
+	for key, ips := range r.attached {
+		if key.SyncerKey.Namespace == pod.Namespace && ips.Has(pod.Status.PodIP) {
+			negs.Insert(key.Name)
+		}
+	}
+	return negs.List()
+}
+
 // SyncPod filter the pods that needed to be processed and put it into queue
 func (r *readinessReflector) SyncPod(pod *v1.Pod) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(pod)
@@ -241,6 +297,45 @@ func (r *readinessReflector) CommitPods(syncerKey negtypes.NegSyncerKey, negName
 	r.poll()
 }
 
+// SyncAttachedEndpoints records the network endpoints in a NEG and enqueues terminating pods once endpoints are detached
+func (r *readinessReflector) SyncAttachedEndpoints(syncerKey negtypes.NegSyncerKey, negName string, zone string, endpoints negtypes.NetworkEndpointSet) {
+	if !flags.F.EnableNegGracefulRemoval {
+		return
+	}
+	key := negMeta{
+		SyncerKey: syncerKey,
+		Name:      negName,
+		Zone:      zone,
+	}
+	ips := sets.NewString()
+	for _, endpoint := range endpoints.List() {
+		ips.Insert(endpoint.IP)
+	}
+
+	r.attachedLock.Lock()
+	detached := r.attached[key].Difference(ips)
+	if len(ips) == 0 {
+		delete(r.attached, key)
+	} else {
+		r.attached[key] = ips
+	}
+	r.attachedLock.Unlock()
+
+	if len(detached) == 0 {
+		return
+	}
+	pods, err := r.podLister.ByIndex(cache.NamespaceIndex, syncerKey.Namespace)
+	if err != nil {
+		klog.Errorf("Failed to list pods in namespace %q: %v", syncerKey.Namespace, err)
+		return
+	}
+	for _, obj := range pods {
+		if pod, ok := obj.(*v1.Pod); ok && needToDrain(pod) && detached.Has(pod.Status.PodIP) {
+			r.SyncPod(pod)
+		}
+	}
+}
+
 // poll spins off go routines to poll NEGs
 func (r *readinessReflector) poll() {
 	r.pollerLock.Lock()
@@ -265,19 +360,24 @@ func (r *readinessReflector) pollNeg(key negMeta) {
 // ensurePodNegCondition ensures the pod neg condition is as expected
 // TODO(freehan): also populate lastTransitionTime in the condition
 func (r *readinessReflector) ensurePodNegCondition(pod *v1.Pod, expectedCondition v1.PodCondition) error {
+	return r.ensurePodCondition(pod, expectedCondition)
+}
+
+// ensurePodCondition ensures the pod condition of the expected condition type is as expected
+func (r *readinessReflector) ensurePodCondition(pod *v1.Pod, expectedCondition v1.PodCondition) error {
 	if pod == nil {
 		return nil
 	}
 	// check if it is necessary to patch
-	condition, ok := NegReadinessConditionStatus(pod)
+	condition, ok := GetPodCondition(pod, expectedCondition.Type)
 	if ok && reflect.DeepEqual(expectedCondition, condition) {
-		klog.V(4).Infof("NEG condition for pod %s/%s is expected, skip patching", pod.Namespace, pod.Name)
+		klog.V(4).Infof("Condition %q for pod %s/%s is expected, skip patching", expectedCondition.Type, pod.Namespace, pod.Name)
 		return nil
 	}
 
 	// calculate patch bytes, send patch and record event
 	oldStatus := pod.Status.DeepCopy()
-	SetNegReadinessConditionStatus(pod, expectedCondition)
+	SetPodCondition(pod, expectedCondition)
 	patchBytes, err := preparePatchBytesforPodStatus(*oldStatus, pod.Status)
 	if err != nil {
 		return fmt.Errorf("failed to prepare patch bytes for pod %v: %v", pod, err)
@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/neg/types/shared"
 )
@@ -64,13 +65,13 @@ func TestSyncPod(t *testing.T) {
 	now := metav1.NewTime(fakeClock.Now()).Rfc3339Copy()
 
 	for _, tc := range []struct {
-		desc                string
-		mutateState         func()
-		inputKey            string
-		inputNeg            *meta.Key
-		inputBackendService *meta.Key
-		expectExists        bool
-		expectPod           *v1.Pod
+		desc                 string
+		mutateState          func()
+		inputKey             string
+		inputNeg             *meta.Key
+		inputBackendServices []*meta.Key
+		expectExists         bool
+		expectPod            *v1.Pod
 	}{
 		{
 			desc:        "empty input",
@@ -174,10 +175,10 @@ func TestSyncPod(t *testing.T) {
 				client.CoreV1().Pods(testNamespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
 				testlookUp.readinessGateEnabledNegs = []string{"neg1", "neg2"}
 			},
-			inputKey:            keyFunc(testNamespace, podName),
-			inputNeg:            meta.ZonalKey("neg1", "zone1"),
-			inputBackendService: nil,
-			expectExists:        true,
+			inputKey:             keyFunc(testNamespace, podName),
+			inputNeg:             meta.ZonalKey("neg1", "zone1"),
+			inputBackendServices: nil,
+			expectExists:         true,
 			expectPod: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: testNamespace,
@@ -244,10 +245,10 @@ func TestSyncPod(t *testing.T) {
 				client.CoreV1().Pods(testNamespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
 				testlookUp.readinessGateEnabledNegs = []string{"neg1", "neg2"}
 			},
-			inputKey:            keyFunc(testNamespace, podName),
-			inputNeg:            meta.ZonalKey("neg1", "zone1"),
-			inputBackendService: meta.GlobalKey("k8s-backendservice"),
-			expectExists:        true,
+			inputKey:             keyFunc(testNamespace, podName),
+			inputNeg:             meta.ZonalKey("neg1", "zone1"),
+			inputBackendServices: []*meta.Key{meta.GlobalKey("k8s-backendservice")},
+			expectExists:         true,
 			expectPod: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: testNamespace,
@@ -270,9 +271,43 @@ func TestSyncPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "need to update pod: pod is healthy in NEG attached to several backend services",
+			mutateState: func() {
+				pod := generatePod(testNamespace, podName, true, false, false)
+				podLister.Update(pod)
+				client.CoreV1().Pods(testNamespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+				testlookUp.readinessGateEnabledNegs = []string{"neg1", "neg2"}
+			},
+			inputKey:             keyFunc(testNamespace, podName),
+			inputNeg:             meta.ZonalKey("neg1", "zone1"),
+			inputBackendServices: []*meta.Key{meta.GlobalKey("k8s-backendservice1"), meta.GlobalKey("k8s-backendservice2")},
+			expectExists:         true,
+			expectPod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      podName,
+				},
+				Spec: v1.PodSpec{
+					ReadinessGates: []v1.PodReadinessGate{
+						{ConditionType: shared.NegReadinessGate},
+					},
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:    shared.NegReadinessGate,
+							Reason:  negReadyReason,
+							Status:  v1.ConditionTrue,
+							Message: fmt.Sprintf("Pod has become Healthy in NEG %q attached to BackendServices %q. Marking condition %q to True.", meta.ZonalKey("neg1", "zone1").String(), []string{meta.GlobalKey("k8s-backendservice1").String(), meta.GlobalKey("k8s-backendservice2").String()}, shared.NegReadinessGate),
+						},
+					},
+				},
+			},
+		},
 	} {
 		tc.mutateState()
-		err := testReadinessReflector.syncPod(tc.inputKey, tc.inputNeg, tc.inputBackendService)
+		err := testReadinessReflector.syncPod(tc.inputKey, tc.inputNeg, tc.inputBackendServices)
 		if err != nil {
 			t.Errorf("For test case %q, expect err to be nil, but got %v", tc.desc, err)
 		}
@@ -291,3 +326,74 @@ func TestSyncPod(t *testing.T) {
 
 	}
 }
+
+func TestSyncPodDrained(t *testing.T) {
+	flags.F.EnableNegGracefulRemoval = true
+	defer func() { flags.F.EnableNegGracefulRemoval = false }()
+
+	fakeContext := negtypes.NewTestContext()
+	testReadinessReflector := newTestReadinessReflector(fakeContext)
+	client := fakeContext.KubeClient
+	podLister := testReadinessReflector.podLister
+	podName := "pod1"
+	podIP := "10.100.1.1"
+	syncerKey := negtypes.NegSyncerKey{Namespace: testNamespace, Name: "svc"}
+
+	now := metav1.Now()
+	pod := generatePod(testNamespace, podName, true, true, true)
+	pod.DeletionTimestamp = &now
+	pod.Status.PodIP = podIP
+	podLister.Add(pod)
+	client.CoreV1().Pods(testNamespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+
+	for _, tc := range []struct {
+		desc            string
+		endpoints       negtypes.NetworkEndpointSet
+		expectQueued    bool
+		expectCondition v1.PodCondition
+	}{
+		{
+			desc:         "pod is still in NEG",
+			endpoints:    negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: podIP, Port: "80", Node: "node1"}),
+			expectQueued: false,
+			expectCondition: v1.PodCondition{
+				Type:    shared.NegDrainedCondition,
+				Status:  v1.ConditionFalse,
+				Reason:  negDrainingReason,
+				Message: fmt.Sprintf("Waiting for pod to be detached from NEG(s): %v", []string{"neg1"}),
+			},
+		},
+		{
+			desc:         "pod is detached from NEG",
+			endpoints:    negtypes.NewNetworkEndpointSet(),
+			expectQueued: true,
+			expectCondition: v1.PodCondition{
+				Type:    shared.NegDrainedCondition,
+				Status:  v1.ConditionTrue,
+				Reason:  negDrainedReason,
+				Message: fmt.Sprintf("Pod has been detached from all NEGs. Marking condition %q to True.", shared.NegDrainedCondition),
+			},
+		},
+	} {
+		testReadinessReflector.SyncAttachedEndpoints(syncerKey, "neg1", "zone1", tc.endpoints)
+		if queued := testReadinessReflector.queue.Len() == 1; queued != tc.expectQueued {
+			t.Errorf("For test case %q, expect pod to be queued = %v, but got %v", tc.desc, tc.expectQueued, queued)
+		}
+		if err := testReadinessReflector.syncPod(keyFunc(testNamespace, podName), nil, nil); err != nil {
+			t.Errorf("For test case %q, expect err to be nil, but got %v", tc.desc, err)
+		}
+
+		updatedPod, err := client.CoreV1().Pods(testNamespace).Get(context.TODO(), podName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("For test case %q, expect err to be nil, but got %v", tc.desc, err)
+		}
+		condition, ok := GetPodCondition(updatedPod, shared.NegDrainedCondition)
+		if !ok || !reflect.DeepEqual(condition, tc.expectCondition) {
+			t.Errorf("For test case %q, expect drained condition to be %v, but got %v", tc.desc, tc.expectCondition, condition)
+		}
+		if readiness, _ := NegReadinessConditionStatus(updatedPod); readiness.Status != v1.ConditionTrue {
+			t.Errorf("For test case %q, expect NEG readiness condition to be left untouched, but got %v", tc.desc, readiness)
+		}
+		podLister.Update(updatedPod)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/neg/types/shared"
 	"k8s.io/ingress-gce/pkg/utils/patch"
@@ -33,11 +34,16 @@ import (
 
 // NegReadinessConditionStatus return (cond, true) if neg condition exists, otherwise (_, false)
 func NegReadinessConditionStatus(pod *v1.Pod) (negCondition v1.PodCondition, exists bool) {
+	return GetPodCondition(pod, shared.NegReadinessGate)
+}
+
+// GetPodCondition returns (cond, true) if the condition of the given type exists, otherwise (_, false)
+func GetPodCondition(pod *v1.Pod, conditionType v1.PodConditionType) (v1.PodCondition, bool) {
 	if pod == nil {
 		return v1.PodCondition{}, false
 	}
 	for _, condition := range pod.Status.Conditions {
-		if condition.Type == shared.NegReadinessGate {
+		if condition.Type == conditionType {
 			return condition, true
 		}
 	}
@@ -102,6 +108,20 @@ func SetNegReadinessConditionStatus(pod *v1.Pod, condition v1.PodCondition) {
 	pod.Status.Conditions = append(pod.Status.Conditions, condition)
 }
 
+// SetPodCondition sets the pod condition of the condition type
+func SetPodCondition(pod *v1.Pod, condition v1.PodCondition) {
+	if pod == nil {
+		return
+	}
+	for i, cond := range pod.Status.Conditions {
+		if cond.Type == condition.Type {
+			pod.Status.Conditions[i] = condition
+			return
+		}
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+}
+
 // patchPodStatus patches pod status with given patchBytes
 func patchPodStatus(c clientset.Interface, namespace, name string, patchBytes []byte) (*v1.Pod, []byte, error) {
 	updatedPod, err := c.CoreV1().Pods(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{}, "status")
@@ -144,6 +164,19 @@ func removeIrrelevantEndpoints(endpointMap negtypes.EndpointPodMap, podLister ca
 	}
 }
 
+// needToDrain checks if the pod needs the NEG drained condition
+// If graceful removal is enabled, the pod has neg readiness gate, is terminating and is not drained yet, then return true.
+func needToDrain(pod *v1.Pod) bool {
+	if pod == nil || !flags.F.EnableNegGracefulRemoval || pod.DeletionTimestamp == nil {
+		return false
+	}
+	if _, readinessGateExists := evalNegReadinessGate(pod); !readinessGateExists {
+		return false
+	}
+	condition, ok := GetPodCondition(pod, shared.NegDrainedCondition)
+	return !ok || condition.Status != v1.ConditionTrue
+}
+
 // needToProcess check if the pod needs to be processed by readiness reflector
 // If pod has neg readiness gate and its condition is False, then return true.
 func needToProcess(pod *v1.Pod) bool {
@@ -153,3 +186,18 @@ func needToProcess(pod *v1.Pod) bool {
 	negConditionReady, readinessGateExists := evalNegReadinessGate(pod)
 	return readinessGateExists && !negConditionReady
 }
+
+// ValidateBackendServicePolicy validates the backend service policy configured
+// with the --neg-readiness-policy and --neg-readiness-backend-services flags.
+func ValidateBackendServicePolicy() error {
+	switch flags.F.NegReadinessPolicy {
+	case AnyBackendServicePolicy, AllBackendServicesPolicy:
+		return nil
+	case NamedBackendServicesPolicy:
+		if len(flags.F.NegReadinessBackendServices) == 0 {
+			return fmt.Errorf("policy %q requires --neg-readiness-backend-services", NamedBackendServicesPolicy)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown policy %q, must be one of %q, %q or %q", flags.F.NegReadinessPolicy, AnyBackendServicePolicy, AllBackendServicesPolicy, NamedBackendServicesPolicy)
+}
@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/neg/types/shared"
 	"net"
@@ -647,3 +648,28 @@ func unionEndpointMap(m1, m2 negtypes.EndpointPodMap) negtypes.EndpointPodMap {
 	}
 	return m1
 }
+
+func TestValidateBackendServicePolicy(t *testing.T) {
+	defer func() {
+		flags.F.NegReadinessPolicy = AnyBackendServicePolicy
+		flags.F.NegReadinessBackendServices = nil
+	}()
+
+	for _, tc := range []struct {
+		policy      string
+		named       []string
+		expectError bool
+	}{
+		{policy: AnyBackendServicePolicy},
+		{policy: AllBackendServicesPolicy},
+		{policy: NamedBackendServicesPolicy, named: []string{"bs1"}},
+		{policy: NamedBackendServicesPolicy, expectError: true},
+		{policy: "some", expectError: true},
+	} {
+		flags.F.NegReadinessPolicy = tc.policy
+		flags.F.NegReadinessBackendServices = tc.named
+		if err := ValidateBackendServicePolicy(); (err != nil) != tc.expectError {
+			t.Errorf("For policy %q with backend services %v, expect error = %v, but got %v", tc.policy, tc.named, tc.expectError, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+const (
+	// maxCheckpointEndpoints is the maximum number of endpoints in a
+	// checkpoint. It keeps the ServiceNetworkEndpointGroup objects well under
+	// the size limit of objects.
+	maxCheckpointEndpoints = 10000
+	// checkpointValidationDelay is the average delay after which a syncer
+	// started from a checkpoint lists the endpoints of its NEGs, to validate
+	// the checkpoint. The delay is jittered so that the syncers started by a
+	// new leader do not list their NEGs at the same time.
+	checkpointValidationDelay = 5 * time.Minute
+)
+
+// newEndpointCheckpoint returns the checkpoint of the endpoints of each zone,
+// or nil if there are too many endpoints to checkpoint.
+func newEndpointCheckpoint(endpointMap map[string]negtypes.NetworkEndpointSet) *negv1beta1.EndpointCheckpoint {
+	var count int
+	for _, endpointSet := range endpointMap {
+		count += endpointSet.Len()
+	}
+	if count > maxCheckpointEndpoints {
+		return nil
+	}
+
+	zones := sortedZoneEndpoints(endpointMap)
+	return &negv1beta1.EndpointCheckpoint{
+		Fingerprint: checkpointFingerprint(zones),
+		Zones:       zones,
+	}
+}
+
+// endpointMapFromCheckpoint returns the endpoints of each zone of the
+// checkpoint. It returns false if the checkpoint is malformed, or if the
+// endpoints do not match its fingerprint.
+func endpointMapFromCheckpoint(checkpoint *negv1beta1.EndpointCheckpoint) (map[string]negtypes.NetworkEndpointSet, bool) {
+	if checkpoint.Fingerprint != checkpointFingerprint(checkpoint.Zones) {
+		return nil, false
+	}
+	endpointMap := map[string]negtypes.NetworkEndpointSet{}
+	for _, zoneEndpoints := range checkpoint.Zones {
+		endpointSet := negtypes.NewNetworkEndpointSet()
+		for _, encoded := range zoneEndpoints.Endpoints {
+			if strings.Count(encoded, separator) != 2 {
+				return nil, false
+			}
+			ip, node, port := decodeEndpoint(encoded)
+			endpointSet.Insert(negtypes.NetworkEndpoint{IP: ip, Node: node, Port: port})
+		}
+		endpointMap[zoneEndpoints.Zone] = endpointSet
+	}
+	return endpointMap, true
+}
+
+// endpointMapFingerprint returns the fingerprint of the checkpoint of the
+// endpoints of each zone, regardless of their number.
+func endpointMapFingerprint(endpointMap map[string]negtypes.NetworkEndpointSet) string {
+	return checkpointFingerprint(sortedZoneEndpoints(endpointMap))
+}
+
+// sortedZoneEndpoints returns the encoded endpoints of each zone, sorted by
+// zone and endpoint.
+func sortedZoneEndpoints(endpointMap map[string]negtypes.NetworkEndpointSet) []negv1beta1.ZoneEndpoints {
+	var zones []negv1beta1.ZoneEndpoints
+	for zone, endpointSet := range endpointMap {
+		zoneEndpoints := negv1beta1.ZoneEndpoints{Zone: zone}
+		for _, endpoint := range endpointSet.List() {
+			zoneEndpoints.Endpoints = append(zoneEndpoints.Endpoints, encodeEndpoint(endpoint.IP, endpoint.Node, endpoint.Port))
+		}
+		sort.Strings(zoneEndpoints.Endpoints)
+		zones = append(zones, zoneEndpoints)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Zone < zones[j].Zone })
+	return zones
+}
+
+// checkpointFingerprint returns the hash of the sorted endpoints of the zones.
+func checkpointFingerprint(zones []negv1beta1.ZoneEndpoints) string {
+	hash := sha256.New()
+	for _, zoneEndpoints := range zones {
+		hash.Write([]byte(zoneEndpoints.Zone))
+		hash.Write([]byte{0})
+		for _, endpoint := range zoneEndpoints.Endpoints {
+			hash.Write([]byte(endpoint))
+			hash.Write([]byte{0})
+		}
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
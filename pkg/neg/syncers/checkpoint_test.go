@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+func TestEndpointCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	endpointMap := map[string]negtypes.NetworkEndpointSet{
+		testZone1: generateEndpointSet(net.ParseIP("1.1.1.1"), 10, testInstance1, "8080"),
+		testZone2: negtypes.NewNetworkEndpointSet(),
+	}
+	checkpoint := newEndpointCheckpoint(endpointMap)
+	if checkpoint == nil {
+		t.Fatalf("newEndpointCheckpoint() = nil, want a checkpoint")
+	}
+	if checkpoint.Fingerprint != endpointMapFingerprint(endpointMap) {
+		t.Errorf("Got fingerprint %q, want %q", checkpoint.Fingerprint, endpointMapFingerprint(endpointMap))
+	}
+	restored, ok := endpointMapFromCheckpoint(checkpoint)
+	if !ok {
+		t.Fatalf("endpointMapFromCheckpoint() returned false for a valid checkpoint")
+	}
+	if !reflect.DeepEqual(restored, endpointMap) {
+		t.Errorf("Got restored endpoints %v, want %v", restored, endpointMap)
+	}
+
+	// The endpoints of a checkpoint are sorted when it is written.
+	reversed := newEndpointCheckpoint(endpointMap)
+	for i, j := 0, len(reversed.Zones[0].Endpoints)-1; i < j; i, j = i+1, j-1 {
+		reversed.Zones[0].Endpoints[i], reversed.Zones[0].Endpoints[j] = reversed.Zones[0].Endpoints[j], reversed.Zones[0].Endpoints[i]
+	}
+	if _, ok := endpointMapFromCheckpoint(reversed); ok {
+		t.Errorf("endpointMapFromCheckpoint() returned true for a checkpoint with unsorted endpoints")
+	}
+
+	tampered := checkpoint.DeepCopy()
+	tampered.Zones[0].Endpoints = tampered.Zones[0].Endpoints[1:]
+	if _, ok := endpointMapFromCheckpoint(tampered); ok {
+		t.Errorf("endpointMapFromCheckpoint() returned true for a checkpoint not matching its fingerprint")
+	}
+
+	malformed := newEndpointCheckpoint(endpointMap)
+	malformed.Zones[0].Endpoints[0] = "1.1.1.1"
+	malformed.Fingerprint = checkpointFingerprint(malformed.Zones)
+	if _, ok := endpointMapFromCheckpoint(malformed); ok {
+		t.Errorf("endpointMapFromCheckpoint() returned true for a malformed checkpoint")
+	}
+
+	large := map[string]negtypes.NetworkEndpointSet{
+		testZone1: generateEndpointSet(net.ParseIP("1.1.1.1"), maxCheckpointEndpoints+1, testInstance1, "8080"),
+	}
+	if checkpoint := newEndpointCheckpoint(large); checkpoint != nil {
+		t.Errorf("newEndpointCheckpoint() returned a checkpoint of %d endpoints, want nil", maxCheckpointEndpoints+1)
+	}
+}
+
+func TestRetrieveCurrentEndpointsFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	testNetwork := cloud.ResourcePath("network", &meta.Key{Name: "test-network"})
+	testSubnetwork := cloud.ResourcePath("subnetwork", &meta.Key{Name: "test-subnetwork"})
+	fakeCloud := negtypes.NewFakeNetworkEndpointGroupCloud(testSubnetwork, testNetwork)
+	_, syncer := newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+	syncer.enableCheckpoint = true
+
+	cloudMap := map[string]negtypes.NetworkEndpointSet{
+		testZone1: generateEndpointSet(net.ParseIP("1.1.1.1"), 5, testInstance1, "8080"),
+		testZone2: negtypes.NewNetworkEndpointSet(),
+	}
+	for zone, endpointSet := range cloudMap {
+		if err := fakeCloud.CreateNetworkEndpointGroup(&composite.NetworkEndpointGroup{Name: testNegName, Version: syncer.NegSyncerKey.GetAPIVersion()}, zone); err != nil {
+			t.Fatalf("Failed to create NEG in zone %s: %v", zone, err)
+		}
+		batch, err := makeEndpointBatch(negtypes.NewNetworkEndpointSet(endpointSet.List()...), syncer.NegType, MAX_NETWORK_ENDPOINTS_PER_BATCH)
+		if err != nil {
+			t.Fatalf("Failed to make endpoint batch: %v", err)
+		}
+		var endpoints []*composite.NetworkEndpoint
+		for _, ne := range batch {
+			endpoints = append(endpoints, ne)
+		}
+		if err := fakeCloud.AttachNetworkEndpoints(context.Background(), testNegName, zone, endpoints, syncer.NegSyncerKey.GetAPIVersion()); err != nil {
+			t.Fatalf("Failed to attach endpoints in zone %s: %v", zone, err)
+		}
+	}
+
+	// The checkpoint misses an endpoint of the NEG.
+	checkpointMap := map[string]negtypes.NetworkEndpointSet{
+		testZone1: generateEndpointSet(net.ParseIP("1.1.1.1"), 4, testInstance1, "8080"),
+		testZone2: negtypes.NewNetworkEndpointSet(),
+	}
+	neg := createNegCR(testNegName, metav1.Now(), true, true, nil)
+	neg.Namespace = testNamespace
+	neg.Status.EndpointCheckpoint = newEndpointCheckpoint(checkpointMap)
+	syncer.svcNegLister.Add(neg)
+
+	currentMap, restored, err := syncer.retrieveCurrentEndpoints(context.Background())
+	if err != nil {
+		t.Fatalf("retrieveCurrentEndpoints() = %v, want nil", err)
+	}
+	if !restored || !reflect.DeepEqual(currentMap, checkpointMap) {
+		t.Errorf("Got endpoints %v (restored: %t), want the endpoints of the checkpoint %v", currentMap, restored, checkpointMap)
+	}
+
+	// Only the first sync starts from the checkpoint.
+	syncer.restoredFingerprint = endpointMapFingerprint(currentMap)
+	currentMap, restored, err = syncer.retrieveCurrentEndpoints(context.Background())
+	if err != nil {
+		t.Fatalf("retrieveCurrentEndpoints() = %v, want nil", err)
+	}
+	if restored || !reflect.DeepEqual(currentMap, cloudMap) {
+		t.Errorf("Got endpoints %v (restored: %t), want the endpoints of the NEG %v", currentMap, restored, cloudMap)
+	}
+	if syncer.restoredFingerprint != "" {
+		t.Errorf("Got restored fingerprint %q after listing the endpoints, want none", syncer.restoredFingerprint)
+	}
+
+	// A checkpoint of other zones is ignored.
+	_, syncer = newTestTransactionSyncer(fakeCloud, negtypes.VmIpPortEndpointType, false)
+	syncer.enableCheckpoint = true
+	neg = neg.DeepCopy()
+	neg.Status.EndpointCheckpoint = newEndpointCheckpoint(map[string]negtypes.NetworkEndpointSet{testZone1: checkpointMap[testZone1]})
+	syncer.svcNegLister.Add(neg)
+	if _, restored, err = syncer.retrieveCurrentEndpoints(context.Background()); err != nil || restored {
+		t.Errorf("retrieveCurrentEndpoints() = (%t, %v), want (false, nil) for a checkpoint of other zones", restored, err)
+	}
+}
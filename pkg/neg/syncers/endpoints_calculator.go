@@ -21,6 +21,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
@@ -37,12 +40,13 @@ import (
 type LocalL4ILBEndpointsCalculator struct {
 	nodeLister      listers.NodeLister
 	zoneGetter      types.ZoneGetter
+	serviceLister   cache.Indexer
 	subsetSizeLimit int
 	svcId           string
 }
 
-func NewLocalL4ILBEndpointsCalculator(nodeLister listers.NodeLister, zoneGetter types.ZoneGetter, svcId string) *LocalL4ILBEndpointsCalculator {
-	return &LocalL4ILBEndpointsCalculator{nodeLister: nodeLister, zoneGetter: zoneGetter, subsetSizeLimit: maxSubsetSizeLocal, svcId: svcId}
+func NewLocalL4ILBEndpointsCalculator(nodeLister listers.NodeLister, zoneGetter types.ZoneGetter, serviceLister cache.Indexer, svcId string) *LocalL4ILBEndpointsCalculator {
+	return &LocalL4ILBEndpointsCalculator{nodeLister: nodeLister, zoneGetter: zoneGetter, serviceLister: serviceLister, subsetSizeLimit: maxSubsetSizeLocal, svcId: svcId}
 }
 
 // Mode indicates the mode that the EndpointsCalculator is operating in.
@@ -88,8 +92,13 @@ func (l *LocalL4ILBEndpointsCalculator) CalculateEndpoints(ep *v1.Endpoints, cur
 		// Not having backends will cause clients to see connection timeout instead of an "ICMP ConnectionRefused".
 		return nil, nil, nil
 	}
-	// Compute the networkEndpoints, with total endpoints count <= l.subsetSizeLimit
-	subsetMap, err := getSubsetPerZone(zoneNodeMap, l.subsetSizeLimit, l.svcId, currentMap)
+	// Compute the networkEndpoints, with total endpoints count <= the subset size limit of the service.
+	subsetSizeLimit, _ := l4SubsetOptions(getService(l.serviceLister, ep.Namespace, ep.Name), l.subsetSizeLimit)
+	subsetMap, err := getSubsetPerZone(zoneNodeMap, subsetSizeLimit, l.svcId, currentMap, nil)
+	if err == nil {
+		added, removed := subsetChurn(currentMap, subsetMap)
+		metrics.PublishSubsetChurn(string(l.Mode()), added, removed)
+	}
 	return subsetMap, nil, err
 }
 
@@ -97,20 +106,23 @@ func (l *LocalL4ILBEndpointsCalculator) CalculateEndpoints(ep *v1.Endpoints, cur
 // It exposes methods to calculate Network endpoints for GCE_VM_IP NEGs when the service
 // uses "ExternalTrafficPolicy: Cluster" mode This is the default mode.
 // In this mode, the endpoints of the NEG are calculated by selecting nodes at random. Upto 25(subset size limit in this
-// mode) are selected.
+// mode) are selected. If the service has the endpoint aware subset mode annotation, the nodes hosting ready endpoints
+// of the service are selected first.
 type ClusterL4ILBEndpointsCalculator struct {
 	// nodeLister is used for listing all the nodes in the cluster when calculating the subset.
 	nodeLister listers.NodeLister
 	// zoneGetter looks up the zone for a given node when calculating subsets.
 	zoneGetter types.ZoneGetter
+	// serviceLister is used to look up the subset annotations of the service.
+	serviceLister cache.Indexer
 	// subsetSizeLimit is the max value of the subset size in this mode.
 	subsetSizeLimit int
 	// svcId is the unique identifier for the service, that is used as a salt when hashing nodenames.
 	svcId string
 }
 
-func NewClusterL4ILBEndpointsCalculator(nodeLister listers.NodeLister, zoneGetter types.ZoneGetter, svcId string) *ClusterL4ILBEndpointsCalculator {
-	return &ClusterL4ILBEndpointsCalculator{nodeLister: nodeLister, zoneGetter: zoneGetter,
+func NewClusterL4ILBEndpointsCalculator(nodeLister listers.NodeLister, zoneGetter types.ZoneGetter, serviceLister cache.Indexer, svcId string) *ClusterL4ILBEndpointsCalculator {
+	return &ClusterL4ILBEndpointsCalculator{nodeLister: nodeLister, zoneGetter: zoneGetter, serviceLister: serviceLister,
 		subsetSizeLimit: maxSubsetSizeDefault, svcId: svcId}
 }
 
@@ -133,11 +145,58 @@ func (l *ClusterL4ILBEndpointsCalculator) CalculateEndpoints(ep *v1.Endpoints, c
 		}
 		nodeZoneMap[zone] = append(nodeZoneMap[zone], node)
 	}
-	// Compute the networkEndpoints, with total endpoints <= l.subsetSizeLimit.
-	subsetMap, err := getSubsetPerZone(nodeZoneMap, l.subsetSizeLimit, l.svcId, currentMap)
+	// Compute the networkEndpoints, with total endpoints <= the subset size limit of the service.
+	subsetSizeLimit, endpointAware := l4SubsetOptions(getService(l.serviceLister, ep.Namespace, ep.Name), l.subsetSizeLimit)
+	var preferred sets.String
+	if endpointAware {
+		preferred = endpointNodes(ep)
+	}
+	subsetMap, err := getSubsetPerZone(nodeZoneMap, subsetSizeLimit, l.svcId, currentMap, preferred)
+	if err == nil {
+		added, removed := subsetChurn(currentMap, subsetMap)
+		metrics.PublishSubsetChurn(string(l.Mode()), added, removed)
+	}
 	return subsetMap, nil, err
 }
 
+// l4SubsetOptions returns the subset size limit of the L4 ILB service and
+// whether its subset is endpoint aware, from the annotations of the service.
+// The subset size limit defaults to defaultLimit, and is capped by the
+// controller-wide maximum subset size.
+func l4SubsetOptions(service *v1.Service, defaultLimit int) (int, bool) {
+	limit := defaultLimit
+	var endpointAware bool
+	if service != nil {
+		svcAnnotations := annotations.FromService(service)
+		if size, ok, err := svcAnnotations.L4ILBSubsetSize(); err != nil {
+			klog.Warningf("Ignoring subset size of service %s/%s: %v", service.Namespace, service.Name, err)
+		} else if ok {
+			limit = size
+		}
+		var err error
+		if endpointAware, err = svcAnnotations.L4ILBEndpointAwareSubset(); err != nil {
+			klog.Warningf("Ignoring subset mode of service %s/%s: %v", service.Namespace, service.Name, err)
+		}
+	}
+	if flags.F.L4ILBMaxSubsetSize > 0 && limit > flags.F.L4ILBMaxSubsetSize {
+		limit = flags.F.L4ILBMaxSubsetSize
+	}
+	return limit, endpointAware
+}
+
+// endpointNodes returns the names of the nodes hosting ready endpoints.
+func endpointNodes(ep *v1.Endpoints) sets.String {
+	nodeNames := sets.NewString()
+	for _, subset := range ep.Subsets {
+		for _, addr := range subset.Addresses {
+			if addr.NodeName != nil {
+				nodeNames.Insert(*addr.NodeName)
+			}
+		}
+	}
+	return nodeNames
+}
+
 // L7EndpointsCalculator implements methods to calculate Network endpoints for VM_IP_PORT NEGs
 type L7EndpointsCalculator struct {
 	zoneGetter          types.ZoneGetter
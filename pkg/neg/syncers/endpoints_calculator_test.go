@@ -24,6 +24,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/legacy-cloud-providers/gce"
 )
@@ -88,7 +90,7 @@ func TestLocalGetEndpointSet(t *testing.T) {
 		},
 	}
 	svcKey := fmt.Sprintf("%s/%s", testServiceName, testServiceNamespace)
-	ec := NewLocalL4ILBEndpointsCalculator(nodeLister, zoneGetter, svcKey)
+	ec := NewLocalL4ILBEndpointsCalculator(nodeLister, zoneGetter, nil, svcKey)
 	for _, tc := range testCases {
 		retSet, _, err := ec.CalculateEndpoints(tc.endpoints, nil)
 		if err != nil {
@@ -163,7 +165,7 @@ func TestClusterGetEndpointSet(t *testing.T) {
 		},
 	}
 	svcKey := fmt.Sprintf("%s/%s", testServiceName, testServiceNamespace)
-	ec := NewClusterL4ILBEndpointsCalculator(nodeLister, zoneGetter, svcKey)
+	ec := NewClusterL4ILBEndpointsCalculator(nodeLister, zoneGetter, nil, svcKey)
 	for _, tc := range testCases {
 		retSet, _, err := ec.CalculateEndpoints(tc.endpoints, nil)
 		if err != nil {
@@ -174,3 +176,70 @@ func TestClusterGetEndpointSet(t *testing.T) {
 		}
 	}
 }
+
+// TestClusterGetEndpointSetWithSubsetAnnotations verifies that the ClusterL4ILBEndpointsCalculator applies the subset
+// annotations of the service, capped by the controller-wide maximum subset size.
+func TestClusterGetEndpointSetWithSubsetAnnotations(t *testing.T) {
+	defer func() { flags.F.L4ILBMaxSubsetSize = 250 }()
+	_, transactionSyncer := newL4ILBTestTransactionSyncer(negtypes.NewAdapter(gce.NewFakeGCECloud(gce.DefaultTestClusterValues())), negtypes.L4ClusterMode)
+	nodeNames := []string{testInstance1, testInstance2, testInstance3, testInstance4, testInstance5, testInstance6}
+	for i := 0; i < len(nodeNames); i++ {
+		transactionSyncer.nodeLister.Add(&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeNames[i],
+			},
+			Status: v1.NodeStatus{
+				Addresses: []v1.NodeAddress{
+					{
+						Type:    v1.NodeInternalIP,
+						Address: fmt.Sprintf("1.2.3.%d", i+1),
+					},
+				},
+				Conditions: []v1.NodeCondition{
+					{
+						Type:   v1.NodeReady,
+						Status: v1.ConditionTrue,
+					},
+				},
+			},
+		})
+	}
+	transactionSyncer.serviceLister.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testServiceName,
+			Namespace: testServiceNamespace,
+			Annotations: map[string]string{
+				annotations.L4ILBSubsetSizeKey: "4",
+				annotations.L4ILBSubsetModeKey: annotations.L4ILBSubsetModeEndpointAware,
+			},
+		},
+	})
+	svcKey := fmt.Sprintf("%s/%s", testServiceName, testServiceNamespace)
+	ec := NewClusterL4ILBEndpointsCalculator(listers.NewNodeLister(transactionSyncer.nodeLister), negtypes.NewFakeZoneGetter(), transactionSyncer.serviceLister, svcKey)
+
+	// The endpoints run on instance1 to instance4, which are picked over
+	// instance5 and instance6.
+	retSet, _, err := ec.CalculateEndpoints(getDefaultEndpoint(), nil)
+	if err != nil {
+		t.Fatalf("Expect nil error, but got %v.", err)
+	}
+	expectSets := map[string]negtypes.NetworkEndpointSet{
+		negtypes.TestZone1: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "1.2.3.1", Node: testInstance1}, negtypes.NetworkEndpoint{IP: "1.2.3.2", Node: testInstance2}),
+		negtypes.TestZone2: negtypes.NewNetworkEndpointSet(negtypes.NetworkEndpoint{IP: "1.2.3.3", Node: testInstance3}, negtypes.NetworkEndpoint{IP: "1.2.3.4", Node: testInstance4}),
+	}
+	if !reflect.DeepEqual(retSet, expectSets) {
+		t.Errorf("Expecting endpoint set %v, but got %v.", expectSets, retSet)
+	}
+
+	// The subset size of the annotation is capped.
+	flags.F.L4ILBMaxSubsetSize = 2
+	retSet, _, err = ec.CalculateEndpoints(getDefaultEndpoint(), nil)
+	if err != nil {
+		t.Fatalf("Expect nil error, but got %v.", err)
+	}
+	for zone, endpointSet := range retSet {
+		if endpointSet.Len() != 1 || !expectSets[zone].HasAll(endpointSet.List()...) {
+			t.Errorf("Expecting one of the endpoints %v in zone %s, but got %v.", expectSets[zone], zone, endpointSet)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/neg/metrics"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+	"k8s.io/klog"
+)
+
+var errSchedulerStopped = errors.New("NEG operation scheduler is stopped")
+
+// endpointOperation is an attach or detach operation of a batch of endpoints,
+// waiting in the queue of an OperationScheduler.
+type endpointOperation struct {
+	ctx       context.Context
+	syncerKey negtypes.NegSyncerKey
+	operation transactionOp
+	zone      string
+	endpoints map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint
+	// done is called with the result of the operation, and the time the
+	// operation started to run.
+	done     func(err error, start time.Time)
+	queuedAt time.Time
+}
+
+// canMerge returns true if both operations can run as a single API call.
+func (op *endpointOperation) canMerge(other *endpointOperation) bool {
+	return op.syncerKey.NegName == other.syncerKey.NegName &&
+		op.syncerKey.GetAPIVersion() == other.syncerKey.GetAPIVersion() &&
+		op.operation == other.operation &&
+		op.zone == other.zone
+}
+
+// OperationScheduler runs the attach and detach operations of all NEG syncers
+// with a bounded number of concurrent API calls. Services are served in turns,
+// so that a large service does not starve the others, and operations of the
+// same NEG that wait in the queue together are merged in a single API call.
+type OperationScheduler struct {
+	cloud        negtypes.NetworkEndpointGroupCloud
+	concurrency  int
+	maxBatchSize int
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// queues holds the operations waiting to run, keyed by service.
+	queues map[string][]*endpointOperation
+	// services holds the keys of the services with queued operations, in
+	// the order they are served.
+	services []string
+	// depth counts the queued operations by operation type.
+	depth   map[transactionOp]int
+	stopped bool
+}
+
+// NewOperationScheduler returns an OperationScheduler running at most
+// concurrency operations at a time, of at most maxBatchSize endpoints each.
+func NewOperationScheduler(cloud negtypes.NetworkEndpointGroupCloud, concurrency, maxBatchSize int) *OperationScheduler {
+	if maxBatchSize <= 0 || maxBatchSize > MAX_NETWORK_ENDPOINTS_PER_BATCH {
+		maxBatchSize = MAX_NETWORK_ENDPOINTS_PER_BATCH
+	}
+	s := &OperationScheduler{
+		cloud:        cloud,
+		concurrency:  concurrency,
+		maxBatchSize: maxBatchSize,
+		queues:       map[string][]*endpointOperation{},
+		depth:        map[transactionOp]int{},
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// MaxBatchSize returns the maximum number of endpoints of an operation.
+func (s *OperationScheduler) MaxBatchSize() int {
+	return s.maxBatchSize
+}
+
+// Run starts the workers of the scheduler and blocks until stopCh is closed.
+// Operations still queued at that point fail.
+func (s *OperationScheduler) Run(stopCh <-chan struct{}) {
+	klog.V(2).Infof("Starting NEG operation scheduler with %d workers", s.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < s.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.worker()
+		}()
+	}
+	<-stopCh
+
+	klog.V(2).Infof("Shutting down NEG operation scheduler")
+	s.mu.Lock()
+	s.stopped = true
+	var pending []*endpointOperation
+	for _, key := range s.services {
+		pending = append(pending, s.queues[key]...)
+	}
+	s.queues = map[string][]*endpointOperation{}
+	s.services = nil
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	for _, op := range pending {
+		op.done(errSchedulerStopped, time.Now())
+	}
+	wg.Wait()
+}
+
+// schedule queues the operation of the endpoints. done is called once the
+// operation ran, from another goroutine.
+func (s *OperationScheduler) schedule(ctx context.Context, syncerKey negtypes.NegSyncerKey, operation transactionOp, zone string, endpoints map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint, done func(err error, start time.Time)) {
+	op := &endpointOperation{
+		ctx:       ctx,
+		syncerKey: syncerKey,
+		operation: operation,
+		zone:      zone,
+		endpoints: endpoints,
+		done:      done,
+		queuedAt:  time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		// done must not run in the caller's goroutine, which may hold the
+		// lock of the syncer.
+		go op.done(errSchedulerStopped, time.Now())
+		return
+	}
+	key := keyFunc(syncerKey.Namespace, syncerKey.Name)
+	if len(s.queues[key]) == 0 {
+		s.services = append(s.services, key)
+	}
+	s.queues[key] = append(s.queues[key], op)
+	s.depth[operation]++
+	metrics.PublishNegOperationQueueDepth(operation.String(), s.depth[operation])
+	s.cond.Signal()
+}
+
+func (s *OperationScheduler) worker() {
+	for {
+		ops := s.next()
+		if ops == nil {
+			return
+		}
+		s.run(ops)
+	}
+}
+
+// next blocks until operations are queued, and returns the operations to run
+// in a single API call. It returns nil once the scheduler is stopped.
+func (s *OperationScheduler) next() []*endpointOperation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.services) == 0 && !s.stopped {
+		s.cond.Wait()
+	}
+	if s.stopped {
+		return nil
+	}
+
+	// Serve the service at the head of the line, then send it to the back
+	// of the line if it has more operations queued.
+	key := s.services[0]
+	s.services = s.services[1:]
+	queue := s.queues[key]
+	head := queue[0]
+	ops := []*endpointOperation{head}
+	size := len(head.endpoints)
+	var rest []*endpointOperation
+	for _, op := range queue[1:] {
+		if head.canMerge(op) && size+len(op.endpoints) <= s.maxBatchSize {
+			ops = append(ops, op)
+			size += len(op.endpoints)
+			continue
+		}
+		rest = append(rest, op)
+	}
+	if len(rest) == 0 {
+		delete(s.queues, key)
+	} else {
+		s.queues[key] = rest
+		s.services = append(s.services, key)
+	}
+
+	for _, op := range ops {
+		metrics.PublishNegOperationQueueLatency(op.operation.String(), op.queuedAt)
+	}
+	s.depth[head.operation] -= len(ops)
+	metrics.PublishNegOperationQueueDepth(head.operation.String(), s.depth[head.operation])
+	return ops
+}
+
+// run runs the operations as a single API call.
+func (s *OperationScheduler) run(ops []*endpointOperation) {
+	head := ops[0]
+	var networkEndpoints []*composite.NetworkEndpoint
+	for _, op := range ops {
+		for _, ne := range op.endpoints {
+			networkEndpoints = append(networkEndpoints, ne)
+		}
+	}
+	if len(ops) > 1 {
+		klog.V(4).Infof("Merged %d %s operations of %d endpoint(s) for NEG %s at %s", len(ops), head.operation.String(), len(networkEndpoints), head.syncerKey.NegName, head.zone)
+	}
+
+	start := time.Now()
+	err := runEndpointOperation(head.ctx, s.cloud, head.syncerKey, head.operation, head.zone, networkEndpoints)
+	for _, op := range ops {
+		op.done(err, start)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
+)
+
+func newTestEndpointBatch(start, count int) map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint {
+	batch := map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint{}
+	for i := start; i < start+count; i++ {
+		ip := fmt.Sprintf("10.100.1.%d", i)
+		batch[negtypes.NetworkEndpoint{IP: ip, Node: "instance1", Port: "80"}] = &composite.NetworkEndpoint{IpAddress: ip, Instance: "instance1", Port: 80}
+	}
+	return batch
+}
+
+func TestOperationSchedulerNext(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewOperationScheduler(negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network"), 1, 10)
+	svc1NEG1 := negtypes.NegSyncerKey{Namespace: testNamespace, Name: "svc1", NegName: "neg1"}
+	svc1NEG2 := negtypes.NegSyncerKey{Namespace: testNamespace, Name: "svc1", NegName: "neg2"}
+	svc2NEG3 := negtypes.NegSyncerKey{Namespace: testNamespace, Name: "svc2", NegName: "neg3"}
+	noop := func(error, time.Time) {}
+
+	for _, op := range []struct {
+		key       negtypes.NegSyncerKey
+		operation transactionOp
+		zone      string
+		size      int
+	}{
+		{svc1NEG1, attachOp, testZone1, 4},
+		{svc1NEG2, attachOp, testZone1, 4},
+		{svc1NEG1, attachOp, testZone1, 4},
+		{svc1NEG1, attachOp, testZone2, 4},
+		{svc1NEG1, detachOp, testZone1, 4},
+		// Would exceed the maximum batch size with the first two
+		// operations of neg1.
+		{svc1NEG1, attachOp, testZone1, 4},
+		{svc2NEG3, attachOp, testZone1, 4},
+	} {
+		scheduler.schedule(context.Background(), op.key, op.operation, op.zone, newTestEndpointBatch(0, op.size), noop)
+	}
+
+	for i, expected := range []struct {
+		negName   string
+		operation transactionOp
+		zone      string
+		ops       int
+	}{
+		{"neg1", attachOp, testZone1, 2},
+		// svc2 is served before the rest of svc1.
+		{"neg3", attachOp, testZone1, 1},
+		{"neg2", attachOp, testZone1, 1},
+		{"neg1", attachOp, testZone2, 1},
+		{"neg1", detachOp, testZone1, 1},
+		{"neg1", attachOp, testZone1, 1},
+	} {
+		ops := scheduler.next()
+		if len(ops) != expected.ops {
+			t.Fatalf("next() #%d returned %d operations, want %d", i, len(ops), expected.ops)
+		}
+		for _, op := range ops {
+			if op.syncerKey.NegName != expected.negName || op.operation != expected.operation || op.zone != expected.zone {
+				t.Errorf("next() #%d returned %s operation of NEG %s at %s, want %s operation of NEG %s at %s", i, op.operation, op.syncerKey.NegName, op.zone, expected.operation, expected.negName, expected.zone)
+			}
+		}
+	}
+	if len(scheduler.services) != 0 || len(scheduler.queues) != 0 {
+		t.Errorf("Got services %v with queued operations, want none", scheduler.services)
+	}
+}
+
+func TestOperationSchedulerRun(t *testing.T) {
+	t.Parallel()
+
+	fakeCloud := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+	scheduler := NewOperationScheduler(fakeCloud, 2, MAX_NETWORK_ENDPOINTS_PER_BATCH)
+	stopCh := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Run(stopCh)
+		close(stopped)
+	}()
+
+	key := negtypes.NegSyncerKey{Namespace: testNamespace, Name: testService, NegName: testNegName}
+	results := make(chan error, 3)
+	done := func(err error, _ time.Time) { results <- err }
+	scheduler.schedule(context.Background(), key, attachOp, testZone1, newTestEndpointBatch(0, 5), done)
+	scheduler.schedule(context.Background(), key, attachOp, testZone1, newTestEndpointBatch(5, 5), done)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Got error %v for attach operation, want nil", err)
+		}
+	}
+	endpoints, err := fakeCloud.ListNetworkEndpoints(context.Background(), testNegName, testZone1, false, key.GetAPIVersion())
+	if err != nil {
+		t.Fatalf("Failed to list network endpoints: %v", err)
+	}
+	if len(endpoints) != 10 {
+		t.Errorf("Got %d network endpoints, want 10", len(endpoints))
+	}
+
+	close(stopCh)
+	<-stopped
+	scheduler.schedule(context.Background(), key, detachOp, testZone1, newTestEndpointBatch(0, 5), done)
+	if err := <-results; err != errSchedulerStopped {
+		t.Errorf("Got error %v for operation scheduled after stop, want %v", err, errSchedulerStopped)
+	}
+}
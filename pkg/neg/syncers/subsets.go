@@ -23,6 +23,7 @@ import (
 	"sort"
 
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -58,6 +59,8 @@ func getHashedName(nodeName, salt string) string {
 // If the input list is smaller than the desired subset count, the entire list is returned. The hash salt
 // is used so that a different subset is returned even when the same node list is passed in, for a different salt value.
 // It also keeps the subset relatively stable for the same service.
+// If preferred nodes are given, they are picked before the other nodes, including the ones of the current subset, so
+// that the subset follows the preferred nodes while keeping the removals to a minimum otherwise.
 // Example 1 - Recalculate subset, subset size increase.
 // nodes = [node1 node2 node3 node4 node5], Current subset - [node3, node2, node5], count 4
 // sorted list is [node3 node2 node5 node4 node1]
@@ -73,46 +76,64 @@ func getHashedName(nodeName, salt string) string {
 // nodes = [node1 node2 node4 node5, node6], Current subset - [node3, node2, node5, node4], count 4
 // sorted list is [node6 node2 node5 node4 node1]
 // Output [node2, node5, node4 node6]
-func pickSubsetsMinRemovals(nodes []*v1.Node, salt string, count int, current []negtypes.NetworkEndpoint) []*v1.Node {
+// ---------------------------------------------------------------------------------------------------------
+// Example 3 - Recalculate subset, preferred node1.
+// nodes = [node1 node2 node3 node4 node5], Current subset - [node3, node2, node5], count 3
+// sorted list is [node3 node2 node5 node4 node1]
+// Output [node1, node3, node2] - node1 replaces the last node of the existing subset.
+func pickSubsetsMinRemovals(nodes []*v1.Node, salt string, count int, current []negtypes.NetworkEndpoint, preferred sets.String) []*v1.Node {
 	if len(nodes) < count {
 		return nodes
 	}
 	subset := make([]*v1.Node, 0, count)
 	info := make([]*NodeInfo, len(nodes))
+	infoByHashedName := make(map[string]*NodeInfo, len(nodes))
 	// Generate hashed names for all cluster nodes and sort them alphabetically, based on the hashed string.
 	for i, node := range nodes {
 		info[i] = &NodeInfo{i, getHashedName(node.Name, salt), false}
+		infoByHashedName[info[i].hashedName] = info[i]
 	}
 	sort.Slice(info, func(i, j int) bool {
 		return info[i].hashedName < info[j].hashedName
 	})
-	// Pick all nodes from existing subset if still available.
-	for _, ep := range current {
-		curHashName := getHashedName(ep.Node, salt)
+	// pick adds the node to the subset unless it was picked already, and
+	// returns true once the subset is complete.
+	pick := func(nodeInfo *NodeInfo) bool {
+		if !nodeInfo.skip {
+			subset = append(subset, nodes[nodeInfo.index])
+			nodeInfo.skip = true
+		}
+		return len(subset) == count
+	}
+	// pickCurrent picks the nodes of the current subset which are still available.
+	pickCurrent := func(wantPreferred bool) bool {
+		for _, ep := range current {
+			if nodeInfo, ok := infoByHashedName[getHashedName(ep.Node, salt)]; ok && preferred.Has(ep.Node) == wantPreferred && pick(nodeInfo) {
+				return true
+			}
+		}
+		return false
+	}
+	// pickSorted picks the nodes in the order of their hashed names.
+	pickSorted := func(wantPreferred bool) bool {
 		for _, nodeInfo := range info {
-			if nodeInfo.hashedName == curHashName {
-				subset = append(subset, nodes[nodeInfo.index])
-				nodeInfo.skip = true
-			} else if nodeInfo.hashedName > curHashName {
-				break
+			if (!wantPreferred || preferred.Has(nodes[nodeInfo.index].Name)) && pick(nodeInfo) {
+				return true
 			}
 		}
+		return false
 	}
-	if len(subset) >= count {
-		// trim the subset to the given subset size, remove extra nodes.
-		subset = subset[:count]
+
+	if count == 0 {
 		return subset
 	}
-	for _, val := range info {
-		if val.skip {
-			// This node was already picked as it is part of the current subset.
-			continue
-		}
-		subset = append(subset, nodes[val.index])
-		if len(subset) == count {
-			break
-		}
+	if len(preferred) > 0 && (pickCurrent(true) || pickSorted(true)) {
+		return subset
+	}
+	if pickCurrent(false) {
+		return subset
 	}
+	pickSorted(false)
 	return subset
 }
 
@@ -156,7 +177,7 @@ func sortZones(nodesPerZone map[string][]*v1.Node) []ZoneInfo {
 //    Since the number of nodes will keep increasing in successive zones due to the sorting, even if fewer nodes were
 //    present in some zones, more nodes will be picked from other nodes, taking the total subset size to the given limit
 //    whenever possible.
-func getSubsetPerZone(nodesPerZone map[string][]*v1.Node, totalLimit int, svcID string, currentMap map[string]negtypes.NetworkEndpointSet) (map[string]negtypes.NetworkEndpointSet, error) {
+func getSubsetPerZone(nodesPerZone map[string][]*v1.Node, totalLimit int, svcID string, currentMap map[string]negtypes.NetworkEndpointSet, preferred sets.String) (map[string]negtypes.NetworkEndpointSet, error) {
 	result := make(map[string]negtypes.NetworkEndpointSet)
 	var currentList []negtypes.NetworkEndpoint
 
@@ -177,7 +198,7 @@ func getSubsetPerZone(nodesPerZone map[string][]*v1.Node, totalLimit int, svcID
 				currentList = nil
 			}
 		}
-		subset := pickSubsetsMinRemovals(nodesPerZone[zone.Name], svcID, subsetSize, currentList)
+		subset := pickSubsetsMinRemovals(nodesPerZone[zone.Name], svcID, subsetSize, currentList, preferred)
 		for _, node := range subset {
 			result[zone.Name].Insert(negtypes.NetworkEndpoint{Node: node.Name, IP: utils.GetNodePrimaryIP(node)})
 		}
@@ -186,3 +207,20 @@ func getSubsetPerZone(nodesPerZone map[string][]*v1.Node, totalLimit int, svcID
 	}
 	return result, nil
 }
+
+// subsetChurn returns the number of nodes added to and removed from the
+// current subsets of each zone.
+func subsetChurn(currentMap, subsetMap map[string]negtypes.NetworkEndpointSet) (added, removed int) {
+	currentNodes, subsetNodes := sets.NewString(), sets.NewString()
+	for _, endpointSet := range currentMap {
+		for _, endpoint := range endpointSet.List() {
+			currentNodes.Insert(endpoint.Node)
+		}
+	}
+	for _, endpointSet := range subsetMap {
+		for _, endpoint := range endpointSet.List() {
+			subsetNodes.Insert(endpoint.Node)
+		}
+	}
+	return subsetNodes.Difference(currentNodes).Len(), currentNodes.Difference(subsetNodes).Len()
+}
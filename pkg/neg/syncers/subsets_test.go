@@ -25,6 +25,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestBasicSubset(t *testing.T) {
@@ -37,15 +38,15 @@ func TestBasicSubset(t *testing.T) {
 		{ObjectMeta: metav1.ObjectMeta{Name: "node25"}},
 	}
 	count := 3
-	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil)
+	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil, nil)
 	if len(subset1) < 3 {
 		t.Errorf("Expected %d subsets, got only %d - %v", count, len(subset1), subset1)
 	}
 	if !validateSubset(subset1, nodes) {
 		t.Errorf("Invalid subset list %v from %v", subset1, nodes)
 	}
-	subset2 := pickSubsetsMinRemovals(nodes, "svc345", count, nil)
-	subset3 := pickSubsetsMinRemovals(nodes, "svc56", count, nil)
+	subset2 := pickSubsetsMinRemovals(nodes, "svc345", count, nil, nil)
+	subset3 := pickSubsetsMinRemovals(nodes, "svc56", count, nil, nil)
 	t.Logf("Subset2 is %s", nodeNames(subset2))
 	t.Logf("Subset3 is %s", nodeNames(subset3))
 	if isIdentical(subset1, subset2) || isIdentical(subset3, subset2) || isIdentical(subset1, subset3) {
@@ -56,7 +57,7 @@ func TestBasicSubset(t *testing.T) {
 func TestEmptyNodes(t *testing.T) {
 	t.Parallel()
 	count := 3
-	subset1 := pickSubsetsMinRemovals(nil, "svc123", count, nil)
+	subset1 := pickSubsetsMinRemovals(nil, "svc123", count, nil, nil)
 	if len(subset1) != 0 {
 		t.Errorf("Expected empty subset, got - %s", nodeNames(subset1))
 	}
@@ -67,7 +68,7 @@ func TestFewerNodes(t *testing.T) {
 	t.Parallel()
 	nodes := makeNodes(0, 5)
 	count := 10
-	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil)
+	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil, nil)
 	if len(subset1) != len(nodes) {
 		t.Errorf("Expected subset of length %d, got %d, subsets - %s", len(nodes), len(subset1), nodeNames(subset1))
 	}
@@ -188,7 +189,7 @@ func TestUnevenNodesInZones(t *testing.T) {
 		},
 	}
 	for _, tc := range testCases {
-		subsetMap, err := getSubsetPerZone(tc.nodesMap, tc.subsetLimit, tc.svcKey, nil)
+		subsetMap, err := getSubsetPerZone(tc.nodesMap, tc.subsetLimit, tc.svcKey, nil, nil)
 		if err != nil {
 			t.Errorf("Failed to get subset for test '%s', err %v", tc.description, err)
 		}
@@ -223,14 +224,14 @@ func TestNoRemovals(t *testing.T) {
 	// pick a random startIndex which is used to construct nodeName.
 	nodes := makeNodes(78, 5)
 	count := 5
-	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil)
+	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil, nil)
 	if len(subset1) < 5 {
 		t.Errorf("Expected %d subsets, got only %d - %v", count, len(subset1), subset1)
 	}
 	// nodeName abcd shows up 2nd in the sorted list for the given salt. So picking a subset of 5 will remove one of the
 	// existing nodes.
 	nodes = append(nodes, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node:abcd"}})
-	subset2 := pickSubsetsMinRemovals(nodes, "svc123", count, nil)
+	subset2 := pickSubsetsMinRemovals(nodes, "svc123", count, nil, nil)
 	if len(subset2) < 5 {
 		t.Errorf("Expected %d subsets, got only %d - %v", count, len(subset2), subset2)
 	}
@@ -241,7 +242,7 @@ func TestNoRemovals(t *testing.T) {
 	for _, node := range subset1 {
 		existingEp = append(existingEp, types.NetworkEndpoint{Node: node.Name})
 	}
-	subset3 := pickSubsetsMinRemovals(nodes, "svc123", count, existingEp)
+	subset3 := pickSubsetsMinRemovals(nodes, "svc123", count, existingEp, nil)
 	if len(subset3) < 5 {
 		t.Errorf("Expected %d subsets, got only %d - %v", count, len(subset3), subset3)
 	}
@@ -250,6 +251,65 @@ func TestNoRemovals(t *testing.T) {
 	}
 }
 
+func TestPreferredNodes(t *testing.T) {
+	t.Parallel()
+	nodes := makeNodes(1, 10)
+	count := 3
+	subset1 := pickSubsetsMinRemovals(nodes, "svc123", count, nil, nil)
+	existingEp := []types.NetworkEndpoint{}
+	existingNodes := sets.NewString()
+	for _, node := range subset1 {
+		existingEp = append(existingEp, types.NetworkEndpoint{Node: node.Name})
+		existingNodes.Insert(node.Name)
+	}
+
+	// A preferred node replaces one of the existing nodes.
+	var preferredNode string
+	for _, node := range nodes {
+		if !existingNodes.Has(node.Name) {
+			preferredNode = node.Name
+			break
+		}
+	}
+	subset2 := pickSubsetsMinRemovals(nodes, "svc123", count, existingEp, sets.NewString(preferredNode))
+	if len(subset2) != count || subset2[0].Name != preferredNode {
+		t.Errorf("Got subset %v, expected %d nodes starting with preferred node %s", nodeNames(subset2), count, preferredNode)
+	}
+	if !isIdentical(subset2[1:], subset1[:count-1]) {
+		t.Errorf("Got subset %v, expected the preferred node and the first nodes of %v", nodeNames(subset2), nodeNames(subset1))
+	}
+
+	// More preferred nodes than the subset size.
+	preferred := sets.NewString()
+	for _, node := range nodes[:count+1] {
+		preferred.Insert(node.Name)
+	}
+	subset3 := pickSubsetsMinRemovals(nodes, "svc123", count, existingEp, preferred)
+	for _, node := range subset3 {
+		if !preferred.Has(node.Name) {
+			t.Errorf("Got subset %v with node %s, expected only preferred nodes %v", nodeNames(subset3), node.Name, preferred.List())
+		}
+	}
+}
+
+func TestSubsetChurn(t *testing.T) {
+	t.Parallel()
+	currentMap := map[string]types.NetworkEndpointSet{
+		"zone1": types.NewNetworkEndpointSet(types.NetworkEndpoint{Node: "node1"}, types.NetworkEndpoint{Node: "node2"}),
+		"zone2": types.NewNetworkEndpointSet(types.NetworkEndpoint{Node: "node3"}),
+	}
+	subsetMap := map[string]types.NetworkEndpointSet{
+		"zone1": types.NewNetworkEndpointSet(types.NetworkEndpoint{Node: "node1"}, types.NetworkEndpoint{Node: "node4"}, types.NetworkEndpoint{Node: "node5"}),
+		"zone2": types.NewNetworkEndpointSet(),
+	}
+	if added, removed := subsetChurn(currentMap, subsetMap); added != 2 || removed != 2 {
+		t.Errorf("subsetChurn() = (%d, %d), want (2, 2)", added, removed)
+	}
+	if added, removed := subsetChurn(nil, subsetMap); added != 3 || removed != 0 {
+		t.Errorf("subsetChurn() = (%d, %d), want (3, 0)", added, removed)
+	}
+}
+
 func validateSubset(subset []*v1.Node, nodes []*v1.Node) bool {
 	for _, val := range subset {
 		found := false
@@ -25,20 +25,27 @@ import (
 
 	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
 	apiv1 "k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
+	"k8s.io/ingress-gce/pkg/audit"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/neg/metrics"
 	"k8s.io/ingress-gce/pkg/neg/readiness"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	svcnegclient "k8s.io/ingress-gce/pkg/svcneg/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/ingress-gce/pkg/utils/patch"
 	"k8s.io/klog"
 )
@@ -61,15 +68,18 @@ type transactionSyncer struct {
 	// transactions stores each transaction
 	transactions networkEndpointTransactionTable
 
-	podLister           cache.Indexer
-	serviceLister       cache.Indexer
-	endpointLister      cache.Indexer
-	nodeLister          cache.Indexer
-	svcNegLister        cache.Indexer
-	recorder            record.EventRecorder
-	cloud               negtypes.NetworkEndpointGroupCloud
-	zoneGetter          negtypes.ZoneGetter
-	endpointsCalculator negtypes.NetworkEndpointsCalculator
+	podLister      cache.Indexer
+	serviceLister  cache.Indexer
+	endpointLister cache.Indexer
+	nodeLister     cache.Indexer
+	svcNegLister   cache.Indexer
+	// workloadEndpointSliceLister lists the EndpointSlices of the Workloads.
+	// It is nil unless NEGs include Workloads.
+	workloadEndpointSliceLister cache.Indexer
+	recorder                    record.EventRecorder
+	cloud                       negtypes.NetworkEndpointGroupCloud
+	zoneGetter                  negtypes.ZoneGetter
+	endpointsCalculator         negtypes.NetworkEndpointsCalculator
 
 	// retry handles back off retry for NEG API operations
 	retry retryHandler
@@ -85,27 +95,56 @@ type transactionSyncer struct {
 
 	// customName indicates whether the NEG name is a generated one or custom one
 	customName bool
+
+	// scheduler runs the NEG operations of all syncers. If nil, each
+	// operation runs right away in its own go routine.
+	scheduler *OperationScheduler
+
+	// enableCheckpoint indicates whether the endpoints of the NEGs are
+	// checkpointed in the status of the NEG CR.
+	enableCheckpoint bool
+	// checkpointChecked indicates whether the checkpoint of the NEG CR was
+	// considered. Only the first sync starts from the checkpoint.
+	checkpointChecked bool
+	// restoredFingerprint is the fingerprint of the checkpoint the syncer
+	// started from, until the endpoints of the NEGs are listed to validate it.
+	restoredFingerprint string
+	// checkpoint is the checkpoint to write in the status of the NEG CR, if
+	// checkpointChanged is set.
+	checkpoint        *negv1beta1.EndpointCheckpoint
+	checkpointChanged bool
+
+	// endpointsChangedAt is the time of the change of the Endpoints being
+	// synced, or zero if unknown.
+	endpointsChangedAt time.Time
+	// attachLatencyObservedAt is the time of the last change of the
+	// Endpoints whose attach latency was observed. The latency of each
+	// change is only observed once.
+	attachLatencyObservedAt time.Time
 }
 
-func NewTransactionSyncer(negSyncerKey negtypes.NegSyncerKey, recorder record.EventRecorder, cloud negtypes.NetworkEndpointGroupCloud, zoneGetter negtypes.ZoneGetter, podLister cache.Indexer, serviceLister cache.Indexer, endpointLister cache.Indexer, nodeLister cache.Indexer, svcNegLister cache.Indexer, reflector readiness.Reflector, epc negtypes.NetworkEndpointsCalculator, kubeSystemUID string, svcNegClient svcnegclient.Interface, customName bool) negtypes.NegSyncer {
+func NewTransactionSyncer(negSyncerKey negtypes.NegSyncerKey, recorder record.EventRecorder, cloud negtypes.NetworkEndpointGroupCloud, zoneGetter negtypes.ZoneGetter, podLister cache.Indexer, serviceLister cache.Indexer, endpointLister cache.Indexer, nodeLister cache.Indexer, svcNegLister cache.Indexer, workloadEndpointSliceLister cache.Indexer, reflector readiness.Reflector, epc negtypes.NetworkEndpointsCalculator, kubeSystemUID string, svcNegClient svcnegclient.Interface, customName bool, scheduler *OperationScheduler) negtypes.NegSyncer {
 	// TransactionSyncer implements the syncer core
 	ts := &transactionSyncer{
-		NegSyncerKey:        negSyncerKey,
-		needInit:            true,
-		transactions:        NewTransactionTable(),
-		nodeLister:          nodeLister,
-		podLister:           podLister,
-		serviceLister:       serviceLister,
-		endpointLister:      endpointLister,
-		svcNegLister:        svcNegLister,
-		recorder:            recorder,
-		cloud:               cloud,
-		zoneGetter:          zoneGetter,
-		endpointsCalculator: epc,
-		reflector:           reflector,
-		kubeSystemUID:       kubeSystemUID,
-		svcNegClient:        svcNegClient,
-		customName:          customName,
+		NegSyncerKey:                negSyncerKey,
+		needInit:                    true,
+		transactions:                NewTransactionTable(),
+		nodeLister:                  nodeLister,
+		podLister:                   podLister,
+		serviceLister:               serviceLister,
+		endpointLister:              endpointLister,
+		svcNegLister:                svcNegLister,
+		workloadEndpointSliceLister: workloadEndpointSliceLister,
+		recorder:                    recorder,
+		cloud:                       cloud,
+		zoneGetter:                  zoneGetter,
+		endpointsCalculator:         epc,
+		reflector:                   reflector,
+		kubeSystemUID:               kubeSystemUID,
+		svcNegClient:                svcNegClient,
+		customName:                  customName,
+		scheduler:                   scheduler,
+		enableCheckpoint:            flags.F.EnableNegCheckpoint && svcNegClient != nil,
 	}
 	// Syncer implements life cycle logic
 	syncer := newSyncer(negSyncerKey, serviceLister, recorder, ts)
@@ -115,15 +154,15 @@ func NewTransactionSyncer(negSyncerKey negtypes.NegSyncerKey, recorder record.Ev
 	return syncer
 }
 
-func GetEndpointsCalculator(nodeLister, podLister cache.Indexer, zoneGetter negtypes.ZoneGetter, syncerKey negtypes.NegSyncerKey, mode negtypes.EndpointsCalculatorMode) negtypes.NetworkEndpointsCalculator {
+func GetEndpointsCalculator(nodeLister, podLister, serviceLister cache.Indexer, zoneGetter negtypes.ZoneGetter, syncerKey negtypes.NegSyncerKey, mode negtypes.EndpointsCalculatorMode) negtypes.NetworkEndpointsCalculator {
 	serviceKey := strings.Join([]string{syncerKey.Name, syncerKey.Namespace}, "/")
 	if syncerKey.NegType == negtypes.VmIpEndpointType {
 		nodeLister := listers.NewNodeLister(nodeLister)
 		switch mode {
 		case negtypes.L4LocalMode:
-			return NewLocalL4ILBEndpointsCalculator(nodeLister, zoneGetter, serviceKey)
+			return NewLocalL4ILBEndpointsCalculator(nodeLister, zoneGetter, serviceLister, serviceKey)
 		default:
-			return NewClusterL4ILBEndpointsCalculator(nodeLister, zoneGetter, serviceKey)
+			return NewClusterL4ILBEndpointsCalculator(nodeLister, zoneGetter, serviceLister, serviceKey)
 		}
 	}
 	return NewL7EndpointsCalculator(zoneGetter, podLister, syncerKey.PortTuple.Name,
@@ -136,6 +175,8 @@ func (s *transactionSyncer) sync() error {
 		s.syncLock.Lock()
 		s.needInit = true
 		s.syncLock.Unlock()
+	} else {
+		metrics.PublishSyncerSuccess(s.NegSyncerKey.NegName)
 	}
 	return err
 }
@@ -144,12 +185,22 @@ func (s *transactionSyncer) syncInternal() error {
 	s.syncLock.Lock()
 	defer s.syncLock.Unlock()
 
+	if svc := getService(s.serviceLister, s.Namespace, s.Name); svc != nil && annotations.FromService(svc).PauseReconciliation() {
+		klog.V(2).Infof("Skip syncing NEG %q for %s, reconciliation of the service is paused.", s.NegSyncerKey.NegName, s.NegSyncerKey.String())
+		return nil
+	}
+
 	// NOTE: Error will be used to update the status on corresponding Neg CR if Neg CRD is enabled
 	// Please reuse and set err before returning
 	var err error
 	defer s.updateStatus(err)
 	start := time.Now()
 	defer metrics.PublishNegSyncMetrics(string(s.NegSyncerKey.NegType), string(s.endpointsCalculator.Mode()), err, start)
+	s.setAuditOwner()
+	ctx, span := tracing.StartSpan(context.Background(), "transactionSyncer.syncInternal",
+		trace.StringAttribute(tracing.KeyAttribute, s.NegSyncerKey.String()),
+		trace.StringAttribute(tracing.ResourceAttribute, s.NegSyncerKey.NegName))
+	defer func() { tracing.EndSpan(span, err) }()
 
 	if s.needInit {
 		if err := s.ensureNetworkEndpointGroups(); err != nil {
@@ -181,12 +232,20 @@ func (s *transactionSyncer) syncInternal() error {
 		klog.Warningf("Endpoint %s/%s does not exist. Skipping NEG sync", s.Namespace, s.Name)
 		return nil
 	}
+	s.endpointsChangedAt = endpointsChangeTime(ep.(*apiv1.Endpoints))
+	defer s.publishTransactionMetrics()
 
-	currentMap, err := retrieveExistingZoneNetworkEndpointMap(s.NegSyncerKey.NegName, s.zoneGetter, s.cloud, s.NegSyncerKey.GetAPIVersion())
+	currentMap, restored, err := s.retrieveCurrentEndpoints(ctx)
 	if err != nil {
 		return err
 	}
 	s.logStats(currentMap, "current NEG endpoints")
+	if s.needCommit() {
+		// The endpoints in the NEG include the ones being detached.
+		for zone, endpointSet := range currentMap {
+			s.reflector.SyncAttachedEndpoints(s.NegSyncerKey, s.NegSyncerKey.NegName, zone, negtypes.NewNetworkEndpointSet(endpointSet.List()...))
+		}
+	}
 
 	// Merge the current state from cloud with the transaction table together
 	// The combined state represents the eventual result when all transactions completed
@@ -198,7 +257,14 @@ func (s *transactionSyncer) syncInternal() error {
 		err = fmt.Errorf("endpoints calculation error in mode %q, err: %v", s.endpointsCalculator.Mode(), err)
 		return err
 	}
+	// Workloads are only added to the NEGs of L7 services without subsets.
+	if s.workloadEndpointSliceLister != nil && s.NegType != negtypes.VmIpEndpointType && s.SubsetLabels == "" {
+		if err := mergeWorkloadEndpoints(targetMap, endpointPodMap, s.workloadEndpointSliceLister, s.Namespace, s.Name, s.PortTuple.Name, s.zoneGetter, s.NegType); err != nil {
+			return fmt.Errorf("failed to merge workload endpoints: %v", err)
+		}
+	}
 	s.logStats(targetMap, "desired NEG endpoints")
+	s.publishEndpointMetrics(targetMap)
 
 	// Calculate the endpoints to add and delete to transform the current state to desire state
 	addEndpoints, removeEndpoints := calculateNetworkEndpointDifference(targetMap, currentMap)
@@ -219,16 +285,43 @@ func (s *transactionSyncer) syncInternal() error {
 
 	if len(addEndpoints) == 0 && len(removeEndpoints) == 0 {
 		klog.V(4).Infof("No endpoint change for %s/%s, skip syncing NEG. ", s.Namespace, s.Name)
+		if s.enableCheckpoint && len(s.transactions.Keys()) == 0 {
+			// The NEGs are in sync, and no operation is in progress.
+			if restored {
+				s.validateCheckpointLater(endpointMapFingerprint(currentMap))
+			}
+			s.setCheckpoint(newEndpointCheckpoint(currentMap))
+		}
 		return nil
 	}
+	if s.enableCheckpoint {
+		// The endpoints of the NEGs change until the operations complete.
+		s.setCheckpoint(nil)
+	}
 	s.logEndpoints(addEndpoints, "adding endpoint")
 	s.logEndpoints(removeEndpoints, "removing endpoint")
 
 	// set err instead of returning directly so that synced condition on neg crd is properly updated in defer
-	err = s.syncNetworkEndpoints(addEndpoints, removeEndpoints)
+	err = s.syncNetworkEndpoints(ctx, addEndpoints, removeEndpoints)
 	return err
 }
 
+// setAuditOwner attributes the NEGs of the syncer in all zones to the service.
+func (s *transactionSyncer) setAuditOwner() {
+	if !audit.Enabled() {
+		return
+	}
+	zones, err := s.zoneGetter.ListZones()
+	if err != nil {
+		return
+	}
+	var keys []*meta.Key
+	for _, zone := range zones {
+		keys = append(keys, meta.ZonalKey(s.NegSyncerKey.NegName, zone))
+	}
+	audit.SetOwner(audit.ControllerNEG, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}.String(), keys...)
+}
+
 // ensureNetworkEndpointGroups ensures NEGs are created and configured correctly in the corresponding zones.
 func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
 	var err error
@@ -269,8 +362,76 @@ func (s *transactionSyncer) ensureNetworkEndpointGroups() error {
 	return utilerrors.NewAggregate(errList)
 }
 
+// retrieveCurrentEndpoints returns the endpoints in the NEGs, and whether they
+// were restored from the checkpoint in the NEG CR. The first sync of the
+// syncer starts from a valid checkpoint, instead of listing the endpoints.
+func (s *transactionSyncer) retrieveCurrentEndpoints(ctx context.Context) (map[string]negtypes.NetworkEndpointSet, bool, error) {
+	if s.enableCheckpoint && !s.checkpointChecked {
+		s.checkpointChecked = true
+		if currentMap, ok := s.restoreCheckpoint(); ok {
+			klog.V(2).Infof("Starting NEG %q for %s from its checkpoint", s.NegSyncerKey.NegName, s.NegSyncerKey.String())
+			return currentMap, true, nil
+		}
+	}
+
+	currentMap, err := retrieveExistingZoneNetworkEndpointMap(ctx, s.NegSyncerKey.NegName, s.zoneGetter, s.cloud, s.NegSyncerKey.GetAPIVersion())
+	if err != nil {
+		return nil, false, err
+	}
+	if s.restoredFingerprint != "" {
+		if endpointMapFingerprint(currentMap) != s.restoredFingerprint {
+			klog.Warningf("Checkpoint of NEG %q for %s was stale, syncing the listed endpoints", s.NegSyncerKey.NegName, s.NegSyncerKey.String())
+		} else {
+			klog.V(2).Infof("Checkpoint of NEG %q for %s is valid", s.NegSyncerKey.NegName, s.NegSyncerKey.String())
+		}
+		s.restoredFingerprint = ""
+	}
+	return currentMap, false, nil
+}
+
+// restoreCheckpoint returns the endpoints in the checkpoint of the NEG CR, if
+// it is valid and covers the current zones.
+func (s *transactionSyncer) restoreCheckpoint() (map[string]negtypes.NetworkEndpointSet, bool) {
+	neg, err := getNegFromStore(s.svcNegLister, s.Namespace, s.NegSyncerKey.NegName)
+	if err != nil || neg.Status.EndpointCheckpoint == nil {
+		return nil, false
+	}
+	currentMap, ok := endpointMapFromCheckpoint(neg.Status.EndpointCheckpoint)
+	if !ok {
+		klog.Warningf("Ignoring invalid checkpoint of NEG %q for %s", s.NegSyncerKey.NegName, s.NegSyncerKey.String())
+		return nil, false
+	}
+	zones, err := s.zoneGetter.ListZones()
+	if err != nil {
+		return nil, false
+	}
+	if len(zones) != len(currentMap) {
+		return nil, false
+	}
+	for _, zone := range zones {
+		if _, ok := currentMap[zone]; !ok {
+			return nil, false
+		}
+	}
+	return currentMap, true
+}
+
+// validateCheckpointLater syncs the syncer after a jittered delay, listing
+// the endpoints of the NEGs, which are expected to match fingerprint.
+func (s *transactionSyncer) validateCheckpointLater(fingerprint string) {
+	s.restoredFingerprint = fingerprint
+	time.AfterFunc(wait.Jitter(checkpointValidationDelay, 1.0), func() { s.syncer.Sync() })
+}
+
+// setCheckpoint sets the checkpoint written in the status of the NEG CR.
+func (s *transactionSyncer) setCheckpoint(checkpoint *negv1beta1.EndpointCheckpoint) {
+	s.checkpoint = checkpoint
+	s.checkpointChanged = true
+}
+
 // syncNetworkEndpoints spins off go routines to execute NEG operations
-func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints map[string]negtypes.NetworkEndpointSet) error {
+// ctx holds the span of the sync that the operations are traced under.
+func (s *transactionSyncer) syncNetworkEndpoints(ctx context.Context, addEndpoints, removeEndpoints map[string]negtypes.NetworkEndpointSet) error {
 	syncFunc := func(endpointMap map[string]negtypes.NetworkEndpointSet, operation transactionOp) error {
 		for zone, endpointSet := range endpointMap {
 			if endpointSet.Len() == 0 {
@@ -278,7 +439,7 @@ func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints m
 				continue
 			}
 
-			batch, err := makeEndpointBatch(endpointSet, s.NegType)
+			batch, err := makeEndpointBatch(endpointSet, s.NegType, s.maxBatchSize())
 			if err != nil {
 				return err
 			}
@@ -286,6 +447,7 @@ func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints m
 			transEntry := transactionEntry{
 				Operation: operation,
 				Zone:      zone,
+				StartTime: time.Now(),
 			}
 
 			// Insert networkEndpoint into transaction table
@@ -294,10 +456,10 @@ func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints m
 			}
 
 			if operation == attachOp {
-				s.attachNetworkEndpoints(zone, batch)
+				s.attachNetworkEndpoints(ctx, zone, batch)
 			}
 			if operation == detachOp {
-				s.detachNetworkEndpoints(zone, batch)
+				s.detachNetworkEndpoints(ctx, zone, batch)
 			}
 		}
 		return nil
@@ -313,36 +475,71 @@ func (s *transactionSyncer) syncNetworkEndpoints(addEndpoints, removeEndpoints m
 	return nil
 }
 
-// attachNetworkEndpoints creates go routine to run operations for attaching network endpoints
-func (s *transactionSyncer) attachNetworkEndpoints(zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
+// attachNetworkEndpoints runs the operation attaching network endpoints
+func (s *transactionSyncer) attachNetworkEndpoints(ctx context.Context, zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
 	klog.V(2).Infof("Attaching %d endpoint(s) for %s in NEG %s at %s.", len(networkEndpointMap), s.NegSyncerKey.String(), s.NegSyncerKey.NegName, zone)
-	go s.operationInternal(attachOp, zone, networkEndpointMap)
+	s.runOperation(ctx, attachOp, zone, networkEndpointMap)
 }
 
-// detachNetworkEndpoints creates go routine to run operations for detaching network endpoints
-func (s *transactionSyncer) detachNetworkEndpoints(zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
+// detachNetworkEndpoints runs the operation detaching network endpoints
+func (s *transactionSyncer) detachNetworkEndpoints(ctx context.Context, zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
 	klog.V(2).Infof("Detaching %d endpoint(s) for %s in NEG %s at %s.", len(networkEndpointMap), s.NegSyncerKey.String(), s.NegSyncerKey.NegName, zone)
-	go s.operationInternal(detachOp, zone, networkEndpointMap)
+	s.runOperation(ctx, detachOp, zone, networkEndpointMap)
 }
 
-// operationInternal executes NEG API call and commits the transactions
-// It will record events when operations are completed
-// If error occurs or any transaction entry requires reconciliation, it will trigger resync
-func (s *transactionSyncer) operationInternal(operation transactionOp, zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
-	var err error
-	start := time.Now()
-	networkEndpoints := []*composite.NetworkEndpoint{}
-	for _, ne := range networkEndpointMap {
-		networkEndpoints = append(networkEndpoints, ne)
+// runOperation runs the operation in the scheduler, or right away in its own
+// go routine if there is no scheduler.
+func (s *transactionSyncer) runOperation(ctx context.Context, operation transactionOp, zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint) {
+	changedAt := s.endpointsChangedAt
+	done := func(err error, start time.Time) {
+		s.operationInternal(operation, zone, networkEndpointMap, err, start, changedAt)
+	}
+	if s.scheduler != nil {
+		s.scheduler.schedule(ctx, s.NegSyncerKey, operation, zone, networkEndpointMap, done)
+		return
+	}
+	go func() {
+		start := time.Now()
+		networkEndpoints := []*composite.NetworkEndpoint{}
+		for _, ne := range networkEndpointMap {
+			networkEndpoints = append(networkEndpoints, ne)
+		}
+		done(runEndpointOperation(ctx, s.cloud, s.NegSyncerKey, operation, zone, networkEndpoints), start)
+	}()
+}
+
+// maxBatchSize returns the maximum number of endpoints of an operation.
+func (s *transactionSyncer) maxBatchSize() int {
+	if s.scheduler != nil {
+		return s.scheduler.MaxBatchSize()
 	}
+	return MAX_NETWORK_ENDPOINTS_PER_BATCH
+}
+
+// runEndpointOperation executes the NEG API call of the operation.
+// ctx holds the span the API call is traced under.
+func runEndpointOperation(ctx context.Context, cloud negtypes.NetworkEndpointGroupCloud, syncerKey negtypes.NegSyncerKey, operation transactionOp, zone string, networkEndpoints []*composite.NetworkEndpoint) error {
+	var err error
+	ctx, span := tracing.StartSpan(ctx, operation.String()+"NetworkEndpoints",
+		trace.StringAttribute(tracing.ResourceAttribute, syncerKey.NegName),
+		trace.StringAttribute("zone", zone),
+		trace.Int64Attribute("endpoints", int64(len(networkEndpoints))))
+	defer func() { tracing.EndSpan(span, err) }()
 
 	if operation == attachOp {
-		err = s.cloud.AttachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion())
+		err = cloud.AttachNetworkEndpoints(ctx, syncerKey.NegName, zone, networkEndpoints, syncerKey.GetAPIVersion())
 	}
 	if operation == detachOp {
-		err = s.cloud.DetachNetworkEndpoints(s.NegSyncerKey.NegName, zone, networkEndpoints, s.NegSyncerKey.GetAPIVersion())
+		err = cloud.DetachNetworkEndpoints(ctx, syncerKey.NegName, zone, networkEndpoints, syncerKey.GetAPIVersion())
 	}
+	return err
+}
 
+// operationInternal handles the result of a NEG API call and commits the transactions
+// It will record events when operations are completed
+// If error occurs or any transaction entry requires reconciliation, it will trigger resync
+// changedAt is the time of the change of the Endpoints the operation was started for.
+func (s *transactionSyncer) operationInternal(operation transactionOp, zone string, networkEndpointMap map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint, err error, start time.Time, changedAt time.Time) {
 	if err == nil {
 		s.recordEvent(apiv1.EventTypeNormal, operation.String(), fmt.Sprintf("%s %d network endpoint(s) (NEG %q in zone %q)", operation.String(), len(networkEndpointMap), s.NegSyncerKey.NegName, zone))
 	} else {
@@ -352,6 +549,38 @@ func (s *transactionSyncer) operationInternal(operation transactionOp, zone stri
 	// WARNING: commitTransaction must be called at last for analyzing the operation result
 	s.commitTransaction(err, networkEndpointMap)
 	metrics.PublishNegOperationMetrics(operation.String(), string(s.NegSyncerKey.NegType), string(s.NegSyncerKey.GetAPIVersion()), err, len(networkEndpointMap), start)
+	metrics.PublishSyncerOperation(s.NegSyncerKey.NegName, operation.String(), err)
+	if operation == attachOp && err == nil {
+		s.observeAttachLatency(changedAt)
+	}
+	s.publishTransactionMetrics()
+}
+
+// observeAttachLatency publishes the latency between the change of the
+// Endpoints and the attachment of its endpoints, once per change.
+func (s *transactionSyncer) observeAttachLatency(changedAt time.Time) {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+	if changedAt.IsZero() || !changedAt.After(s.attachLatencyObservedAt) {
+		return
+	}
+	s.attachLatencyObservedAt = changedAt
+	metrics.PublishEndpointAttachLatency(string(s.NegSyncerKey.NegType), changedAt)
+}
+
+// publishEndpointMetrics publishes the number of endpoints of each zone.
+func (s *transactionSyncer) publishEndpointMetrics(endpointMap map[string]negtypes.NetworkEndpointSet) {
+	endpoints := map[string]int{}
+	for zone, endpointSet := range endpointMap {
+		endpoints[zone] = endpointSet.Len()
+	}
+	metrics.PublishSyncerEndpoints(s.NegSyncerKey.NegName, endpoints)
+}
+
+// publishTransactionMetrics publishes the start times of the transactions in
+// the transaction table.
+func (s *transactionSyncer) publishTransactionMetrics() {
+	metrics.PublishSyncerTransactions(s.NegSyncerKey.NegName, transactionStartTimes(s.transactions))
 }
 
 func (s *transactionSyncer) recordEvent(eventType, reason, eventDesc string) {
@@ -435,6 +664,28 @@ func filterEndpointByTransaction(endpointMap map[string]negtypes.NetworkEndpoint
 	}
 }
 
+// transactionStartTimes returns the start time of the operation of each
+// endpoint in the transaction table.
+func transactionStartTimes(transactions networkEndpointTransactionTable) []time.Time {
+	var startTimes []time.Time
+	for _, endpointKey := range transactions.Keys() {
+		if entry, ok := transactions.Get(endpointKey); ok {
+			startTimes = append(startTimes, entry.StartTime)
+		}
+	}
+	return startTimes
+}
+
+// endpointsChangeTime returns the time of the change that triggered the last
+// update of the Endpoints, or the zero time if it is unknown.
+func endpointsChangeTime(ep *apiv1.Endpoints) time.Time {
+	changedAt, err := time.Parse(time.RFC3339Nano, ep.Annotations[apiv1.EndpointsLastChangeTriggerTime])
+	if err != nil {
+		return time.Time{}
+	}
+	return changedAt
+}
+
 // mergeTransactionIntoZoneEndpointMap merges the ongoing transaction into the endpointMap.
 // This converts the existing endpointMap to the state when all transactions completed
 func mergeTransactionIntoZoneEndpointMap(endpointMap map[string]negtypes.NetworkEndpointSet, transactions networkEndpointTransactionTable) {
@@ -525,6 +776,14 @@ func (s *transactionSyncer) updateStatus(syncErr error) {
 
 	ensureCondition(neg, getSyncedCondition(syncErr))
 	neg.Status.LastSyncTime = ts
+	if s.checkpointChanged {
+		neg.Status.EndpointCheckpoint = s.checkpoint
+		s.checkpointChanged = false
+	}
+	if !s.enableCheckpoint {
+		// A checkpoint left by an earlier run would be stale.
+		neg.Status.EndpointCheckpoint = nil
+	}
 
 	if len(neg.Status.NetworkEndpointGroups) == 0 {
 		s.needInit = true
@@ -560,7 +819,9 @@ func patchNegStatus(svcNegClient svcnegclient.Interface, oldStatus, newStatus ne
 }
 
 // ensureCondition will update the condition on the neg object if necessary
+// The condition records the generation of the neg object it was computed for.
 func ensureCondition(neg *negv1beta1.ServiceNetworkEndpointGroup, expectedCondition negv1beta1.Condition) negv1beta1.Condition {
+	expectedCondition.ObservedGeneration = neg.Generation
 	condition, index, exists := findCondition(neg.Status.Conditions, expectedCondition.Type)
 	if !exists {
 		neg.Status.Conditions = append(neg.Status.Conditions, expectedCondition)
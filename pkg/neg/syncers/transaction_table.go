@@ -17,8 +17,10 @@ limitations under the License.
 package syncers
 
 import (
-	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"sync"
+	"time"
+
+	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 )
 
 type networkEndpointTransactionTable interface {
@@ -51,6 +53,8 @@ type transactionEntry struct {
 	Operation transactionOp
 	// Zone represents the zone of the transaction
 	Zone string
+	// StartTime is the time the transaction started
+	StartTime time.Time
 }
 
 // transactionTable records ongoing NEG API operation per endpoint
@@ -48,8 +48,8 @@ func TestTransactionTable(t *testing.T) {
 	for i := 0; i < testNum; i++ {
 		key := negtypes.NetworkEndpoint{IP: fmt.Sprintf("%s%d", ipPrefix, i), Port: fmt.Sprintf("%s%d", portPrefix, i), Node: fmt.Sprintf("%s%d", nodePrefix, i)}
 		entry := transactionEntry{
-			attachOp,
-			fmt.Sprintf("%s%d", zonePrefix, i),
+			Operation: attachOp,
+			Zone:      fmt.Sprintf("%s%d", zonePrefix, i),
 		}
 		table.Put(key, entry)
 		testKeyMap[key] = entry
@@ -61,8 +61,8 @@ func TestTransactionTable(t *testing.T) {
 	for i := 0; i < testNum/2; i++ {
 		key := negtypes.NetworkEndpoint{IP: fmt.Sprintf("%s%d", ipPrefix, i), Port: fmt.Sprintf("%s%d", portPrefix, i), Node: fmt.Sprintf("%s%d", nodePrefix, i)}
 		newEntry := transactionEntry{
-			detachOp,
-			fmt.Sprintf("%s%d", zonePrefix, i),
+			Operation: detachOp,
+			Zone:      fmt.Sprintf("%s%d", zonePrefix, i),
 		}
 		table.Put(key, newEntry)
 		testKeyMap[key] = newEntry
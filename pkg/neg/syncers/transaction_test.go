@@ -189,7 +189,7 @@ func TestTransactionSyncNetworkEndpoints(t *testing.T) {
 		}
 
 		for _, tc := range testCases {
-			err := transactionSyncer.syncNetworkEndpoints(tc.addEndpoints, tc.removeEndpoints)
+			err := transactionSyncer.syncNetworkEndpoints(context2.Background(), tc.addEndpoints, tc.removeEndpoints)
 			if err != nil {
 				t.Errorf("For case %q, syncNetworkEndpoints() got %v, want nil", tc.desc, err)
 			}
@@ -199,7 +199,7 @@ func TestTransactionSyncNetworkEndpoints(t *testing.T) {
 			}
 
 			for zone, endpoints := range tc.expectEndpoints {
-				list, err := fakeCloud.ListNetworkEndpoints(transactionSyncer.NegSyncerKey.NegName, zone, false, transactionSyncer.NegSyncerKey.GetAPIVersion())
+				list, err := fakeCloud.ListNetworkEndpoints(context2.Background(), transactionSyncer.NegSyncerKey.NegName, zone, false, transactionSyncer.NegSyncerKey.GetAPIVersion())
 				if err != nil {
 					t.Errorf("For case %q, ListNetworkEndpoints() got %v, want nil", tc.desc, err)
 				}
@@ -1169,6 +1169,7 @@ func TestUpdateStatus(t *testing.T) {
 				// Since timestamp gets truncated to the second, there is a chance that the timestamps will be the same as LastTransitionTime or LastSyncTime so use creation TS from an earlier date
 				creationTS := v1.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC)
 				origCR := createNegCR(testNegName, creationTS, tc.populateConditions[negv1beta1.Initialized], tc.populateConditions[negv1beta1.Synced], tc.negRefs)
+				origCR.Generation = 2
 				origCR, err := svcNegClient.NetworkingV1beta1().ServiceNetworkEndpointGroups(testNamespace).Create(context2.Background(), origCR, v1.CreateOptions{})
 				if err != nil {
 					t.Errorf("Failed to create test NEG CR: %s", err)
@@ -1190,6 +1191,10 @@ func TestUpdateStatus(t *testing.T) {
 					checkCondition(t, negCR.Status.Conditions, negv1beta1.Synced, creationTS, corev1.ConditionTrue, true)
 				}
 
+				if condition, _, exists := findCondition(negCR.Status.Conditions, negv1beta1.Synced); !exists || condition.ObservedGeneration != origCR.Generation {
+					t.Errorf("expected Synced condition to have observed generation %d, but got %+v", origCR.Generation, condition)
+				}
+
 				if syncer.needInit != tc.expectedNeedInit {
 					t.Errorf("expected manager.needInit to be %t, but was %t", tc.expectedNeedInit, syncer.needInit)
 				}
@@ -1204,7 +1209,7 @@ func TestUpdateStatus(t *testing.T) {
 
 func newL4ILBTestTransactionSyncer(fakeGCE negtypes.NetworkEndpointGroupCloud, mode negtypes.EndpointsCalculatorMode) (negtypes.NegSyncer, *transactionSyncer) {
 	negsyncer, ts := newTestTransactionSyncer(fakeGCE, negtypes.VmIpEndpointType, false)
-	ts.endpointsCalculator = GetEndpointsCalculator(ts.nodeLister, ts.podLister, ts.zoneGetter, ts.NegSyncerKey, mode)
+	ts.endpointsCalculator = GetEndpointsCalculator(ts.nodeLister, ts.podLister, ts.serviceLister, ts.zoneGetter, ts.NegSyncerKey, mode)
 	return negsyncer, ts
 }
 
@@ -1241,12 +1246,14 @@ func newTestTransactionSyncer(fakeGCE negtypes.NetworkEndpointGroupCloud, negTyp
 		testContext.EndpointInformer.GetIndexer(),
 		testContext.NodeInformer.GetIndexer(),
 		testContext.SvcNegInformer.GetIndexer(),
+		nil, // workloadEndpointSliceLister
 		reflector,
-		GetEndpointsCalculator(testContext.NodeInformer.GetIndexer(), testContext.PodInformer.GetIndexer(), negtypes.NewFakeZoneGetter(),
+		GetEndpointsCalculator(testContext.NodeInformer.GetIndexer(), testContext.PodInformer.GetIndexer(), testContext.ServiceInformer.GetIndexer(), negtypes.NewFakeZoneGetter(),
 			svcPort, mode),
 		string(kubeSystemUID),
 		testContext.SvcNegClient,
 		customName,
+		nil, // scheduler
 	)
 	transactionSyncer := negsyncer.(*syncer).core.(*transactionSyncer)
 	return negsyncer, transactionSyncer
@@ -1297,7 +1304,7 @@ func unionEndpointMap(m1, m2 negtypes.EndpointPodMap) negtypes.EndpointPodMap {
 }
 
 func generateEndpointBatch(endpointSet negtypes.NetworkEndpointSet) map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint {
-	ret, _ := makeEndpointBatch(endpointSet, negtypes.VmIpPortEndpointType)
+	ret, _ := makeEndpointBatch(endpointSet, negtypes.VmIpPortEndpointType, MAX_NETWORK_ENDPOINTS_PER_BATCH)
 	return ret
 }
 
@@ -1519,3 +1526,53 @@ func checkNegCR(t *testing.T, negCR *negv1beta1.ServiceNetworkEndpointGroup, pre
 		t.Errorf("Expected to have at most 2 conditions, found %d", len(negCR.Status.Conditions))
 	}
 }
+
+func TestTransactionStartTimes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	table := NewTransactionTable()
+	generateTransaction(table, transactionEntry{Zone: testZone1, Operation: attachOp, StartTime: now.Add(-time.Minute)}, net.ParseIP("1.1.1.1"), 5, testInstance1, "8080")
+	generateTransaction(table, transactionEntry{Zone: testZone1, Operation: detachOp, StartTime: now.Add(-time.Hour)}, net.ParseIP("1.1.2.1"), 3, testInstance2, "8080")
+	generateTransaction(table, transactionEntry{Zone: testZone2, Operation: attachOp}, net.ParseIP("1.1.3.1"), 2, testInstance3, "8080")
+
+	counts := map[time.Time]int{}
+	for _, start := range transactionStartTimes(table) {
+		counts[start]++
+	}
+	expected := map[time.Time]int{now.Add(-time.Minute): 5, now.Add(-time.Hour): 3, {}: 2}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("transactionStartTimes() returned start times %v, want %v", counts, expected)
+	}
+}
+
+func TestEndpointsChangeTime(t *testing.T) {
+	t.Parallel()
+
+	changedAt := time.Date(2021, 3, 1, 10, 0, 0, 123000000, time.UTC)
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		expected    time.Time
+	}{
+		{
+			desc:     "no annotation",
+			expected: time.Time{},
+		},
+		{
+			desc:        "valid annotation",
+			annotations: map[string]string{corev1.EndpointsLastChangeTriggerTime: changedAt.Format(time.RFC3339Nano)},
+			expected:    changedAt,
+		},
+		{
+			desc:        "invalid annotation",
+			annotations: map[string]string{corev1.EndpointsLastChangeTriggerTime: "yesterday"},
+			expected:    time.Time{},
+		},
+	} {
+		ep := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		if got := endpointsChangeTime(ep); !got.Equal(tc.expected) {
+			t.Errorf("For case %q, endpointsChangeTime() = %v, want %v", tc.desc, got, tc.expected)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package syncers
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
@@ -266,7 +269,16 @@ func toZoneNetworkEndpointMap(endpoints *apiv1.Endpoints, zoneGetter negtypes.Zo
 					klog.V(2).Infof("Endpoint %q in Endpoints %s/%s does not have an associated pod. Skipping", address.IP, endpoints.Namespace, endpoints.Name)
 					continue
 				}
+				// Detach terminating pods while they still serve, before they are removed from the Endpoints.
+				if flags.F.EnableNegGracefulRemoval && isPodTerminating(podLister, address.TargetRef.Namespace, address.TargetRef.Name) {
+					klog.V(2).Infof("Endpoint %q in Endpoints %s/%s belongs to terminating pod %s/%s. Skipping", address.IP, endpoints.Namespace, endpoints.Name, address.TargetRef.Namespace, address.TargetRef.Name)
+					continue
+				}
 				zone, err := zoneGetter.GetZoneForNode(*address.NodeName)
+				if err == negtypes.ErrZoneNotSelected {
+					klog.V(4).Infof("Endpoint %q in Endpoints %s/%s is on node %q outside of the selected zones. Skipping", address.IP, endpoints.Namespace, endpoints.Name, *address.NodeName)
+					continue
+				}
 				if err != nil {
 					return fmt.Errorf("failed to retrieve associated zone of node %q: %v", *address.NodeName, err)
 				}
@@ -304,8 +316,70 @@ func toZoneNetworkEndpointMap(endpoints *apiv1.Endpoints, zoneGetter negtypes.Zo
 	return zoneNetworkEndpointMap, networkEndpointPodMap, nil
 }
 
+// mergeWorkloadEndpoints adds the endpoints in the workload EndpointSlices of
+// the service to the zone endpoint map and the endpoint pod map. The zone of
+// each endpoint is the one reported by its Workload. Endpoints outside of the
+// zones of the zoneGetter are skipped.
+func mergeWorkloadEndpoints(zoneNetworkEndpointMap map[string]negtypes.NetworkEndpointSet, networkEndpointPodMap negtypes.EndpointPodMap, sliceLister cache.Indexer, namespace, serviceName, servicePortName string, zoneGetter negtypes.ZoneGetter, networkEndpointType negtypes.NetworkEndpointType) error {
+	zones, err := zoneGetter.ListZones()
+	if err != nil {
+		return err
+	}
+	validZones := sets.NewString(zones...)
+
+	objs, err := sliceLister.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		slice := obj.(*discovery.EndpointSlice)
+		if slice.Labels[discovery.LabelServiceName] != serviceName {
+			continue
+		}
+		matchPort := ""
+		for _, port := range slice.Ports {
+			if port.Name != nil && *port.Name == servicePortName && port.Port != nil {
+				matchPort = strconv.Itoa(int(*port.Port))
+				break
+			}
+		}
+		if len(matchPort) == 0 {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.TargetRef == nil {
+				klog.V(2).Infof("Endpoint %v in EndpointSlice %s/%s does not have an associated workload. Skipping", endpoint.Addresses, slice.Namespace, slice.Name)
+				continue
+			}
+			zone := endpoint.Topology[v1.LabelZoneFailureDomainStable]
+			if !validZones.Has(zone) {
+				klog.V(2).Infof("Workload %s/%s is in zone %q which does not have NEGs. Skipping", endpoint.TargetRef.Namespace, endpoint.TargetRef.Name, zone)
+				continue
+			}
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				networkEndpoint := negtypes.NetworkEndpoint{IP: address, Port: matchPort}
+				// The Workloads of VM instances are named after their instance.
+				if networkEndpointType != negtypes.NonGCPPrivateEndpointType {
+					networkEndpoint.Node = endpoint.TargetRef.Name
+				}
+				if zoneNetworkEndpointMap[zone] == nil {
+					zoneNetworkEndpointMap[zone] = negtypes.NewNetworkEndpointSet()
+				}
+				zoneNetworkEndpointMap[zone].Insert(networkEndpoint)
+				networkEndpointPodMap[networkEndpoint] = types.NamespacedName{Namespace: endpoint.TargetRef.Namespace, Name: endpoint.TargetRef.Name}
+			}
+		}
+	}
+	return nil
+}
+
 // retrieveExistingZoneNetworkEndpointMap lists existing network endpoints in the neg and return the zone and endpoints map
-func retrieveExistingZoneNetworkEndpointMap(negName string, zoneGetter negtypes.ZoneGetter, cloud negtypes.NetworkEndpointGroupCloud, version meta.Version) (map[string]negtypes.NetworkEndpointSet, error) {
+// The list calls are traced under ctx.
+func retrieveExistingZoneNetworkEndpointMap(ctx context.Context, negName string, zoneGetter negtypes.ZoneGetter, cloud negtypes.NetworkEndpointGroupCloud, version meta.Version) (map[string]negtypes.NetworkEndpointSet, error) {
 	zones, err := zoneGetter.ListZones()
 	if err != nil {
 		return nil, err
@@ -314,7 +388,7 @@ func retrieveExistingZoneNetworkEndpointMap(negName string, zoneGetter negtypes.
 	zoneNetworkEndpointMap := map[string]negtypes.NetworkEndpointSet{}
 	for _, zone := range zones {
 		zoneNetworkEndpointMap[zone] = negtypes.NewNetworkEndpointSet()
-		networkEndpointsWithHealthStatus, err := cloud.ListNetworkEndpoints(negName, zone, false, version)
+		networkEndpointsWithHealthStatus, err := cloud.ListNetworkEndpoints(ctx, negName, zone, false, version)
 		if err != nil {
 			return nil, err
 		}
@@ -329,12 +403,12 @@ func retrieveExistingZoneNetworkEndpointMap(negName string, zoneGetter negtypes.
 	return zoneNetworkEndpointMap, nil
 }
 
-// makeEndpointBatch return a batch of endpoint from the input and remove the endpoints from input set
+// makeEndpointBatch return a batch of at most maxBatchSize endpoints from the input and remove the endpoints from input set
 // The return map has the encoded endpoint as key and GCE network endpoint object as value
-func makeEndpointBatch(endpoints negtypes.NetworkEndpointSet, negType negtypes.NetworkEndpointType) (map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint, error) {
+func makeEndpointBatch(endpoints negtypes.NetworkEndpointSet, negType negtypes.NetworkEndpointType, maxBatchSize int) (map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint, error) {
 	endpointBatch := map[negtypes.NetworkEndpoint]*composite.NetworkEndpoint{}
 
-	for i := 0; i < MAX_NETWORK_ENDPOINTS_PER_BATCH; i++ {
+	for i := 0; i < maxBatchSize; i++ {
 		networkEndpoint, ok := endpoints.PopAny()
 		if !ok {
 			break
@@ -390,6 +464,19 @@ func shouldPodBeInNeg(podLister cache.Indexer, namespace, name string) bool {
 	return true
 }
 
+// isPodTerminating returns true if the pod exists and its graceful termination has started.
+func isPodTerminating(podLister cache.Indexer, namespace, name string) bool {
+	if podLister == nil {
+		return false
+	}
+	obj, exists, err := podLister.GetByKey(keyFunc(namespace, name))
+	if err != nil || !exists {
+		return false
+	}
+	pod, ok := obj.(*v1.Pod)
+	return ok && pod.DeletionTimestamp != nil
+}
+
 // shouldPodBeInDestinationRuleSubset return ture if pod match the DestinationRule subset lables.
 func shouldPodBeInDestinationRuleSubset(podLister cache.Indexer, namespace, name string, subsetLables string) bool {
 	if podLister == nil {
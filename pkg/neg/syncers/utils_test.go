@@ -17,6 +17,7 @@ limitations under the License.
 package syncers
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
@@ -26,11 +27,14 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -565,6 +569,139 @@ func TestToZoneNetworkEndpointMapUtil(t *testing.T) {
 	}
 }
 
+func TestToZoneNetworkEndpointMapGracefulRemoval(t *testing.T) {
+	flags.F.EnableNegGracefulRemoval = true
+	defer func() { flags.F.EnableNegGracefulRemoval = false }()
+
+	_, transactionSyncer := newTestTransactionSyncer(negtypes.NewAdapter(gce.NewFakeGCECloud(gce.DefaultTestClusterValues())), negtypes.VmIpPortEndpointType, false)
+	podLister := transactionSyncer.podLister
+	for i := 1; i <= 5; i++ {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testServiceNamespace,
+				Name:      fmt.Sprintf("pod%v", i),
+			},
+		}
+		// pod1 is terminating, but still ready in the endpoints
+		if i == 1 {
+			pod.DeletionTimestamp = &metav1.Time{}
+		}
+		podLister.Add(pod)
+	}
+
+	expectEndpointSets := map[string]negtypes.NetworkEndpointSet{
+		negtypes.TestZone1: negtypes.NewNetworkEndpointSet(
+			networkEndpointFromEncodedEndpoint("10.100.1.2||instance1||80"),
+			networkEndpointFromEncodedEndpoint("10.100.2.1||instance2||80"),
+			networkEndpointFromEncodedEndpoint("10.100.1.3||instance1||80")),
+		negtypes.TestZone2: negtypes.NewNetworkEndpointSet(
+			networkEndpointFromEncodedEndpoint("10.100.3.1||instance3||80")),
+	}
+	retSet, retMap, err := toZoneNetworkEndpointMap(getDefaultEndpoint(), negtypes.NewFakeZoneGetter(), "", podLister, "", negtypes.VmIpPortEndpointType)
+	if err != nil {
+		t.Fatalf("expect nil error, but got %v.", err)
+	}
+	if !reflect.DeepEqual(retSet, expectEndpointSets) {
+		t.Errorf("expecting endpoint set %v, but got %v.", expectEndpointSets, retSet)
+	}
+	if _, ok := retMap[networkEndpointFromEncodedEndpoint("10.100.1.1||instance1||80")]; ok {
+		t.Errorf("expecting endpoint of terminating pod to be excluded from endpoint map, but got %v.", retMap)
+	}
+}
+
+func TestMergeWorkloadEndpoints(t *testing.T) {
+	portName := "http"
+	port := int32(8080)
+	ready := true
+	notReady := false
+	newEndpoint := func(name, zone, ip string, ready *bool) discovery.Endpoint {
+		ep := discovery.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discovery.EndpointConditions{Ready: ready},
+			TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: testServiceNamespace, Name: name},
+		}
+		if zone != "" {
+			ep.Topology = map[string]string{v1.LabelZoneFailureDomainStable: zone}
+		}
+		return ep
+	}
+	sliceLister := cache.NewIndexer(cache.MetaNamespaceKeyFunc, utils.NewNamespaceIndexer())
+	sliceLister.Add(&discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testServiceNamespace,
+			Name:      "workload-slice",
+			Labels:    map[string]string{discovery.LabelServiceName: testServiceName},
+		},
+		Ports: []discovery.EndpointPort{{Name: &portName, Port: &port}},
+		Endpoints: []discovery.Endpoint{
+			newEndpoint("vm1", negtypes.TestZone1, "10.200.0.1", &ready),
+			newEndpoint("vm2", negtypes.TestZone2, "10.200.0.2", &ready),
+			// Not ready
+			newEndpoint("vm3", negtypes.TestZone1, "10.200.0.3", &notReady),
+			// Zone without NEGs
+			newEndpoint("vm4", "other-zone", "10.200.0.4", &ready),
+			// No zone
+			newEndpoint("vm5", "", "10.200.0.5", &ready),
+		},
+	})
+	sliceLister.Add(&discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testServiceNamespace,
+			Name:      "other-slice",
+			Labels:    map[string]string{discovery.LabelServiceName: "other-service"},
+		},
+		Ports:     []discovery.EndpointPort{{Name: &portName, Port: &port}},
+		Endpoints: []discovery.Endpoint{newEndpoint("vm6", negtypes.TestZone1, "10.200.0.6", &ready)},
+	})
+
+	for _, tc := range []struct {
+		desc                string
+		networkEndpointType negtypes.NetworkEndpointType
+		expectNode          map[string]string
+	}{
+		{
+			desc:                "GCE_VM_IP_PORT NEGs",
+			networkEndpointType: negtypes.VmIpPortEndpointType,
+			expectNode:          map[string]string{"10.200.0.1": "vm1", "10.200.0.2": "vm2"},
+		},
+		{
+			desc:                "NON_GCP_PRIVATE_IP_PORT NEGs",
+			networkEndpointType: negtypes.NonGCPPrivateEndpointType,
+			expectNode:          map[string]string{"10.200.0.1": "", "10.200.0.2": ""},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			podEndpoint := negtypes.NetworkEndpoint{IP: "10.100.1.1", Port: "80", Node: negtypes.TestInstance1}
+			endpointMap := map[string]negtypes.NetworkEndpointSet{
+				negtypes.TestZone1: negtypes.NewNetworkEndpointSet(podEndpoint),
+			}
+			podMap := negtypes.EndpointPodMap{podEndpoint: types.NamespacedName{Namespace: testServiceNamespace, Name: "pod1"}}
+
+			if err := mergeWorkloadEndpoints(endpointMap, podMap, sliceLister, testServiceNamespace, testServiceName, portName, negtypes.NewFakeZoneGetter(), tc.networkEndpointType); err != nil {
+				t.Fatalf("mergeWorkloadEndpoints() = %v, want nil", err)
+			}
+
+			vm1 := negtypes.NetworkEndpoint{IP: "10.200.0.1", Port: "8080", Node: tc.expectNode["10.200.0.1"]}
+			vm2 := negtypes.NetworkEndpoint{IP: "10.200.0.2", Port: "8080", Node: tc.expectNode["10.200.0.2"]}
+			expectEndpointMap := map[string]negtypes.NetworkEndpointSet{
+				negtypes.TestZone1: negtypes.NewNetworkEndpointSet(podEndpoint, vm1),
+				negtypes.TestZone2: negtypes.NewNetworkEndpointSet(vm2),
+			}
+			if !reflect.DeepEqual(endpointMap, expectEndpointMap) {
+				t.Errorf("got endpoint map %v, want %v", endpointMap, expectEndpointMap)
+			}
+			expectPodMap := negtypes.EndpointPodMap{
+				podEndpoint: types.NamespacedName{Namespace: testServiceNamespace, Name: "pod1"},
+				vm1:         types.NamespacedName{Namespace: testServiceNamespace, Name: "vm1"},
+				vm2:         types.NamespacedName{Namespace: testServiceNamespace, Name: "vm2"},
+			}
+			if !reflect.DeepEqual(podMap, expectPodMap) {
+				t.Errorf("got endpoint pod map %v, want %v", podMap, expectPodMap)
+			}
+		})
+	}
+}
+
 func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 	zoneGetter := negtypes.NewFakeZoneGetter()
 	negCloud := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-newtork")
@@ -615,7 +752,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 		{
 			desc: "one empty and one non-empty negs",
 			mutate: func(cloud negtypes.NetworkEndpointGroupCloud) {
-				cloud.AttachNetworkEndpoints(testNegName, negtypes.TestZone1, []*composite.NetworkEndpoint{
+				cloud.AttachNetworkEndpoints(context.Background(), testNegName, negtypes.TestZone1, []*composite.NetworkEndpoint{
 					{
 						Instance:  negtypes.TestInstance1,
 						IpAddress: testIP1,
@@ -632,7 +769,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 		{
 			desc: "one neg with multiple endpoints",
 			mutate: func(cloud negtypes.NetworkEndpointGroupCloud) {
-				cloud.AttachNetworkEndpoints(testNegName, negtypes.TestZone1, []*composite.NetworkEndpoint{
+				cloud.AttachNetworkEndpoints(context.Background(), testNegName, negtypes.TestZone1, []*composite.NetworkEndpoint{
 					{
 						Instance:  negtypes.TestInstance2,
 						IpAddress: testIP2,
@@ -652,7 +789,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 		{
 			desc: "both negs with multiple endpoints",
 			mutate: func(cloud negtypes.NetworkEndpointGroupCloud) {
-				cloud.AttachNetworkEndpoints(testNegName, negtypes.TestZone2, []*composite.NetworkEndpoint{
+				cloud.AttachNetworkEndpoints(context.Background(), testNegName, negtypes.TestZone2, []*composite.NetworkEndpoint{
 					{
 						Instance:  negtypes.TestInstance3,
 						IpAddress: testIP3,
@@ -680,7 +817,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 		{
 			desc: "irrelevant neg",
 			mutate: func(cloud negtypes.NetworkEndpointGroupCloud) {
-				cloud.AttachNetworkEndpoints(irrelevantNegName, negtypes.TestZone2, []*composite.NetworkEndpoint{
+				cloud.AttachNetworkEndpoints(context.Background(), irrelevantNegName, negtypes.TestZone2, []*composite.NetworkEndpoint{
 					{
 						Instance:  negtypes.TestInstance3,
 						IpAddress: testIP4,
@@ -704,7 +841,7 @@ func TestRetrieveExistingZoneNetworkEndpointMap(t *testing.T) {
 
 	for _, tc := range testCases {
 		tc.mutate(negCloud)
-		out, err := retrieveExistingZoneNetworkEndpointMap(negName, zoneGetter, negCloud, meta.VersionGA)
+		out, err := retrieveExistingZoneNetworkEndpointMap(context.Background(), negName, zoneGetter, negCloud, meta.VersionGA)
 
 		if tc.expectErr {
 			if err == nil {
@@ -759,7 +896,7 @@ func TestMakeEndpointBatch(t *testing.T) {
 	for _, negType := range []negtypes.NetworkEndpointType{negtypes.VmIpPortEndpointType, negtypes.VmIpEndpointType} {
 		for _, tc := range testCases {
 			endpointSet, endpointMap := genTestEndpoints(tc.endpointNum, negType)
-			out, err := makeEndpointBatch(endpointSet, negType)
+			out, err := makeEndpointBatch(endpointSet, negType, MAX_NETWORK_ENDPOINTS_PER_BATCH)
 
 			if err != nil {
 				t.Errorf("Expect err = nil, but got %v", err)
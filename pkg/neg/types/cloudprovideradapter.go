@@ -17,6 +17,7 @@ limitations under the License.
 package types
 
 import (
+	"context"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/legacy-cloud-providers/gce"
@@ -82,25 +83,25 @@ func (a *cloudProviderAdapter) DeleteNetworkEndpointGroup(name string, zone stri
 }
 
 // AttachNetworkEndpoints implements NetworkEndpointGroupCloud.
-func (a cloudProviderAdapter) AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
+func (a cloudProviderAdapter) AttachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
 	req := &composite.NetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}
-	return composite.AttachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req)
+	return composite.AttachNetworkEndpointsWithContext(ctx, a.c, meta.ZonalKey(name, zone), version, req)
 }
 
 // DetachNetworkEndpoints implements NetworkEndpointGroupCloud.
-func (a *cloudProviderAdapter) DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
+func (a *cloudProviderAdapter) DetachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
 	req := &composite.NetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}
-	return composite.DetachNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req)
+	return composite.DetachNetworkEndpointsWithContext(ctx, a.c, meta.ZonalKey(name, zone), version, req)
 }
 
 // ListNetworkEndpoints implements NetworkEndpointGroupCloud.
-func (a *cloudProviderAdapter) ListNetworkEndpoints(name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error) {
+func (a *cloudProviderAdapter) ListNetworkEndpoints(ctx context.Context, name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error) {
 	healthStatus := "SKIP"
 	if showHealthStatus {
 		healthStatus = "SHOW"
 	}
 	req := &composite.NetworkEndpointGroupsListEndpointsRequest{HealthStatus: healthStatus}
-	return composite.ListNetworkEndpoints(a.c, meta.ZonalKey(name, zone), version, req)
+	return composite.ListNetworkEndpointsWithContext(ctx, a.c, meta.ZonalKey(name, zone), version, req)
 }
 
 // NetworkURL implements NetworkEndpointGroupCloud.
@@ -112,3 +113,54 @@ func (a *cloudProviderAdapter) NetworkURL() string {
 func (a *cloudProviderAdapter) SubnetworkURL() string {
 	return a.subnetworkURL
 }
+
+// NewExternalAdapter takes a Cloud and returns an ExternalNetworkEndpointGroupCloud.
+func NewExternalAdapter(g *gce.Cloud) ExternalNetworkEndpointGroupCloud {
+	return &externalCloudProviderAdapter{c: g}
+}
+
+// externalCloudProviderAdapter is the ExternalNetworkEndpointGroupCloud
+// counterpart of cloudProviderAdapter.
+type externalCloudProviderAdapter struct {
+	c *gce.Cloud
+}
+
+// GetExternalNetworkEndpointGroup implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) GetExternalNetworkEndpointGroup(key *meta.Key) (*composite.NetworkEndpointGroup, error) {
+	return composite.GetNonZonalNetworkEndpointGroup(a.c, key)
+}
+
+// ListExternalNetworkEndpointGroups implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) ListExternalNetworkEndpointGroups(key *meta.Key) ([]*composite.NetworkEndpointGroup, error) {
+	return composite.ListNonZonalNetworkEndpointGroups(a.c, key)
+}
+
+// CreateExternalNetworkEndpointGroup implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) CreateExternalNetworkEndpointGroup(neg *composite.NetworkEndpointGroup, key *meta.Key) error {
+	return composite.CreateNonZonalNetworkEndpointGroup(a.c, key, neg)
+}
+
+// DeleteExternalNetworkEndpointGroup implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) DeleteExternalNetworkEndpointGroup(key *meta.Key) error {
+	return composite.DeleteNonZonalNetworkEndpointGroup(a.c, key)
+}
+
+// AttachExternalNetworkEndpoints implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error {
+	return composite.AttachGlobalNetworkEndpoints(a.c, key, endpoints)
+}
+
+// DetachExternalNetworkEndpoints implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error {
+	return composite.DetachGlobalNetworkEndpoints(a.c, key, endpoints)
+}
+
+// ListExternalNetworkEndpoints implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) ListExternalNetworkEndpoints(key *meta.Key) ([]*composite.NetworkEndpoint, error) {
+	return composite.ListGlobalNetworkEndpoints(a.c, key)
+}
+
+// Region implements ExternalNetworkEndpointGroupCloud.
+func (a *externalCloudProviderAdapter) Region() string {
+	return a.c.Region()
+}
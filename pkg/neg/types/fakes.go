@@ -17,12 +17,15 @@ limitations under the License.
 package types
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"sync"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -157,14 +160,14 @@ func (f *FakeNetworkEndpointGroupCloud) DeleteNetworkEndpointGroup(name string,
 	return nil
 }
 
-func (f *FakeNetworkEndpointGroupCloud) AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
+func (f *FakeNetworkEndpointGroupCloud) AttachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	f.NetworkEndpoints[networkEndpointKey(name, zone)] = append(f.NetworkEndpoints[networkEndpointKey(name, zone)], endpoints...)
 	return nil
 }
 
-func (f *FakeNetworkEndpointGroupCloud) DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
+func (f *FakeNetworkEndpointGroupCloud) DetachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	newList := []*composite.NetworkEndpoint{}
@@ -185,7 +188,7 @@ func (f *FakeNetworkEndpointGroupCloud) DetachNetworkEndpoints(name, zone string
 	return nil
 }
 
-func (f *FakeNetworkEndpointGroupCloud) ListNetworkEndpoints(name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error) {
+func (f *FakeNetworkEndpointGroupCloud) ListNetworkEndpoints(ctx context.Context, name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	ret := []*composite.NetworkEndpointWithHealthStatus{}
@@ -206,3 +209,115 @@ func (f *FakeNetworkEndpointGroupCloud) NetworkURL() string {
 func (f *FakeNetworkEndpointGroupCloud) SubnetworkURL() string {
 	return f.Subnetwork
 }
+
+// FakeExternalNetworkEndpointGroupCloud is a fake ExternalNetworkEndpointGroupCloud.
+type FakeExternalNetworkEndpointGroupCloud struct {
+	NetworkEndpointGroups map[meta.Key]*composite.NetworkEndpointGroup
+	NetworkEndpoints      map[meta.Key][]*composite.NetworkEndpoint
+	// UsedBy are the backend services using the NEGs. Like in GCE, a NEG
+	// used by a backend service cannot be deleted.
+	UsedBy     map[meta.Key]string
+	FakeRegion string
+	mu         sync.Mutex
+}
+
+func NewFakeExternalNetworkEndpointGroupCloud(region string) *FakeExternalNetworkEndpointGroupCloud {
+	return &FakeExternalNetworkEndpointGroupCloud{
+		NetworkEndpointGroups: map[meta.Key]*composite.NetworkEndpointGroup{},
+		NetworkEndpoints:      map[meta.Key][]*composite.NetworkEndpoint{},
+		UsedBy:                map[meta.Key]string{},
+		FakeRegion:            region,
+	}
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) GetExternalNetworkEndpointGroup(key *meta.Key) (*composite.NetworkEndpointGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if neg, ok := f.NetworkEndpointGroups[*key]; ok {
+		return neg, nil
+	}
+	return nil, NotFoundError
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) ListExternalNetworkEndpointGroups(key *meta.Key) ([]*composite.NetworkEndpointGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ret []*composite.NetworkEndpointGroup
+	for negKey, neg := range f.NetworkEndpointGroups {
+		if negKey.Type() == key.Type() && negKey.Region == key.Region {
+			ret = append(ret, neg)
+		}
+	}
+	return ret, nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) CreateExternalNetworkEndpointGroup(neg *composite.NetworkEndpointGroup, key *meta.Key) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.NetworkEndpointGroups[*key]; ok {
+		return fmt.Errorf("network endpoint group %v already exists", key)
+	}
+	neg.SelfLink = cloud.SelfLink(meta.VersionGA, "mock-project", "networkEndpointGroups", key)
+	neg.Scope = key.Type()
+	f.NetworkEndpointGroups[*key] = neg
+	f.NetworkEndpoints[*key] = []*composite.NetworkEndpoint{}
+	return nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) DeleteExternalNetworkEndpointGroup(key *meta.Key) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.NetworkEndpointGroups[*key]; !ok {
+		return NotFoundError
+	}
+	if backendService, ok := f.UsedBy[*key]; ok {
+		return &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("The network_endpoint_group resource '%s' is already being used by '%s'", key.Name, backendService)}
+	}
+	delete(f.NetworkEndpointGroups, *key)
+	delete(f.NetworkEndpoints, *key)
+	return nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.NetworkEndpoints[*key]; !ok {
+		return NotFoundError
+	}
+	f.NetworkEndpoints[*key] = append(f.NetworkEndpoints[*key], endpoints...)
+	return nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	newList := []*composite.NetworkEndpoint{}
+	for _, ne := range f.NetworkEndpoints[*key] {
+		found := false
+		for _, remove := range endpoints {
+			if reflect.DeepEqual(*ne, *remove) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			newList = append(newList, ne)
+		}
+	}
+	f.NetworkEndpoints[*key] = newList
+	return nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) ListExternalNetworkEndpoints(key *meta.Key) ([]*composite.NetworkEndpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	nes, ok := f.NetworkEndpoints[*key]
+	if !ok {
+		return nil, NotFoundError
+	}
+	return nes, nil
+}
+
+func (f *FakeExternalNetworkEndpointGroupCloud) Region() string {
+	return f.FakeRegion
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ErrZoneNotSelected is returned by a filtered ZoneGetter for nodes in zones
+// which are not selected.
+var ErrZoneNotSelected = errors.New("zone of node is not selected")
+
+// filteredZoneGetter implements ZoneGetter interface
+// It only returns the selected zones of the wrapped ZoneGetter
+type filteredZoneGetter struct {
+	zoneGetter ZoneGetter
+	zones      sets.String
+}
+
+func (f *filteredZoneGetter) GetZoneForNode(name string) (string, error) {
+	zone, err := f.zoneGetter.GetZoneForNode(name)
+	if err != nil {
+		return "", err
+	}
+	if !f.zones.Has(zone) {
+		return "", ErrZoneNotSelected
+	}
+	return zone, nil
+}
+
+func (f *filteredZoneGetter) ListZones() ([]string, error) {
+	zones, err := f.zoneGetter.ListZones()
+	if err != nil {
+		return nil, err
+	}
+	var ret []string
+	for _, zone := range zones {
+		if f.zones.Has(zone) {
+			ret = append(ret, zone)
+		}
+	}
+	return ret, nil
+}
+
+// NewFilteredZoneGetter returns a ZoneGetter which restricts zoneGetter to
+// zones. zoneGetter is returned as is if zones is empty.
+func NewFilteredZoneGetter(zoneGetter ZoneGetter, zones []string) ZoneGetter {
+	if len(zones) == 0 {
+		return zoneGetter
+	}
+	return &filteredZoneGetter{zoneGetter: zoneGetter, zones: sets.NewString(zones...)}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilteredZoneGetter(t *testing.T) {
+	zoneGetter := NewFilteredZoneGetter(NewFakeZoneGetter(), []string{TestZone2, "unknown-zone"})
+	ret, err := zoneGetter.ListZones()
+	if err != nil {
+		t.Errorf("expect err = nil, but got %v", err)
+	}
+	expectZones := []string{TestZone2}
+	if !reflect.DeepEqual(expectZones, ret) {
+		t.Errorf("expect list zones = %v, but got %v", expectZones, ret)
+	}
+
+	if zone, err := zoneGetter.GetZoneForNode(TestInstance3); err != nil || zone != TestZone2 {
+		t.Errorf("expect GetZoneForNode(%q) = %q, nil, but got %q, %v", TestInstance3, TestZone2, zone, err)
+	}
+	if _, err := zoneGetter.GetZoneForNode(TestInstance1); err != ErrZoneNotSelected {
+		t.Errorf("expect GetZoneForNode(%q) err = %v, but got %v", TestInstance1, ErrZoneNotSelected, err)
+	}
+
+	unfiltered := NewFakeZoneGetter()
+	if NewFilteredZoneGetter(unfiltered, nil) != unfiltered {
+		t.Errorf("expect NewFilteredZoneGetter() without zones to return the wrapped zone getter")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package types
 
 import (
+	"context"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -35,13 +36,29 @@ type NetworkEndpointGroupCloud interface {
 	AggregatedListNetworkEndpointGroup(version meta.Version) (map[*meta.Key]*composite.NetworkEndpointGroup, error)
 	CreateNetworkEndpointGroup(neg *composite.NetworkEndpointGroup, zone string) error
 	DeleteNetworkEndpointGroup(name string, zone string, version meta.Version) error
-	AttachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error
-	DetachNetworkEndpoints(name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error
-	ListNetworkEndpoints(name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error)
+	// The endpoint calls are traced under the span in ctx.
+	AttachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error
+	DetachNetworkEndpoints(ctx context.Context, name, zone string, endpoints []*composite.NetworkEndpoint, version meta.Version) error
+	ListNetworkEndpoints(ctx context.Context, name, zone string, showHealthStatus bool, version meta.Version) ([]*composite.NetworkEndpointWithHealthStatus, error)
 	NetworkURL() string
 	SubnetworkURL() string
 }
 
+// ExternalNetworkEndpointGroupCloud is an interface for managing the regional
+// serverless and the global Internet network endpoint groups. Keys are regional
+// or global.
+type ExternalNetworkEndpointGroupCloud interface {
+	GetExternalNetworkEndpointGroup(key *meta.Key) (*composite.NetworkEndpointGroup, error)
+	ListExternalNetworkEndpointGroups(key *meta.Key) ([]*composite.NetworkEndpointGroup, error)
+	CreateExternalNetworkEndpointGroup(neg *composite.NetworkEndpointGroup, key *meta.Key) error
+	DeleteExternalNetworkEndpointGroup(key *meta.Key) error
+	AttachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error
+	DetachExternalNetworkEndpoints(key *meta.Key, endpoints []*composite.NetworkEndpoint) error
+	ListExternalNetworkEndpoints(key *meta.Key) ([]*composite.NetworkEndpoint, error)
+	// Region returns the region of the serverless network endpoint groups.
+	Region() string
+}
+
 // NetworkEndpointGroupNamer is an interface for generating network endpoint group name.
 type NetworkEndpointGroupNamer interface {
 	NEG(namespace, name string, port int32) string
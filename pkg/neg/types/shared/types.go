@@ -18,4 +18,8 @@ package shared
 
 const (
 	NegReadinessGate = "cloud.google.com/load-balancer-neg-ready"
+	// NegDrainedCondition is the pod condition which reports whether a
+	// terminating pod has been detached from all of its NEGs. It does not
+	// delay the deletion of the pod.
+	NegDrainedCondition = "cloud.google.com/load-balancer-neg-drained"
 )
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
+	negv1beta1 "k8s.io/ingress-gce/pkg/apis/svcneg/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 )
 
@@ -59,6 +60,10 @@ const (
 
 	// NEG CRD Enabled Garbage Collection Event Reasons
 	NegGCError = "NegCRError"
+
+	// InvalidNegCR is the event reason for ServiceNetworkEndpointGroups
+	// which do not declare a valid NEG.
+	InvalidNegCR = "InvalidNegCR"
 )
 
 // SvcPortTuple is the tuple representing one service port
@@ -131,6 +136,13 @@ type PortInfo struct {
 	// This is applicable in GCE_VM_IP NEGs where the endpoints are the nodes instead of pods.
 	// L7 NEGs will have either "" or L7Mode.
 	EpCalculatorMode EndpointsCalculatorMode
+
+	// NegType overrides the type of the NEG, if set. It is only set for NEGs
+	// declared by a ServiceNetworkEndpointGroup.
+	NegType NetworkEndpointType
+	// Zones restricts the NEG to the comma separated, sorted zones, if set.
+	// It is only set for NEGs declared by a ServiceNetworkEndpointGroup.
+	Zones string
 }
 
 // PortInfoMapKey is the Key of PortInfoMap
@@ -212,6 +224,38 @@ func NewPortInfoMapWithDestinationRule(namespace, name string, svcPortTupleSet S
 	return ret, nil
 }
 
+// NewPortInfoMapForServiceNetworkEndpointGroup creates the PortInfoMap of the
+// NEG declared by svcNeg for the service port tuple. The NEG is named after
+// svcNeg, and its key has the name of svcNeg as Subset, so that several NEGs
+// can be declared for the same service port. It returns an error if the name
+// of svcNeg is not a valid GCE resource name.
+func NewPortInfoMapForServiceNetworkEndpointGroup(tuple SvcPortTuple, svcNeg *negv1beta1.ServiceNetworkEndpointGroup) (PortInfoMap, error) {
+	if !namer.IsValidGCEResourceName(svcNeg.Name) {
+		return nil, fmt.Errorf("name %q is not a valid NEG name, it must be at most 63 characters long, start with a lowercase letter, end with a lowercase letter or a digit, and contain only lowercase letters, digits and dashes", svcNeg.Name)
+	}
+	negType := NetworkEndpointType(svcNeg.Spec.NetworkEndpointType)
+	switch negType {
+	case "", VmIpPortEndpointType, NonGCPPrivateEndpointType:
+	default:
+		return nil, fmt.Errorf("network endpoint type %q is not supported, must be %s or %s", negType, VmIpPortEndpointType, NonGCPPrivateEndpointType)
+	}
+	zones := sets.NewString(svcNeg.Spec.Zones...).List()
+	var subsetLabels string
+	if len(svcNeg.Spec.SubsetLabels) > 0 {
+		subsetLabels = labels.Set(svcNeg.Spec.SubsetLabels).String()
+	}
+	return PortInfoMap{
+		PortInfoMapKey{tuple.Port, svcNeg.Name}: PortInfo{
+			PortTuple:    tuple,
+			Subset:       svcNeg.Name,
+			SubsetLabels: subsetLabels,
+			NegName:      svcNeg.Name,
+			NegType:      negType,
+			Zones:        strings.Join(zones, ","),
+		},
+	}, nil
+}
+
 // Merge merges p2 into p1 PortInfoMap
 // It assumes the same key (service port) will have the same target port and negName
 // If not, it will throw error
@@ -244,6 +288,8 @@ func (p1 PortInfoMap) Merge(p2 PortInfoMap) error {
 		mergedInfo.EpCalculatorMode = portInfo.EpCalculatorMode
 		mergedInfo.Subset = portInfo.Subset
 		mergedInfo.SubsetLabels = portInfo.SubsetLabels
+		mergedInfo.NegType = portInfo.NegType
+		mergedInfo.Zones = portInfo.Zones
 
 		p1[mapKey] = mergedInfo
 	}
@@ -323,12 +369,23 @@ type NegSyncerKey struct {
 	//   The endpoints are nodes selected at random in case of Cluster trafficPolicy(L4ClusterMode).
 	//   The endpoints are nodes running backends of this service in case of Local trafficPolicy(L4LocalMode).
 	EpCalculatorMode EndpointsCalculatorMode
+
+	// Zones restricts the NEG to the comma separated zones, if set.
+	Zones string
 }
 
 func (key NegSyncerKey) String() string {
 	return fmt.Sprintf("%s/%s-%s-%s-%s-%s-%s", key.Namespace, key.Name, key.NegName, key.Subset, key.PortTuple.String(), string(key.NegType), key.EpCalculatorMode)
 }
 
+// ZoneList returns the zones the NEG is restricted to, nil if it is not.
+func (key NegSyncerKey) ZoneList() []string {
+	if key.Zones == "" {
+		return nil
+	}
+	return strings.Split(key.Zones, ",")
+}
+
 // GetAPIVersion returns the compute API version to be used in order
 // to create the negType specified in the given NegSyncerKey.
 func (key NegSyncerKey) GetAPIVersion() meta.Version {
@@ -344,3 +401,12 @@ func (key NegSyncerKey) GetAPIVersion() meta.Version {
 
 // EndpointPodMap is a map from network endpoint to a namespaced name of a pod
 type EndpointPodMap map[NetworkEndpoint]types.NamespacedName
+
+// ExternalNEGKey returns the key of the external NEG of a service port: a
+// regional key in region for serverless NEGs, a global key for Internet NEGs.
+func ExternalNEGKey(negName, region string, externalNEG *annotations.ExternalNEG) *meta.Key {
+	if externalNEG.IsServerless() {
+		return meta.RegionalKey(negName, region)
+	}
+	return meta.GlobalKey(negName)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networksecurity
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+)
+
+// FakeClient is an in memory implementation of Interface for tests.
+type FakeClient struct {
+	mu                sync.Mutex
+	serverTlsPolicies map[string]*ServerTlsPolicy
+	authConfigs       map[string]*BackendAuthenticationConfig
+}
+
+// NewFakeClient returns an empty FakeClient.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		serverTlsPolicies: map[string]*ServerTlsPolicy{},
+		authConfigs:       map[string]*BackendAuthenticationConfig{},
+	}
+}
+
+// ServerTlsPolicyLink implements Interface.
+func (f *FakeClient) ServerTlsPolicyLink(name string) string {
+	return fmt.Sprintf("projects/test-project/locations/global/serverTlsPolicies/%s", name)
+}
+
+// GetServerTlsPolicy implements Interface.
+func (f *FakeClient) GetServerTlsPolicy(name string) (*ServerTlsPolicy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	policy, ok := f.serverTlsPolicies[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	ret := *policy
+	return &ret, nil
+}
+
+// CreateServerTlsPolicy implements Interface.
+func (f *FakeClient) CreateServerTlsPolicy(name string, policy *ServerTlsPolicy) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.serverTlsPolicies[name]; ok {
+		return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("server TLS policy %s already exists", name)}
+	}
+	f.set(name, policy)
+	return nil
+}
+
+// UpdateServerTlsPolicy implements Interface.
+func (f *FakeClient) UpdateServerTlsPolicy(name string, policy *ServerTlsPolicy) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.serverTlsPolicies[name]; !ok {
+		return notFound(name)
+	}
+	f.set(name, policy)
+	return nil
+}
+
+// DeleteServerTlsPolicy implements Interface.
+func (f *FakeClient) DeleteServerTlsPolicy(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.serverTlsPolicies[name]; !ok {
+		return notFound(name)
+	}
+	delete(f.serverTlsPolicies, name)
+	return nil
+}
+
+func (f *FakeClient) set(name string, policy *ServerTlsPolicy) {
+	stored := *policy
+	if policy.MtlsPolicy != nil {
+		mtls := *policy.MtlsPolicy
+		stored.MtlsPolicy = &mtls
+	}
+	stored.Name = f.ServerTlsPolicyLink(name)
+	f.serverTlsPolicies[name] = &stored
+}
+
+// BackendAuthenticationConfigLink implements Interface.
+func (f *FakeClient) BackendAuthenticationConfigLink(name string) string {
+	return fmt.Sprintf("projects/test-project/locations/global/backendAuthenticationConfigs/%s", name)
+}
+
+// GetBackendAuthenticationConfig implements Interface.
+func (f *FakeClient) GetBackendAuthenticationConfig(name string) (*BackendAuthenticationConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	config, ok := f.authConfigs[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	ret := *config
+	return &ret, nil
+}
+
+// CreateBackendAuthenticationConfig implements Interface.
+func (f *FakeClient) CreateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.authConfigs[name]; ok {
+		return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("backend authentication config %s already exists", name)}
+	}
+	f.setAuthConfig(name, config)
+	return nil
+}
+
+// UpdateBackendAuthenticationConfig implements Interface.
+func (f *FakeClient) UpdateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.authConfigs[name]; !ok {
+		return notFound(name)
+	}
+	f.setAuthConfig(name, config)
+	return nil
+}
+
+// DeleteBackendAuthenticationConfig implements Interface.
+func (f *FakeClient) DeleteBackendAuthenticationConfig(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.authConfigs[name]; !ok {
+		return notFound(name)
+	}
+	delete(f.authConfigs, name)
+	return nil
+}
+
+func (f *FakeClient) setAuthConfig(name string, config *BackendAuthenticationConfig) {
+	stored := *config
+	stored.Name = f.BackendAuthenticationConfigLink(name)
+	f.authConfigs[name] = &stored
+}
+
+func notFound(name string) error {
+	return &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("%s was not found", name)}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package networksecurity manages the global resources of the Network
+// Security API which the load balancers of the controller use.
+package networksecurity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/ingress-gce/pkg/utils/gcprest"
+	"k8s.io/klog"
+)
+
+const basePath = "https://networksecurity.googleapis.com/v1/"
+
+// ServerTlsPolicy describes how a target HTTPS proxy validates client
+// certificates.
+type ServerTlsPolicy struct {
+	// Name is the relative resource name of the policy, e.g.
+	// projects/p/locations/global/serverTlsPolicies/name. It is ignored on
+	// create and update.
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	MtlsPolicy  *MtlsPolicy `json:"mtlsPolicy,omitempty"`
+}
+
+// MtlsPolicy is the mutual TLS configuration of a server TLS policy.
+type MtlsPolicy struct {
+	// ClientValidationMode is REJECT_INVALID or
+	// ALLOW_INVALID_OR_MISSING_CLIENT_CERT.
+	ClientValidationMode string `json:"clientValidationMode,omitempty"`
+	// ClientValidationTrustConfig is the relative resource name of the
+	// Certificate Manager trust config of the policy.
+	ClientValidationTrustConfig string `json:"clientValidationTrustConfig,omitempty"`
+}
+
+// BackendAuthenticationConfig describes how a load balancer validates the
+// certificates of its backends, and which certificate it presents to them.
+type BackendAuthenticationConfig struct {
+	// Name is the relative resource name of the config, e.g.
+	// projects/p/locations/global/backendAuthenticationConfigs/name. It is
+	// ignored on create and update.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// TrustConfig is the relative resource name of the Certificate Manager
+	// trust config which backend certificates are validated against.
+	TrustConfig string `json:"trustConfig,omitempty"`
+	// ClientCertificate is the relative resource name of the Certificate
+	// Manager certificate presented to the backends.
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	// WellKnownRoots is PUBLIC_ROOTS or NONE.
+	WellKnownRoots string `json:"wellKnownRoots,omitempty"`
+}
+
+// Interface manages Network Security resources. Resources are identified
+// by their name, the project and location of the client are implied.
+type Interface interface {
+	// ServerTlsPolicyLink returns the relative resource name of the policy.
+	ServerTlsPolicyLink(name string) string
+	// GetServerTlsPolicy returns the policy. A not found error is returned
+	// if it does not exist.
+	GetServerTlsPolicy(name string) (*ServerTlsPolicy, error)
+	// CreateServerTlsPolicy creates the policy.
+	CreateServerTlsPolicy(name string, policy *ServerTlsPolicy) error
+	// UpdateServerTlsPolicy replaces the description and mTLS policy of the
+	// policy.
+	UpdateServerTlsPolicy(name string, policy *ServerTlsPolicy) error
+	// DeleteServerTlsPolicy deletes the policy.
+	DeleteServerTlsPolicy(name string) error
+
+	// BackendAuthenticationConfigLink returns the relative resource name of
+	// the config.
+	BackendAuthenticationConfigLink(name string) string
+	// GetBackendAuthenticationConfig returns the config. A not found error is
+	// returned if it does not exist.
+	GetBackendAuthenticationConfig(name string) (*BackendAuthenticationConfig, error)
+	// CreateBackendAuthenticationConfig creates the config.
+	CreateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error
+	// UpdateBackendAuthenticationConfig replaces the description, trust
+	// config, client certificate and well known roots of the config.
+	UpdateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error
+	// DeleteBackendAuthenticationConfig deletes the config.
+	DeleteBackendAuthenticationConfig(name string) error
+}
+
+// Client implements Interface with the Network Security API.
+type Client struct {
+	rest    *gcprest.Client
+	project string
+}
+
+// NewClient returns a Client for the global resources of project. Changes
+// return a *gcprest.OperationInProgressError until their operation is done.
+func NewClient(config *gcprest.Config, project string) *Client {
+	return &Client{rest: gcprest.NewClient(config, "NetworkSecurity", basePath), project: project}
+}
+
+// ServerTlsPolicyLink implements Interface.
+func (c *Client) ServerTlsPolicyLink(name string) string {
+	return fmt.Sprintf("projects/%s/locations/global/serverTlsPolicies/%s", c.project, name)
+}
+
+// GetServerTlsPolicy implements Interface.
+func (c *Client) GetServerTlsPolicy(name string) (*ServerTlsPolicy, error) {
+	ret := &ServerTlsPolicy{}
+	if err := c.rest.Get(context.Background(), c.ServerTlsPolicyLink(name), ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CreateServerTlsPolicy implements Interface.
+func (c *Client) CreateServerTlsPolicy(name string, policy *ServerTlsPolicy) error {
+	klog.V(3).Infof("Creating server TLS policy %s", name)
+	path := fmt.Sprintf("projects/%s/locations/global/serverTlsPolicies?serverTlsPolicyId=%s", c.project, name)
+	return c.rest.Start(context.Background(), http.MethodPost, path, c.ServerTlsPolicyLink(name), withoutName(policy))
+}
+
+// UpdateServerTlsPolicy implements Interface.
+func (c *Client) UpdateServerTlsPolicy(name string, policy *ServerTlsPolicy) error {
+	klog.V(3).Infof("Updating server TLS policy %s", name)
+	path := c.ServerTlsPolicyLink(name) + "?updateMask=description,mtlsPolicy"
+	return c.rest.Start(context.Background(), http.MethodPatch, path, c.ServerTlsPolicyLink(name), withoutName(policy))
+}
+
+// DeleteServerTlsPolicy implements Interface.
+func (c *Client) DeleteServerTlsPolicy(name string) error {
+	klog.V(3).Infof("Deleting server TLS policy %s", name)
+	return c.rest.Start(context.Background(), http.MethodDelete, c.ServerTlsPolicyLink(name), c.ServerTlsPolicyLink(name), nil)
+}
+
+// BackendAuthenticationConfigLink implements Interface.
+func (c *Client) BackendAuthenticationConfigLink(name string) string {
+	return fmt.Sprintf("projects/%s/locations/global/backendAuthenticationConfigs/%s", c.project, name)
+}
+
+// GetBackendAuthenticationConfig implements Interface.
+func (c *Client) GetBackendAuthenticationConfig(name string) (*BackendAuthenticationConfig, error) {
+	ret := &BackendAuthenticationConfig{}
+	if err := c.rest.Get(context.Background(), c.BackendAuthenticationConfigLink(name), ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CreateBackendAuthenticationConfig implements Interface.
+func (c *Client) CreateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error {
+	klog.V(3).Infof("Creating backend authentication config %s", name)
+	path := fmt.Sprintf("projects/%s/locations/global/backendAuthenticationConfigs?backendAuthenticationConfigId=%s", c.project, name)
+	body := *config
+	body.Name = ""
+	return c.rest.Start(context.Background(), http.MethodPost, path, c.BackendAuthenticationConfigLink(name), &body)
+}
+
+// UpdateBackendAuthenticationConfig implements Interface.
+func (c *Client) UpdateBackendAuthenticationConfig(name string, config *BackendAuthenticationConfig) error {
+	klog.V(3).Infof("Updating backend authentication config %s", name)
+	path := c.BackendAuthenticationConfigLink(name) + "?updateMask=description,trustConfig,clientCertificate,wellKnownRoots"
+	body := *config
+	body.Name = ""
+	return c.rest.Start(context.Background(), http.MethodPatch, path, c.BackendAuthenticationConfigLink(name), &body)
+}
+
+// DeleteBackendAuthenticationConfig implements Interface.
+func (c *Client) DeleteBackendAuthenticationConfig(name string) error {
+	klog.V(3).Infof("Deleting backend authentication config %s", name)
+	return c.rest.Start(context.Background(), http.MethodDelete, c.BackendAuthenticationConfigLink(name), c.BackendAuthenticationConfigLink(name), nil)
+}
+
+func withoutName(policy *ServerTlsPolicy) *ServerTlsPolicy {
+	ret := *policy
+	ret.Name = ""
+	return &ret
+}
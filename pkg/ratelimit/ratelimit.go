@@ -25,6 +25,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog"
 )
@@ -98,7 +99,16 @@ func (l *GCERateLimiter) Accept(ctx context.Context, key *cloud.RateLimitKey) er
 		}
 	}
 
-	return rl.Accept(ctx, key)
+	start := time.Now()
+	err := rl.Accept(ctx, key)
+	// Annotate the span of the GCE call, if any, with the time spent waiting.
+	trace.FromContext(ctx).Annotate([]trace.Attribute{
+		trace.StringAttribute("service", key.Service),
+		trace.StringAttribute("operation", key.Operation),
+		trace.StringAttribute("version", string(key.Version)),
+		trace.Int64Attribute("waitMillis", time.Since(start).Milliseconds()),
+	}, "Accepted by rate limiter")
+	return err
 }
 
 // rateLimitImpl returns the flowcontrol.RateLimiter implementation
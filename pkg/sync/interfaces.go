@@ -17,6 +17,8 @@ limitations under the License.
 package sync
 
 import (
+	"context"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/api/networking/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
@@ -25,7 +27,8 @@ import (
 // Syncer is an interface to sync GCP resources associated with an Ingress.
 type Syncer interface {
 	// Sync creates a full GCLB given some state related to an Ingress.
-	Sync(state interface{}) error
+	// ctx carries the span of the sync.
+	Sync(ctx context.Context, state interface{}) error
 	// GC cleans up GCLB resources for all Ingresses and can optionally
 	// use some arbitrary to help with the process.
 	// GC workflow performs frontend resource deletion based on given gc algorithm.
@@ -38,11 +41,11 @@ type Syncer interface {
 // on how to sync the various portions of the GCLB for an Ingress.
 type Controller interface {
 	// SyncBackends syncs the backends for a GCLB given some existing state.
-	SyncBackends(state interface{}) error
+	SyncBackends(ctx context.Context, state interface{}) error
 	// GCBackends garbage collects backends for all ingresses given a list of ingresses to exclude from GC.
 	GCBackends(toKeep []*v1beta1.Ingress) error
 	// SyncLoadBalancer syncs the front-end load balancer resources for a GCLB given some existing state.
-	SyncLoadBalancer(state interface{}) error
+	SyncLoadBalancer(ctx context.Context, state interface{}) error
 	// GCv1LoadBalancers garbage collects front-end load balancer resources for all ingresses
 	// given a list of ingresses with v1 naming policy to exclude from GC.
 	GCv1LoadBalancers(toKeep []*v1beta1.Ingress) error
@@ -50,7 +53,7 @@ type Controller interface {
 	// with v2 naming policy.
 	GCv2LoadBalancer(ing *v1beta1.Ingress, scope meta.KeyType) error
 	// PostProcess allows for doing some post-processing after an Ingress is synced to a GCLB.
-	PostProcess(state interface{}) error
+	PostProcess(ctx context.Context, state interface{}) error
 	// EnsureDeleteV1Finalizers ensures that v1 finalizers are removed for given list of ingresses.
 	EnsureDeleteV1Finalizers(toCleanup []*v1beta1.Ingress) error
 	// EnsureDeleteV2Finalizer ensures that v2 finalizer is removed for given ingress.
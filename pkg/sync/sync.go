@@ -17,12 +17,15 @@ limitations under the License.
 package sync
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"k8s.io/api/networking/v1beta1"
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/common/operator"
+	"k8s.io/ingress-gce/pkg/tracing"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/ingress-gce/pkg/utils/namer"
@@ -45,25 +48,37 @@ func NewIngressSyncer(controller Controller) Syncer {
 }
 
 // Sync implements Syncer.
-func (s *IngressSyncer) Sync(state interface{}) error {
-	if err := s.controller.SyncBackends(state); err != nil {
+func (s *IngressSyncer) Sync(ctx context.Context, state interface{}) error {
+	if err := s.runPhase(ctx, "SyncBackends", state, s.controller.SyncBackends); err != nil {
 		if err == ErrSkipBackendsSync {
 			return nil
 		}
 		return fmt.Errorf("error running backend syncing routine: %v", err)
 	}
 
-	if err := s.controller.SyncLoadBalancer(state); err != nil {
+	if err := s.runPhase(ctx, "SyncLoadBalancer", state, s.controller.SyncLoadBalancer); err != nil {
 		return fmt.Errorf("error running load balancer syncing routine: %v", err)
 	}
 
-	if err := s.controller.PostProcess(state); err != nil {
+	if err := s.runPhase(ctx, "PostProcess", state, s.controller.PostProcess); err != nil {
 		return fmt.Errorf("error running post-process routine: %v", err)
 	}
 
 	return nil
 }
 
+// runPhase runs a phase of the sync in a child span of ctx.
+func (s *IngressSyncer) runPhase(ctx context.Context, name string, state interface{}, phase func(context.Context, interface{}) error) error {
+	ctx, span := tracing.StartSpan(ctx, name)
+	err := phase(ctx, state)
+	if err == ErrSkipBackendsSync {
+		tracing.EndSpan(span, nil)
+	} else {
+		tracing.EndSpan(span, err)
+	}
+	return err
+}
+
 // GC implements Syncer.
 func (s *IngressSyncer) GC(ings []*v1beta1.Ingress, currIng *v1beta1.Ingress, frontendGCAlgorithm utils.FrontendGCAlgorithm, scope meta.KeyType) error {
 	var lbErr, err error
@@ -89,7 +104,7 @@ func (s *IngressSyncer) GC(ings []*v1beta1.Ingress, currIng *v1beta1.Ingress, fr
 			return namer.FrontendNamingScheme(ing) == namer.V1NamingScheme
 		})
 		// Partition these into ingresses those need cleanup and those don't.
-		toCleanupV1, toKeepV1 := v1Ingresses.Partition(utils.NeedsCleanup)
+		toCleanupV1, toKeepV1 := v1Ingresses.Partition(needsCleanup)
 		// Note that only GCE ingress associated resources are managed by this controller.
 		toKeepV1Gce := toKeepV1.Filter(utils.IsGCEIngress)
 		lbErr = s.controller.GCv1LoadBalancers(toKeepV1Gce.AsList())
@@ -113,8 +128,9 @@ func (s *IngressSyncer) GC(ings []*v1beta1.Ingress, currIng *v1beta1.Ingress, fr
 	// 1) It is a GCLB Ingress.
 	// 2) It is not a deletion candidate. A deletion candidate is an ingress
 	//    with deletion stamp and a finalizer.
+	// 3) Or its reconciliation is paused and it is not being deleted.
 	toKeep := operator.Ingresses(ings).Filter(func(ing *v1beta1.Ingress) bool {
-		return !utils.NeedsCleanup(ing)
+		return !needsCleanup(ing)
 	}).AsList()
 	if beErr := s.controller.GCBackends(toKeep); beErr != nil {
 		errs = append(errs, fmt.Errorf("error running backend garbage collection routine: %v", beErr))
@@ -124,3 +140,13 @@ func (s *IngressSyncer) GC(ings []*v1beta1.Ingress, currIng *v1beta1.Ingress, fr
 	}
 	return err
 }
+
+// needsCleanup returns true if the resources of the ingress need to be
+// cleaned up. Resources of ingresses with paused reconciliation are only
+// cleaned up when the ingress is deleted.
+func needsCleanup(ing *v1beta1.Ingress) bool {
+	if annotations.FromIngress(ing).PauseReconciliation() && !common.IsDeletionCandidate(ing.ObjectMeta) {
+		return false
+	}
+	return utils.NeedsCleanup(ing)
+}
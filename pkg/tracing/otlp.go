@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
+	"k8s.io/klog"
+)
+
+const (
+	// serviceName is reported as the service.name resource attribute.
+	serviceName = "glbc"
+	// instrumentationScope is the name of the instrumentation scope.
+	instrumentationScope = "k8s.io/ingress-gce"
+
+	otlpBufferSize    = 2048
+	otlpMaxBatchSize  = 512
+	otlpFlushInterval = 5 * time.Second
+	otlpTimeout       = 10 * time.Second
+
+	// OTLP span kinds.
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+
+	// OTLP status codes.
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+// OTLPExporter sends spans to an OpenTelemetry collector using OTLP over HTTP
+// with JSON encoding. Spans are batched and sent asynchronously.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+	spans    chan *trace.SpanData
+}
+
+// NewOTLPExporter returns an exporter that sends spans to endpoint, e.g.
+// "http://localhost:4318/v1/traces", until stopCh is closed.
+func NewOTLPExporter(endpoint string, stopCh <-chan struct{}) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: otlpTimeout},
+		spans:    make(chan *trace.SpanData, otlpBufferSize),
+	}
+	go e.run(stopCh)
+	return e
+}
+
+// ExportSpan implements trace.Exporter.
+func (e *OTLPExporter) ExportSpan(s *trace.SpanData) {
+	select {
+	case e.spans <- s:
+	default:
+		klog.V(4).Infof("OTLP span buffer is full, dropping span %q", s.Name)
+	}
+}
+
+func (e *OTLPExporter) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*trace.SpanData
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			klog.Errorf("Failed to export %d span(s) to %s: %v", len(batch), e.endpoint, err)
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case s := <-e.spans:
+			batch = append(batch, s)
+			if len(batch) >= otlpMaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-stopCh:
+			// Send the spans that are still buffered.
+			for len(e.spans) > 0 {
+				batch = append(batch, <-e.spans)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+func (e *OTLPExporter) send(batch []*trace.SpanData) error {
+	data, err := json.Marshal(toOTLPRequest(batch))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %q", resp.Status)
+	}
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP ExportTraceServiceRequest.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	// IntValue is a string as 64 bit integers are encoded as decimal strings.
+	IntValue *string `json:"intValue,omitempty"`
+}
+
+func toOTLPRequest(batch []*trace.SpanData) *otlpRequest {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		spans = append(spans, toOTLPSpan(s))
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: toOTLPAttributes(map[string]interface{}{"service.name": serviceName}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: instrumentationScope},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func toOTLPSpan(s *trace.SpanData) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.TraceID[:]),
+		SpanID:            hex.EncodeToString(s.SpanID[:]),
+		Name:              s.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: unixNano(s.StartTime),
+		EndTimeUnixNano:   unixNano(s.EndTime),
+		Attributes:        toOTLPAttributes(s.Attributes),
+		Status:            otlpStatus{Code: otlpStatusCodeOk},
+	}
+	if s.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+	}
+	switch s.SpanKind {
+	case trace.SpanKindServer:
+		span.Kind = otlpSpanKindServer
+	case trace.SpanKindClient:
+		span.Kind = otlpSpanKindClient
+	}
+	if s.Code != trace.StatusCodeOK {
+		span.Status = otlpStatus{Code: otlpStatusCodeError, Message: s.Message}
+	}
+	for _, a := range s.Annotations {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: unixNano(a.Time),
+			Name:         a.Message,
+			Attributes:   toOTLPAttributes(a.Attributes),
+		})
+	}
+	return span
+}
+
+func toOTLPAttributes(attrs map[string]interface{}) []otlpKeyValue {
+	var result []otlpKeyValue
+	for k, v := range attrs {
+		kv := otlpKeyValue{Key: k}
+		switch val := v.(type) {
+		case string:
+			kv.Value.StringValue = &val
+		case bool:
+			kv.Value.BoolValue = &val
+		case int64:
+			s := strconv.FormatInt(val, 10)
+			kv.Value.IntValue = &s
+		default:
+			s := fmt.Sprint(val)
+			kv.Value.StringValue = &s
+		}
+		result = append(result, kv)
+	}
+	return result
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
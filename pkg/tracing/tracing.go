@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing provides spans for the controller sync loops and the GCE
+// API calls they make. Tracing is disabled until Init is called; all helpers
+// are no-ops in that case.
+//
+// Sync loops start a span with StartSpan and pass the returned context down
+// to the GCE calls they make, e.g. through the WithContext functions of the
+// composite layer. Spans for the calls become children of the span in that
+// context. Calls made directly through gce.Cloud, such as those on instance
+// groups and health checks, take no context and have no span.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
+)
+
+// Attribute keys used on spans.
+const (
+	KeyAttribute       = "key"
+	ResourceAttribute  = "gce.resource"
+	ServiceAttribute   = "gce.service"
+	OperationAttribute = "gce.operation"
+	VersionAttribute   = "gce.version"
+	ScopeAttribute     = "gce.scope"
+)
+
+var (
+	lock    sync.Mutex
+	enabled bool
+)
+
+// Init enables tracing. Spans are sampled with the given probability and sent
+// to exporter. It returns an error if sampleFraction is not within [0, 1].
+func Init(exporter trace.Exporter, sampleFraction float64) error {
+	if sampleFraction < 0 || sampleFraction > 1 {
+		return fmt.Errorf("sample fraction %v is not within [0, 1]", sampleFraction)
+	}
+	trace.RegisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(sampleFraction)})
+
+	lock.Lock()
+	defer lock.Unlock()
+	enabled = true
+	return nil
+}
+
+// Enabled returns true if tracing has been initialized.
+func Enabled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return enabled
+}
+
+// StartSpan starts a span with the given name as a child of the span in ctx,
+// or as a root span if ctx has none.
+func StartSpan(ctx context.Context, name string, attrs ...trace.Attribute) (context.Context, *trace.Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	ctx, span := trace.StartSpan(ctx, name)
+	span.AddAttributes(attrs...)
+	return ctx, span
+}
+
+// EndSpan records err on span, if any, and ends it.
+func EndSpan(span *trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.End()
+}
+
+// ObserveSpan records the result of a GCE call on span, ends it and returns
+// err unchanged.
+func ObserveSpan(span *trace.Span, err error) error {
+	EndSpan(span, err)
+	return err
+}
+
+// StartGCESpan starts a span for a GCE API call on the resource with key, as
+// a child of the span in ctx. ctx may be nil. The returned context is derived
+// from callCtx and must be used for the call so that the rate limiter can
+// annotate the span.
+func StartGCESpan(ctx, callCtx context.Context, service, operation string, key *meta.Key, version meta.Version) (context.Context, *trace.Span) {
+	if !Enabled() {
+		return callCtx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if version == "" {
+		version = meta.VersionGA
+	}
+	_, span := trace.StartSpan(ctx, service+"."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	span.AddAttributes(
+		trace.StringAttribute(ResourceAttribute, key.Name),
+		trace.StringAttribute(ServiceAttribute, service),
+		trace.StringAttribute(OperationAttribute, operation),
+		trace.StringAttribute(VersionAttribute, string(version)),
+		trace.StringAttribute(ScopeAttribute, string(key.Type())),
+	)
+	return trace.NewContext(callCtx, span), span
+}
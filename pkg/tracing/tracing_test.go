@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"go.opencensus.io/trace"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type fakeExporter struct {
+	lock  sync.Mutex
+	spans map[string]*trace.SpanData
+}
+
+func (e *fakeExporter) ExportSpan(s *trace.SpanData) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans[s.Name] = s
+}
+
+func (e *fakeExporter) span(t *testing.T, name string) *trace.SpanData {
+	t.Helper()
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	s, ok := e.spans[name]
+	if !ok {
+		t.Fatalf("Span %q was not exported", name)
+	}
+	return s
+}
+
+// enableTracing enables tracing with an in-memory exporter until the returned
+// func is called.
+func enableTracing(t *testing.T) (*fakeExporter, func()) {
+	e := &fakeExporter{spans: map[string]*trace.SpanData{}}
+	if err := Init(e, 1.0); err != nil {
+		t.Fatalf("Init() = %v, want nil", err)
+	}
+	return e, func() {
+		trace.UnregisterExporter(e)
+		lock.Lock()
+		defer lock.Unlock()
+		enabled = false
+	}
+}
+
+func TestInitSampleFraction(t *testing.T) {
+	for _, fraction := range []float64{-0.1, 1.5} {
+		if err := Init(&fakeExporter{}, fraction); err == nil {
+			t.Errorf("Init(_, %v) = nil, want error", fraction)
+		}
+		if Enabled() {
+			t.Fatalf("Init(_, %v) enabled tracing", fraction)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	ctx, span := StartSpan(context.Background(), "sync")
+	if span != nil {
+		t.Errorf("StartSpan() returned span %v, want nil when tracing is disabled", span)
+	}
+	callCtx := context.Background()
+	gceCtx, gceSpan := StartGCESpan(ctx, callCtx, "BackendServices", "Get", meta.GlobalKey("foo"), meta.VersionGA)
+	if gceSpan != nil {
+		t.Errorf("StartGCESpan() returned span %v, want nil when tracing is disabled", gceSpan)
+	}
+	if gceCtx != callCtx {
+		t.Errorf("StartGCESpan() returned context %v, want the call context", gceCtx)
+	}
+	// All helpers must accept nil spans.
+	wantErr := fmt.Errorf("error")
+	if err := ObserveSpan(gceSpan, wantErr); err != wantErr {
+		t.Errorf("ObserveSpan(nil, %v) = %v, want %v", wantErr, err, wantErr)
+	}
+}
+
+func TestGCESpanParent(t *testing.T) {
+	exporter, disable := enableTracing(t)
+	defer disable()
+
+	syncCtx, syncSpan := StartSpan(context.Background(), "sync", trace.StringAttribute(KeyAttribute, "default/ing"))
+	batchCtx, batchSpan := StartSpan(syncCtx, "AttachNetworkEndpoints")
+
+	// Calls are traced under the span of the context they are given, even
+	// when they run concurrently on the same resource.
+	callCtx, zoneASpan := StartGCESpan(batchCtx, context.Background(), "NetworkEndpointGroups", "AttachNetworkEndpoints", meta.ZonalKey("neg", "zone-a"), "")
+	if trace.FromContext(callCtx) != zoneASpan {
+		t.Errorf("StartGCESpan() returned a context without the call span")
+	}
+	_, zoneBSpan := StartGCESpan(syncCtx, context.Background(), "NetworkEndpointGroups", "Get", meta.ZonalKey("neg", "zone-a"), meta.VersionBeta)
+	ObserveSpan(zoneBSpan, nil)
+	ObserveSpan(zoneASpan, fmt.Errorf("quota exceeded"))
+	EndSpan(batchSpan, nil)
+
+	// Calls without a parent span start their own trace.
+	_, otherSpan := StartGCESpan(nil, context.Background(), "NetworkEndpointGroups", "Delete", meta.ZonalKey("neg", "zone-a"), "")
+	ObserveSpan(otherSpan, nil)
+	EndSpan(syncSpan, nil)
+
+	syncData := exporter.span(t, "sync")
+	batch := exporter.span(t, "AttachNetworkEndpoints")
+	zoneA := exporter.span(t, "NetworkEndpointGroups.AttachNetworkEndpoints")
+	zoneB := exporter.span(t, "NetworkEndpointGroups.Get")
+	other := exporter.span(t, "NetworkEndpointGroups.Delete")
+
+	if batch.ParentSpanID != syncData.SpanID {
+		t.Errorf("Batch span parent = %v, want %v", batch.ParentSpanID, syncData.SpanID)
+	}
+	if zoneA.ParentSpanID != batch.SpanID {
+		t.Errorf("Attach call span parent = %v, want batch span %v", zoneA.ParentSpanID, batch.SpanID)
+	}
+	if zoneB.ParentSpanID != syncData.SpanID {
+		t.Errorf("Get call span parent = %v, want sync span %v", zoneB.ParentSpanID, syncData.SpanID)
+	}
+	if other.TraceID == syncData.TraceID {
+		t.Errorf("Call span without parent has trace ID %v of the sync span", other.TraceID)
+	}
+
+	wantAttrs := map[string]interface{}{
+		ResourceAttribute:  "neg",
+		ServiceAttribute:   "NetworkEndpointGroups",
+		OperationAttribute: "AttachNetworkEndpoints",
+		VersionAttribute:   "ga",
+		ScopeAttribute:     "zonal",
+	}
+	for k, want := range wantAttrs {
+		if got := zoneA.Attributes[k]; got != want {
+			t.Errorf("Attribute %q = %v, want %v", k, got, want)
+		}
+	}
+	if zoneA.Code == trace.StatusCodeOK || zoneA.Message != "quota exceeded" {
+		t.Errorf("Failed call span status = %d %q, want error with message %q", zoneA.Code, zoneA.Message, "quota exceeded")
+	}
+	if zoneB.Code != trace.StatusCodeOK {
+		t.Errorf("Successful call span status = %d, want OK", zoneB.Code)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan *otlpRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := &otlpRequest{}
+		if err := json.NewDecoder(req.Body).Decode(r); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		received <- r
+	}))
+	defer collector.Close()
+
+	stopCh := make(chan struct{})
+	exporter := NewOTLPExporter(collector.URL, stopCh)
+	exporter.ExportSpan(&trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		},
+		ParentSpanID: trace.SpanID{3},
+		Name:         "BackendServices.Update",
+		SpanKind:     trace.SpanKindClient,
+		StartTime:    time.Unix(0, 1),
+		EndTime:      time.Unix(0, 2),
+		Attributes:   map[string]interface{}{ResourceAttribute: "k8s-be-30000"},
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "not found"},
+	})
+	// Stopping the exporter flushes pending spans.
+	close(stopCh)
+
+	var r *otlpRequest
+	select {
+	case r = <-received:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("Timed out waiting for spans")
+	}
+	if len(r.ResourceSpans) != 1 || len(r.ResourceSpans[0].ScopeSpans) != 1 || len(r.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("Got request %+v, want a single span", r)
+	}
+	got := r.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if got.TraceID != "01000000000000000000000000000000" || got.SpanID != "0200000000000000" || got.ParentSpanID != "0300000000000000" {
+		t.Errorf("Got IDs trace=%q span=%q parent=%q", got.TraceID, got.SpanID, got.ParentSpanID)
+	}
+	if got.Name != "BackendServices.Update" || got.Kind != otlpSpanKindClient {
+		t.Errorf("Got name %q kind %d, want %q kind %d", got.Name, got.Kind, "BackendServices.Update", otlpSpanKindClient)
+	}
+	if got.StartTimeUnixNano != "1" || got.EndTimeUnixNano != "2" {
+		t.Errorf("Got times %q-%q, want 1-2", got.StartTimeUnixNano, got.EndTimeUnixNano)
+	}
+	if got.Status.Code != otlpStatusCodeError || got.Status.Message != "not found" {
+		t.Errorf("Got status %+v, want error %q", got.Status, "not found")
+	}
+	if len(got.Attributes) != 1 || got.Attributes[0].Key != ResourceAttribute || *got.Attributes[0].Value.StringValue != "k8s-be-30000" {
+		t.Errorf("Got attributes %+v, want %s=k8s-be-30000", got.Attributes, ResourceAttribute)
+	}
+}
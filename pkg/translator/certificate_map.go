@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+)
+
+// certificateMapService prefixes the relative resource name of a certificate
+// map in the certificateMap of a target https proxy.
+const certificateMapService = "//certificatemanager.googleapis.com/"
+
+// certificateMapNameRegexp matches the relative resource name of a certificate
+// map, optionally prefixed with its service.
+var certificateMapNameRegexp = regexp.MustCompile(`^(//certificatemanager\.googleapis\.com/)?projects/[^/]+/locations/global/certificateMaps/[^/]+$`)
+
+// ToCertificateMap returns the link of the certificate map which the target
+// https proxy serves, empty if the Ingress uses SSL certificates. The map is
+// taken from the frontend config, or else from the certificate map annotation
+// of the Ingress. A map given by name is looked up in env.Project.
+func (t *Translator) ToCertificateMap(env *Env, annotation string) (string, error) {
+	var certMap, source string
+	if fc := env.FrontendConfig; fc != nil && fc.Spec.CertificateMap != nil {
+		certMap = *fc.Spec.CertificateMap
+		source = fmt.Sprintf("FrontendConfig %s/%s", fc.Namespace, fc.Name)
+		if annotation != "" && annotation != certMap {
+			return "", fmt.Errorf("certificate map %q of %s conflicts with %q of annotation %s", certMap, source, annotation, annotations.CertificateMapKey)
+		}
+	} else {
+		certMap = annotation
+		source = fmt.Sprintf("annotation %s", annotations.CertificateMapKey)
+	}
+	if certMap == "" {
+		return "", nil
+	}
+	if t.IsL7ILB || t.IsL7XLBRegional {
+		return "", fmt.Errorf("certificate map of %s is only supported for global external Ingresses", source)
+	}
+
+	if !strings.Contains(certMap, "/") {
+		return fmt.Sprintf("%sprojects/%s/locations/global/certificateMaps/%s", certificateMapService, env.Project, certMap), nil
+	}
+	if !certificateMapNameRegexp.MatchString(certMap) {
+		return "", fmt.Errorf("invalid certificate map %q in %s, must be a name or projects/PROJECT/locations/global/certificateMaps/NAME", certMap, source)
+	}
+	if !strings.HasPrefix(certMap, certificateMapService) {
+		certMap = certificateMapService + certMap
+	}
+	return certMap, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"testing"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+func TestToCertificateMap(t *testing.T) {
+	t.Parallel()
+
+	newFrontendConfig := func(certMap string) *frontendconfigv1beta1.FrontendConfig {
+		return &frontendconfigv1beta1.FrontendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{Namespace: "ns", Name: "fc"},
+			Spec:       frontendconfigv1beta1.FrontendConfigSpec{CertificateMap: &certMap},
+		}
+	}
+	const link = "//certificatemanager.googleapis.com/projects/test-project/locations/global/certificateMaps/map"
+
+	for _, tc := range []struct {
+		desc       string
+		fc         *frontendconfigv1beta1.FrontendConfig
+		annotation string
+		regional   bool
+		want       string
+		wantErr    bool
+	}{
+		{
+			desc: "no certificate map",
+			fc:   &frontendconfigv1beta1.FrontendConfig{},
+		},
+		{
+			desc:       "name in annotation",
+			annotation: "map",
+			want:       link,
+		},
+		{
+			desc: "name in frontend config",
+			fc:   newFrontendConfig("map"),
+			want: link,
+		},
+		{
+			desc:       "same map in frontend config and annotation",
+			fc:         newFrontendConfig("map"),
+			annotation: "map",
+			want:       link,
+		},
+		{
+			desc:       "relative resource name",
+			annotation: "projects/other-project/locations/global/certificateMaps/map",
+			want:       "//certificatemanager.googleapis.com/projects/other-project/locations/global/certificateMaps/map",
+		},
+		{
+			desc:       "full resource name",
+			annotation: link,
+			want:       link,
+		},
+		{
+			desc:       "conflicting maps",
+			fc:         newFrontendConfig("map"),
+			annotation: "other-map",
+			wantErr:    true,
+		},
+		{
+			desc:       "regional map",
+			annotation: "projects/test-project/locations/us-central1/certificateMaps/map",
+			wantErr:    true,
+		},
+		{
+			desc:       "regional ingress",
+			annotation: "map",
+			regional:   true,
+			wantErr:    true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewTranslator(tc.regional, &testNamer{"foo"})
+			got, err := tr.ToCertificateMap(&Env{FrontendConfig: tc.fc, Project: "test-project"}, tc.annotation)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ToCertificateMap() = _, %v, want err? %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToCertificateMap() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// parseTLSCertificate validates the PEM encoded certificate chain cert
+// against the private key and returns its leaf certificate. The chain starts
+// with the certificate of the key, and every following certificate must have
+// signed the one before it.
+func parseTLSCertificate(cert, key string) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]*x509.Certificate, len(pair.Certificate))
+	for i, der := range pair.Certificate {
+		if chain[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, fmt.Errorf("certificate %d of the chain: %v", i, err)
+		}
+	}
+	for i := 1; i < len(chain); i++ {
+		if err := chain[i-1].CheckSignatureFrom(chain[i]); err != nil {
+			return nil, fmt.Errorf("certificate %d of the chain (%q) is not signed by the next one (%q): %v", i-1, chain[i-1].Subject, chain[i].Subject, err)
+		}
+	}
+	return chain[0], nil
+}
+
+// ExpiryWarningThreshold returns the smallest of the thresholds which the
+// time left until notAfter has fallen below, and false if it is above all of
+// them.
+func ExpiryWarningThreshold(notAfter, now time.Time, thresholds []time.Duration) (time.Duration, bool) {
+	left := notAfter.Sub(now)
+	var (
+		ret   time.Duration
+		found bool
+	)
+	for _, threshold := range thresholds {
+		if left < threshold && (!found || threshold < ret) {
+			ret, found = threshold, true
+		}
+	}
+	return ret, found
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/ingress-gce/pkg/test"
+)
+
+func TestParseTLSCertificate(t *testing.T) {
+	t.Parallel()
+
+	cert, key, err := test.NewSelfSignedCertificateAndKey("example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCert, otherKey, err := test.NewSelfSignedCertificateAndKey("other.example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		cert, key string
+		wantErr   bool
+	}{
+		{
+			desc: "valid certificate",
+			cert: cert,
+			key:  key,
+		},
+		{
+			desc:    "key of another certificate",
+			cert:    cert,
+			key:     otherKey,
+			wantErr: true,
+		},
+		{
+			desc:    "chain with a certificate which did not sign the leaf",
+			cert:    cert + otherCert,
+			key:     key,
+			wantErr: true,
+		},
+		{
+			desc:    "not PEM",
+			cert:    "cert",
+			key:     "key",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			leaf, err := parseTLSCertificate(tc.cert, tc.key)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("parseTLSCertificate() = _, %v, want err? %v", err, tc.wantErr)
+			}
+			if err == nil && leaf.Subject.CommonName != "example.com" {
+				t.Errorf("parseTLSCertificate() returned certificate of %q, want example.com", leaf.Subject.CommonName)
+			}
+		})
+	}
+}
+
+func TestExpiryWarningThreshold(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+	now := time.Now()
+	thresholds := []time.Duration{30 * day, day, 7 * day}
+	for _, tc := range []struct {
+		desc          string
+		left          time.Duration
+		wantThreshold time.Duration
+		wantOK        bool
+	}{
+		{desc: "far from expiry", left: 60 * day},
+		{desc: "within the largest threshold", left: 20 * day, wantThreshold: 30 * day, wantOK: true},
+		{desc: "within the middle threshold", left: 2 * day, wantThreshold: 7 * day, wantOK: true},
+		{desc: "within the smallest threshold", left: time.Hour, wantThreshold: day, wantOK: true},
+		{desc: "expired", left: -time.Hour, wantThreshold: day, wantOK: true},
+	} {
+		threshold, ok := ExpiryWarningThreshold(now.Add(tc.left), now, thresholds)
+		if threshold != tc.wantThreshold || ok != tc.wantOK {
+			t.Errorf("%s: ExpiryWarningThreshold() = %v, %v, want %v, %v", tc.desc, threshold, ok, tc.wantThreshold, tc.wantOK)
+		}
+	}
+	if _, ok := ExpiryWarningThreshold(now, now.Add(time.Hour), nil); ok {
+		t.Errorf("ExpiryWarningThreshold() without thresholds = _, true, want false")
+	}
+}
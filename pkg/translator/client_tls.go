@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/certificatemanager"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/networksecurity"
+)
+
+const (
+	// ClientValidationModeRejectInvalid rejects requests without a valid
+	// client certificate.
+	ClientValidationModeRejectInvalid = "REJECT_INVALID"
+	// ClientValidationModeAllowInvalid forwards requests without a valid
+	// client certificate to the backends.
+	ClientValidationModeAllowInvalid = "ALLOW_INVALID_OR_MISSING_CLIENT_CERT"
+	// DefaultTrustBundleKey is the key of the trust bundle in its Secret or
+	// ConfigMap if none is given.
+	DefaultTrustBundleKey = "ca.crt"
+)
+
+// clientCertHeaders are the headers which forward the result of the client
+// certificate validation to the backends. The values are variables of the
+// load balancer.
+var clientCertHeaders = []struct{ name, value string }{
+	{"X-Client-Cert-Present", "{client_cert_present}"},
+	{"X-Client-Cert-Chain-Verified", "{client_cert_chain_verified}"},
+	{"X-Client-Cert-Error", "{client_cert_error}"},
+	{"X-Client-Cert-Hash", "{client_cert_sha256_fingerprint}"},
+	{"X-Client-Cert-Serial-Number", "{client_cert_serial_number}"},
+	{"X-Client-Cert-SPIFFE-ID", "{client_cert_spiffe_id}"},
+	{"X-Client-Cert-URI-SANs", "{client_cert_uri_sans}"},
+	{"X-Client-Cert-DNSName-SANs", "{client_cert_dnsname_sans}"},
+	{"X-Client-Cert-Valid-Not-Before", "{client_cert_valid_not_before}"},
+	{"X-Client-Cert-Valid-Not-After", "{client_cert_valid_not_after}"},
+}
+
+// GetClientTrustBundle returns the trust bundle of the client TLS config of
+// the frontend config, empty if it has none. The bundle is read from the
+// Secret or ConfigMap it references and validated.
+func GetClientTrustBundle(client kubernetes.Interface, fc *frontendconfigv1beta1.FrontendConfig) (string, error) {
+	if fc == nil || fc.Spec.ClientTls == nil {
+		return "", nil
+	}
+	ref := fc.Spec.ClientTls.TrustBundle
+	key := ref.Key
+	if key == "" {
+		key = DefaultTrustBundleKey
+	}
+
+	var (
+		bundle string
+		ok     bool
+		source string
+	)
+	switch {
+	case ref.SecretName != "" && ref.ConfigMapName != "":
+		return "", fmt.Errorf("trust bundle of FrontendConfig %s/%s references both a Secret and a ConfigMap", fc.Namespace, fc.Name)
+	case ref.SecretName != "":
+		source = fmt.Sprintf("Secret %s/%s", fc.Namespace, ref.SecretName)
+		secret, err := client.CoreV1().Secrets(fc.Namespace).Get(context.TODO(), ref.SecretName, meta_v1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		var data []byte
+		data, ok = secret.Data[key]
+		bundle = string(data)
+	case ref.ConfigMapName != "":
+		source = fmt.Sprintf("ConfigMap %s/%s", fc.Namespace, ref.ConfigMapName)
+		cm, err := client.CoreV1().ConfigMaps(fc.Namespace).Get(context.TODO(), ref.ConfigMapName, meta_v1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		bundle, ok = cm.Data[key]
+	default:
+		return "", fmt.Errorf("trust bundle of FrontendConfig %s/%s references neither a Secret nor a ConfigMap", fc.Namespace, fc.Name)
+	}
+	if !ok {
+		return "", fmt.Errorf("%s has no key %q", source, key)
+	}
+	if _, err := parseTrustBundle(bundle); err != nil {
+		return "", fmt.Errorf("invalid trust bundle in %s: %v", source, err)
+	}
+	return bundle, nil
+}
+
+// parseTrustBundle returns the certificates of the PEM encoded bundle. The
+// bundle must only contain certificates, at least one of them of a CA.
+func parseTrustBundle(bundle string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	hasCA := false
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q, the bundle may only contain certificates", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		hasCA = hasCA || cert.IsCA
+		certs = append(certs, cert)
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, fmt.Errorf("bundle contains data which is not PEM encoded")
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("bundle contains no certificates")
+	}
+	if !hasCA {
+		return nil, fmt.Errorf("bundle contains no CA certificate")
+	}
+	return certs, nil
+}
+
+// validateClientTls returns an error if the client TLS config of the frontend
+// config cannot be applied to the load balancer.
+func (t *Translator) validateClientTls(env *Env) error {
+	fc := env.FrontendConfig
+	if t.IsL7ILB || t.IsL7XLBRegional {
+		return fmt.Errorf("clientTls in FrontendConfig %s/%s is only supported for global external Ingresses", fc.Namespace, fc.Name)
+	}
+	switch fc.Spec.ClientTls.ClientValidationMode {
+	case "", ClientValidationModeRejectInvalid, ClientValidationModeAllowInvalid:
+		return nil
+	default:
+		return fmt.Errorf("invalid clientValidationMode %q in FrontendConfig %s/%s, must be %s or %s", fc.Spec.ClientTls.ClientValidationMode, fc.Namespace, fc.Name, ClientValidationModeRejectInvalid, ClientValidationModeAllowInvalid)
+	}
+}
+
+// ToTrustConfig returns the trust config of the client TLS config of the
+// frontend config, nil if it has none. Self signed certificates of the bundle
+// are trust anchors, the others intermediate CAs.
+func (t *Translator) ToTrustConfig(env *Env, bundle string) (*certificatemanager.TrustConfig, error) {
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.ClientTls == nil {
+		return nil, nil
+	}
+	if err := t.validateClientTls(env); err != nil {
+		return nil, err
+	}
+	certs, err := parseTrustBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := t.FrontendNamer.ServerTLSPolicy()
+	if !ok {
+		return nil, fmt.Errorf("clientTls cannot be enabled with the V1 Ingress naming scheme. Please recreate your ingress to use the newest naming scheme.")
+	}
+
+	var store certificatemanager.TrustStore
+	for _, cert := range certs {
+		c := certificatemanager.TrustedCertificate{PemCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))}
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) {
+			store.TrustAnchors = append(store.TrustAnchors, c)
+		} else {
+			store.IntermediateCas = append(store.IntermediateCas, c)
+		}
+	}
+	return &certificatemanager.TrustConfig{
+		Name:        name,
+		Description: fmt.Sprintf("Client certificate trust bundle of FrontendConfig %s/%s", env.FrontendConfig.Namespace, env.FrontendConfig.Name),
+		TrustStores: []certificatemanager.TrustStore{store},
+	}, nil
+}
+
+// ToServerTlsPolicy returns the server TLS policy of the client TLS config of
+// the frontend config, nil if it has none. trustConfigLink is the trust config
+// that client certificates are validated against.
+func (t *Translator) ToServerTlsPolicy(env *Env, trustConfigLink string) (*networksecurity.ServerTlsPolicy, error) {
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.ClientTls == nil {
+		return nil, nil
+	}
+	if err := t.validateClientTls(env); err != nil {
+		return nil, err
+	}
+	name, ok := t.FrontendNamer.ServerTLSPolicy()
+	if !ok {
+		return nil, fmt.Errorf("clientTls cannot be enabled with the V1 Ingress naming scheme. Please recreate your ingress to use the newest naming scheme.")
+	}
+	mode := env.FrontendConfig.Spec.ClientTls.ClientValidationMode
+	if mode == "" {
+		mode = ClientValidationModeRejectInvalid
+	}
+	return &networksecurity.ServerTlsPolicy{
+		Name:        name,
+		Description: fmt.Sprintf("Client TLS policy of FrontendConfig %s/%s", env.FrontendConfig.Namespace, env.FrontendConfig.Name),
+		MtlsPolicy: &networksecurity.MtlsPolicy{
+			ClientValidationMode:        mode,
+			ClientValidationTrustConfig: trustConfigLink,
+		},
+	}, nil
+}
+
+// ApplyClientCertHeaders adds the client certificate headers to the requests
+// which the url map m forwards to the backends, if the frontend config asks
+// for them.
+func (t *Translator) ApplyClientCertHeaders(env *Env, m *composite.UrlMap) {
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.ClientTls == nil || !env.FrontendConfig.Spec.ClientTls.ForwardClientCertHeaders {
+		return
+	}
+	action := &composite.HttpHeaderAction{}
+	for _, h := range clientCertHeaders {
+		// Replace headers sent by the client, so they cannot be spoofed.
+		action.RequestHeadersToAdd = append(action.RequestHeadersToAdd, &composite.HttpHeaderOption{HeaderName: h.name, HeaderValue: h.value, Replace: true})
+	}
+	m.HeaderAction = action
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+// DefaultRedirectResponseCode is the response code of redirects which do not
+// specify one.
+const DefaultRedirectResponseCode = "MOVED_PERMANENTLY_DEFAULT"
+
+// redirectResponseCodes are the valid response codes of url map redirects.
+var redirectResponseCodes = sets.NewString(DefaultRedirectResponseCode, "FOUND", "SEE_OTHER", "TEMPORARY_REDIRECT", "PERMANENT_REDIRECT")
+
+// ApplyRedirectRules renders the redirect rules of the frontend config into the
+// url map m, which maps the Ingress to its backend services. A rule without a
+// path prefix redirects the whole host. Paths of the Ingress below a redirected
+// prefix are removed unless they are below one of the exceptions of the rule.
+func (t *Translator) ApplyRedirectRules(env *Env, m *composite.UrlMap) error {
+	rules, err := redirectRules(env)
+	if err != nil {
+		return err
+	}
+	for _, host := range redirectHosts(rules) {
+		pm := pathMatcherForHost(m, host)
+		served := append([]*composite.PathRule{}, pm.PathRules...)
+		defaultService := pm.DefaultService
+		for _, rule := range rulesForHost(rules, host) {
+			prefix := normalizePathPrefix(rule.PathPrefix)
+			action := redirectAction(rule)
+			if prefix == "" {
+				pm.DefaultService = ""
+				pm.DefaultUrlRedirect = action
+			} else {
+				pm.PathRules = append(pm.PathRules, &composite.PathRule{Paths: prefixPaths(prefix), UrlRedirect: action})
+			}
+
+			// Remove the served paths which are redirected by the rule.
+			var pathRules []*composite.PathRule
+			for _, pr := range pm.PathRules {
+				if pr.Service != "" && pathBelow(pr.Paths[0], prefix) && !belowAny(pr.Paths[0], rule.Exceptions) {
+					continue
+				}
+				pathRules = append(pathRules, pr)
+			}
+			pm.PathRules = pathRules
+
+			for _, exception := range rule.Exceptions {
+				exception = normalizePathPrefix(exception)
+				// Paths of the Ingress below the exception are still served.
+				var paths []string
+				for _, path := range prefixPaths(exception) {
+					if !hasPath(pm.PathRules, path) {
+						paths = append(paths, path)
+					}
+				}
+				if len(paths) == 0 {
+					continue
+				}
+				pm.PathRules = append(pm.PathRules, &composite.PathRule{
+					Paths:   paths,
+					Service: serviceForPath(served, defaultService, exception),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyRedirectRulesToRedirectUrlMap renders the redirect rules of the frontend
+// config into the HTTPS redirect url map rm. um is the url map of the Ingress,
+// with the redirect rules applied. The redirects of the rules also redirect to
+// HTTPS, while the exceptions of the rules are served over HTTP.
+func (t *Translator) ApplyRedirectRulesToRedirectUrlMap(env *Env, rm, um *composite.UrlMap) error {
+	rules, err := redirectRules(env)
+	if err != nil {
+		return err
+	}
+	for _, host := range redirectHosts(rules) {
+		var exceptions []string
+		for _, rule := range rulesForHost(rules, host) {
+			exceptions = append(exceptions, rule.Exceptions...)
+		}
+		served := findPathMatcher(um, host)
+		if served == nil {
+			continue
+		}
+		pm := pathMatcherForHost(rm, host)
+		if served.DefaultUrlRedirect != nil {
+			pm.DefaultUrlRedirect = withHTTPSRedirect(served.DefaultUrlRedirect)
+		}
+		for _, pr := range served.PathRules {
+			switch {
+			case pr.UrlRedirect != nil:
+				pm.PathRules = append(pm.PathRules, &composite.PathRule{Paths: pr.Paths, UrlRedirect: withHTTPSRedirect(pr.UrlRedirect)})
+			case belowAny(pr.Paths[0], exceptions):
+				pm.PathRules = append(pm.PathRules, &composite.PathRule{Paths: pr.Paths, Service: pr.Service})
+			}
+		}
+	}
+	return nil
+}
+
+// redirectRules returns the validated redirect rules of the frontend config.
+func redirectRules(env *Env) ([]frontendconfigv1beta1.RedirectRule, error) {
+	if env.FrontendConfig == nil {
+		return nil, nil
+	}
+	fc := env.FrontendConfig
+	seen := sets.NewString()
+	for _, rule := range fc.Spec.RedirectRules {
+		if err := validateRedirectRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid redirect rule for host %q in FrontendConfig %s/%s: %v", rule.Host, fc.Namespace, fc.Name, err)
+		}
+		key := rule.Host + " " + normalizePathPrefix(rule.PathPrefix)
+		if seen.Has(key) {
+			return nil, fmt.Errorf("duplicate redirect rule for host %q and path prefix %q in FrontendConfig %s/%s", rule.Host, rule.PathPrefix, fc.Namespace, fc.Name)
+		}
+		seen.Insert(key)
+	}
+	return fc.Spec.RedirectRules, nil
+}
+
+// validateRedirectRule returns an error if the rule cannot be rendered into a
+// url map.
+func validateRedirectRule(rule frontendconfigv1beta1.RedirectRule) error {
+	if rule.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if rule.TargetHost == "" && rule.TargetPathPrefix == "" {
+		return fmt.Errorf("one of targetHost or targetPathPrefix is required")
+	}
+	if rule.ResponseCodeName != "" && !redirectResponseCodes.Has(rule.ResponseCodeName) {
+		return fmt.Errorf("invalid response code %q, must be one of %v", rule.ResponseCodeName, redirectResponseCodes.List())
+	}
+	prefix := normalizePathPrefix(rule.PathPrefix)
+	if rule.PathPrefix != "" {
+		if err := validatePathPrefix(rule.PathPrefix); err != nil {
+			return err
+		}
+	}
+	if rule.TargetPathPrefix != "" {
+		if prefix == "" {
+			return fmt.Errorf("targetPathPrefix requires a pathPrefix")
+		}
+		if err := validatePathPrefix(rule.TargetPathPrefix); err != nil {
+			return err
+		}
+	}
+	if prefix == "" && rule.TargetHost == rule.Host {
+		return fmt.Errorf("host redirects to itself")
+	}
+	for _, exception := range rule.Exceptions {
+		if err := validatePathPrefix(exception); err != nil {
+			return err
+		}
+		exception = normalizePathPrefix(exception)
+		if exception == "" {
+			return fmt.Errorf("exception %q matches all paths", "/")
+		}
+		if !pathBelow(exception, prefix) {
+			return fmt.Errorf("exception %q is not below path prefix %q", exception, rule.PathPrefix)
+		}
+	}
+	return nil
+}
+
+func validatePathPrefix(path string) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %q must start with /", path)
+	}
+	if strings.Contains(path, "*") {
+		return fmt.Errorf("path %q must not contain wildcards", path)
+	}
+	return nil
+}
+
+// redirectHosts returns the hosts of the rules in the order they first appear.
+func redirectHosts(rules []frontendconfigv1beta1.RedirectRule) []string {
+	var hosts []string
+	seen := sets.NewString()
+	for _, rule := range rules {
+		if !seen.Has(rule.Host) {
+			seen.Insert(rule.Host)
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	return hosts
+}
+
+// rulesForHost returns the rules for host, the rule for the whole host first
+// and then the rules for shorter path prefixes before longer ones.
+func rulesForHost(rules []frontendconfigv1beta1.RedirectRule, host string) []frontendconfigv1beta1.RedirectRule {
+	var ret []frontendconfigv1beta1.RedirectRule
+	for _, rule := range rules {
+		if rule.Host == host {
+			ret = append(ret, rule)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return len(normalizePathPrefix(ret[i].PathPrefix)) < len(normalizePathPrefix(ret[j].PathPrefix))
+	})
+	return ret
+}
+
+// pathMatcherForHost returns the path matcher of host in m. A host rule and a
+// path matcher with the defaults of m are added if the host has none.
+func pathMatcherForHost(m *composite.UrlMap, host string) *composite.PathMatcher {
+	if pm := findPathMatcher(m, host); pm != nil {
+		return pm
+	}
+	pm := &composite.PathMatcher{
+		Name:           getNameForPathMatcher(host),
+		DefaultService: m.DefaultService,
+	}
+	if m.DefaultUrlRedirect != nil {
+		action := *m.DefaultUrlRedirect
+		pm.DefaultUrlRedirect = &action
+	}
+	m.HostRules = append(m.HostRules, &composite.HostRule{Hosts: []string{host}, PathMatcher: pm.Name})
+	m.PathMatchers = append(m.PathMatchers, pm)
+	return pm
+}
+
+// findPathMatcher returns the path matcher of host in m, nil if it has none.
+func findPathMatcher(m *composite.UrlMap, host string) *composite.PathMatcher {
+	for _, hr := range m.HostRules {
+		for _, h := range hr.Hosts {
+			if h != host {
+				continue
+			}
+			for _, pm := range m.PathMatchers {
+				if pm.Name == hr.PathMatcher {
+					return pm
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// redirectAction returns the url map redirect of the rule.
+func redirectAction(rule frontendconfigv1beta1.RedirectRule) *composite.HttpRedirectAction {
+	code := rule.ResponseCodeName
+	if code == "" {
+		code = DefaultRedirectResponseCode
+	}
+	action := &composite.HttpRedirectAction{
+		HostRedirect:         rule.TargetHost,
+		RedirectResponseCode: code,
+	}
+	if rule.TargetPathPrefix != "" {
+		action.PrefixRedirect = rule.TargetPathPrefix
+	}
+	return action
+}
+
+// withHTTPSRedirect returns a copy of the action which also redirects to HTTPS.
+func withHTTPSRedirect(action *composite.HttpRedirectAction) *composite.HttpRedirectAction {
+	ret := *action
+	ret.HttpsRedirect = true
+	return &ret
+}
+
+// normalizePathPrefix strips the trailing slash of a path prefix, "/" becomes
+// the empty prefix which matches all paths.
+func normalizePathPrefix(prefix string) string {
+	return strings.TrimSuffix(prefix, "/")
+}
+
+// prefixPaths returns the url map paths which match the prefix and the paths
+// below it.
+func prefixPaths(prefix string) []string {
+	return []string{prefix, prefix + "/*"}
+}
+
+// pathBelow returns true if the url map path is the prefix or below it.
+func pathBelow(path, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// belowAny returns true if the url map path is below any of the prefixes.
+func belowAny(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if pathBelow(path, normalizePathPrefix(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPath returns true if any of the path rules matches path.
+func hasPath(pathRules []*composite.PathRule, path string) bool {
+	for _, pr := range pathRules {
+		for _, p := range pr.Paths {
+			if p == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceForPath returns the service which serves the path according to the
+// path rules, defaultService if none of them matches. The longest match wins.
+func serviceForPath(pathRules []*composite.PathRule, defaultService, path string) string {
+	service, longest := defaultService, -1
+	for _, pr := range pathRules {
+		for _, p := range pr.Paths {
+			matches := p == path
+			if strings.HasSuffix(p, "/*") {
+				matches = pathBelow(path, strings.TrimSuffix(p, "/*"))
+			}
+			if matches && len(p) > longest {
+				service, longest = pr.Service, len(p)
+			}
+		}
+	}
+	return service
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+const (
+	testDefaultService = "global/backendServices/default"
+	testWebService     = "global/backendServices/web"
+)
+
+// testRedirectUrlMap returns a url map which serves the paths of example.com.
+func testRedirectUrlMap() *composite.UrlMap {
+	pmName := getNameForPathMatcher("example.com")
+	return &composite.UrlMap{
+		DefaultService: testDefaultService,
+		HostRules:      []*composite.HostRule{{Hosts: []string{"example.com"}, PathMatcher: pmName}},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				Name:           pmName,
+				DefaultService: testDefaultService,
+				PathRules: []*composite.PathRule{
+					{Paths: []string{"/web/*"}, Service: testWebService},
+					{Paths: []string{"/blog/posts/*"}, Service: testWebService},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyRedirectRules(t *testing.T) {
+	t.Parallel()
+
+	pmName := getNameForPathMatcher("example.com")
+	apexName := getNameForPathMatcher("apex.com")
+
+	for _, tc := range []struct {
+		desc    string
+		rules   []frontendconfigv1beta1.RedirectRule
+		want    *composite.UrlMap
+		wantErr bool
+	}{
+		{
+			desc: "no rules",
+			want: testRedirectUrlMap(),
+		},
+		{
+			desc:  "host not in the Ingress",
+			rules: []frontendconfigv1beta1.RedirectRule{{Host: "apex.com", TargetHost: "www.apex.com"}},
+			want: func() *composite.UrlMap {
+				m := testRedirectUrlMap()
+				m.HostRules = append(m.HostRules, &composite.HostRule{Hosts: []string{"apex.com"}, PathMatcher: apexName})
+				m.PathMatchers = append(m.PathMatchers, &composite.PathMatcher{
+					Name:               apexName,
+					DefaultUrlRedirect: &composite.HttpRedirectAction{HostRedirect: "www.apex.com", RedirectResponseCode: DefaultRedirectResponseCode},
+				})
+				return m
+			}(),
+		},
+		{
+			desc: "whole host with exception",
+			rules: []frontendconfigv1beta1.RedirectRule{{
+				Host:             "example.com",
+				TargetHost:       "example.org",
+				ResponseCodeName: "FOUND",
+				Exceptions:       []string{"/.well-known/acme-challenge/", "/web/static"},
+			}},
+			want: &composite.UrlMap{
+				DefaultService: testDefaultService,
+				HostRules:      []*composite.HostRule{{Hosts: []string{"example.com"}, PathMatcher: pmName}},
+				PathMatchers: []*composite.PathMatcher{
+					{
+						Name:               pmName,
+						DefaultUrlRedirect: &composite.HttpRedirectAction{HostRedirect: "example.org", RedirectResponseCode: "FOUND"},
+						PathRules: []*composite.PathRule{
+							{Paths: []string{"/.well-known/acme-challenge", "/.well-known/acme-challenge/*"}, Service: testDefaultService},
+							{Paths: []string{"/web/static", "/web/static/*"}, Service: testWebService},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "path prefix",
+			rules: []frontendconfigv1beta1.RedirectRule{{
+				Host:             "example.com",
+				PathPrefix:       "/blog/",
+				TargetPathPrefix: "/news",
+				ResponseCodeName: "PERMANENT_REDIRECT",
+			}},
+			want: func() *composite.UrlMap {
+				m := testRedirectUrlMap()
+				m.PathMatchers[0].PathRules = []*composite.PathRule{
+					{Paths: []string{"/web/*"}, Service: testWebService},
+					{Paths: []string{"/blog", "/blog/*"}, UrlRedirect: &composite.HttpRedirectAction{PrefixRedirect: "/news", RedirectResponseCode: "PERMANENT_REDIRECT"}},
+				}
+				return m
+			}(),
+		},
+		{
+			desc:    "missing target",
+			rules:   []frontendconfigv1beta1.RedirectRule{{Host: "example.com"}},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid response code",
+			rules:   []frontendconfigv1beta1.RedirectRule{{Host: "example.com", TargetHost: "example.org", ResponseCodeName: "MOVED"}},
+			wantErr: true,
+		},
+		{
+			desc:    "target path prefix without path prefix",
+			rules:   []frontendconfigv1beta1.RedirectRule{{Host: "example.com", TargetPathPrefix: "/new"}},
+			wantErr: true,
+		},
+		{
+			desc:    "exception not below path prefix",
+			rules:   []frontendconfigv1beta1.RedirectRule{{Host: "example.com", PathPrefix: "/blog", TargetHost: "example.org", Exceptions: []string{"/web"}}},
+			wantErr: true,
+		},
+		{
+			desc: "duplicate rule",
+			rules: []frontendconfigv1beta1.RedirectRule{
+				{Host: "example.com", PathPrefix: "/blog", TargetHost: "example.org"},
+				{Host: "example.com", PathPrefix: "/blog/", TargetHost: "example.net"},
+			},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewTranslator(false, &testNamer{"foo"})
+			env := &Env{FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{RedirectRules: tc.rules}}}
+			m := testRedirectUrlMap()
+			err := tr.ApplyRedirectRules(env, m)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ApplyRedirectRules() = %v, want err? %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, m); diff != "" {
+				t.Errorf("ApplyRedirectRules() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyRedirectRulesToRedirectUrlMap(t *testing.T) {
+	t.Parallel()
+
+	tr := NewTranslator(false, &testNamer{"foo"})
+	env := &Env{FrontendConfig: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+		RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true},
+		RedirectRules: []frontendconfigv1beta1.RedirectRule{{
+			Host:       "example.com",
+			PathPrefix: "/blog",
+			TargetHost: "example.org",
+			Exceptions: []string{"/blog/posts"},
+		}},
+	}}}
+	um := testRedirectUrlMap()
+	if err := tr.ApplyRedirectRules(env, um); err != nil {
+		t.Fatalf("ApplyRedirectRules() = %v, want nil", err)
+	}
+	rm := tr.ToRedirectUrlMap(env, "")
+	if err := tr.ApplyRedirectRulesToRedirectUrlMap(env, rm, um); err != nil {
+		t.Fatalf("ApplyRedirectRulesToRedirectUrlMap() = %v, want nil", err)
+	}
+
+	pmName := getNameForPathMatcher("example.com")
+	want := &composite.UrlMap{
+		Name:               "foo-rm",
+		DefaultUrlRedirect: &composite.HttpRedirectAction{HttpsRedirect: true},
+		HostRules:          []*composite.HostRule{{Hosts: []string{"example.com"}, PathMatcher: pmName}},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				Name:               pmName,
+				DefaultUrlRedirect: &composite.HttpRedirectAction{HttpsRedirect: true},
+				PathRules: []*composite.PathRule{
+					{Paths: []string{"/blog/posts/*"}, Service: testWebService},
+					{Paths: []string{"/blog", "/blog/*"}, UrlRedirect: &composite.HttpRedirectAction{HostRedirect: "example.org", HttpsRedirect: true, RedirectResponseCode: DefaultRedirectResponseCode}},
+					{Paths: []string{"/blog/posts"}, Service: testWebService},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, rm); diff != "" {
+		t.Errorf("ApplyRedirectRulesToRedirectUrlMap() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -21,20 +21,40 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/ingress-gce/pkg/utils/common"
 	"k8s.io/ingress-gce/pkg/utils/namer"
 )
 
+const (
+	// SSL policy profiles.
+	SslPolicyProfileCompatible = "COMPATIBLE"
+	SslPolicyProfileModern     = "MODERN"
+	SslPolicyProfileRestricted = "RESTRICTED"
+	SslPolicyProfileCustom     = "CUSTOM"
+
+	// Minimum TLS versions of SSL policies.
+	SslPolicyMinTLSVersion10 = "TLS_1_0"
+	SslPolicyMinTLSVersion11 = "TLS_1_1"
+	SslPolicyMinTLSVersion12 = "TLS_1_2"
+)
+
 // Env contains all k8s & GCP configuration needed to perform the translation.
 type Env struct {
 	// Ing is the Ingress we are translating.
@@ -53,6 +73,9 @@ type Env struct {
 	Subnetwork string
 	Region     string
 	Project    string
+	// NetworkTier is the GCE network tier of the forwarding rules, empty for
+	// the default tier.
+	NetworkTier string
 }
 
 // NewEnv returns an Env for the given Ingress.
@@ -68,6 +91,10 @@ func NewEnv(ing *v1beta1.Ingress, client kubernetes.Interface, vip, net, subnet
 			continue
 		}
 		secret, err := client.CoreV1().Secrets(ing.Namespace).Get(context.TODO(), tlsSpec.SecretName, meta_v1.GetOptions{})
+		if apierrors.IsNotFound(err) && annotations.FromIngress(ing).ACMEEnabled() {
+			// The certificate is not issued yet, see secrets().
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -80,6 +107,9 @@ func NewEnv(ing *v1beta1.Ingress, client kubernetes.Interface, vip, net, subnet
 type Translator struct {
 	// IsL7ILB is true if the Ingress will be translated into an L7 ILB (as opposed to an XLB).
 	IsL7ILB bool
+	// IsL7XLBRegional is true if the Ingress will be translated into a regional
+	// external L7 load balancer.
+	IsL7XLBRegional bool
 	// FrontendNamer generates names for frontend resources.
 	FrontendNamer namer.IngressFrontendNamer
 }
@@ -101,15 +131,23 @@ type TLSCerts struct {
 	Name  string
 	// md5 hash(first 8 bytes) of the cert contents
 	CertHash string
+	// NotAfter is the expiry of the leaf certificate.
+	NotAfter time.Time
 }
 
 // Secrets returns the Secrets from the environment which are specified in the Ingress.
+// Missing Secrets of an Ingress with ACME enabled are skipped: they are created
+// once their certificate is issued, which needs the HTTP frontend to be served.
 func secrets(env *Env) ([]*api_v1.Secret, error) {
 	var ret []*api_v1.Secret
 	spec := env.Ing.Spec
+	acme := annotations.FromIngress(env.Ing).ACMEEnabled()
 	for _, tlsSpec := range spec.TLS {
 		secret, ok := env.SecretsMap[tlsSpec.SecretName]
 		if !ok {
+			if acme {
+				continue
+			}
 			return nil, fmt.Errorf("secret %q does not exist", tlsSpec.SecretName)
 		}
 		// Fail-fast if the user's secret does not have the proper fields specified.
@@ -262,6 +300,7 @@ func (t *Translator) ToCompositeForwardingRule(env *Env, protocol namer.NamerPro
 		IPProtocol:  "TCP",
 		Description: description,
 		Version:     version,
+		NetworkTier: env.NetworkTier,
 	}
 
 	if t.IsL7ILB {
@@ -272,6 +311,9 @@ func (t *Translator) ToCompositeForwardingRule(env *Env, protocol namer.NamerPro
 		} else {
 			fr.Subnetwork = env.Subnetwork
 		}
+	} else if t.IsL7XLBRegional {
+		fr.LoadBalancingScheme = "EXTERNAL_MANAGED"
+		fr.Network = env.Network
 	}
 
 	return fr
@@ -312,7 +354,7 @@ func (t *Translator) ToCompositeTargetHttpsProxy(env *Env, description string, v
 	}
 	var sslPolicySet bool
 	if flags.F.EnableFrontendConfig {
-		sslPolicy, err := sslPolicyLink(env)
+		sslPolicy, err := t.sslPolicyLink(env)
 		if err != nil {
 			return nil, sslPolicySet, err
 		}
@@ -332,7 +374,7 @@ func (t *Translator) ToCompositeSSLCertificates(env *Env, tlsName string, tls []
 	tlsNames := utils.SplitAnnotation(tlsName)
 	for _, name := range tlsNames {
 		resID := cloud.ResourceID{Resource: "sslCertificates", Key: &meta.Key{Name: name}, ProjectID: env.Project}
-		if t.IsL7ILB {
+		if t.IsL7ILB || t.IsL7XLBRegional {
 			resID.Key.Region = env.Region
 		}
 		preSharedCert := &composite.SslCertificate{
@@ -347,7 +389,7 @@ func (t *Translator) ToCompositeSSLCertificates(env *Env, tlsName string, tls []
 		ingKey := tlsCert.Key
 		gcpCertName := t.FrontendNamer.SSLCertName(tlsCert.CertHash)
 		resID := cloud.ResourceID{Resource: "sslCertificates", Key: &meta.Key{Name: gcpCertName}, ProjectID: env.Project}
-		if t.IsL7ILB {
+		if t.IsL7ILB || t.IsL7XLBRegional {
 			resID.Key.Region = env.Region
 		}
 		cert := &composite.SslCertificate{
@@ -362,6 +404,80 @@ func (t *Translator) ToCompositeSSLCertificates(env *Env, tlsName string, tls []
 	return certs
 }
 
+// sslPolicyLink returns the ref to the ssl policy of the frontend config,
+// either the one declared in ManagedSslPolicy or the one named in SslPolicy.
+func (t *Translator) sslPolicyLink(env *Env) (*string, error) {
+	policy, err := t.ToSslPolicy(env)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return sslPolicyLink(env)
+	}
+	resourceID := cloud.ResourceID{
+		Resource: "sslPolicies",
+		Key:      meta.GlobalKey(policy.Name),
+	}
+	resID := resourceID.ResourcePath()
+	return &resID, nil
+}
+
+// ToSslPolicy returns the SSL policy declared in the ManagedSslPolicy of the
+// frontend config, nil if there is none. The policy is named after the hash of
+// its configuration, so a change of the configuration results in a new policy.
+func (t *Translator) ToSslPolicy(env *Env) (*compute.SslPolicy, error) {
+	if env.FrontendConfig == nil || env.FrontendConfig.Spec.ManagedSslPolicy == nil {
+		return nil, nil
+	}
+	fc := env.FrontendConfig
+	if fc.Spec.SslPolicy != nil {
+		return nil, fmt.Errorf("FrontendConfig %s/%s cannot specify both sslPolicy and managedSslPolicy", fc.Namespace, fc.Name)
+	}
+	if t.IsL7ILB || t.IsL7XLBRegional {
+		return nil, fmt.Errorf("managedSslPolicy of FrontendConfig %s/%s is not supported for regional Ingresses", fc.Namespace, fc.Name)
+	}
+
+	config := fc.Spec.ManagedSslPolicy
+	profile := config.Profile
+	if profile == "" {
+		profile = SslPolicyProfileCompatible
+	}
+	minTLSVersion := config.MinTlsVersion
+	if minTLSVersion == "" {
+		minTLSVersion = SslPolicyMinTLSVersion10
+	}
+	switch profile {
+	case SslPolicyProfileCompatible, SslPolicyProfileModern, SslPolicyProfileRestricted:
+		if len(config.CustomFeatures) != 0 {
+			return nil, fmt.Errorf("customFeatures of FrontendConfig %s/%s require the %s profile", fc.Namespace, fc.Name, SslPolicyProfileCustom)
+		}
+	case SslPolicyProfileCustom:
+		if len(config.CustomFeatures) == 0 {
+			return nil, fmt.Errorf("the %s profile of FrontendConfig %s/%s requires customFeatures", SslPolicyProfileCustom, fc.Namespace, fc.Name)
+		}
+	default:
+		return nil, fmt.Errorf("invalid SSL policy profile %q in FrontendConfig %s/%s", profile, fc.Namespace, fc.Name)
+	}
+	switch minTLSVersion {
+	case SslPolicyMinTLSVersion10, SslPolicyMinTLSVersion11, SslPolicyMinTLSVersion12:
+	default:
+		return nil, fmt.Errorf("invalid minimum TLS version %q in FrontendConfig %s/%s", minTLSVersion, fc.Namespace, fc.Name)
+	}
+
+	var features []string
+	if len(config.CustomFeatures) != 0 {
+		features = sets.NewString(config.CustomFeatures...).List()
+	}
+	policyHash := common.ContentHash(strings.Join(append([]string{profile, minTLSVersion}, features...), ";"), 8)
+	return &compute.SslPolicy{
+		Name:           t.FrontendNamer.SSLPolicy(policyHash),
+		Description:    "SSL policy declared in FrontendConfig managedSslPolicy",
+		Profile:        profile,
+		MinTlsVersion:  minTLSVersion,
+		CustomFeatures: features,
+	}, nil
+}
+
 // sslPolicyLink returns the ref to the ssl policy that is described by the
 // frontend config.  Since Ssl Policy is a *string, there are three possible I/O situations
 // 1) policy is nil -> this returns nil
@@ -405,11 +521,18 @@ func ToTLSCerts(env *Env) ([]*TLSCerts, error) {
 	}
 	for _, secret := range secrets {
 		cert := string(secret.Data[api_v1.TLSCertKey])
+		key := string(secret.Data[api_v1.TLSPrivateKeyKey])
+		// Reject broken certificates before GCE does.
+		leaf, err := parseTLSCertificate(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("secret %q does not contain a valid certificate chain and private key: %v", secret.Name, err)
+		}
 		newCert := &TLSCerts{
-			Key:      string(secret.Data[api_v1.TLSPrivateKeyKey]),
+			Key:      key,
 			Cert:     cert,
 			Name:     secret.Name,
 			CertHash: GetCertHash(cert),
+			NotAfter: leaf.NotAfter,
 		}
 		certs = append(certs, newCert)
 	}
@@ -21,10 +21,12 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/api/networking/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/pkg/annotations"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/flags"
 
@@ -54,6 +56,10 @@ func (n *testNamer) RedirectUrlMap() (string, bool) {
 	return fmt.Sprintf("%s-rm", n.prefix), true
 }
 
+func (n *testNamer) ServerTLSPolicy() (string, bool) {
+	return fmt.Sprintf("%s-st", n.prefix), true
+}
+
 func (n *testNamer) SSLCertName(secretHash string) string {
 	return fmt.Sprintf("%s-cert-%s", n.prefix, secretHash)
 }
@@ -66,6 +72,14 @@ func (n *testNamer) IsLegacySSLCert(string) bool {
 	panic("Unimplemented")
 }
 
+func (n *testNamer) SSLPolicy(policyHash string) string {
+	return fmt.Sprintf("%s-sp-%s", n.prefix, policyHash)
+}
+
+func (n *testNamer) IsSSLPolicy(string) bool {
+	panic("Unimplemented")
+}
+
 func (n *testNamer) LoadBalancer() namer_util.LoadBalancerName {
 	panic("Unimplemented")
 }
@@ -303,6 +317,21 @@ func TestSecrets(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "acme-missing-secret",
+			ing: &v1beta1.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Annotations: map[string]string{annotations.ACMEKey: "true"},
+				},
+				Spec: v1beta1.IngressSpec{
+					TLS: []v1beta1.IngressTLS{
+						{SecretName: "first-secret"},
+						{SecretName: "does-not-exist-secret"},
+					},
+				},
+			},
+			want: []*api_v1.Secret{secretsMap["first-secret"]},
+		},
 		{
 			desc: "mci-secret-empty-cert",
 			ing: &v1beta1.Ingress{
@@ -730,3 +759,119 @@ func TestSslPolicyLink(t *testing.T) {
 		})
 	}
 }
+
+func TestToSslPolicy(t *testing.T) {
+	t.Parallel()
+	managed := func(config *frontendconfigv1beta1.ManagedSslPolicy) *frontendconfigv1beta1.FrontendConfig {
+		return &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{ManagedSslPolicy: config}}
+	}
+	testCases := []struct {
+		desc     string
+		fc       *frontendconfigv1beta1.FrontendConfig
+		regional bool
+		want     *compute.SslPolicy
+		wantErr  bool
+	}{
+		{
+			desc: "Empty frontendconfig",
+			fc:   nil,
+		},
+		{
+			desc: "frontendconfig with named ssl policy",
+			fc:   &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: utils.NewStringPointer("test-policy")}},
+		},
+		{
+			desc: "defaults",
+			fc:   managed(&frontendconfigv1beta1.ManagedSslPolicy{}),
+			want: &compute.SslPolicy{Profile: "COMPATIBLE", MinTlsVersion: "TLS_1_0"},
+		},
+		{
+			desc: "modern profile with TLS 1.2",
+			fc:   managed(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "MODERN", MinTlsVersion: "TLS_1_2"}),
+			want: &compute.SslPolicy{Profile: "MODERN", MinTlsVersion: "TLS_1_2"},
+		},
+		{
+			desc: "custom profile with sorted features",
+			fc: managed(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "CUSTOM", MinTlsVersion: "TLS_1_2",
+				CustomFeatures: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}}),
+			want: &compute.SslPolicy{Profile: "CUSTOM", MinTlsVersion: "TLS_1_2",
+				CustomFeatures: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+		},
+		{
+			desc:    "custom profile without features",
+			fc:      managed(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "CUSTOM"}),
+			wantErr: true,
+		},
+		{
+			desc:    "features without custom profile",
+			fc:      managed(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "MODERN", CustomFeatures: []string{"TLS_RSA_WITH_AES_128_GCM_SHA256"}}),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid profile",
+			fc:      managed(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "STRICT"}),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid min TLS version",
+			fc:      managed(&frontendconfigv1beta1.ManagedSslPolicy{MinTlsVersion: "TLS_1_3"}),
+			wantErr: true,
+		},
+		{
+			desc: "both named and managed ssl policy",
+			fc: &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{
+				SslPolicy:        utils.NewStringPointer("test-policy"),
+				ManagedSslPolicy: &frontendconfigv1beta1.ManagedSslPolicy{},
+			}},
+			wantErr: true,
+		},
+		{
+			desc:     "regional ingress",
+			fc:       managed(&frontendconfigv1beta1.ManagedSslPolicy{}),
+			regional: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := NewTranslator(tc.regional, &testNamer{"foo"})
+			got, err := tr.ToSslPolicy(&Env{FrontendConfig: tc.fc})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ToSslPolicy() = %v, want error %v", err, tc.wantErr)
+			}
+			if got == nil || tc.want == nil {
+				if got != tc.want {
+					t.Fatalf("ToSslPolicy() = %+v, want %+v", got, tc.want)
+				}
+				return
+			}
+			// The name and the description are covered by TestToSslPolicyName.
+			got.Name, got.Description = "", ""
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Got diff for SslPolicy (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToSslPolicyName(t *testing.T) {
+	t.Parallel()
+	tr := NewTranslator(false, &testNamer{"foo"})
+	policyName := func(config *frontendconfigv1beta1.ManagedSslPolicy) string {
+		fc := &frontendconfigv1beta1.FrontendConfig{Spec: frontendconfigv1beta1.FrontendConfigSpec{ManagedSslPolicy: config}}
+		policy, err := tr.ToSslPolicy(&Env{FrontendConfig: fc})
+		if err != nil {
+			t.Fatalf("ToSslPolicy() = %v, want nil", err)
+		}
+		return policy.Name
+	}
+
+	defaults := policyName(&frontendconfigv1beta1.ManagedSslPolicy{})
+	if explicit := policyName(&frontendconfigv1beta1.ManagedSslPolicy{Profile: "COMPATIBLE", MinTlsVersion: "TLS_1_0"}); explicit != defaults {
+		t.Errorf("Policy with explicit defaults is named %q, want %q", explicit, defaults)
+	}
+	if tls12 := policyName(&frontendconfigv1beta1.ManagedSslPolicy{MinTlsVersion: "TLS_1_2"}); tls12 == defaults {
+		t.Errorf("Policies with different min TLS versions are both named %q", tls12)
+	}
+}
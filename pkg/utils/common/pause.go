@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	client "k8s.io/client-go/kubernetes/typed/networking/v1beta1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/utils/patch"
+	"k8s.io/klog"
+)
+
+// EnsureIngressPausedStatus records on the Ingress whether its reconciliation
+// is paused. An event is emitted when the status changes. Returns the updated
+// Ingress.
+func EnsureIngressPausedStatus(ing *v1beta1.Ingress, ingClient client.IngressInterface, recorder record.EventRecorder, paused bool) (*v1beta1.Ingress, error) {
+	if _, ok := ing.Annotations[annotations.PausedKey]; ok == paused {
+		return ing, nil
+	}
+
+	// Make a copy of object metadata so we don't mutate the shared informer cache.
+	updatedObjectMeta := ing.ObjectMeta.DeepCopy()
+	if paused {
+		if updatedObjectMeta.Annotations == nil {
+			updatedObjectMeta.Annotations = map[string]string{}
+		}
+		updatedObjectMeta.Annotations[annotations.PausedKey] = "true"
+	} else {
+		delete(updatedObjectMeta.Annotations, annotations.PausedKey)
+	}
+
+	updated, err := PatchIngressObjectMetadata(ingClient, ing, *updatedObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	recordPausedEvent(recorder, ing, paused)
+	klog.V(2).Infof("Set paused status of Ingress %s/%s to %v", ing.Namespace, ing.Name, paused)
+	return updated, nil
+}
+
+// EnsureServicePausedStatus records on the Service whether its reconciliation
+// is paused. An event is emitted when the status changes.
+func EnsureServicePausedStatus(service *corev1.Service, kubeClient kubernetes.Interface, recorder record.EventRecorder, paused bool) error {
+	if _, ok := service.Annotations[annotations.ServicePausedKey]; ok == paused {
+		return nil
+	}
+
+	// Make a copy of object metadata so we don't mutate the shared informer cache.
+	updatedObjectMeta := service.ObjectMeta.DeepCopy()
+	if paused {
+		if updatedObjectMeta.Annotations == nil {
+			updatedObjectMeta.Annotations = map[string]string{}
+		}
+		updatedObjectMeta.Annotations[annotations.ServicePausedKey] = "true"
+	} else {
+		delete(updatedObjectMeta.Annotations, annotations.ServicePausedKey)
+	}
+
+	if err := patch.PatchServiceObjectMetadata(kubeClient.CoreV1(), service, *updatedObjectMeta); err != nil {
+		return err
+	}
+	recordPausedEvent(recorder, service, paused)
+	klog.V(2).Infof("Set paused status of service %s/%s to %v", service.Namespace, service.Name, paused)
+	return nil
+}
+
+func recordPausedEvent(recorder record.EventRecorder, obj runtime.Object, paused bool) {
+	if paused {
+		recorder.Eventf(obj, corev1.EventTypeNormal, events.Paused, "Reconciliation is paused by annotation %s, GCE resources will not be modified", annotations.PauseReconciliationKey)
+	} else {
+		recorder.Eventf(obj, corev1.EventTypeNormal, events.Resumed, "Reconciliation is resumed")
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/networking/v1beta1"
+	client "k8s.io/client-go/kubernetes/typed/networking/v1beta1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/klog"
+)
+
+// EnsureIngressProxyOnlySubnetStatus records on the regional Ingress whether
+// the proxy-only subnet of network in region exists. An event is emitted when
+// the status changes. Returns the updated Ingress.
+func EnsureIngressProxyOnlySubnetStatus(ing *v1beta1.Ingress, ingClient client.IngressInterface, recorder record.EventRecorder, network, region string, ready bool) (*v1beta1.Ingress, error) {
+	status := "False"
+	if ready {
+		status = "True"
+	}
+	if ing.Annotations[annotations.ProxyOnlySubnetReadyKey] == status {
+		return ing, nil
+	}
+
+	// Make a copy of object metadata so we don't mutate the shared informer cache.
+	updatedObjectMeta := ing.ObjectMeta.DeepCopy()
+	if updatedObjectMeta.Annotations == nil {
+		updatedObjectMeta.Annotations = map[string]string{}
+	}
+	updatedObjectMeta.Annotations[annotations.ProxyOnlySubnetReadyKey] = status
+
+	updated, err := PatchIngressObjectMetadata(ingClient, ing, *updatedObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	if ready {
+		recorder.Eventf(ing, corev1.EventTypeNormal, events.ProxyOnlySubnetReady, "Found proxy-only subnet in network %s region %s", network, region)
+	} else {
+		recorder.Eventf(ing, corev1.EventTypeWarning, events.ProxyOnlySubnetMissing,
+			"No active proxy-only subnet in network %s region %s, the load balancer will be created once a subnet with purpose REGIONAL_MANAGED_PROXY exists", network, region)
+	}
+	klog.V(2).Infof("Set proxy-only subnet status of Ingress %s/%s to %v", ing.Namespace, ing.Name, status)
+	return updated, nil
+}
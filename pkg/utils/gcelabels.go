@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/ingress-gce/pkg/flags"
+)
+
+const (
+	// maxGCELabels is the maximum number of labels of a GCE resource.
+	maxGCELabels = 64
+	// maxGCELabelLength is the maximum length of a GCE label key or value.
+	maxGCELabelLength = 63
+)
+
+var (
+	gceLabelKeyRegexp   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	gceLabelValueRegexp = regexp.MustCompile(`^[a-z0-9_-]*$`)
+	invalidGCELabelChar = regexp.MustCompile(`[^a-z0-9_-]`)
+)
+
+// GCELabelsEnabled returns true if labels are propagated onto GCE resources.
+func GCELabelsEnabled() bool {
+	return len(flags.F.GCELabelAllowlist) > 0 || len(flags.F.GCEStaticLabels) > 0
+}
+
+// ValidateGCELabel returns an error if key and value violate the GCE label
+// rules: keys start with a lowercase letter, keys and values only contain
+// lowercase letters, digits, underscores and dashes and are at most 63
+// characters long.
+func ValidateGCELabel(key, value string) error {
+	if len(key) == 0 || len(key) > maxGCELabelLength || !gceLabelKeyRegexp.MatchString(key) {
+		return fmt.Errorf("invalid GCE label key %q: must start with a lowercase letter, only contain lowercase letters, digits, '_' and '-' and be at most %d characters long", key, maxGCELabelLength)
+	}
+	if len(value) > maxGCELabelLength || !gceLabelValueRegexp.MatchString(value) {
+		return fmt.Errorf("invalid value %q of GCE label %q: must only contain lowercase letters, digits, '_' and '-' and be at most %d characters long", value, key, maxGCELabelLength)
+	}
+	return nil
+}
+
+// ValidateStaticGCELabels validates the labels configured with the
+// --gce-static-labels flag.
+func ValidateStaticGCELabels() error {
+	if len(flags.F.GCEStaticLabels) > maxGCELabels {
+		return fmt.Errorf("%d static GCE labels configured, at most %d are allowed", len(flags.F.GCEStaticLabels), maxGCELabels)
+	}
+	for k, v := range flags.F.GCEStaticLabels {
+		if err := ValidateGCELabel(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toGCELabel converts a Kubernetes label key or value to the GCE label
+// character set, e.g. "app.kubernetes.io/name" becomes "app_kubernetes_io_name".
+func toGCELabel(s string) string {
+	return invalidGCELabelChar.ReplaceAllString(strings.ToLower(s), "_")
+}
+
+// managedGCELabelKeys returns the keys of the GCE labels managed by the
+// controller.
+func managedGCELabelKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, k := range flags.F.GCELabelAllowlist {
+		keys[toGCELabel(k)] = true
+	}
+	for k := range flags.F.GCEStaticLabels {
+		keys[k] = true
+	}
+	return keys
+}
+
+// GCELabels returns the GCE labels for a resource of a Kubernetes object with
+// the given labels. These are the static labels and the allowlisted labels of
+// the object, converted to the GCE label character set. Labels which violate
+// the GCE label rules after conversion are skipped and returned as errors.
+func GCELabels(objLabels map[string]string) (map[string]string, []error) {
+	labels := map[string]string{}
+	var errs []error
+	for _, k := range flags.F.GCELabelAllowlist {
+		v, ok := objLabels[k]
+		if !ok {
+			continue
+		}
+		key, value := toGCELabel(k), toGCELabel(v)
+		if err := ValidateGCELabel(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("label %s=%s: %v", k, v, err))
+			continue
+		}
+		labels[key] = value
+	}
+	// Static labels take precedence over labels of the object.
+	for k, v := range flags.F.GCEStaticLabels {
+		labels[k] = v
+	}
+	return labels, errs
+}
+
+// MergeGCELabels returns the labels of a resource with existing labels once
+// labels are applied. Managed labels which are not in labels are removed,
+// labels set by others are kept. Returns an error if the result exceeds the
+// number of labels allowed on a resource.
+func MergeGCELabels(existing, labels map[string]string) (map[string]string, error) {
+	managed := managedGCELabelKeys()
+	merged := map[string]string{}
+	for k, v := range existing {
+		if !managed[k] {
+			merged[k] = v
+		}
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	if len(merged) > maxGCELabels {
+		return nil, fmt.Errorf("resource would have %d GCE labels, at most %d are allowed", len(merged), maxGCELabels)
+	}
+	return merged, nil
+}
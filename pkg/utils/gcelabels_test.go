@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/ingress-gce/pkg/flags"
+)
+
+func TestValidateGCELabel(t *testing.T) {
+	for _, tc := range []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{key: "team", value: "payments"},
+		{key: "cost-center_1", value: ""},
+		{key: "", value: "foo", wantErr: true},
+		{key: "1team", value: "foo", wantErr: true},
+		{key: "Team", value: "foo", wantErr: true},
+		{key: "team", value: "Payments", wantErr: true},
+		{key: "team", value: "a.b", wantErr: true},
+		{key: strings.Repeat("a", 64), value: "foo", wantErr: true},
+		{key: "team", value: strings.Repeat("a", 64), wantErr: true},
+	} {
+		if err := ValidateGCELabel(tc.key, tc.value); (err != nil) != tc.wantErr {
+			t.Errorf("ValidateGCELabel(%q, %q) = %v, want error %v", tc.key, tc.value, err, tc.wantErr)
+		}
+	}
+}
+
+func TestGCELabels(t *testing.T) {
+	flags.F.GCELabelAllowlist = []string{"team", "app.kubernetes.io/name", "cost-center"}
+	flags.F.GCEStaticLabels = map[string]string{"env": "prod", "team": "platform"}
+	defer func() {
+		flags.F.GCELabelAllowlist = nil
+		flags.F.GCEStaticLabels = nil
+	}()
+
+	labels, errs := GCELabels(map[string]string{
+		"team":                   "payments",
+		"app.kubernetes.io/name": "Store",
+		"cost-center":            strings.Repeat("a", 64),
+		"other":                  "foo",
+	})
+	want := map[string]string{"env": "prod", "team": "platform", "app_kubernetes_io_name": "store"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("GCELabels() = %v, want %v", labels, want)
+	}
+	if len(errs) != 1 {
+		t.Errorf("GCELabels() returned errors %v, want an error for the cost-center label", errs)
+	}
+
+	merged, err := MergeGCELabels(map[string]string{"app_kubernetes_io_name": "old", "cost-center": "1", "owner": "alice"}, labels)
+	if err != nil {
+		t.Fatalf("MergeGCELabels() = %v", err)
+	}
+	want = map[string]string{"env": "prod", "team": "platform", "app_kubernetes_io_name": "store", "owner": "alice"}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeGCELabels() = %v, want %v", merged, want)
+	}
+
+	existing := map[string]string{}
+	for i := 0; i < maxGCELabels; i++ {
+		existing[fmt.Sprintf("label%d", i)] = ""
+	}
+	if _, err := MergeGCELabels(existing, labels); err == nil {
+		t.Errorf("MergeGCELabels() with %d existing labels returned no error", len(existing))
+	}
+}
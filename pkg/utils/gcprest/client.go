@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcprest is a minimal client for Google Cloud REST APIs which have
+// no Go client in the google.golang.org/api version used by the controller.
+// Errors are *googleapi.Error, so the helpers of pkg/utils apply to them.
+// Requests share the authentication and the rate limiter of the GCE client
+// of the controller, and long running operations are polled on the next call
+// instead of blocking the caller.
+package gcprest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+	"k8s.io/ingress-gce/pkg/composite/metrics"
+	"k8s.io/klog"
+)
+
+// Config holds what the Clients of a controller share.
+type Config struct {
+	// HTTPClient authenticates requests with the token source of the cloud
+	// config of the controller.
+	HTTPClient *http.Client
+	// RateLimiter is accepted before each request, with the service of the
+	// Client and the operation of the request in the key. It may be nil.
+	RateLimiter cloud.RateLimiter
+	// ProjectID is the project of the rate limit keys.
+	ProjectID string
+	// ComputeEndpoint is the endpoint of the GA compute API, e.g.
+	// https://www.googleapis.com/compute/v1/.
+	ComputeEndpoint string
+	// AlphaComputeEndpoint is the endpoint of the alpha compute API, e.g.
+	// https://www.googleapis.com/compute/alpha/.
+	AlphaComputeEndpoint string
+}
+
+// Client issues requests to a Google Cloud REST API.
+type Client struct {
+	config *Config
+	// service names the API in rate limit keys and metrics, e.g.
+	// NetworkSecurity.
+	service string
+	// basePath is the URL of the API, e.g.
+	// https://networksecurity.googleapis.com/v1/.
+	basePath string
+
+	mu sync.Mutex
+	// pending are the running operations started by the Client, by the
+	// resource they change.
+	pending map[string]*Operation
+}
+
+// NewClient returns a Client for service, the API at basePath.
+func NewClient(config *Config, service, basePath string) *Client {
+	return &Client{config: config, service: service, basePath: basePath, pending: map[string]*Operation{}}
+}
+
+// Operation is a long running operation.
+type Operation struct {
+	Name  string           `json:"name"`
+	Done  bool             `json:"done"`
+	Error *OperationStatus `json:"error,omitempty"`
+}
+
+// OperationStatus is the error of a failed operation.
+type OperationStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Do issues a request for path, relative to the base path of the API. in is
+// encoded as the JSON body of the request if not nil, the response is decoded
+// into out if not nil.
+func (c *Client) Do(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.do(ctx, operationName(method), method, path, in, out)
+}
+
+func (c *Client) do(ctx context.Context, operation, method, path string, in, out interface{}) error {
+	if rl := c.config.RateLimiter; rl != nil {
+		key := &cloud.RateLimitKey{ProjectID: c.config.ProjectID, Operation: operation, Version: meta.VersionGA, Service: c.service}
+		if err := rl.Accept(ctx, key); err != nil {
+			return err
+		}
+	}
+	mc := metrics.NewMetricContext(c.service, operation, "", "", "v1")
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return mc.Observe(err)
+		}
+		body = bytes.NewReader(b)
+	}
+	url := path
+	if !strings.HasPrefix(path, "https://") {
+		url = strings.TrimSuffix(c.basePath, "/") + "/" + strings.TrimPrefix(path, "/")
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return mc.Observe(err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.config.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return mc.Observe(err)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return mc.Observe(err)
+	}
+	if out == nil {
+		return mc.Observe(nil)
+	}
+	return mc.Observe(json.NewDecoder(resp.Body).Decode(out))
+}
+
+// Get reads resource into out. An OperationInProgressError is returned while
+// an operation the Client started on resource is running.
+func (c *Client) Get(ctx context.Context, resource string, out interface{}) error {
+	if err := c.checkPending(ctx, resource); err != nil {
+		return err
+	}
+	return c.Do(ctx, http.MethodGet, resource, nil, out)
+}
+
+// Start issues a request for path which starts a long running operation on
+// resource. It does not wait for the operation: an OperationInProgressError is
+// returned if the operation is still running, and the next calls for resource
+// poll it once, e.g. on the next sync of the caller. They return the error of
+// the operation if it failed.
+func (c *Client) Start(ctx context.Context, method, path, resource string, in interface{}) error {
+	if err := c.checkPending(ctx, resource); err != nil {
+		return err
+	}
+	op := &Operation{}
+	if err := c.Do(ctx, method, path, in, op); err != nil {
+		return err
+	}
+	return c.track(resource, op)
+}
+
+// checkPending polls the pending operation on resource, if any.
+func (c *Client) checkPending(ctx context.Context, resource string) error {
+	c.mu.Lock()
+	op, ok := c.pending[resource]
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	klog.V(4).Infof("Polling operation %s", op.Name)
+	polled := &Operation{}
+	if err := c.do(ctx, "GetOperation", http.MethodGet, op.Name, nil, polled); err != nil {
+		return err
+	}
+	return c.track(resource, polled)
+}
+
+// track records op as the pending operation on resource until it is done.
+func (c *Client) track(resource string, op *Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !op.Done {
+		c.pending[resource] = op
+		return &OperationInProgressError{Operation: op.Name, Resource: resource}
+	}
+	delete(c.pending, resource)
+	if op.Error != nil {
+		return &googleapi.Error{Code: httpStatus(op.Error.Code), Message: op.Error.Message}
+	}
+	return nil
+}
+
+// OperationInProgressError is returned for a resource while an operation on
+// it is running.
+type OperationInProgressError struct {
+	Operation string
+	Resource  string
+}
+
+func (e *OperationInProgressError) Error() string {
+	return fmt.Sprintf("operation %s on %s is in progress", e.Operation, e.Resource)
+}
+
+// operationName returns the operation of the rate limit key and the metrics
+// of a request with method.
+func operationName(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "Get"
+	case http.MethodPost:
+		return "Insert"
+	case http.MethodPatch:
+		return "Patch"
+	case http.MethodDelete:
+		return "Delete"
+	}
+	return method
+}
+
+// httpStatus maps the google.rpc.Code of a failed operation to the HTTP status
+// of the same error.
+func httpStatus(code int) int {
+	switch code {
+	case 3, 9, 11: // INVALID_ARGUMENT, FAILED_PRECONDITION, OUT_OF_RANGE
+		return http.StatusBadRequest
+	case 5: // NOT_FOUND
+		return http.StatusNotFound
+	case 6, 10: // ALREADY_EXISTS, ABORTED
+		return http.StatusConflict
+	case 7: // PERMISSION_DENIED
+		return http.StatusForbidden
+	case 8: // RESOURCE_EXHAUSTED
+		return http.StatusTooManyRequests
+	case 16: // UNAUTHENTICATED
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
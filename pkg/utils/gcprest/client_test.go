@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcprest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	type resource struct {
+		Name string `json:"name"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/resources/foo":
+			json.NewEncoder(w).Encode(&resource{Name: "foo"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := NewClient(&Config{HTTPClient: server.Client()}, "Test", server.URL+"/v1/")
+
+	got := &resource{}
+	if err := c.Do(context.Background(), http.MethodGet, "resources/foo", nil, got); err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if got.Name != "foo" {
+		t.Errorf("Do() decoded %+v, want name foo", got)
+	}
+
+	err := c.Do(context.Background(), http.MethodGet, "resources/bar", nil, got)
+	if !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("Do() = %v, want a not found error", err)
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc string
+		// ops are the operation returned by the request, then by each poll.
+		ops      []Operation
+		wantCode int
+	}{
+		{
+			desc: "done",
+			ops:  []Operation{{Name: "operations/op", Done: true}},
+		},
+		{
+			desc:     "failed",
+			ops:      []Operation{{Name: "operations/op", Done: true, Error: &OperationStatus{Code: 9, Message: "in use"}}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "done on the second poll",
+			ops:  []Operation{{Name: "operations/op"}, {Name: "operations/op"}, {Name: "operations/op", Done: true}},
+		},
+		{
+			desc:     "failed on the first poll",
+			ops:      []Operation{{Name: "operations/op"}, {Name: "operations/op", Done: true, Error: &OperationStatus{Code: 5, Message: "not found"}}},
+			wantCode: http.StatusNotFound,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/resources/foo":
+					json.NewEncoder(w).Encode(struct{}{})
+				case len(requests) <= len(tc.ops):
+					json.NewEncoder(w).Encode(&tc.ops[len(requests)-1])
+				default:
+					json.NewEncoder(w).Encode(&Operation{Name: "operations/other", Done: true})
+				}
+			}))
+			defer server.Close()
+			c := NewClient(&Config{HTTPClient: server.Client()}, "Test", server.URL)
+
+			// The operation is polled once by each call for the resource,
+			// until it is done.
+			err := c.Start(context.Background(), http.MethodDelete, "resources/foo", "resources/foo", nil)
+			for i := 1; i < len(tc.ops); i++ {
+				if _, ok := err.(*OperationInProgressError); !ok {
+					t.Fatalf("Call %d for a running operation = %v, want an OperationInProgressError", i, err)
+				}
+				err = c.Get(context.Background(), "resources/foo", &struct{}{})
+			}
+			if tc.wantCode == 0 {
+				if err != nil {
+					t.Errorf("Call for a done operation = %v, want nil", err)
+				}
+			} else if !utils.IsHTTPErrorCode(err, tc.wantCode) {
+				t.Errorf("Call for a failed operation = %v, want error with code %d", err, tc.wantCode)
+			}
+
+			// The operation is no longer polled once it is done.
+			if err := c.Get(context.Background(), "resources/foo", &struct{}{}); err != nil {
+				t.Errorf("Get() = %v, want nil", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			var opRequests int
+			for _, r := range requests {
+				if r != "GET /resources/foo" {
+					opRequests++
+				}
+			}
+			if opRequests != len(tc.ops) {
+				t.Errorf("Client issued requests %v, want %d for the operation", requests, len(tc.ops))
+			}
+		})
+	}
+}
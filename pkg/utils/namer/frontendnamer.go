@@ -50,6 +50,10 @@ const (
 	targetHTTPSProxyPrefixV2 = "ts"
 	// sslCertPrefixV2 is ssl certificate prefix for v2 naming scheme.
 	sslCertPrefixV2 = "cr"
+	// sslPolicyPrefixV2 is ssl policy prefix for v2 naming scheme.
+	sslPolicyPrefixV2 = "sp"
+	// serverTLSPolicyPrefixV2 is server TLS policy prefix for v2 naming scheme.
+	serverTLSPolicyPrefixV2 = "st"
 	// clusterUIDLength is length of cluster UID to be included in resource names.
 	clusterUIDLength = 8
 )
@@ -95,6 +99,11 @@ func (ln *V1IngressFrontendNamer) RedirectUrlMap() (string, bool) {
 	return "", false
 }
 
+// ServerTLSPolicy implements IngressFrontendNamer.
+func (ln *V1IngressFrontendNamer) ServerTLSPolicy() (string, bool) {
+	return "", false
+}
+
 // SSLCertName implements IngressFrontendNamer.
 func (ln *V1IngressFrontendNamer) SSLCertName(secretHash string) string {
 	return ln.namer.SSLCertName(ln.lbName, secretHash)
@@ -110,6 +119,16 @@ func (ln *V1IngressFrontendNamer) IsLegacySSLCert(certName string) bool {
 	return ln.namer.IsLegacySSLCert(ln.lbName, certName)
 }
 
+// SSLPolicy implements IngressFrontendNamer.
+func (ln *V1IngressFrontendNamer) SSLPolicy(policyHash string) string {
+	return ln.namer.SSLPolicy(policyHash)
+}
+
+// IsSSLPolicy implements IngressFrontendNamer.
+func (ln *V1IngressFrontendNamer) IsSSLPolicy(name string) bool {
+	return ln.namer.IsSSLPolicy(name)
+}
+
 // LoadBalancer implements IngressFrontendNamer.
 func (ln *V1IngressFrontendNamer) LoadBalancer() LoadBalancerName {
 	return ln.lbName
@@ -118,7 +137,7 @@ func (ln *V1IngressFrontendNamer) LoadBalancer() LoadBalancerName {
 // IsValidLoadBalancer implements IngressFrontendNamer.
 func (ln *V1IngressFrontendNamer) IsValidLoadBalancer() bool {
 	// Verify if URL Map is a valid GCE resource name.
-	return isValidGCEResourceName(ln.UrlMap())
+	return IsValidGCEResourceName(ln.UrlMap())
 }
 
 // V2IngressFrontendNamer implements IngressFrontendNamer.
@@ -193,6 +212,12 @@ func (vn *V2IngressFrontendNamer) RedirectUrlMap() (string, bool) {
 	return fmt.Sprintf("%s%s-%s-%s", vn.prefix, schemaVersionV2, redirectUrlMapPrefixV2, vn.lbName), true
 }
 
+// ServerTLSPolicy returns the name of the server TLS policy and of its trust
+// config.
+func (vn *V2IngressFrontendNamer) ServerTLSPolicy() (string, bool) {
+	return fmt.Sprintf("%s%s-%s-%s", vn.prefix, schemaVersionV2, serverTLSPolicyPrefixV2, vn.lbName), true
+}
+
 // SSLCertName returns the name of the certificate.
 func (vn *V2IngressFrontendNamer) SSLCertName(secretHash string) string {
 	return fmt.Sprintf("%s%s-%s-%s-%s-%s", vn.prefix, schemaVersionV2, sslCertPrefixV2, vn.clusterUID, vn.lbNameToHash(), secretHash)
@@ -210,6 +235,18 @@ func (vn *V2IngressFrontendNamer) IsLegacySSLCert(certName string) bool {
 	return false
 }
 
+// SSLPolicy returns the name of the SSL policy with the given hash. The name
+// does not depend on the ingress so that the policy is shared.
+func (vn *V2IngressFrontendNamer) SSLPolicy(policyHash string) string {
+	return fmt.Sprintf("%s%s-%s-%s-%s", vn.prefix, schemaVersionV2, sslPolicyPrefixV2, vn.clusterUID, policyHash)
+}
+
+// IsSSLPolicy returns true if the SSL policy name is managed by this cluster.
+func (vn *V2IngressFrontendNamer) IsSSLPolicy(name string) bool {
+	prefix := fmt.Sprintf("%s%s-%s-%s-", vn.prefix, schemaVersionV2, sslPolicyPrefixV2, vn.clusterUID)
+	return strings.HasPrefix(name, prefix)
+}
+
 // LoadBalancer returns loadbalancer name.
 // Note that this is used for generating GCE resource names.
 func (vn *V2IngressFrontendNamer) LoadBalancer() LoadBalancerName {
@@ -219,7 +256,7 @@ func (vn *V2IngressFrontendNamer) LoadBalancer() LoadBalancerName {
 // IsValidLoadBalancer implements IngressFrontendNamer.
 func (vn *V2IngressFrontendNamer) IsValidLoadBalancer() bool {
 	// Verify if URL Map is a valid GCE resource name.
-	return isValidGCEResourceName(vn.UrlMap())
+	return IsValidGCEResourceName(vn.UrlMap())
 }
 
 // suffix returns hash string of length 8 of a concatenated string generated from
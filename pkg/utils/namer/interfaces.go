@@ -27,6 +27,9 @@ type IngressFrontendNamer interface {
 	UrlMap() string
 	// RedirectUrlMap returns the name of the URL Map and if the namer supports naming redirectUrlMap
 	RedirectUrlMap() (string, bool)
+	// ServerTLSPolicy returns the name of the server TLS policy and of its
+	// trust config, and if the namer supports naming them.
+	ServerTLSPolicy() (string, bool)
 	// SSLCertName returns the SSL certificate name given secret hash.
 	SSLCertName(secretHash string) string
 	// IsCertNameForLB returns true if certName belongs to this ingress.
@@ -35,6 +38,10 @@ type IngressFrontendNamer interface {
 	// and cert is managed by this ingress.
 	// old naming convention is of the form k8s-ssl-<lbName> or k8s-ssl-1-<lbName>.
 	IsLegacySSLCert(certName string) bool
+	// SSLPolicy returns the name of the SSL policy given the policy hash.
+	SSLPolicy(policyHash string) string
+	// IsSSLPolicy returns true if the SSL policy is managed by this cluster.
+	IsSSLPolicy(name string) bool
 	// LoadBalancer returns load-balancer name for the ingress.
 	LoadBalancer() LoadBalancerName
 	// IsValidLoadBalancer returns if the derived loadbalancer is valid.
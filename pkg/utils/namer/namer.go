@@ -37,6 +37,9 @@ const (
 	// This prefix is used along with namespace/name of ingress in legacy cert names. New names use this prefix along
 	// with hash of the ingress/namespace name and cert contents.
 	sslCertPrefix = "ssl"
+	// SSL policies declared in FrontendConfigs are shared by all Ingresses of
+	// the cluster, they are named with this prefix and the hash of the policy.
+	sslPolicyPrefix = "sp"
 	// TODO: this should really be "fr" and "frs".
 	forwardingRulePrefix      = "fw"
 	httpsForwardingRulePrefix = "fws"
@@ -401,6 +404,17 @@ func (n *Namer) SSLCertName(lbName LoadBalancerName, secretHash string) string {
 	return n.decorateName(fmt.Sprintf("%s-%s-%s-%s", n.prefix, sslCertPrefix, lbNameHash, secretHash))
 }
 
+// SSLPolicy returns the name of the SSL policy with the given hash.
+func (n *Namer) SSLPolicy(policyHash string) string {
+	// k8s-sp-[policyHash]--[clusterUID]
+	return n.decorateName(fmt.Sprintf("%s-%s-%s", n.prefix, sslPolicyPrefix, policyHash))
+}
+
+// IsSSLPolicy returns true if the SSL policy name is managed by this cluster.
+func (n *Namer) IsSSLPolicy(name string) bool {
+	return strings.HasPrefix(name, fmt.Sprintf("%s-%s-", n.prefix, sslPolicyPrefix)) && n.NameBelongsToCluster(name)
+}
+
 // ForwardingRule returns the name of the forwarding rule prefix.
 func (n *Namer) ForwardingRule(lbName LoadBalancerName, protocol NamerProtocol) string {
 	switch protocol {
@@ -483,6 +497,34 @@ func (namer *Namer) VMIPNEG(namespace, name string) (string, bool) {
 	return "", false
 }
 
+// BackendNameForScheme returns the name of the backend service with the
+// given base name and load balancing scheme. The load balancing scheme of a
+// backend service is immutable, so a backend that moves to another scheme in
+// the same scope needs another name. The name keeps the prefix of name, so it
+// still belongs to the same cluster.
+func BackendNameForScheme(name, scheme string) string {
+	return nameWithHash(name, scheme)
+}
+
+// ExternalNEGName returns the name of the serverless or Internet NEG with the
+// given base name and target, see annotations.ExternalNEG.Target. A NEG
+// cannot be updated, so a NEG with another target needs another name. It is
+// created alongside the NEG still used by the backend service, and the old
+// one is deleted once it is no longer used.
+func ExternalNEGName(name, target string) string {
+	return nameWithHash(name, target)
+}
+
+// nameWithHash returns name, truncated if needed, followed by a hash of name
+// and value.
+func nameWithHash(name, value string) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name+";"+value)))[:8]
+	if len(name) > nameLenLimit-len(hash) {
+		name = name[:nameLenLimit-len(hash)]
+	}
+	return fmt.Sprintf("%s-%s", strings.TrimSuffix(name, "-"), hash)
+}
+
 func (n *Namer) negPrefix() string {
 	return fmt.Sprintf("%s%s-%s", n.prefix, schemaVersionV1, n.shortUID())
 }
@@ -499,3 +499,42 @@ func TestIsNEG(t *testing.T) {
 		}
 	}
 }
+
+func TestBackendNameForScheme(t *testing.T) {
+	newNamer := NewNamerWithPrefix("k8s", "uid1", "fw1")
+	for _, base := range []string{
+		newNamer.NEG("namespace", "name", 80),
+		newNamer.NEG(strings.Repeat("n", 63), strings.Repeat("s", 63), 80),
+		newNamer.IGBackend(30000),
+	} {
+		name := BackendNameForScheme(base, "EXTERNAL_MANAGED")
+		if name == base || name == BackendNameForScheme(base, "INTERNAL_MANAGED") {
+			t.Errorf("BackendNameForScheme(%q, %q) = %q, want a name distinct from other schemes", base, "EXTERNAL_MANAGED", name)
+		}
+		if !IsValidGCEResourceName(name) {
+			t.Errorf("BackendNameForScheme(%q, %q) = %q, want a valid GCE resource name", base, "EXTERNAL_MANAGED", name)
+		}
+		if newNamer.IsNEG(base) != newNamer.IsNEG(name) {
+			t.Errorf("newNamer.IsNEG(%q) = %v, want %v", name, newNamer.IsNEG(name), newNamer.IsNEG(base))
+		}
+	}
+}
+
+func TestNamerSSLPolicy(t *testing.T) {
+	newNamer := NewNamerWithPrefix("k8s", "cluster-uid", "fw1")
+	otherNamer := NewNamerWithPrefix("k8s", "other-cluster-uid", "fw1")
+
+	name := newNamer.SSLPolicy("abcd1234")
+	if want := "k8s-sp-abcd1234--cluster-uid"; name != want {
+		t.Errorf("newNamer.SSLPolicy(%q) = %q, want %q", "abcd1234", name, want)
+	}
+	if !newNamer.IsSSLPolicy(name) {
+		t.Errorf("newNamer.IsSSLPolicy(%q) = false, want true", name)
+	}
+	if otherNamer.IsSSLPolicy(name) {
+		t.Errorf("otherNamer.IsSSLPolicy(%q) = true, want false", name)
+	}
+	if cert := newNamer.SSLCertName(newNamer.LoadBalancer("default/ing"), "abcd1234"); newNamer.IsSSLPolicy(cert) {
+		t.Errorf("newNamer.IsSSLPolicy(%q) = true, want false", cert)
+	}
+}
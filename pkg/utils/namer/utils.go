@@ -86,8 +86,8 @@ func FinalizerForNamingScheme(scheme Scheme) (string, error) {
 	}
 }
 
-// isValidGCEResourceName returns if given name is a valid GCE resource name.
-func isValidGCEResourceName(name string) bool {
+// IsValidGCEResourceName returns if given name is a valid GCE resource name.
+func IsValidGCEResourceName(name string) bool {
 	if len(name) == 0 {
 		return false
 	}
@@ -154,8 +154,8 @@ func TestIsValidGCEResourceName(t *testing.T) {
 			expectIsValid: true,
 		},
 	} {
-		if got := isValidGCEResourceName(tc.name); got != tc.expectIsValid {
-			t.Errorf("isValidGCEResourceName(%s) = %t, want %t", tc.name, got, tc.expectIsValid)
+		if got := IsValidGCEResourceName(tc.name); got != tc.expectIsValid {
+			t.Errorf("IsValidGCEResourceName(%s) = %t, want %t", tc.name, got, tc.expectIsValid)
 		}
 	}
 }
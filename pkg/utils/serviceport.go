@@ -50,8 +50,27 @@ type ServicePort struct {
 	NEGEnabled     bool
 	VMIPNEGEnabled bool
 	L7ILBEnabled   bool
-	BackendConfig  *backendconfigv1.BackendConfig
-	BackendNamer   namer.BackendNamer
+	// L7XLBRegionalEnabled is true if the backend is used by a regional
+	// external L7 load balancer.
+	L7XLBRegionalEnabled bool
+	// ExternalNEG is set if the backend is the serverless or Internet NEG
+	// declared by the Service, instead of its endpoints.
+	ExternalNEG   *annotations.ExternalNEG
+	BackendConfig *backendconfigv1.BackendConfig
+	// BackendTLSCertificates are read from the Secrets referenced by the
+	// backend TLS config of BackendConfig, nil if it has none.
+	BackendTLSCertificates *BackendTLSCertificates
+	BackendNamer           namer.BackendNamer
+}
+
+// BackendTLSCertificates is the PEM encoded key material of the backend TLS
+// config of a BackendConfig. Empty fields are not configured.
+type BackendTLSCertificates struct {
+	// CACertificates are the certificates the backends are validated against.
+	CACertificates string
+	// ClientCertificate and ClientPrivateKey are presented to the backends.
+	ClientCertificate string
+	ClientPrivateKey  string
 }
 
 // GetDescription returns a Description for this ServicePort.
@@ -64,13 +83,32 @@ func (sp ServicePort) GetDescription() Description {
 
 // BackendName returns the name of the backend which would be used for this ServicePort.
 func (sp ServicePort) BackendName() string {
-	if sp.NEGEnabled {
-		return sp.BackendNamer.NEG(sp.ID.Service.Namespace, sp.ID.Service.Name, sp.Port)
+	var name string
+	if sp.NEGEnabled || sp.ExternalNEG != nil {
+		name = sp.BackendNamer.NEG(sp.ID.Service.Namespace, sp.ID.Service.Name, sp.Port)
 	} else if sp.VMIPNEGEnabled {
+		name, _ = sp.BackendNamer.VMIPNEG(sp.ID.Service.Namespace, sp.ID.Service.Name)
+	} else {
+		name = sp.BackendNamer.IGBackend(sp.NodePort)
+	}
+	// Internal and regional external backend services share the regional
+	// scope, but not the load balancing scheme.
+	if sp.L7XLBRegionalEnabled {
+		return namer.BackendNameForScheme(name, "EXTERNAL_MANAGED")
+	}
+	return name
+}
+
+// NEGName returns the name of the NEG which would be used for this ServicePort.
+func (sp ServicePort) NEGName() string {
+	if sp.ExternalNEG != nil {
+		return namer.ExternalNEGName(sp.BackendNamer.NEG(sp.ID.Service.Namespace, sp.ID.Service.Name, sp.Port), sp.ExternalNEG.Target())
+	}
+	if !sp.NEGEnabled && sp.VMIPNEGEnabled {
 		negName, _ := sp.BackendNamer.VMIPNEG(sp.ID.Service.Namespace, sp.ID.Service.Name)
 		return negName
 	}
-	return sp.BackendNamer.IGBackend(sp.NodePort)
+	return sp.BackendNamer.NEG(sp.ID.Service.Namespace, sp.ID.Service.Name, sp.Port)
 }
 
 // IGName returns the name of the instance group which would be used for this ServicePort.
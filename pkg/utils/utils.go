@@ -266,6 +266,31 @@ func EqualResourceIDs(a, b string) bool {
 	return aId.Equal(bId)
 }
 
+// EqualLocationResourcePaths returns true if a and b, links of resources of
+// APIs with locations such as Certificate Manager or Network Security, refer to
+// the same location, resource type and resource name. The project is ignored,
+// as it may be returned as the project number instead of the project ID. Empty
+// links are only equal to each other.
+func EqualLocationResourcePaths(a, b string) bool {
+	aPath, bPath := locationResourcePath(a), locationResourcePath(b)
+	if aPath == "" || bPath == "" {
+		return a == b
+	}
+	return aPath == bPath
+}
+
+// locationResourcePath returns the part of link from its location on, e.g.
+// "locations/global/certificateMaps/name", empty if link has no location.
+func locationResourcePath(link string) string {
+	parts := strings.Split(link, "/")
+	for i, part := range parts {
+		if part == "locations" && i > 0 && parts[i-1] != "" {
+			return strings.Join(parts[i:], "/")
+		}
+	}
+	return ""
+}
+
 // IGLinks returns a list of links extracted from the passed in list of
 // compute.InstanceGroup's.
 func IGLinks(igs []*compute.InstanceGroup) (igLinks []string) {
@@ -294,6 +319,8 @@ func IsGCEIngress(ing *v1beta1.Ingress) bool {
 	case annotations.GceL7ILBIngressClass:
 		// TODO: (shance) remove flag check for L7-ILB once fully rolled out
 		return flags.F.EnableL7Ilb
+	case annotations.GceL7XLBRegionalIngressClass:
+		return flags.F.EnableL7XLBRegional
 	default:
 		return false
 	}
@@ -313,6 +340,19 @@ func IsGCEL7ILBIngress(ing *v1beta1.Ingress) bool {
 	return class == annotations.GceL7ILBIngressClass
 }
 
+// IsGCEL7XLBRegionalIngress returns true if the given Ingress has
+// ingress.class annotation set to "gce-regional-external"
+func IsGCEL7XLBRegionalIngress(ing *v1beta1.Ingress) bool {
+	class := annotations.FromIngress(ing).IngressClass()
+	return class == annotations.GceL7XLBRegionalIngressClass
+}
+
+// IsGCEL7RegionalIngress returns true if the given Ingress is served by a
+// regional L7 load balancer, internal or external.
+func IsGCEL7RegionalIngress(ing *v1beta1.Ingress) bool {
+	return IsGCEL7ILBIngress(ing) || IsGCEL7XLBRegionalIngress(ing)
+}
+
 // IsGLBCIngress returns true if the given Ingress should be processed by GLBC
 func IsGLBCIngress(ing *v1beta1.Ingress) bool {
 	return IsGCEIngress(ing) || IsGCEMultiClusterIngress(ing)
@@ -444,7 +484,7 @@ func TraverseIngressBackends(ing *v1beta1.Ingress, process func(id ServicePortID
 	}
 	// Check service of default backend
 	if ing.Spec.Backend != nil {
-		if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: ing.Spec.Backend.ServiceName}, Port: ing.Spec.Backend.ServicePort}) {
+		if process(IngressBackendToServicePortID(ing, *ing.Spec.Backend)) {
 			return
 		}
 	}
@@ -455,7 +495,7 @@ func TraverseIngressBackends(ing *v1beta1.Ingress, process func(id ServicePortID
 			continue
 		}
 		for _, p := range rule.IngressRuleValue.HTTP.Paths {
-			if process(ServicePortID{Service: types.NamespacedName{Namespace: ing.Namespace, Name: p.Backend.ServiceName}, Port: p.Backend.ServicePort}) {
+			if process(IngressBackendToServicePortID(ing, p.Backend)) {
 				return
 			}
 		}
@@ -463,6 +503,31 @@ func TraverseIngressBackends(ing *v1beta1.Ingress, process func(id ServicePortID
 	return
 }
 
+// IngressBackendToServicePortID returns the ServicePortID referenced by the given
+// backend of the ingress. The Service is resolved in the namespace of the ingress
+// unless cross-namespace backends are enabled and the ingress maps the Service to
+// another namespace.
+func IngressBackendToServicePortID(ing *v1beta1.Ingress, be v1beta1.IngressBackend) ServicePortID {
+	return BackendToServicePortID(be, IngressBackendNamespace(ing, be.ServiceName))
+}
+
+// IngressBackendNamespace returns the namespace of the Service with the given
+// name when it is referenced by the ingress. Invalid cross-namespace backend
+// annotations are ignored here and surfaced by the translator.
+func IngressBackendNamespace(ing *v1beta1.Ingress, svcName string) string {
+	if !flags.F.EnableCrossNamespaceBackends {
+		return ing.Namespace
+	}
+	namespaces, err := annotations.FromIngress(ing).BackendNamespaces()
+	if err != nil {
+		return ing.Namespace
+	}
+	if ns, ok := namespaces[svcName]; ok && ns != "" {
+		return ns
+	}
+	return ing.Namespace
+}
+
 func ServiceKeyFunc(namespace, name string) string {
 	return fmt.Sprintf("%s/%s", namespace, name)
 }
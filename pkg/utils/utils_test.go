@@ -159,6 +159,54 @@ func TestEqualResourcePaths(t *testing.T) {
 	}
 }
 
+func TestEqualLocationResourcePaths(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		a    string
+		b    string
+		want bool
+	}{
+		"equal": {
+			a:    "projects/project-id/locations/global/certificateMaps/example-map",
+			b:    "projects/project-id/locations/global/certificateMaps/example-map",
+			want: true,
+		},
+		"project number vs project id": {
+			a:    "//certificatemanager.googleapis.com/projects/123456/locations/global/certificateMaps/example-map",
+			b:    "projects/project-id/locations/global/certificateMaps/example-map",
+			want: true,
+		},
+		"diff name": {
+			a:    "projects/project-id/locations/global/certificateMaps/example-mapA",
+			b:    "projects/project-id/locations/global/certificateMaps/example-mapB",
+			want: false,
+		},
+		"diff resource": {
+			a:    "projects/project-id/locations/global/serverTlsPolicies/example",
+			b:    "projects/project-id/locations/global/certificateMaps/example",
+			want: false,
+		},
+		"empty vs link": {
+			a:    "",
+			b:    "projects/project-id/locations/global/certificateMaps/example-map",
+			want: false,
+		},
+		"both empty": {
+			want: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := EqualLocationResourcePaths(tc.a, tc.b); got != tc.want {
+				t.Errorf("EqualLocationResourcePaths(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestEqualResourceIDs(t *testing.T) {
 	t.Parallel()
 	testCases := map[string]struct {
@@ -391,6 +439,72 @@ func TestTraverseIngressBackends(t *testing.T) {
 	}
 }
 
+func TestIngressBackendNamespace(t *testing.T) {
+	ing := &v1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "edge",
+			Annotations: map[string]string{
+				annotations.BackendNamespacesKey: `{"shop": "shop-prod", "empty": ""}`,
+			},
+		},
+	}
+	malformed := ing.DeepCopy()
+	malformed.Annotations[annotations.BackendNamespacesKey] = `{"shop"`
+
+	for _, tc := range []struct {
+		desc    string
+		enabled bool
+		ing     *v1beta1.Ingress
+		svcName string
+		want    string
+	}{
+		{
+			desc:    "feature disabled",
+			enabled: false,
+			ing:     ing,
+			svcName: "shop",
+			want:    "edge",
+		},
+		{
+			desc:    "service mapped to another namespace",
+			enabled: true,
+			ing:     ing,
+			svcName: "shop",
+			want:    "shop-prod",
+		},
+		{
+			desc:    "service not mapped",
+			enabled: true,
+			ing:     ing,
+			svcName: "billing",
+			want:    "edge",
+		},
+		{
+			desc:    "service mapped to empty namespace",
+			enabled: true,
+			ing:     ing,
+			svcName: "empty",
+			want:    "edge",
+		},
+		{
+			desc:    "malformed annotation",
+			enabled: true,
+			ing:     malformed,
+			svcName: "shop",
+			want:    "edge",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags.F.EnableCrossNamespaceBackends = tc.enabled
+			defer func() { flags.F.EnableCrossNamespaceBackends = false }()
+
+			if got := IngressBackendNamespace(tc.ing, tc.svcName); got != tc.want {
+				t.Errorf("IngressBackendNamespace(_, %q) = %q, want %q", tc.svcName, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestGetNodeConditionPredicate(t *testing.T) {
 	tests := []struct {
 		node         api_v1.Node
@@ -626,6 +740,28 @@ func TestIsGCEL7ILBIngress(t *testing.T) {
 	}
 }
 
+func TestIsGCEL7RegionalIngress(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		class           string
+		wantXLBRegional bool
+		wantRegional    bool
+	}{
+		{class: "", wantXLBRegional: false, wantRegional: false},
+		{class: annotations.GceIngressClass, wantXLBRegional: false, wantRegional: false},
+		{class: annotations.GceL7ILBIngressClass, wantXLBRegional: false, wantRegional: true},
+		{class: annotations.GceL7XLBRegionalIngressClass, wantXLBRegional: true, wantRegional: true},
+	} {
+		ing := &v1beta1.Ingress{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{annotations.IngressClassKey: tc.class}}}
+		if got := IsGCEL7XLBRegionalIngress(ing); got != tc.wantXLBRegional {
+			t.Errorf("IsGCEL7XLBRegionalIngress() with class %q = %v, want %v", tc.class, got, tc.wantXLBRegional)
+		}
+		if got := IsGCEL7RegionalIngress(ing); got != tc.wantRegional {
+			t.Errorf("IsGCEL7RegionalIngress() with class %q = %v, want %v", tc.class, got, tc.wantRegional)
+		}
+	}
+}
+
 func TestNeedsCleanup(t *testing.T) {
 	testCases := []struct {
 		isGLBCIngress       bool